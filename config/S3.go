@@ -2,7 +2,7 @@ package config
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"os"
 
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -12,21 +12,20 @@ import (
 
 var S3Client *s3.Client
 
-func ConnectS3() {
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithRegion(os.Getenv("AWS_REGION")),
-		config.WithCredentialsProvider(
-			credentials.NewStaticCredentialsProvider(
-				os.Getenv("AWS_ACCESS_KEY_ID"),
-				os.Getenv("AWS_SECRET_ACCESS_KEY"),
-				"",
-			),
-		),
-	)
+func ConnectS3(cfg S3Config) {
+	options := []func(*config.LoadOptions) error{config.WithRegion(cfg.Region)}
+	if cfg.AccessKeyID != "" {
+		options = append(options, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(context.TODO(), options...)
 	if err != nil {
-		log.Fatal("❌ Failed to load AWS config:", err)
+		slog.Error("failed to load AWS config", "error", err)
+		os.Exit(1)
 	}
 
-	S3Client = s3.NewFromConfig(cfg)
-	log.Println("✅ AWS S3 connected successfully")
+	S3Client = s3.NewFromConfig(awsCfg)
+	slog.Info("AWS S3 connected")
 }
@@ -1,21 +1,364 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+
+	// redacted replaces secrets in printed configuration
+	redacted = "[redacted]"
+
+	// minProductionSecretLength is the shortest JWT secret accepted in
+	// production, 256 bits for HS256
+	minProductionSecretLength = 32
 )
 
+// placeholderSecrets are example values that must never sign real tokens
+var placeholderSecrets = []string{
+	"your_super_secret_key_change_this",
+	"default-secret-key-change-in-production",
+}
+
+// AppConfig is the application configuration. Values start from Defaults,
+// are overridden by the YAML file named in CONFIG_FILE and then by
+// environment variables, so non-secret settings can live in a file while
+// secrets are injected through the environment. Fields marked secret are
+// redacted when printed.
 type AppConfig struct {
-	Port      string
-	JWTSecret string
-	JWTExpiry string
-	AppEnv    string
+	AppEnv       string             `yaml:"app_env" env:"APP_ENV"`
+	Server       ServerConfig       `yaml:"server"`
+	Database     DatabaseConfig     `yaml:"database"`
+	JWT          JWTConfig          `yaml:"jwt"`
+	S3           S3Config           `yaml:"s3"`
+	Mail         MailConfig         `yaml:"mail"`
+	InboundEmail InboundEmailConfig `yaml:"inbound_email"`
+	Tracing      TracingConfig      `yaml:"tracing"`
+	Webhooks     WebhooksConfig     `yaml:"webhooks"`
+	Trash        TrashConfig        `yaml:"trash"`
+	Jobs         JobsConfig         `yaml:"jobs"`
+}
+
+type ServerConfig struct {
+	Port     string `yaml:"port" env:"PORT"`
+	LogLevel string `yaml:"log_level" env:"LOG_LEVEL"`
+	// AppURL is the frontend address used for links in emails
+	AppURL             string        `yaml:"app_url" env:"APP_URL"`
+	CORSAllowedOrigins string        `yaml:"cors_allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	MetricsToken       string        `yaml:"metrics_token" env:"METRICS_TOKEN" secret:"true"`
+	ShutdownDelay      time.Duration `yaml:"shutdown_delay" env:"SHUTDOWN_DELAY"`
+	ShutdownTimeout    time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	MigrationsPath     string        `yaml:"migrations_path" env:"MIGRATIONS_PATH"`
 }
 
-func GetConfig() AppConfig {
+type DatabaseConfig struct {
+	Host     string `yaml:"host" env:"DB_HOST"`
+	Port     string `yaml:"port" env:"DB_PORT"`
+	User     string `yaml:"user" env:"DB_USER"`
+	Password string `yaml:"password" env:"DB_PASSWORD" secret:"true"`
+	Name     string `yaml:"name" env:"DB_NAME"`
+	SSLMode  string `yaml:"sslmode" env:"DB_SSLMODE"`
+}
+
+type JWTConfig struct {
+	Secret string        `yaml:"secret" env:"JWT_SECRET" secret:"true"`
+	Expiry time.Duration `yaml:"expiry" env:"JWT_EXPIRY"`
+}
+
+// S3Config holds the bucket for attachments. Without static keys the AWS
+// default credential chain is used, e.g. an instance role.
+type S3Config struct {
+	Region          string `yaml:"region" env:"AWS_REGION"`
+	Bucket          string `yaml:"bucket" env:"AWS_S3_BUCKET"`
+	AccessKeyID     string `yaml:"access_key_id" env:"AWS_ACCESS_KEY_ID"`
+	SecretAccessKey string `yaml:"secret_access_key" env:"AWS_SECRET_ACCESS_KEY" secret:"true"`
+}
+
+// MailConfig picks how notifications are emailed: "smtp", "file" or empty
+// to not email them
+type MailConfig struct {
+	Driver       string `yaml:"driver" env:"MAIL_DRIVER"`
+	From         string `yaml:"from" env:"MAIL_FROM"`
+	Dir          string `yaml:"dir" env:"MAIL_DIR"`
+	SMTPHost     string `yaml:"smtp_host" env:"SMTP_HOST"`
+	SMTPPort     string `yaml:"smtp_port" env:"SMTP_PORT"`
+	SMTPUsername string `yaml:"smtp_username" env:"SMTP_USERNAME"`
+	SMTPPassword string `yaml:"smtp_password" env:"SMTP_PASSWORD" secret:"true"`
+}
+
+type InboundEmailConfig struct {
+	Domain string `yaml:"domain" env:"INBOUND_EMAIL_DOMAIN"`
+	Secret string `yaml:"secret" env:"INBOUND_EMAIL_SECRET" secret:"true"`
+}
+
+// TracingConfig selects the span exporter. The OTLP exporter itself is
+// configured through the standard OTEL_EXPORTER_OTLP_* variables.
+type TracingConfig struct {
+	Exporter string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER"`
+}
+
+type WebhooksConfig struct {
+	Timeout time.Duration `yaml:"timeout" env:"WEBHOOK_TIMEOUT"`
+	// AllowPrivateTargets lets webhooks reach loopback and private addresses,
+	// e.g. a receiver running next to a development server
+	AllowPrivateTargets bool `yaml:"allow_private_targets" env:"WEBHOOK_ALLOW_PRIVATE_TARGETS"`
+}
+
+type TrashConfig struct {
+	RetentionDays int `yaml:"retention_days" env:"TRASH_RETENTION_DAYS"`
+}
+
+// JobsConfig holds how often each background job runs
+type JobsConfig struct {
+	ReminderInterval          time.Duration `yaml:"reminder_interval" env:"REMINDER_INTERVAL"`
+	TrashPurgeInterval        time.Duration `yaml:"trash_purge_interval" env:"TRASH_PURGE_INTERVAL"`
+	WebhookDeliveryInterval   time.Duration `yaml:"webhook_delivery_interval" env:"WEBHOOK_DELIVERY_INTERVAL"`
+	RuleDueInterval           time.Duration `yaml:"rule_due_interval" env:"RULE_DUE_INTERVAL"`
+	NotificationEmailInterval time.Duration `yaml:"notification_email_interval" env:"NOTIFICATION_EMAIL_INTERVAL"`
+}
+
+// Defaults returns the configuration used for anything not set in the file
+// or the environment
+func Defaults() AppConfig {
 	return AppConfig{
-		Port:      os.Getenv("PORT"),
-		JWTSecret: os.Getenv("JWT_SECRET"),
-		JWTExpiry: os.Getenv("JWT_EXPIRY"),
-		AppEnv:    os.Getenv("APP_ENV"),
+		AppEnv: EnvDevelopment,
+		Server: ServerConfig{
+			Port:               "8080",
+			LogLevel:           "info",
+			CORSAllowedOrigins: "*",
+			ShutdownTimeout:    30 * time.Second,
+			MigrationsPath:     "migrations",
+		},
+		Database: DatabaseConfig{
+			Host:    "localhost",
+			Port:    "5432",
+			SSLMode: "disable",
+		},
+		JWT: JWTConfig{
+			Expiry: 24 * time.Hour,
+		},
+		Mail: MailConfig{
+			From:     "Kanban <noreply@localhost>",
+			Dir:      "mail",
+			SMTPPort: "587",
+		},
+		Tracing: TracingConfig{
+			Exporter: "none",
+		},
+		Webhooks: WebhooksConfig{
+			Timeout: 10 * time.Second,
+		},
+		Trash: TrashConfig{
+			RetentionDays: 30,
+		},
+		Jobs: JobsConfig{
+			ReminderInterval:          time.Minute,
+			TrashPurgeInterval:        time.Hour,
+			WebhookDeliveryInterval:   10 * time.Second,
+			RuleDueInterval:           time.Minute,
+			NotificationEmailInterval: time.Minute,
+		},
+	}
+}
+
+// Load reads the configuration from the YAML file named in CONFIG_FILE, if
+// any, and the environment. The result still has to be checked with Validate.
+func Load() (*AppConfig, error) {
+	return LoadFrom(os.Getenv("CONFIG_FILE"), os.LookupEnv)
+}
+
+// LoadFrom reads the configuration from path, which may be empty, and the
+// variables returned by lookup. Empty variables count as unset.
+func LoadFrom(path string, lookup func(string) (string, bool)) (*AppConfig, error) {
+	cfg := Defaults()
+
+	if path != "" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("open config file: %w", err)
+		}
+		defer file.Close()
+
+		decoder := yaml.NewDecoder(file)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parse config file %s: %w", path, err)
+		}
+	}
+
+	if err := applyEnv(reflect.ValueOf(&cfg).Elem(), lookup); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// applyEnv overrides every field tagged env whose variable is set
+func applyEnv(v reflect.Value, lookup func(string) (string, bool)) error {
+	var errs []error
+	for i := range v.NumField() {
+		field, value := v.Type().Field(i), v.Field(i)
+		if value.Kind() == reflect.Struct {
+			errs = append(errs, applyEnv(value, lookup))
+			continue
+		}
+
+		name := field.Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		raw, ok := lookup(name)
+		if !ok || raw == "" {
+			continue
+		}
+		if err := setField(value, strings.TrimSpace(raw)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func setField(value reflect.Value, raw string) error {
+	switch value.Interface().(type) {
+	case string:
+		value.SetString(raw)
+	case time.Duration:
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+	case int:
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(number))
+	case bool:
+		enabled, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(enabled)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// IsProduction reports whether the stricter production checks apply
+func (c *AppConfig) IsProduction() bool {
+	return c.AppEnv == EnvProduction
+}
+
+// Validate reports every invalid setting at once. In production it also
+// rejects settings that are only acceptable during development.
+func (c *AppConfig) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(slices.Contains([]string{EnvDevelopment, EnvTest, EnvStaging, EnvProduction}, c.AppEnv),
+		"app_env must be one of development, test, staging or production, got %q", c.AppEnv)
+
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Server.LogLevel)) == nil, "server.log_level %q is not a log level", c.Server.LogLevel)
+	check(isPort(c.Server.Port), "server.port %q is not a port", c.Server.Port)
+	check(c.Server.ShutdownDelay >= 0, "server.shutdown_delay must not be negative")
+	check(c.Server.ShutdownTimeout > 0, "server.shutdown_timeout must be positive")
+	check(c.Server.MigrationsPath != "", "server.migrations_path is required")
+
+	check(c.Database.Host != "", "database.host is required")
+	check(isPort(c.Database.Port), "database.port %q is not a port", c.Database.Port)
+	check(c.Database.User != "", "database.user is required")
+	check(c.Database.Name != "", "database.name is required")
+
+	check(c.JWT.Secret != "", "jwt.secret is required")
+	check(c.JWT.Expiry > 0, "jwt.expiry must be positive")
+
+	check(c.S3.Region != "", "s3.region is required")
+	check(c.S3.Bucket != "", "s3.bucket is required")
+	check((c.S3.AccessKeyID == "") == (c.S3.SecretAccessKey == ""), "s3.access_key_id and s3.secret_access_key must be set together")
+
+	check(slices.Contains([]string{"", "smtp", "file"}, c.Mail.Driver), "mail.driver must be smtp, file or empty, got %q", c.Mail.Driver)
+	if c.Mail.Driver == "smtp" {
+		check(c.Mail.SMTPHost != "", "mail.smtp_host is required for the smtp driver")
+		check(isPort(c.Mail.SMTPPort), "mail.smtp_port %q is not a port", c.Mail.SMTPPort)
+	}
+	check(c.InboundEmail.Domain == "" || c.InboundEmail.Secret != "", "inbound_email.secret is required when inbound_email.domain is set")
+
+	check(slices.Contains([]string{"", "none", "otlp", "console", "stdout"}, c.Tracing.Exporter),
+		"tracing.exporter must be otlp, console or none, got %q", c.Tracing.Exporter)
+
+	check(c.Webhooks.Timeout > 0, "webhooks.timeout must be positive")
+	check(c.Trash.RetentionDays > 0, "trash.retention_days must be positive")
+	check(c.Jobs.ReminderInterval > 0, "jobs.reminder_interval must be positive")
+	check(c.Jobs.TrashPurgeInterval > 0, "jobs.trash_purge_interval must be positive")
+	check(c.Jobs.WebhookDeliveryInterval > 0, "jobs.webhook_delivery_interval must be positive")
+	check(c.Jobs.RuleDueInterval > 0, "jobs.rule_due_interval must be positive")
+	check(c.Jobs.NotificationEmailInterval > 0, "jobs.notification_email_interval must be positive")
+
+	if c.IsProduction() {
+		check(len(c.JWT.Secret) >= minProductionSecretLength, "jwt.secret must be at least %d characters in production", minProductionSecretLength)
+		check(!slices.Contains(placeholderSecrets, c.JWT.Secret), "jwt.secret is an example value")
+		check(c.Database.SSLMode != "disable", "database.sslmode must not be disable in production")
+		check(c.Server.CORSAllowedOrigins != "*", "server.cors_allowed_origins must list origins in production")
+		check(!c.Webhooks.AllowPrivateTargets, "webhooks.allow_private_targets must be off in production")
+	}
+
+	return errors.Join(errs...)
+}
+
+func isPort(value string) bool {
+	port, err := strconv.Atoi(value)
+	return err == nil && port > 0 && port < 65536
+}
+
+// Redacted returns a copy with every non-empty secret replaced, safe to log
+// or print
+func (c *AppConfig) Redacted() AppConfig {
+	cfg := *c
+	redact(reflect.ValueOf(&cfg).Elem())
+	return cfg
+}
+
+func redact(v reflect.Value) {
+	for i := range v.NumField() {
+		field, value := v.Type().Field(i), v.Field(i)
+		if value.Kind() == reflect.Struct {
+			redact(value)
+			continue
+		}
+		if field.Tag.Get("secret") == "true" && value.String() != "" {
+			value.SetString(redacted)
+		}
+	}
+}
+
+// Print writes the configuration as YAML with secrets redacted. The output
+// can be used as a CONFIG_FILE once the secrets are filled in.
+func (c *AppConfig) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return err
 	}
+	return encoder.Close()
 }
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envMap is a lookup over a fixed set of variables
+func envMap(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// validConfig is a complete development configuration
+func validConfig() *AppConfig {
+	cfg := Defaults()
+	cfg.Database.User = "kanban_user"
+	cfg.Database.Name = "kanban_db"
+	cfg.JWT.Secret = "dev-secret"
+	cfg.S3.Region = "ap-southeast-1"
+	cfg.S3.Bucket = "kanban-file"
+	return &cfg
+}
+
+func TestLoadFrom_Precedence(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: "9000"
+  shutdown_timeout: 45s
+database:
+  host: db.internal
+  user: from_file
+jobs:
+  reminder_interval: 5m
+`)
+
+	cfg, err := LoadFrom(path, envMap(map[string]string{
+		"DB_USER":                       "from_env",
+		"DB_PASSWORD":                   "hunter2",
+		"TRASH_RETENTION_DAYS":          "7",
+		"WEBHOOK_ALLOW_PRIVATE_TARGETS": "true",
+		"METRICS_TOKEN":                 "",
+	}))
+	require.NoError(t, err)
+
+	assert.Equal(t, "9000", cfg.Server.Port, "file overrides defaults")
+	assert.Equal(t, 45*time.Second, cfg.Server.ShutdownTimeout)
+	assert.Equal(t, "db.internal", cfg.Database.Host)
+	assert.Equal(t, 5*time.Minute, cfg.Jobs.ReminderInterval)
+	assert.Equal(t, "from_env", cfg.Database.User, "environment overrides the file")
+	assert.Equal(t, "hunter2", cfg.Database.Password)
+	assert.Equal(t, 7, cfg.Trash.RetentionDays)
+	assert.True(t, cfg.Webhooks.AllowPrivateTargets)
+	assert.Equal(t, "5432", cfg.Database.Port, "defaults fill the rest")
+	assert.Equal(t, time.Hour, cfg.Jobs.TrashPurgeInterval)
+	assert.Empty(t, cfg.Server.MetricsToken, "empty variables count as unset")
+}
+
+func TestLoadFrom_Errors(t *testing.T) {
+	_, err := LoadFrom(filepath.Join(t.TempDir(), "missing.yaml"), envMap(nil))
+	assert.Error(t, err)
+
+	_, err = LoadFrom(writeConfigFile(t, "server:\n  prot: \"9000\"\n"), envMap(nil))
+	assert.ErrorContains(t, err, "prot", "unknown keys are rejected")
+
+	_, err = LoadFrom("", envMap(map[string]string{"JWT_EXPIRY": "a day", "TRASH_RETENTION_DAYS": "thirty"}))
+	assert.ErrorContains(t, err, "JWT_EXPIRY")
+	assert.ErrorContains(t, err, "TRASH_RETENTION_DAYS")
+
+	cfg, err := LoadFrom(writeConfigFile(t, ""), envMap(nil))
+	require.NoError(t, err, "an empty file is allowed")
+	assert.Equal(t, Defaults(), *cfg)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *AppConfig)
+		wantErr []string
+	}{
+		{name: "valid development config", modify: func(cfg *AppConfig) {}},
+		{
+			name:    "missing secrets and bucket",
+			modify:  func(cfg *AppConfig) { cfg.JWT.Secret, cfg.S3.Bucket = "", "" },
+			wantErr: []string{"jwt.secret is required", "s3.bucket is required"},
+		},
+		{
+			name: "bad values",
+			modify: func(cfg *AppConfig) {
+				cfg.AppEnv = "prod"
+				cfg.Server.Port = "http"
+				cfg.Server.LogLevel = "verbose"
+				cfg.Mail.Driver = "sendmail"
+				cfg.Jobs.RuleDueInterval = 0
+			},
+			wantErr: []string{"app_env", "server.port", "server.log_level", "mail.driver", "jobs.rule_due_interval"},
+		},
+		{
+			name:    "smtp without host",
+			modify:  func(cfg *AppConfig) { cfg.Mail.Driver = "smtp" },
+			wantErr: []string{"mail.smtp_host"},
+		},
+		{
+			name:    "inbound email without secret",
+			modify:  func(cfg *AppConfig) { cfg.InboundEmail.Domain = "in.example.com" },
+			wantErr: []string{"inbound_email.secret"},
+		},
+		{
+			name:    "insecure production settings",
+			modify:  func(cfg *AppConfig) { cfg.AppEnv, cfg.Webhooks.AllowPrivateTargets = EnvProduction, true },
+			wantErr: []string{"jwt.secret must be at least", "database.sslmode", "server.cors_allowed_origins", "webhooks.allow_private_targets"},
+		},
+		{
+			name: "placeholder secret in production",
+			modify: func(cfg *AppConfig) {
+				cfg.AppEnv = EnvProduction
+				cfg.JWT.Secret = "default-secret-key-change-in-production"
+				cfg.Database.SSLMode = "require"
+				cfg.Server.CORSAllowedOrigins = "https://kanban.example.com"
+			},
+			wantErr: []string{"jwt.secret is an example value"},
+		},
+		{
+			name: "secure production settings",
+			modify: func(cfg *AppConfig) {
+				cfg.AppEnv = EnvProduction
+				cfg.JWT.Secret = strings.Repeat("k", 48)
+				cfg.Database.SSLMode = "verify-full"
+				cfg.Server.CORSAllowedOrigins = "https://kanban.example.com"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
+
+func TestPrint_RedactsSecrets(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database.Password = "db-password"
+	cfg.S3.AccessKeyID = "AKIAEXAMPLE"
+	cfg.S3.SecretAccessKey = "aws-secret"
+
+	var out bytes.Buffer
+	require.NoError(t, cfg.Print(&out))
+
+	for _, secret := range []string{"dev-secret", "db-password", "aws-secret"} {
+		assert.NotContains(t, out.String(), secret)
+	}
+	assert.Contains(t, out.String(), "password: '[redacted]'")
+	assert.Contains(t, out.String(), "access_key_id: AKIAEXAMPLE")
+	assert.Contains(t, out.String(), "metrics_token: \"\"", "unset secrets stay visibly empty")
+	assert.Contains(t, out.String(), "shutdown_timeout: 30s")
+	assert.Equal(t, "dev-secret", cfg.JWT.Secret, "printing does not modify the config")
+
+	printed, err := LoadFrom(writeConfigFile(t, out.String()), envMap(nil))
+	require.NoError(t, err, "printed config can be loaded back")
+	assert.Equal(t, cfg.Redacted(), *printed)
+}
@@ -1,9 +1,15 @@
 package config
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"time"
+
+	"kanban-backend/logging"
+	"kanban-backend/metrics"
+	"kanban-backend/tracing"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -11,21 +17,35 @@ import (
 
 var DB *gorm.DB
 
-func ConnectDB() {
+func ConnectDB(cfg DatabaseConfig) {
 	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=Asia/Kuala_Lumpur",
-		os.Getenv("DB_HOST"),
-		os.Getenv("DB_PORT"),
-		os.Getenv("DB_USER"),
-		os.Getenv("DB_PASSWORD"),
-		os.Getenv("DB_NAME"),
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=UTC",
+		cfg.Host,
+		cfg.Port,
+		cfg.User,
+		cfg.Password,
+		cfg.Name,
+		cfg.SSLMode,
 	)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// Timestamps are always stored and compared in UTC; user-facing local
+	// times are derived from each user's timezone preference
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+		Logger: logging.GormLogger(),
+	})
 	if err != nil {
-		log.Fatal("❌ Failed to connect to database:", err)
+		slog.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+
+	if err := errors.Join(metrics.InstrumentDB(db), tracing.InstrumentDB(db)); err != nil {
+		slog.Error("failed to instrument database", "error", err)
+		os.Exit(1)
 	}
 
 	DB = db
-	log.Println("✅ Database connected successfully")
+	slog.Info("database connected")
 }
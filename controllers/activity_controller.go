@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"errors"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ActivityController struct {
+	activityService services.ActivityService
+}
+
+func NewActivityController(activityService services.ActivityService) *ActivityController {
+	return &ActivityController{
+		activityService: activityService,
+	}
+}
+
+func (ctrl *ActivityController) FindByTaskID(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	taskID := c.Params("id")
+
+	if taskID == "" {
+		return utils.ValidationError(c, "id", "task id is required")
+	}
+
+	var req utils.PaginationRequest
+	c.QueryParser(&req)
+	utils.ValidatePagination(&req)
+
+	activities, total, err := ctrl.activityService.FindByTaskIDWithPagination(c.UserContext(), taskID, userID, req.Page, req.Limit)
+	if err != nil {
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		var unauthorizedErr utils.ErrUnauthorized
+		if errors.As(err, &unauthorizedErr) {
+			return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+		}
+		return utils.Error(c, "Failed to find activity", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, utils.NewPaginatedResponse(activities, req.Page, req.Limit, total))
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockActivityService struct {
+	findByTaskIDFunc func(ctx context.Context, taskID, userID string, page, limit int) ([]*models.TaskActivity, int, error)
+}
+
+func (m *mockActivityService) FindByTaskIDWithPagination(ctx context.Context, taskID, userID string, page, limit int) ([]*models.TaskActivity, int, error) {
+	if m.findByTaskIDFunc != nil {
+		return m.findByTaskIDFunc(ctx, taskID, userID, page, limit)
+	}
+	return nil, 0, nil
+}
+
+func (m *mockActivityService) HandleEvent(ctx context.Context, event events.Event) {}
+
+func TestActivityController_FindByTaskID(t *testing.T) {
+	app := fiber.New()
+
+	var gotPage, gotLimit int
+	ctrl := NewActivityController(&mockActivityService{
+		findByTaskIDFunc: func(ctx context.Context, taskID, userID string, page, limit int) ([]*models.TaskActivity, int, error) {
+			gotPage, gotLimit = page, limit
+			return []*models.TaskActivity{{ID: "act-1", TaskID: taskID, UserID: userID, Action: models.ActivityCreated}}, 1, nil
+		},
+	})
+	app.Get("/tasks/:id/activity", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.FindByTaskID(c)
+	})
+
+	req := httptest.NewRequest("GET", "/tasks/task-1/activity?page=2&limit=5", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, gotPage)
+	assert.Equal(t, 5, gotLimit)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"action":"created"`)
+}
+
+func TestActivityController_FindByTaskID_Unauthorized(t *testing.T) {
+	app := fiber.New()
+
+	ctrl := NewActivityController(&mockActivityService{
+		findByTaskIDFunc: func(ctx context.Context, taskID, userID string, page, limit int) ([]*models.TaskActivity, int, error) {
+			return nil, 0, utils.NewUnauthorized("you do not have access to this task")
+		},
+	})
+	app.Get("/tasks/:id/activity", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.FindByTaskID(c)
+	})
+
+	req := httptest.NewRequest("GET", "/tasks/task-1/activity", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+}
@@ -0,0 +1,124 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AnalyticsController struct {
+	analyticsService services.AnalyticsService
+}
+
+func NewAnalyticsController(analyticsService services.AnalyticsService) *AnalyticsController {
+	return &AnalyticsController{
+		analyticsService: analyticsService,
+	}
+}
+
+type SetColumnStageRequest struct {
+	Stage string `json:"stage"`
+}
+
+func (ctrl *AnalyticsController) BoardAnalytics(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	from, to, err := utils.ParseDateRange(c.Query("from"), c.Query("to"), time.Now(), services.DefaultAnalyticsRange)
+	if err != nil {
+		return utils.ValidationError(c, "from", err.Error())
+	}
+
+	analytics, err := ctrl.analyticsService.BoardAnalytics(c.UserContext(), boardID, userID, from, to)
+	if err != nil {
+		return analyticsError(c, err, "Failed to compute analytics")
+	}
+
+	return utils.Success(c, analytics)
+}
+
+// CumulativeFlow returns per-interval column counts for drawing a cumulative
+// flow diagram. Responses made only of closed intervals never change, so they
+// are marked as cacheable.
+func (ctrl *AnalyticsController) CumulativeFlow(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	from, to, err := utils.ParseDateRange(c.Query("from"), c.Query("to"), time.Now(), services.DefaultAnalyticsRange)
+	if err != nil {
+		return utils.ValidationError(c, "from", err.Error())
+	}
+
+	interval := c.Query("interval", services.CFDIntervalDay)
+
+	flow, err := ctrl.analyticsService.CumulativeFlow(c.UserContext(), boardID, userID, from, to, interval)
+	if err != nil {
+		return analyticsError(c, err, "Failed to compute cumulative flow")
+	}
+
+	if flow.Closed() {
+		c.Set(fiber.HeaderCacheControl, "private, max-age=86400")
+	} else {
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+	}
+
+	return utils.Success(c, flow)
+}
+
+func (ctrl *AnalyticsController) SetColumnStage(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+	columnID := c.Params("column_id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	if columnID == "" {
+		return utils.ValidationError(c, "column_id", "column id is required")
+	}
+
+	var req SetColumnStageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+	}
+
+	if req.Stage == "" {
+		return utils.ValidationError(c, "stage", "stage is required")
+	}
+
+	column, err := ctrl.analyticsService.SetColumnStage(c.UserContext(), boardID, columnID, userID, req.Stage)
+	if err != nil {
+		return analyticsError(c, err, "Failed to update column stage")
+	}
+
+	return utils.Success(c, column)
+}
+
+func analyticsError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
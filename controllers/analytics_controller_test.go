@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAnalyticsService struct {
+	boardAnalyticsFunc func(ctx context.Context, boardID, userID string, from, to time.Time) (*services.BoardAnalytics, error)
+	cumulativeFlowFunc func(ctx context.Context, boardID, userID string, from, to time.Time, interval string) (*services.CumulativeFlow, error)
+	setColumnStageFunc func(ctx context.Context, boardID, columnID, userID, stage string) (*models.Column, error)
+}
+
+func (m *mockAnalyticsService) CumulativeFlow(ctx context.Context, boardID, userID string, from, to time.Time, interval string) (*services.CumulativeFlow, error) {
+	if m.cumulativeFlowFunc != nil {
+		return m.cumulativeFlowFunc(ctx, boardID, userID, from, to, interval)
+	}
+	return &services.CumulativeFlow{BoardID: boardID, From: from, To: to, Interval: interval}, nil
+}
+
+func (m *mockAnalyticsService) BoardAnalytics(ctx context.Context, boardID, userID string, from, to time.Time) (*services.BoardAnalytics, error) {
+	if m.boardAnalyticsFunc != nil {
+		return m.boardAnalyticsFunc(ctx, boardID, userID, from, to)
+	}
+	return &services.BoardAnalytics{BoardID: boardID, From: from, To: to}, nil
+}
+
+func (m *mockAnalyticsService) SetColumnStage(ctx context.Context, boardID, columnID, userID, stage string) (*models.Column, error) {
+	if m.setColumnStageFunc != nil {
+		return m.setColumnStageFunc(ctx, boardID, columnID, userID, stage)
+	}
+	return &models.Column{ID: columnID, BoardID: boardID, Stage: stage}, nil
+}
+
+func TestAnalyticsController_BoardAnalytics(t *testing.T) {
+	app := fiber.New()
+
+	var gotFrom, gotTo time.Time
+	ctrl := NewAnalyticsController(&mockAnalyticsService{
+		boardAnalyticsFunc: func(ctx context.Context, boardID, userID string, from, to time.Time) (*services.BoardAnalytics, error) {
+			gotFrom, gotTo = from, to
+			return &services.BoardAnalytics{BoardID: boardID, Completed: 4}, nil
+		},
+	})
+	app.Get("/boards/:id/analytics", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.BoardAnalytics(c)
+	})
+
+	req := httptest.NewRequest("GET", "/boards/board-1/analytics?from=2026-03-01&to=2026-03-31", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), gotFrom)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), gotTo.Add(time.Nanosecond))
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"completed":4`)
+}
+
+func TestAnalyticsController_CumulativeFlow(t *testing.T) {
+	tests := []struct {
+		name         string
+		closed       bool
+		serviceErr   error
+		query        string
+		wantStatus   int
+		wantInterval string
+		wantCache    string
+	}{
+		{name: "closed range is cacheable", closed: true, query: "?from=2026-03-01&to=2026-03-07", wantStatus: fiber.StatusOK, wantInterval: "day", wantCache: "private, max-age=86400"},
+		{name: "open range is not cached", query: "?interval=week", wantStatus: fiber.StatusOK, wantInterval: "week", wantCache: "no-cache"},
+		{name: "invalid interval", query: "?interval=month", serviceErr: utils.NewValidation("interval must be day or week"), wantStatus: fiber.StatusBadRequest},
+		{name: "invalid date", query: "?from=yesterday", wantStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var gotInterval string
+			ctrl := NewAnalyticsController(&mockAnalyticsService{
+				cumulativeFlowFunc: func(ctx context.Context, boardID, userID string, from, to time.Time, interval string) (*services.CumulativeFlow, error) {
+					gotInterval = interval
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &services.CumulativeFlow{BoardID: boardID, Points: []services.CFDPoint{{Closed: tt.closed}}}, nil
+				},
+			})
+			app.Get("/boards/:id/cfd", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user-123")
+				return ctrl.CumulativeFlow(c)
+			})
+
+			req := httptest.NewRequest("GET", "/boards/board-1/cfd"+tt.query, nil)
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantStatus == fiber.StatusOK {
+				assert.Equal(t, tt.wantInterval, gotInterval)
+				assert.Equal(t, tt.wantCache, resp.Header.Get(fiber.HeaderCacheControl))
+			}
+		})
+	}
+}
+
+func TestAnalyticsController_BoardAnalytics_InvalidRange(t *testing.T) {
+	app := fiber.New()
+
+	ctrl := NewAnalyticsController(&mockAnalyticsService{})
+	app.Get("/boards/:id/analytics", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.BoardAnalytics(c)
+	})
+
+	req := httptest.NewRequest("GET", "/boards/board-1/analytics?from=2026-03-31&to=2026-03-01", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
+
+func TestAnalyticsController_SetColumnStage(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", body: `{"stage":"done"}`, wantStatus: fiber.StatusOK},
+		{name: "missing stage", body: `{}`, wantStatus: fiber.StatusBadRequest},
+		{name: "invalid stage", body: `{"stage":"archived"}`, err: utils.NewValidation("stage must be one of todo, in_progress, done"), wantStatus: fiber.StatusBadRequest},
+		{name: "column not found", body: `{"stage":"done"}`, err: utils.NewNotFound("column not found"), wantStatus: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			ctrl := NewAnalyticsController(&mockAnalyticsService{
+				setColumnStageFunc: func(ctx context.Context, boardID, columnID, userID, stage string) (*models.Column, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &models.Column{ID: columnID, BoardID: boardID, Stage: stage}, nil
+				},
+			})
+			app.Put("/boards/:id/columns/:column_id/stage", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user-123")
+				return ctrl.SetColumnStage(c)
+			})
+
+			req := httptest.NewRequest("PUT", "/boards/board-1/columns/col-1/stage", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
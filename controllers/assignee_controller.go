@@ -0,0 +1,106 @@
+package controllers
+
+import (
+	"errors"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AssigneeController struct {
+	assigneeService services.AssigneeService
+}
+
+func NewAssigneeController(assigneeService services.AssigneeService) *AssigneeController {
+	return &AssigneeController{
+		assigneeService: assigneeService,
+	}
+}
+
+func (ctrl *AssigneeController) FindByTaskID(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	taskID := c.Params("id")
+
+	if taskID == "" {
+		return utils.ValidationError(c, "id", "task id is required")
+	}
+
+	assignees, err := ctrl.assigneeService.FindByTaskID(c.UserContext(), taskID, userID)
+	if err != nil {
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		var unauthorizedErr utils.ErrUnauthorized
+		if errors.As(err, &unauthorizedErr) {
+			return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+		}
+		return utils.Error(c, "Failed to find assignees", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, assignees)
+}
+
+func (ctrl *AssigneeController) AddToTask(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	taskID := c.Params("id")
+	assigneeID := c.Params("user_id")
+
+	if taskID == "" {
+		return utils.ValidationError(c, "id", "task id is required")
+	}
+
+	if assigneeID == "" {
+		return utils.ValidationError(c, "user_id", "user id is required")
+	}
+
+	err := ctrl.assigneeService.AddToTask(c.UserContext(), taskID, assigneeID, userID)
+	if err != nil {
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		var unauthorizedErr utils.ErrUnauthorized
+		if errors.As(err, &unauthorizedErr) {
+			return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+		}
+		return utils.Error(c, "Failed to assign user to task", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "User assigned to task successfully",
+	})
+}
+
+func (ctrl *AssigneeController) RemoveFromTask(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	taskID := c.Params("id")
+	assigneeID := c.Params("user_id")
+
+	if taskID == "" {
+		return utils.ValidationError(c, "id", "task id is required")
+	}
+
+	if assigneeID == "" {
+		return utils.ValidationError(c, "user_id", "user id is required")
+	}
+
+	err := ctrl.assigneeService.RemoveFromTask(c.UserContext(), taskID, assigneeID, userID)
+	if err != nil {
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		var unauthorizedErr utils.ErrUnauthorized
+		if errors.As(err, &unauthorizedErr) {
+			return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+		}
+		return utils.Error(c, "Failed to unassign user from task", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "User unassigned from task successfully",
+	})
+}
@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockAssigneeService struct {
+	addToTaskFunc      func(ctx context.Context, taskID, assigneeID, userID string) error
+	removeFromTaskFunc func(ctx context.Context, taskID, assigneeID, userID string) error
+	findByTaskIDFunc   func(ctx context.Context, taskID, userID string) ([]*models.TaskAssignee, error)
+}
+
+func (m *mockAssigneeService) AddToTask(ctx context.Context, taskID, assigneeID, userID string) error {
+	if m.addToTaskFunc != nil {
+		return m.addToTaskFunc(ctx, taskID, assigneeID, userID)
+	}
+	return nil
+}
+
+func (m *mockAssigneeService) RemoveFromTask(ctx context.Context, taskID, assigneeID, userID string) error {
+	if m.removeFromTaskFunc != nil {
+		return m.removeFromTaskFunc(ctx, taskID, assigneeID, userID)
+	}
+	return nil
+}
+
+func (m *mockAssigneeService) FindByTaskID(ctx context.Context, taskID, userID string) ([]*models.TaskAssignee, error) {
+	if m.findByTaskIDFunc != nil {
+		return m.findByTaskIDFunc(ctx, taskID, userID)
+	}
+	return []*models.TaskAssignee{{TaskID: taskID, UserID: "user-2"}}, nil
+}
+
+func TestAssigneeController_AddToTask_Success(t *testing.T) {
+	app := fiber.New()
+
+	ctrl := NewAssigneeController(&mockAssigneeService{})
+	app.Post("/tasks/:id/assignees/:user_id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.AddToTask(c)
+	})
+
+	req := httptest.NewRequest("POST", "/tasks/task-1/assignees/user-2", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), "User assigned to task successfully")
+}
+
+func TestAssigneeController_AddToTask_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "task not found", err: utils.NewNotFound("task not found"), wantStatus: fiber.StatusNotFound},
+		{name: "unauthorized", err: utils.NewUnauthorized("you do not have access to this task"), wantStatus: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			ctrl := NewAssigneeController(&mockAssigneeService{
+				addToTaskFunc: func(ctx context.Context, taskID, assigneeID, userID string) error {
+					return tt.err
+				},
+			})
+			app.Post("/tasks/:id/assignees/:user_id", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user-123")
+				return ctrl.AddToTask(c)
+			})
+
+			req := httptest.NewRequest("POST", "/tasks/task-1/assignees/user-2", nil)
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestAssigneeController_RemoveFromTask_Success(t *testing.T) {
+	app := fiber.New()
+
+	ctrl := NewAssigneeController(&mockAssigneeService{})
+	app.Delete("/tasks/:id/assignees/:user_id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.RemoveFromTask(c)
+	})
+
+	req := httptest.NewRequest("DELETE", "/tasks/task-1/assignees/user-2", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestAssigneeController_FindByTaskID_Success(t *testing.T) {
+	app := fiber.New()
+
+	ctrl := NewAssigneeController(&mockAssigneeService{})
+	app.Get("/tasks/:id/assignees", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.FindByTaskID(c)
+	})
+
+	req := httptest.NewRequest("GET", "/tasks/task-1/assignees", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"user_id":"user-2"`)
+}
@@ -84,7 +84,7 @@ func (ctrl *AttachmentController) Create(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "file_url", "file_url is required")
 	}
 
-	attachment, err := ctrl.attachmentService.Create(c.Context(), req.TaskID, userID, req.FileName, req.FileURL, req.FileSize)
+	attachment, err := ctrl.attachmentService.Create(c.UserContext(), req.TaskID, userID, req.FileName, req.FileURL, req.FileSize)
 	if err != nil {
 		var validationErr utils.ErrValidation
 		if errors.As(err, &validationErr) {
@@ -104,7 +104,7 @@ func (ctrl *AttachmentController) FindByID(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "id", "attachment id is required")
 	}
 
-	attachment, err := ctrl.attachmentService.FindByID(c.Context(), attachmentID, userID)
+	attachment, err := ctrl.attachmentService.FindByID(c.UserContext(), attachmentID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -132,7 +132,7 @@ func (ctrl *AttachmentController) FindByTaskID(c *fiber.Ctx) error {
 	c.QueryParser(&req)
 	utils.ValidatePagination(&req)
 
-	attachments, total, err := ctrl.attachmentService.FindByTaskIDWithPagination(c.Context(), taskID, userID, req.Page, req.Limit)
+	attachments, total, err := ctrl.attachmentService.FindByTaskIDWithPagination(c.UserContext(), taskID, userID, req.Page, req.Limit)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -161,7 +161,7 @@ func (ctrl *AttachmentController) Update(c *fiber.Ctx) error {
 		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
 	}
 
-	attachment, err := ctrl.attachmentService.Update(c.Context(), attachmentID, userID, req.FileName, req.FileURL, req.FileSize)
+	attachment, err := ctrl.attachmentService.Update(c.UserContext(), attachmentID, userID, req.FileName, req.FileURL, req.FileSize)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -185,7 +185,7 @@ func (ctrl *AttachmentController) Delete(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "id", "attachment id is required")
 	}
 
-	err := ctrl.attachmentService.Delete(c.Context(), attachmentID, userID)
+	err := ctrl.attachmentService.Delete(c.UserContext(), attachmentID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -71,7 +71,7 @@ func (ctrl *AuthController) Register(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "password", "password must be at least 8 characters long")
 	}
 
-	user, err := ctrl.authService.Register(c.Context(), req.Username, req.Email, req.Password)
+	user, err := ctrl.authService.Register(c.UserContext(), req.Username, req.Email, req.Password)
 	if err != nil {
 		var conflictErr utils.ErrConflict
 		if errors.As(err, &conflictErr) {
@@ -113,7 +113,7 @@ func (ctrl *AuthController) Login(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "password", "password is required")
 	}
 
-	token, err := ctrl.authService.Login(c.Context(), req.Email, req.Password)
+	token, err := ctrl.authService.Login(c.UserContext(), req.Email, req.Password)
 	if err != nil {
 		var unauthorizedErr utils.ErrUnauthorized
 		if errors.As(err, &unauthorizedErr) {
@@ -121,7 +121,7 @@ func (ctrl *AuthController) Login(c *fiber.Ctx) error {
 		}
 		return utils.Error(c, "Failed to login", fiber.StatusInternalServerError)
 	}
-	user, err := ctrl.authService.GetUserByID(c.Context(), req.Email)
+	user, err := ctrl.authService.GetUserByID(c.UserContext(), req.Email)
 	if err != nil {
 		return utils.Error(c, "Failed to get user", fiber.StatusInternalServerError)
 	}
@@ -136,7 +136,7 @@ func (ctrl *AuthController) Login(c *fiber.Ctx) error {
 func (ctrl *AuthController) Me(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(string)
 
-	user, err := ctrl.authService.GetUserByID(c.Context(), userID)
+	user, err := ctrl.authService.GetUserByID(c.UserContext(), userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -21,37 +21,59 @@ func NewBoardController(boardService services.BoardService) *BoardController {
 	}
 }
 
+// TemplateID is optional and names a built-in or saved board template.
+// TaskKeyPrefix is optional and derived from the title when left out.
 type CreateBoardRequest struct {
-	Title string `json:"title"`
-	Color string `json:"color"`
+	Title         string `json:"title"`
+	Color         string `json:"color"`
+	TaskKeyPrefix string `json:"task_key_prefix,omitempty"`
+	TemplateID    string `json:"template_id,omitempty"`
 }
 
+// Changing TaskKeyPrefix re-keys every task on the board
 type UpdateBoardRequest struct {
-	Title string `json:"title"`
-	Color string `json:"color"`
+	Title         string `json:"title"`
+	Color         string `json:"color"`
+	TaskKeyPrefix string `json:"task_key_prefix,omitempty"`
+}
+
+// DuplicateBoardRequest selects what is copied besides the columns; comments,
+// labels and attachments require tasks
+type DuplicateBoardRequest struct {
+	Title       string `json:"title"`
+	Tasks       bool   `json:"tasks"`
+	Comments    bool   `json:"comments"`
+	Labels      bool   `json:"labels"`
+	Attachments bool   `json:"attachments"`
 }
 
 type BoardResponse struct {
-	ID        string          `json:"id"`
-	Title     string          `json:"title"`
-	Color     string          `json:"color"`
-	UserID    string          `json:"user_id"`
-	CreatedAt time.Time       `json:"created_at"`
-	UpdatedAt time.Time       `json:"updated_at"`
-	Columns   []models.Column `json:"columns"`
-	Members   []models.Member `json:"members"`
+	ID            string               `json:"id"`
+	Title         string               `json:"title"`
+	Color         string               `json:"color"`
+	TaskKeyPrefix string               `json:"task_key_prefix"`
+	UserID        string               `json:"user_id"`
+	ArchivedAt    *time.Time           `json:"archived_at,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+	Columns       []models.Column      `json:"columns"`
+	Members       []models.Member      `json:"members"`
+	CustomFields  []models.CustomField `json:"custom_fields,omitempty"`
 }
 
 func toBoardResponse(board *models.Board) BoardResponse {
 	return BoardResponse{
-		ID:        board.ID,
-		Title:     board.Title,
-		Color:     board.Color,
-		UserID:    board.UserID,
-		CreatedAt: board.CreatedAt,
-		UpdatedAt: board.UpdatedAt,
-		Columns:   board.Columns,
-		Members:   board.Members,
+		ID:            board.ID,
+		Title:         board.Title,
+		Color:         board.Color,
+		TaskKeyPrefix: board.TaskKeyPrefix,
+		UserID:        board.UserID,
+		ArchivedAt:    board.ArchivedAt,
+		CreatedAt:     board.CreatedAt,
+		UpdatedAt:     board.UpdatedAt,
+		Columns:       board.Columns,
+		Members:       board.Members,
+		CustomFields:  board.CustomFields,
 	}
 }
 
@@ -79,12 +101,20 @@ func (ctrl *BoardController) Create(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "color", "color is required")
 	}
 
-	board, err := ctrl.boardService.Create(c.Context(), userID, req.Title, req.Color)
+	board, err := ctrl.boardService.Create(c.UserContext(), userID, req.Title, req.Color, req.TaskKeyPrefix, req.TemplateID)
 	if err != nil {
 		var validationErr utils.ErrValidation
 		if errors.As(err, &validationErr) {
 			return utils.Error(c, err.Error(), fiber.StatusBadRequest)
 		}
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		var unauthorizedErr utils.ErrUnauthorized
+		if errors.As(err, &unauthorizedErr) {
+			return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+		}
 		return utils.Error(c, "Failed to create board", fiber.StatusInternalServerError)
 	}
 
@@ -99,7 +129,7 @@ func (ctrl *BoardController) FindByID(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "id", "board id is required")
 	}
 
-	board, err := ctrl.boardService.FindByID(c.Context(), boardID, userID)
+	board, err := ctrl.boardService.FindByID(c.UserContext(), boardID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -123,8 +153,9 @@ func (ctrl *BoardController) FindAll(c *fiber.Ctx) error {
 	utils.ValidatePagination(&req)
 
 	title := c.Query("title")
+	archived := c.QueryBool("archived")
 
-	boards, total, err := ctrl.boardService.FindByUserIDWithFilters(c.Context(), userID, title, req.Page, req.Limit)
+	boards, total, err := ctrl.boardService.FindByUserIDWithFilters(c.UserContext(), userID, title, archived, req.Page, req.Limit)
 	if err != nil {
 		return utils.Error(c, "Failed to find boards", fiber.StatusInternalServerError)
 	}
@@ -145,8 +176,12 @@ func (ctrl *BoardController) Update(c *fiber.Ctx) error {
 		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
 	}
 
-	board, err := ctrl.boardService.Update(c.Context(), boardID, userID, req.Title, req.Color)
+	board, err := ctrl.boardService.Update(c.UserContext(), boardID, userID, req.Title, req.Color, req.TaskKeyPrefix)
 	if err != nil {
+		var validationErr utils.ErrValidation
+		if errors.As(err, &validationErr) {
+			return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+		}
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
 			return utils.Error(c, err.Error(), fiber.StatusNotFound)
@@ -169,7 +204,7 @@ func (ctrl *BoardController) Delete(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "id", "board id is required")
 	}
 
-	err := ctrl.boardService.Delete(c.Context(), boardID, userID)
+	err := ctrl.boardService.Delete(c.UserContext(), boardID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -187,6 +222,80 @@ func (ctrl *BoardController) Delete(c *fiber.Ctx) error {
 	})
 }
 
+func (ctrl *BoardController) Archive(c *fiber.Ctx) error {
+	return ctrl.setArchived(c, true)
+}
+
+func (ctrl *BoardController) Unarchive(c *fiber.Ctx) error {
+	return ctrl.setArchived(c, false)
+}
+
+func (ctrl *BoardController) setArchived(c *fiber.Ctx, archived bool) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	board, err := ctrl.boardService.Archive(c.UserContext(), boardID, userID, archived)
+	if err != nil {
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		var unauthorizedErr utils.ErrUnauthorized
+		if errors.As(err, &unauthorizedErr) {
+			return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+		}
+		return utils.Error(c, "Failed to archive board", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, toBoardResponse(board))
+}
+
+func (ctrl *BoardController) Duplicate(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	var req DuplicateBoardRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+		}
+	}
+
+	options := models.BoardCopyOptions{
+		Tasks:       req.Tasks,
+		Comments:    req.Comments,
+		Labels:      req.Labels,
+		Attachments: req.Attachments,
+	}
+
+	board, err := ctrl.boardService.Duplicate(c.UserContext(), boardID, userID, req.Title, options)
+	if err != nil {
+		var validationErr utils.ErrValidation
+		if errors.As(err, &validationErr) {
+			return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+		}
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		var unauthorizedErr utils.ErrUnauthorized
+		if errors.As(err, &unauthorizedErr) {
+			return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+		}
+		return utils.Error(c, "Failed to duplicate board", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, toBoardResponse(board))
+}
+
 func (ctrl *BoardController) Search(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(string)
 
@@ -199,7 +308,7 @@ func (ctrl *BoardController) Search(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "keyword", "keyword is required")
 	}
 
-	boards, total, err := ctrl.boardService.Search(c.Context(), userID, keyword, req.Page, req.Limit)
+	boards, total, err := ctrl.boardService.Search(c.UserContext(), userID, keyword, req.Page, req.Limit)
 	if err != nil {
 		return utils.Error(c, "Failed to search boards", fiber.StatusInternalServerError)
 	}
@@ -1,14 +1,20 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
+	"log/slog"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"kanban-backend/logging"
+	"kanban-backend/middleware"
 	"kanban-backend/models"
 	"kanban-backend/utils"
 
@@ -17,18 +23,20 @@ import (
 )
 
 type mockBoardService struct {
-	createFunc                  func(ctx context.Context, userID, title, color string) (*models.Board, error)
+	createFunc                  func(ctx context.Context, userID, title, color, taskKeyPrefix, templateID string) (*models.Board, error)
 	findByIDFunc                func(ctx context.Context, boardID, userID string) (*models.Board, error)
 	findByUserIDFunc            func(ctx context.Context, userID string) ([]*models.Board, error)
-	findByUserIDWithFiltersFunc func(ctx context.Context, userID string, title string, page, limit int) ([]*models.Board, int, error)
+	findByUserIDWithFiltersFunc func(ctx context.Context, userID string, title string, archived bool, page, limit int) ([]*models.Board, int, error)
 	searchFunc                  func(ctx context.Context, userID string, keyword string, page, limit int) ([]*models.Board, int, error)
-	updateFunc                  func(ctx context.Context, boardID, userID, title, color string) (*models.Board, error)
+	updateFunc                  func(ctx context.Context, boardID, userID, title, color, taskKeyPrefix string) (*models.Board, error)
 	deleteFunc                  func(ctx context.Context, boardID, userID string) error
+	duplicateFunc               func(ctx context.Context, boardID, userID, title string, options models.BoardCopyOptions) (*models.Board, error)
+	archiveFunc                 func(ctx context.Context, boardID, userID string, archived bool) (*models.Board, error)
 }
 
-func (m *mockBoardService) Create(ctx context.Context, userID, title, color string) (*models.Board, error) {
+func (m *mockBoardService) Create(ctx context.Context, userID, title, color, taskKeyPrefix, templateID string) (*models.Board, error) {
 	if m.createFunc != nil {
-		return m.createFunc(ctx, userID, title, color)
+		return m.createFunc(ctx, userID, title, color, taskKeyPrefix, templateID)
 	}
 	board := &models.Board{
 		ID:        "board-123",
@@ -91,9 +99,9 @@ func (m *mockBoardService) FindByUserID(ctx context.Context, userID string) ([]*
 	return boards, nil
 }
 
-func (m *mockBoardService) Update(ctx context.Context, boardID, userID, title, color string) (*models.Board, error) {
+func (m *mockBoardService) Update(ctx context.Context, boardID, userID, title, color, taskKeyPrefix string) (*models.Board, error) {
 	if m.updateFunc != nil {
-		return m.updateFunc(ctx, boardID, userID, title, color)
+		return m.updateFunc(ctx, boardID, userID, title, color, taskKeyPrefix)
 	}
 	board := &models.Board{
 		ID:        boardID,
@@ -114,9 +122,23 @@ func (m *mockBoardService) Delete(ctx context.Context, boardID, userID string) e
 	return nil
 }
 
-func (m *mockBoardService) FindByUserIDWithFilters(ctx context.Context, userID string, title string, page, limit int) ([]*models.Board, int, error) {
+func (m *mockBoardService) Archive(ctx context.Context, boardID, userID string, archived bool) (*models.Board, error) {
+	if m.archiveFunc != nil {
+		return m.archiveFunc(ctx, boardID, userID, archived)
+	}
+	return &models.Board{ID: boardID, UserID: userID}, nil
+}
+
+func (m *mockBoardService) Duplicate(ctx context.Context, boardID, userID, title string, options models.BoardCopyOptions) (*models.Board, error) {
+	if m.duplicateFunc != nil {
+		return m.duplicateFunc(ctx, boardID, userID, title, options)
+	}
+	return &models.Board{ID: "board-copy", Title: title, UserID: userID}, nil
+}
+
+func (m *mockBoardService) FindByUserIDWithFilters(ctx context.Context, userID string, title string, archived bool, page, limit int) ([]*models.Board, int, error) {
 	if m.findByUserIDWithFiltersFunc != nil {
-		return m.findByUserIDWithFiltersFunc(ctx, userID, title, page, limit)
+		return m.findByUserIDWithFiltersFunc(ctx, userID, title, archived, page, limit)
 	}
 	boards := []*models.Board{
 		{
@@ -162,7 +184,7 @@ func TestBoardController_Create_Success(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockBoardService{
-		createFunc: func(ctx context.Context, userID, title, color string) (*models.Board, error) {
+		createFunc: func(ctx context.Context, userID, title, color, taskKeyPrefix, templateID string) (*models.Board, error) {
 			board := &models.Board{
 				ID:        "board-123",
 				Title:     title,
@@ -268,7 +290,7 @@ func TestBoardController_Create_ServiceError(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockBoardService{
-		createFunc: func(ctx context.Context, userID, title, color string) (*models.Board, error) {
+		createFunc: func(ctx context.Context, userID, title, color, taskKeyPrefix, templateID string) (*models.Board, error) {
 			return nil, utils.NewValidation("board title must be at least 3 characters")
 		},
 	}
@@ -395,7 +417,7 @@ func TestBoardController_FindAll_Success(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockBoardService{
-		findByUserIDWithFiltersFunc: func(ctx context.Context, userID string, title string, page, limit int) ([]*models.Board, int, error) {
+		findByUserIDWithFiltersFunc: func(ctx context.Context, userID string, title string, archived bool, page, limit int) ([]*models.Board, int, error) {
 			boards := []*models.Board{
 				{
 					ID:        "board-1",
@@ -448,7 +470,7 @@ func TestBoardController_Update_Success(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockBoardService{
-		updateFunc: func(ctx context.Context, boardID, userID, title, color string) (*models.Board, error) {
+		updateFunc: func(ctx context.Context, boardID, userID, title, color, taskKeyPrefix string) (*models.Board, error) {
 			board := &models.Board{
 				ID:        boardID,
 				Title:     title,
@@ -490,7 +512,7 @@ func TestBoardController_Update_NotFound(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockBoardService{
-		updateFunc: func(ctx context.Context, boardID, userID, title, color string) (*models.Board, error) {
+		updateFunc: func(ctx context.Context, boardID, userID, title, color, taskKeyPrefix string) (*models.Board, error) {
 			return nil, utils.NewNotFound("board not found")
 		},
 	}
@@ -600,3 +622,131 @@ func TestBoardController_ServiceError(t *testing.T) {
 	assert.Contains(t, respBody, `"success":false`)
 	assert.Contains(t, respBody, "Failed to find board")
 }
+
+func TestBoardController_Create_WithTemplate(t *testing.T) {
+	tests := []struct {
+		name           string
+		templateID     string
+		wantStatusCode int
+	}{
+		{name: "built-in template", templateID: "scrum", wantStatusCode: fiber.StatusOK},
+		{name: "unknown template", templateID: "missing", wantStatusCode: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var gotTemplateID string
+			mockService := &mockBoardService{
+				createFunc: func(ctx context.Context, userID, title, color, taskKeyPrefix, templateID string) (*models.Board, error) {
+					gotTemplateID = templateID
+					if templateID == "missing" {
+						return nil, utils.NewNotFound("board template not found")
+					}
+					return &models.Board{ID: "board-123", Title: title, UserID: userID, Color: color}, nil
+				},
+			}
+
+			ctrl := NewBoardController(mockService)
+			app.Post("/boards", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user-123")
+				return ctrl.Create(c)
+			})
+
+			reqBody := `{"title":"My Board","color":"#ff0000","template_id":"` + tt.templateID + `"}`
+			req := httptest.NewRequest("POST", "/boards", strings.NewReader(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.templateID, gotTemplateID)
+		})
+	}
+}
+
+func TestBoardController_Duplicate(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		wantStatusCode int
+		wantOptions    models.BoardCopyOptions
+	}{
+		{name: "columns only without body", wantStatusCode: fiber.StatusOK},
+		{name: "tasks with labels", body: `{"title":"Copy","tasks":true,"labels":true}`, wantStatusCode: fiber.StatusOK, wantOptions: models.BoardCopyOptions{Tasks: true, Labels: true}},
+		{name: "invalid options", body: `{"comments":true}`, serviceErr: utils.NewValidation("comments, labels and attachments can only be copied together with tasks"), wantStatusCode: fiber.StatusBadRequest, wantOptions: models.BoardCopyOptions{Comments: true}},
+		{name: "board not found", serviceErr: utils.NewNotFound("board not found"), wantStatusCode: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var gotOptions models.BoardCopyOptions
+			mockService := &mockBoardService{
+				duplicateFunc: func(ctx context.Context, boardID, userID, title string, options models.BoardCopyOptions) (*models.Board, error) {
+					gotOptions = options
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &models.Board{ID: "board-copy", Title: title, UserID: userID}, nil
+				},
+			}
+
+			ctrl := NewBoardController(mockService)
+			app.Post("/boards/:id/duplicate", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user-123")
+				return ctrl.Duplicate(c)
+			})
+
+			req := httptest.NewRequest("POST", "/boards/board-123/duplicate", strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatusCode, resp.StatusCode)
+			assert.Equal(t, tt.wantOptions, gotOptions)
+		})
+	}
+}
+
+func TestBoardController_RequestIDReachesServiceLogs(t *testing.T) {
+	var out bytes.Buffer
+	logging.Setup(&out, "info")
+	defer logging.Setup(os.Stderr, "info")
+
+	ctrl := NewBoardController(&mockBoardService{
+		findByIDFunc: func(ctx context.Context, boardID, userID string) (*models.Board, error) {
+			slog.InfoContext(ctx, "loading board", "board_id", boardID)
+			return &models.Board{ID: boardID, UserID: userID}, nil
+		},
+	})
+
+	app := fiber.New()
+	app.Use(middleware.Logger())
+	app.Get("/boards/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.FindByID(c)
+	})
+
+	req := httptest.NewRequest("GET", "/boards/board-123", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-abc")
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		if record["msg"] == "loading board" {
+			found = true
+			assert.Equal(t, "req-abc", record["request_id"])
+		}
+	}
+	assert.True(t, found, "service log record missing:\n%s", out.String())
+}
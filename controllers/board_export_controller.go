@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	"kanban-backend/models"
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BoardExportController struct {
+	exportService services.BoardExportService
+}
+
+func NewBoardExportController(exportService services.BoardExportService) *BoardExportController {
+	return &BoardExportController{
+		exportService: exportService,
+	}
+}
+
+// Export downloads the board as a JSON document. The document is returned
+// bare rather than in the usual response envelope so it can be posted back to
+// Import unchanged.
+func (ctrl *BoardExportController) Export(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	export, err := ctrl.exportService.Export(c.UserContext(), boardID, userID)
+	if err != nil {
+		return boardExportError(c, err, "Failed to export board")
+	}
+
+	c.Attachment(fmt.Sprintf("board-%s.json", boardID))
+	return c.JSON(export)
+}
+
+// Import creates a new board owned by the caller from an export document
+func (ctrl *BoardExportController) Import(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	var export models.BoardExport
+	if err := c.BodyParser(&export); err != nil {
+		return utils.Error(c, "Invalid export document", fiber.StatusBadRequest)
+	}
+
+	board, err := ctrl.exportService.Import(c.UserContext(), userID, &export)
+	if err != nil {
+		return boardExportError(c, err, "Failed to import board")
+	}
+
+	return utils.Success(c, board)
+}
+
+func boardExportError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"errors"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BoardTemplateController struct {
+	templateService services.BoardTemplateService
+}
+
+func NewBoardTemplateController(templateService services.BoardTemplateService) *BoardTemplateController {
+	return &BoardTemplateController{
+		templateService: templateService,
+	}
+}
+
+type SaveBoardTemplateRequest struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	IncludeTasks bool   `json:"include_tasks"`
+}
+
+func (ctrl *BoardTemplateController) FindAll(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	templates, err := ctrl.templateService.FindAll(c.UserContext(), userID)
+	if err != nil {
+		return utils.Error(c, "Failed to find board templates", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, templates)
+}
+
+func (ctrl *BoardTemplateController) FindByID(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	templateID := c.Params("id")
+
+	if templateID == "" {
+		return utils.ValidationError(c, "id", "template id is required")
+	}
+
+	template, err := ctrl.templateService.FindByID(c.UserContext(), templateID, userID)
+	if err != nil {
+		return templateError(c, err, "Failed to find board template")
+	}
+
+	return utils.Success(c, template)
+}
+
+// SaveFromBoard stores an existing board's layout as a new template
+func (ctrl *BoardTemplateController) SaveFromBoard(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	var req SaveBoardTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+	}
+
+	if req.Name == "" {
+		return utils.ValidationError(c, "name", "name is required")
+	}
+
+	template, err := ctrl.templateService.SaveFromBoard(c.UserContext(), boardID, userID, req.Name, req.Description, req.IncludeTasks)
+	if err != nil {
+		return templateError(c, err, "Failed to save board template")
+	}
+
+	return utils.Success(c, template)
+}
+
+func (ctrl *BoardTemplateController) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	templateID := c.Params("id")
+
+	if templateID == "" {
+		return utils.ValidationError(c, "id", "template id is required")
+	}
+
+	if err := ctrl.templateService.Delete(c.UserContext(), templateID, userID); err != nil {
+		return templateError(c, err, "Failed to delete board template")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Board template deleted successfully",
+	})
+}
+
+func templateError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
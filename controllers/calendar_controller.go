@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CalendarController struct {
+	calendarService services.CalendarService
+}
+
+func NewCalendarController(calendarService services.CalendarService) *CalendarController {
+	return &CalendarController{
+		calendarService: calendarService,
+	}
+}
+
+type CreateCalendarTokenRequest struct {
+	Name string `json:"name"`
+}
+
+// CalendarTokenResponse is returned once when a token is created; URL is the
+// feed address to subscribe to from a calendar app
+type CalendarTokenResponse struct {
+	*services.CreatedCalendarToken
+	URL string `json:"url"`
+}
+
+func (ctrl *CalendarController) CreateToken(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	var req CreateCalendarTokenRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+		}
+	}
+
+	token, err := ctrl.calendarService.CreateToken(c.UserContext(), userID, req.Name)
+	if err != nil {
+		return utils.Error(c, "Failed to create calendar token", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, CalendarTokenResponse{
+		CreatedCalendarToken: token,
+		URL:                  c.BaseURL() + "/calendar/" + token.Token + ".ics",
+	})
+}
+
+func (ctrl *CalendarController) FindTokens(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	tokens, err := ctrl.calendarService.FindTokens(c.UserContext(), userID)
+	if err != nil {
+		return utils.Error(c, "Failed to find calendar tokens", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, tokens)
+}
+
+func (ctrl *CalendarController) RevokeToken(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	tokenID := c.Params("id")
+
+	if tokenID == "" {
+		return utils.ValidationError(c, "id", "calendar token id is required")
+	}
+
+	if err := ctrl.calendarService.RevokeToken(c.UserContext(), tokenID, userID); err != nil {
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		return utils.Error(c, "Failed to revoke calendar token", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Calendar token revoked successfully",
+	})
+}
+
+// Feed serves the iCalendar feed. Calendar apps cannot send bearer tokens, so
+// the secret in the path is the only credential; ?board_id= narrows the feed
+// to one board.
+func (ctrl *CalendarController) Feed(c *fiber.Ctx) error {
+	token := c.Params("token")
+
+	feed, err := ctrl.calendarService.Feed(c.UserContext(), token, c.Query("board_id"), time.Now().UTC())
+	if err != nil {
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		return utils.Error(c, "Failed to render calendar", fiber.StatusInternalServerError)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return c.SendString(feed)
+}
@@ -76,7 +76,7 @@ func (ctrl *CommentController) Create(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "content", "content is required")
 	}
 
-	comment, err := ctrl.commentService.Create(c.Context(), req.TaskID, userID, req.Content)
+	comment, err := ctrl.commentService.Create(c.UserContext(), req.TaskID, userID, req.Content)
 	if err != nil {
 		var validationErr utils.ErrValidation
 		if errors.As(err, &validationErr) {
@@ -96,7 +96,7 @@ func (ctrl *CommentController) FindByID(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "id", "comment id is required")
 	}
 
-	comment, err := ctrl.commentService.FindByID(c.Context(), commentID, userID)
+	comment, err := ctrl.commentService.FindByID(c.UserContext(), commentID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -124,7 +124,7 @@ func (ctrl *CommentController) FindByTaskID(c *fiber.Ctx) error {
 	c.QueryParser(&req)
 	utils.ValidatePagination(&req)
 
-	comments, total, err := ctrl.commentService.FindByTaskIDWithPagination(c.Context(), taskID, userID, req.Page, req.Limit)
+	comments, total, err := ctrl.commentService.FindByTaskIDWithPagination(c.UserContext(), taskID, userID, req.Page, req.Limit)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -157,7 +157,7 @@ func (ctrl *CommentController) Update(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "content", "content is required")
 	}
 
-	comment, err := ctrl.commentService.Update(c.Context(), commentID, userID, req.Content)
+	comment, err := ctrl.commentService.Update(c.UserContext(), commentID, userID, req.Content)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -181,7 +181,7 @@ func (ctrl *CommentController) Delete(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "id", "comment id is required")
 	}
 
-	err := ctrl.commentService.Delete(c.Context(), commentID, userID)
+	err := ctrl.commentService.Delete(c.UserContext(), commentID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
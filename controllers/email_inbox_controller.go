@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type EmailInboxController struct {
+	inboxService services.EmailInboxService
+}
+
+func NewEmailInboxController(inboxService services.EmailInboxService) *EmailInboxController {
+	return &EmailInboxController{
+		inboxService: inboxService,
+	}
+}
+
+// ConfigureEmailInboxRequest sets the column emailed tasks are created in.
+// An omitted column is left as it is; an empty string uses the board's first
+// column.
+type ConfigureEmailInboxRequest struct {
+	ColumnID      *string `json:"column_id,omitempty"`
+	RotateAddress bool    `json:"rotate_address,omitempty"`
+}
+
+func (ctrl *EmailInboxController) Configure(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	var req ConfigureEmailInboxRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+		}
+	}
+
+	inbox, err := ctrl.inboxService.Configure(c.UserContext(), boardID, userID, req.ColumnID, req.RotateAddress)
+	if err != nil {
+		return emailInboxError(c, err, "Failed to configure email inbox")
+	}
+
+	return utils.Success(c, inbox)
+}
+
+func (ctrl *EmailInboxController) Find(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	inbox, err := ctrl.inboxService.Find(c.UserContext(), boardID, userID)
+	if err != nil {
+		return emailInboxError(c, err, "Failed to find email inbox")
+	}
+
+	return utils.Success(c, inbox)
+}
+
+func (ctrl *EmailInboxController) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	if err := ctrl.inboxService.Delete(c.UserContext(), boardID, userID); err != nil {
+		return emailInboxError(c, err, "Failed to delete email inbox")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Email inbox deleted successfully",
+	})
+}
+
+// Receive accepts a raw RFC 5322 message from the mail relay. The relay
+// authenticates with the shared secret and may pass the envelope recipients,
+// comma separated, since Bcc and forwarded mail do not name them in headers.
+func (ctrl *EmailInboxController) Receive(c *fiber.Ctx) error {
+	secret := c.Get("X-Inbound-Email-Secret")
+	if secret == "" {
+		secret = c.Query("token")
+	}
+
+	envelope := c.Get("X-Envelope-To")
+	if envelope == "" {
+		envelope = c.Query("recipient")
+	}
+	var recipients []string
+	for _, recipient := range strings.Split(envelope, ",") {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			recipients = append(recipients, recipient)
+		}
+	}
+
+	result, err := ctrl.inboxService.Receive(c.UserContext(), secret, recipients, c.Body())
+	if err != nil {
+		return emailInboxError(c, err, "Failed to process email")
+	}
+
+	return utils.Success(c, result)
+}
+
+func emailInboxError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
@@ -0,0 +1,132 @@
+package controllers
+
+import (
+	"errors"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type GitIntegrationController struct {
+	gitService services.GitIntegrationService
+}
+
+func NewGitIntegrationController(gitService services.GitIntegrationService) *GitIntegrationController {
+	return &GitIntegrationController{
+		gitService: gitService,
+	}
+}
+
+// ConfigureGitIntegrationRequest sets the columns tasks move to when their pull
+// request is opened or merged. Omitted columns are left as they are; an empty
+// string turns the move off.
+type ConfigureGitIntegrationRequest struct {
+	OpenedColumnID *string `json:"opened_column_id,omitempty"`
+	MergedColumnID *string `json:"merged_column_id,omitempty"`
+	RotateSecret   bool    `json:"rotate_secret,omitempty"`
+}
+
+// GitIntegrationResponse includes the address to register as the webhook URL
+// in GitHub or GitLab
+type GitIntegrationResponse struct {
+	*services.ConfiguredGitIntegration
+	WebhookURL string `json:"webhook_url"`
+}
+
+func (ctrl *GitIntegrationController) Configure(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	var req ConfigureGitIntegrationRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+		}
+	}
+
+	integration, err := ctrl.gitService.Configure(c.UserContext(), boardID, userID, req.OpenedColumnID, req.MergedColumnID, req.RotateSecret)
+	if err != nil {
+		return gitIntegrationError(c, err, "Failed to configure git integration")
+	}
+
+	return utils.Success(c, GitIntegrationResponse{
+		ConfiguredGitIntegration: integration,
+		WebhookURL:               c.BaseURL() + "/hooks/git/" + integration.ID,
+	})
+}
+
+func (ctrl *GitIntegrationController) Find(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	integration, err := ctrl.gitService.Find(c.UserContext(), boardID, userID)
+	if err != nil {
+		return gitIntegrationError(c, err, "Failed to find git integration")
+	}
+
+	return utils.Success(c, GitIntegrationResponse{
+		ConfiguredGitIntegration: &services.ConfiguredGitIntegration{GitIntegration: integration},
+		WebhookURL:               c.BaseURL() + "/hooks/git/" + integration.ID,
+	})
+}
+
+func (ctrl *GitIntegrationController) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	if err := ctrl.gitService.Delete(c.UserContext(), boardID, userID); err != nil {
+		return gitIntegrationError(c, err, "Failed to delete git integration")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Git integration deleted successfully",
+	})
+}
+
+// Webhook receives deliveries from GitHub or GitLab. It is authenticated by
+// the delivery's signature or token rather than a user session.
+func (ctrl *GitIntegrationController) Webhook(c *fiber.Ctx) error {
+	headers := services.GitWebhookHeaders{
+		GitHubEvent:     c.Get("X-GitHub-Event"),
+		GitHubSignature: c.Get("X-Hub-Signature-256"),
+		GitLabEvent:     c.Get("X-Gitlab-Event"),
+		GitLabToken:     c.Get("X-Gitlab-Token"),
+	}
+
+	result, err := ctrl.gitService.HandleWebhook(c.UserContext(), c.Params("id"), headers, c.Body())
+	if err != nil {
+		return gitIntegrationError(c, err, "Failed to process webhook")
+	}
+
+	return utils.Success(c, result)
+}
+
+func gitIntegrationError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"kanban-backend/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// HealthController serves the probes used by orchestrators and load
+// balancers. Their responses are plain JSON rather than the API envelope.
+type HealthController struct {
+	healthService services.HealthService
+}
+
+func NewHealthController(healthService services.HealthService) *HealthController {
+	return &HealthController{
+		healthService: healthService,
+	}
+}
+
+// Livez reports that the process is up and serving requests. It does not
+// check dependencies, so an outage of the database does not get the
+// instance restarted.
+func (ctrl *HealthController) Livez(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": services.HealthStatusOK})
+}
+
+// Readyz reports whether the instance can serve traffic, answering 503 when
+// a dependency is down or the instance is shutting down
+func (ctrl *HealthController) Readyz(c *fiber.Ctx) error {
+	report := ctrl.healthService.Ready(c.UserContext())
+	if !report.Ready() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(report)
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"kanban-backend/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockHealthService struct {
+	report *services.ReadinessReport
+}
+
+func (m *mockHealthService) Ready(ctx context.Context) *services.ReadinessReport {
+	return m.report
+}
+
+func (m *mockHealthService) Drain() {}
+
+func TestHealthController_Readyz(t *testing.T) {
+	tests := []struct {
+		name       string
+		report     *services.ReadinessReport
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "ready",
+			report:     &services.ReadinessReport{Status: services.HealthStatusOK, Checks: map[string]string{"database": services.HealthStatusOK}},
+			wantStatus: fiber.StatusOK,
+			wantBody:   `"database":"ok"`,
+		},
+		{
+			name:       "dependency down",
+			report:     &services.ReadinessReport{Status: services.HealthStatusUnavailable, Checks: map[string]string{"storage": services.HealthStatusFailed}},
+			wantStatus: fiber.StatusServiceUnavailable,
+			wantBody:   `"storage":"failed"`,
+		},
+		{
+			name:       "draining",
+			report:     &services.ReadinessReport{Status: services.HealthStatusDraining, Checks: map[string]string{}},
+			wantStatus: fiber.StatusServiceUnavailable,
+			wantBody:   `"status":"draining"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			ctrl := NewHealthController(&mockHealthService{report: tt.report})
+			app.Get("/readyz", ctrl.Readyz)
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/readyz", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+
+			body, _ := io.ReadAll(resp.Body)
+			assert.Contains(t, string(body), tt.wantBody)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"errors"
+	"io"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImportController struct {
+	importService services.ImportService
+}
+
+func NewImportController(importService services.ImportService) *ImportController {
+	return &ImportController{
+		importService: importService,
+	}
+}
+
+// ImportTrello starts importing a Trello board export, sent either as the
+// request body or as a multipart "file" upload. The import runs in the
+// background; poll FindJob with the returned job ID for progress.
+func (ctrl *ImportController) ImportTrello(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	data := c.Body()
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			return utils.Error(c, "Failed to read uploaded file", fiber.StatusBadRequest)
+		}
+		defer f.Close()
+
+		if data, err = io.ReadAll(f); err != nil {
+			return utils.Error(c, "Failed to read uploaded file", fiber.StatusBadRequest)
+		}
+	}
+
+	if len(data) == 0 {
+		return utils.ValidationError(c, "file", "a Trello board export is required")
+	}
+
+	job, err := ctrl.importService.StartTrelloImport(c.UserContext(), userID, data)
+	if err != nil {
+		return importError(c, err, "Failed to start import")
+	}
+
+	return utils.Success(c, job)
+}
+
+func (ctrl *ImportController) FindJob(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	jobID := c.Params("id")
+
+	if jobID == "" {
+		return utils.ValidationError(c, "id", "import job id is required")
+	}
+
+	job, err := ctrl.importService.FindJob(c.UserContext(), jobID, userID)
+	if err != nil {
+		return importError(c, err, "Failed to find import job")
+	}
+
+	return utils.Success(c, job)
+}
+
+func importError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
@@ -72,7 +72,7 @@ func (ctrl *LabelController) Create(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "name", "name is required")
 	}
 
-	label, err := ctrl.labelService.Create(c.Context(), req.Name, req.Color)
+	label, err := ctrl.labelService.Create(c.UserContext(), req.Name, req.Color)
 	if err != nil {
 		var validationErr utils.ErrValidation
 		if errors.As(err, &validationErr) {
@@ -91,7 +91,7 @@ func (ctrl *LabelController) FindByID(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "id", "label id is required")
 	}
 
-	label, err := ctrl.labelService.FindByID(c.Context(), labelID)
+	label, err := ctrl.labelService.FindByID(c.UserContext(), labelID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -108,7 +108,7 @@ func (ctrl *LabelController) FindAll(c *fiber.Ctx) error {
 	c.QueryParser(&req)
 	utils.ValidatePagination(&req)
 
-	labels, total, err := ctrl.labelService.FindAllWithPagination(c.Context(), req.Page, req.Limit)
+	labels, total, err := ctrl.labelService.FindAllWithPagination(c.UserContext(), req.Page, req.Limit)
 	if err != nil {
 		return utils.Error(c, "Failed to find labels", fiber.StatusInternalServerError)
 	}
@@ -128,7 +128,7 @@ func (ctrl *LabelController) Update(c *fiber.Ctx) error {
 		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
 	}
 
-	label, err := ctrl.labelService.Update(c.Context(), labelID, req.Name, req.Color)
+	label, err := ctrl.labelService.Update(c.UserContext(), labelID, req.Name, req.Color)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -147,7 +147,7 @@ func (ctrl *LabelController) Delete(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "id", "label id is required")
 	}
 
-	err := ctrl.labelService.Delete(c.Context(), labelID)
+	err := ctrl.labelService.Delete(c.UserContext(), labelID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -174,7 +174,7 @@ func (ctrl *LabelController) AddToTask(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "label_id", "label id is required")
 	}
 
-	err := ctrl.labelService.AddToTask(c.Context(), taskID, labelID, userID)
+	err := ctrl.labelService.AddToTask(c.UserContext(), taskID, labelID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -205,7 +205,7 @@ func (ctrl *LabelController) RemoveFromTask(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "label_id", "label id is required")
 	}
 
-	err := ctrl.labelService.RemoveFromTask(c.Context(), taskID, labelID, userID)
+	err := ctrl.labelService.RemoveFromTask(c.UserContext(), taskID, labelID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -233,7 +233,7 @@ func (ctrl *LabelController) Search(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "keyword", "keyword is required")
 	}
 
-	labels, total, err := ctrl.labelService.Search(c.Context(), keyword, req.Page, req.Limit)
+	labels, total, err := ctrl.labelService.Search(c.UserContext(), keyword, req.Page, req.Limit)
 	if err != nil {
 		return utils.Error(c, "Failed to search labels", fiber.StatusInternalServerError)
 	}
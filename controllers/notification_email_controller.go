@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"errors"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type NotificationEmailController struct {
+	emailService services.NotificationEmailService
+}
+
+func NewNotificationEmailController(emailService services.NotificationEmailService) *NotificationEmailController {
+	return &NotificationEmailController{
+		emailService: emailService,
+	}
+}
+
+// UpdateNotificationPreferencesRequest maps notification types to how they
+// are emailed: instant, hourly, daily or off. Types left out keep their
+// current setting.
+type UpdateNotificationPreferencesRequest struct {
+	Preferences map[string]string `json:"preferences"`
+}
+
+func (ctrl *NotificationEmailController) GetPreferences(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	preferences, err := ctrl.emailService.GetPreferences(c.UserContext(), userID)
+	if err != nil {
+		return utils.Error(c, "Failed to get notification preferences", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, preferences)
+}
+
+func (ctrl *NotificationEmailController) UpdatePreferences(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	var req UpdateNotificationPreferencesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+	}
+
+	preferences, err := ctrl.emailService.UpdatePreferences(c.UserContext(), userID, req.Preferences)
+	if err != nil {
+		var validationErr utils.ErrValidation
+		if errors.As(err, &validationErr) {
+			return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+		}
+		return utils.Error(c, "Failed to update notification preferences", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, preferences)
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"errors"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RuleController struct {
+	ruleService services.RuleService
+}
+
+func NewRuleController(ruleService services.RuleService) *RuleController {
+	return &RuleController{
+		ruleService: ruleService,
+	}
+}
+
+func (ctrl *RuleController) Create(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	var req services.RuleInput
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+	}
+
+	rule, err := ctrl.ruleService.Create(c.UserContext(), boardID, userID, req)
+	if err != nil {
+		return ruleError(c, err, "Failed to create rule")
+	}
+
+	return utils.Success(c, rule)
+}
+
+func (ctrl *RuleController) FindByBoardID(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	rules, err := ctrl.ruleService.FindByBoardID(c.UserContext(), boardID, userID)
+	if err != nil {
+		return ruleError(c, err, "Failed to find rules")
+	}
+
+	return utils.Success(c, rules)
+}
+
+func (ctrl *RuleController) Update(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	ruleID := c.Params("id")
+
+	if ruleID == "" {
+		return utils.ValidationError(c, "id", "rule id is required")
+	}
+
+	var req services.RuleInput
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+	}
+
+	rule, err := ctrl.ruleService.Update(c.UserContext(), ruleID, userID, req)
+	if err != nil {
+		return ruleError(c, err, "Failed to update rule")
+	}
+
+	return utils.Success(c, rule)
+}
+
+func (ctrl *RuleController) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	ruleID := c.Params("id")
+
+	if ruleID == "" {
+		return utils.ValidationError(c, "id", "rule id is required")
+	}
+
+	if err := ctrl.ruleService.Delete(c.UserContext(), ruleID, userID); err != nil {
+		return ruleError(c, err, "Failed to delete rule")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Rule deleted successfully",
+	})
+}
+
+func (ctrl *RuleController) FindExecutions(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	ruleID := c.Params("id")
+
+	if ruleID == "" {
+		return utils.ValidationError(c, "id", "rule id is required")
+	}
+
+	executions, err := ctrl.ruleService.FindExecutions(c.UserContext(), ruleID, userID)
+	if err != nil {
+		return ruleError(c, err, "Failed to find rule executions")
+	}
+
+	return utils.Success(c, executions)
+}
+
+func ruleError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
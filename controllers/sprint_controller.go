@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SprintController struct {
+	sprintService services.SprintService
+}
+
+func NewSprintController(sprintService services.SprintService) *SprintController {
+	return &SprintController{
+		sprintService: sprintService,
+	}
+}
+
+// Sprint dates are calendar dates ("2024-05-01"); the end date is the last day
+// of the sprint.
+type CreateSprintRequest struct {
+	Name      string `json:"name"`
+	Goal      string `json:"goal"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+type UpdateSprintRequest struct {
+	Name      string `json:"name"`
+	Goal      string `json:"goal"`
+	StartDate string `json:"start_date,omitempty"`
+	EndDate   string `json:"end_date,omitempty"`
+}
+
+type CompleteSprintRequest struct {
+	NextSprintID string `json:"next_sprint_id,omitempty"`
+}
+
+func (ctrl *SprintController) Create(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	var req CreateSprintRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+	}
+
+	if req.Name == "" {
+		return utils.ValidationError(c, "name", "name is required")
+	}
+
+	startDate, err := parseSprintDate(req.StartDate)
+	if err != nil || startDate == nil {
+		return utils.ValidationError(c, "start_date", "start_date must be a date (YYYY-MM-DD)")
+	}
+
+	endDate, err := parseSprintDate(req.EndDate)
+	if err != nil || endDate == nil {
+		return utils.ValidationError(c, "end_date", "end_date must be a date (YYYY-MM-DD)")
+	}
+
+	sprint, err := ctrl.sprintService.Create(c.UserContext(), boardID, userID, req.Name, req.Goal, *startDate, *endDate)
+	if err != nil {
+		return sprintError(c, err, "Failed to create sprint")
+	}
+
+	return utils.Success(c, sprint)
+}
+
+func (ctrl *SprintController) FindByBoardID(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	sprints, err := ctrl.sprintService.FindByBoardID(c.UserContext(), boardID, userID)
+	if err != nil {
+		return sprintError(c, err, "Failed to find sprints")
+	}
+
+	return utils.Success(c, sprints)
+}
+
+func (ctrl *SprintController) FindByID(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	sprintID := c.Params("id")
+
+	if sprintID == "" {
+		return utils.ValidationError(c, "id", "sprint id is required")
+	}
+
+	sprint, err := ctrl.sprintService.FindByID(c.UserContext(), sprintID, userID)
+	if err != nil {
+		return sprintError(c, err, "Failed to find sprint")
+	}
+
+	return utils.Success(c, sprint)
+}
+
+func (ctrl *SprintController) Update(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	sprintID := c.Params("id")
+
+	if sprintID == "" {
+		return utils.ValidationError(c, "id", "sprint id is required")
+	}
+
+	var req UpdateSprintRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+	}
+
+	startDate, err := parseSprintDate(req.StartDate)
+	if err != nil {
+		return utils.ValidationError(c, "start_date", "start_date must be a date (YYYY-MM-DD)")
+	}
+
+	endDate, err := parseSprintDate(req.EndDate)
+	if err != nil {
+		return utils.ValidationError(c, "end_date", "end_date must be a date (YYYY-MM-DD)")
+	}
+
+	sprint, err := ctrl.sprintService.Update(c.UserContext(), sprintID, userID, req.Name, req.Goal, startDate, endDate)
+	if err != nil {
+		return sprintError(c, err, "Failed to update sprint")
+	}
+
+	return utils.Success(c, sprint)
+}
+
+func (ctrl *SprintController) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	sprintID := c.Params("id")
+
+	if sprintID == "" {
+		return utils.ValidationError(c, "id", "sprint id is required")
+	}
+
+	if err := ctrl.sprintService.Delete(c.UserContext(), sprintID, userID); err != nil {
+		return sprintError(c, err, "Failed to delete sprint")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Sprint deleted successfully",
+	})
+}
+
+func (ctrl *SprintController) AddTask(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	sprintID := c.Params("id")
+	taskID := c.Params("task_id")
+
+	if sprintID == "" {
+		return utils.ValidationError(c, "id", "sprint id is required")
+	}
+
+	if taskID == "" {
+		return utils.ValidationError(c, "task_id", "task id is required")
+	}
+
+	if err := ctrl.sprintService.AddTask(c.UserContext(), sprintID, taskID, userID); err != nil {
+		return sprintError(c, err, "Failed to add task to sprint")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Task added to sprint",
+	})
+}
+
+func (ctrl *SprintController) RemoveTask(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	sprintID := c.Params("id")
+	taskID := c.Params("task_id")
+
+	if sprintID == "" {
+		return utils.ValidationError(c, "id", "sprint id is required")
+	}
+
+	if taskID == "" {
+		return utils.ValidationError(c, "task_id", "task id is required")
+	}
+
+	if err := ctrl.sprintService.RemoveTask(c.UserContext(), sprintID, taskID, userID); err != nil {
+		return sprintError(c, err, "Failed to remove task from sprint")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Task removed from sprint",
+	})
+}
+
+func (ctrl *SprintController) Start(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	sprintID := c.Params("id")
+
+	if sprintID == "" {
+		return utils.ValidationError(c, "id", "sprint id is required")
+	}
+
+	sprint, err := ctrl.sprintService.Start(c.UserContext(), sprintID, userID)
+	if err != nil {
+		return sprintError(c, err, "Failed to start sprint")
+	}
+
+	return utils.Success(c, sprint)
+}
+
+func (ctrl *SprintController) Complete(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	sprintID := c.Params("id")
+
+	if sprintID == "" {
+		return utils.ValidationError(c, "id", "sprint id is required")
+	}
+
+	var req CompleteSprintRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+		}
+	}
+
+	completion, err := ctrl.sprintService.Complete(c.UserContext(), sprintID, userID, req.NextSprintID)
+	if err != nil {
+		return sprintError(c, err, "Failed to complete sprint")
+	}
+
+	return utils.Success(c, completion)
+}
+
+func (ctrl *SprintController) Burndown(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	sprintID := c.Params("id")
+
+	if sprintID == "" {
+		return utils.ValidationError(c, "id", "sprint id is required")
+	}
+
+	burndown, err := ctrl.sprintService.Burndown(c.UserContext(), sprintID, userID)
+	if err != nil {
+		return sprintError(c, err, "Failed to compute burndown")
+	}
+
+	return utils.Success(c, burndown)
+}
+
+// parseSprintDate parses an optional calendar date; an empty value yields nil
+func parseSprintDate(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(utils.DateLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
+
+func sprintError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockSprintService struct {
+	createFunc   func(ctx context.Context, boardID, userID, name, goal string, startDate, endDate time.Time) (*models.Sprint, error)
+	completeFunc func(ctx context.Context, sprintID, userID, nextSprintID string) (*services.SprintCompletion, error)
+}
+
+func (m *mockSprintService) Create(ctx context.Context, boardID, userID, name, goal string, startDate, endDate time.Time) (*models.Sprint, error) {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, boardID, userID, name, goal, startDate, endDate)
+	}
+	return &models.Sprint{ID: "sprint-1", BoardID: boardID, Name: name}, nil
+}
+
+func (m *mockSprintService) FindByID(ctx context.Context, sprintID, userID string) (*models.Sprint, error) {
+	return &models.Sprint{ID: sprintID}, nil
+}
+
+func (m *mockSprintService) FindByBoardID(ctx context.Context, boardID, userID string) ([]*models.Sprint, error) {
+	return []*models.Sprint{}, nil
+}
+
+func (m *mockSprintService) Update(ctx context.Context, sprintID, userID, name, goal string, startDate, endDate *time.Time) (*models.Sprint, error) {
+	return &models.Sprint{ID: sprintID, Name: name}, nil
+}
+
+func (m *mockSprintService) Delete(ctx context.Context, sprintID, userID string) error {
+	return nil
+}
+
+func (m *mockSprintService) AddTask(ctx context.Context, sprintID, taskID, userID string) error {
+	return nil
+}
+
+func (m *mockSprintService) RemoveTask(ctx context.Context, sprintID, taskID, userID string) error {
+	return nil
+}
+
+func (m *mockSprintService) Start(ctx context.Context, sprintID, userID string) (*models.Sprint, error) {
+	return &models.Sprint{ID: sprintID, Status: models.SprintStatusActive}, nil
+}
+
+func (m *mockSprintService) Complete(ctx context.Context, sprintID, userID, nextSprintID string) (*services.SprintCompletion, error) {
+	if m.completeFunc != nil {
+		return m.completeFunc(ctx, sprintID, userID, nextSprintID)
+	}
+	return &services.SprintCompletion{Sprint: &models.Sprint{ID: sprintID}}, nil
+}
+
+func (m *mockSprintService) Burndown(ctx context.Context, sprintID, userID string) (*services.Burndown, error) {
+	return &services.Burndown{SprintID: sprintID}, nil
+}
+
+func TestSprintController_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "valid sprint", body: `{"name":"Sprint 1","goal":"Ship it","start_date":"2026-03-02","end_date":"2026-03-13"}`, wantStatus: fiber.StatusOK},
+		{name: "missing name", body: `{"start_date":"2026-03-02","end_date":"2026-03-13"}`, wantStatus: fiber.StatusBadRequest},
+		{name: "timestamp instead of date", body: `{"name":"Sprint 1","start_date":"2026-03-02T09:00:00Z","end_date":"2026-03-13"}`, wantStatus: fiber.StatusBadRequest},
+		{name: "missing end date", body: `{"name":"Sprint 1","start_date":"2026-03-02"}`, wantStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var gotStart, gotEnd time.Time
+			ctrl := NewSprintController(&mockSprintService{
+				createFunc: func(ctx context.Context, boardID, userID, name, goal string, startDate, endDate time.Time) (*models.Sprint, error) {
+					gotStart, gotEnd = startDate, endDate
+					return &models.Sprint{ID: "sprint-1", BoardID: boardID, Name: name}, nil
+				},
+			})
+			app.Post("/boards/:id/sprints", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user-123")
+				return ctrl.Create(c)
+			})
+
+			req := httptest.NewRequest("POST", "/boards/board-1/sprints", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantStatus == fiber.StatusOK {
+				assert.Equal(t, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), gotStart)
+				assert.Equal(t, time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC), gotEnd)
+			}
+		})
+	}
+}
+
+func TestSprintController_Complete(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+		wantNext   string
+	}{
+		{name: "roll over to backlog", wantStatus: fiber.StatusOK},
+		{name: "roll over to next sprint", body: `{"next_sprint_id":"sprint-2"}`, wantStatus: fiber.StatusOK, wantNext: "sprint-2"},
+		{name: "sprint not active", serviceErr: utils.NewValidation("only active sprints can be completed"), wantStatus: fiber.StatusBadRequest},
+		{name: "sprint not found", serviceErr: utils.NewNotFound("sprint not found"), wantStatus: fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var gotNext string
+			ctrl := NewSprintController(&mockSprintService{
+				completeFunc: func(ctx context.Context, sprintID, userID, nextSprintID string) (*services.SprintCompletion, error) {
+					gotNext = nextSprintID
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &services.SprintCompletion{Sprint: &models.Sprint{ID: sprintID}, RolledOver: 2}, nil
+				},
+			})
+			app.Post("/sprints/:id/complete", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user-123")
+				return ctrl.Complete(c)
+			})
+
+			req := httptest.NewRequest("POST", "/sprints/sprint-1/complete", strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantNext, gotNext)
+
+			if tt.wantStatus == fiber.StatusOK {
+				body, err := io.ReadAll(resp.Body)
+				assert.NoError(t, err)
+				assert.Contains(t, string(body), `"rolled_over":2`)
+			}
+		})
+	}
+}
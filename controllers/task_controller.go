@@ -21,17 +21,20 @@ func NewTaskController(taskService services.TaskService) *TaskController {
 	}
 }
 
+// Deadlines accept either a calendar date ("2024-05-01") for all-day tasks or
+// an RFC 3339 timestamp.
 type CreateTaskRequest struct {
-	ColumnID    string     `json:"column_id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Deadline    *time.Time `json:"deadline,omitempty"`
+	ColumnID    string `json:"column_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Deadline    string `json:"deadline,omitempty"`
 }
 
 type UpdateTaskRequest struct {
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Deadline    *time.Time `json:"deadline,omitempty"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Deadline    string `json:"deadline,omitempty"`
+	StoryPoints *int   `json:"story_points,omitempty"`
 }
 
 type MoveTaskRequest struct {
@@ -39,30 +42,44 @@ type MoveTaskRequest struct {
 }
 
 type TaskResponse struct {
-	ID          string              `json:"id"`
-	ColumnID    string              `json:"column_id"`
-	Title       string              `json:"title"`
-	Description string              `json:"description"`
-	Deadline    *time.Time          `json:"deadline,omitempty"`
-	CreatedAt   time.Time           `json:"created_at"`
-	UpdatedAt   time.Time           `json:"updated_at"`
-	Comments    []models.Comment    `json:"comments,omitempty"`
-	Labels      []models.Label      `json:"labels,omitempty"`
-	Attachments []models.Attachment `json:"attachments,omitempty"`
+	ID          string                `json:"id"`
+	Key         string                `json:"key"`
+	Number      int                   `json:"number"`
+	ColumnID    string                `json:"column_id"`
+	Title       string                `json:"title"`
+	Description string                `json:"description"`
+	Deadline    *time.Time            `json:"deadline,omitempty"`
+	AllDay      bool                  `json:"deadline_all_day"`
+	SprintID    *string               `json:"sprint_id,omitempty"`
+	StoryPoints *int                  `json:"story_points,omitempty"`
+	ArchivedAt  *time.Time            `json:"archived_at,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	UpdatedAt   time.Time             `json:"updated_at"`
+	Comments    []models.Comment      `json:"comments,omitempty"`
+	Labels      []models.Label        `json:"labels,omitempty"`
+	Attachments []models.Attachment   `json:"attachments,omitempty"`
+	Assignees   []models.TaskAssignee `json:"assignees,omitempty"`
 }
 
 func toTaskResponse(task *models.Task) TaskResponse {
 	return TaskResponse{
 		ID:          task.ID,
+		Key:         task.Key,
+		Number:      task.Number,
 		ColumnID:    task.ColumnID,
 		Title:       task.Title,
 		Description: task.Description,
 		Deadline:    task.Deadline,
+		AllDay:      task.DeadlineAllDay,
+		SprintID:    task.SprintID,
+		StoryPoints: task.StoryPoints,
+		ArchivedAt:  task.ArchivedAt,
 		CreatedAt:   task.CreatedAt,
 		UpdatedAt:   task.UpdatedAt,
 		Comments:    task.Comments,
 		Labels:      task.Labels,
 		Attachments: task.Attachments,
+		Assignees:   task.Assignees,
 	}
 }
 
@@ -90,7 +107,12 @@ func (ctrl *TaskController) Create(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "column_id", "column_id is required")
 	}
 
-	task, err := ctrl.taskService.Create(c.Context(), userID, req.ColumnID, req.Title, req.Description, req.Deadline)
+	deadline, allDay, err := utils.ParseDeadline(req.Deadline)
+	if err != nil {
+		return utils.ValidationError(c, "deadline", err.Error())
+	}
+
+	task, err := ctrl.taskService.Create(c.UserContext(), userID, req.ColumnID, req.Title, req.Description, deadline, allDay)
 	if err != nil {
 		var validationErr utils.ErrValidation
 		if errors.As(err, &validationErr) {
@@ -110,7 +132,7 @@ func (ctrl *TaskController) FindByID(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "id", "task id is required")
 	}
 
-	task, err := ctrl.taskService.FindByID(c.Context(), taskID, userID)
+	task, err := ctrl.taskService.FindByID(c.UserContext(), taskID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -126,6 +148,30 @@ func (ctrl *TaskController) FindByID(c *fiber.Ctx) error {
 	return utils.Success(c, toTaskResponse(task))
 }
 
+// FindByKey looks a task up by its human-readable key, e.g. OPS-123
+func (ctrl *TaskController) FindByKey(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	task, err := ctrl.taskService.FindByKey(c.UserContext(), c.Params("key"), userID)
+	if err != nil {
+		var validationErr utils.ErrValidation
+		if errors.As(err, &validationErr) {
+			return utils.ValidationError(c, "key", err.Error())
+		}
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		var conflictErr utils.ErrConflict
+		if errors.As(err, &conflictErr) {
+			return utils.Error(c, err.Error(), fiber.StatusConflict)
+		}
+		return utils.Error(c, "Failed to find task", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, toTaskResponse(task))
+}
+
 func (ctrl *TaskController) FindByColumnID(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(string)
 	columnID := c.Params("columnId")
@@ -139,8 +185,9 @@ func (ctrl *TaskController) FindByColumnID(c *fiber.Ctx) error {
 	utils.ValidatePagination(&req)
 
 	title := c.Query("title")
+	archived := c.QueryBool("archived")
 
-	tasks, total, err := ctrl.taskService.FindByColumnIDWithFilters(c.Context(), columnID, userID, title, req.Page, req.Limit)
+	tasks, total, err := ctrl.taskService.FindByColumnIDWithFilters(c.UserContext(), columnID, userID, title, archived, req.Page, req.Limit)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -169,7 +216,12 @@ func (ctrl *TaskController) Update(c *fiber.Ctx) error {
 		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
 	}
 
-	task, err := ctrl.taskService.Update(c.Context(), taskID, userID, req.Title, req.Description, req.Deadline)
+	deadline, allDay, err := utils.ParseDeadline(req.Deadline)
+	if err != nil {
+		return utils.ValidationError(c, "deadline", err.Error())
+	}
+
+	task, err := ctrl.taskService.Update(c.UserContext(), taskID, userID, req.Title, req.Description, deadline, allDay, req.StoryPoints)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -193,7 +245,7 @@ func (ctrl *TaskController) Delete(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "id", "task id is required")
 	}
 
-	err := ctrl.taskService.Delete(c.Context(), taskID, userID)
+	err := ctrl.taskService.Delete(c.UserContext(), taskID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -211,6 +263,38 @@ func (ctrl *TaskController) Delete(c *fiber.Ctx) error {
 	})
 }
 
+func (ctrl *TaskController) Archive(c *fiber.Ctx) error {
+	return ctrl.setArchived(c, true)
+}
+
+func (ctrl *TaskController) Unarchive(c *fiber.Ctx) error {
+	return ctrl.setArchived(c, false)
+}
+
+func (ctrl *TaskController) setArchived(c *fiber.Ctx, archived bool) error {
+	userID := c.Locals("user_id").(string)
+	taskID := c.Params("id")
+
+	if taskID == "" {
+		return utils.ValidationError(c, "id", "task id is required")
+	}
+
+	task, err := ctrl.taskService.Archive(c.UserContext(), taskID, userID, archived)
+	if err != nil {
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		var unauthorizedErr utils.ErrUnauthorized
+		if errors.As(err, &unauthorizedErr) {
+			return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+		}
+		return utils.Error(c, "Failed to archive task", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, toTaskResponse(task))
+}
+
 func (ctrl *TaskController) Move(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(string)
 	taskID := c.Params("id")
@@ -228,7 +312,7 @@ func (ctrl *TaskController) Move(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "column_id", "column_id is required")
 	}
 
-	err := ctrl.taskService.Move(c.Context(), taskID, req.ColumnID, userID)
+	err := ctrl.taskService.Move(c.UserContext(), taskID, req.ColumnID, userID)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
@@ -263,7 +347,7 @@ func (ctrl *TaskController) Search(c *fiber.Ctx) error {
 		return utils.ValidationError(c, "keyword", "keyword is required")
 	}
 
-	tasks, total, err := ctrl.taskService.Search(c.Context(), boardID, userID, keyword, req.Page, req.Limit)
+	tasks, total, err := ctrl.taskService.Search(c.UserContext(), boardID, userID, keyword, req.Page, req.Limit)
 	if err != nil {
 		var notFoundErr utils.ErrNotFound
 		if errors.As(err, &notFoundErr) {
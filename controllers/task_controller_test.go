@@ -17,19 +17,21 @@ import (
 )
 
 type mockTaskService struct {
-	createFunc                    func(ctx context.Context, userID, columnID, title, description string, deadline *time.Time) (*models.Task, error)
+	createFunc                    func(ctx context.Context, userID, columnID, title, description string, deadline *time.Time, deadlineAllDay bool) (*models.Task, error)
 	findByIDFunc                  func(ctx context.Context, taskID, userID string) (*models.Task, error)
+	findByKeyFunc                 func(ctx context.Context, key, userID string) (*models.Task, error)
 	findByColumnIDFunc            func(ctx context.Context, columnID, userID string) ([]*models.Task, error)
-	findByColumnIDWithFiltersFunc func(ctx context.Context, columnID, userID string, title string, page, limit int) ([]*models.Task, int, error)
+	findByColumnIDWithFiltersFunc func(ctx context.Context, columnID, userID string, title string, archived bool, page, limit int) ([]*models.Task, int, error)
 	searchFunc                    func(ctx context.Context, boardID, userID string, keyword string, page, limit int) ([]*models.Task, int, error)
-	updateFunc                    func(ctx context.Context, taskID, userID, title, description string, deadline *time.Time) (*models.Task, error)
+	updateFunc                    func(ctx context.Context, taskID, userID, title, description string, deadline *time.Time, deadlineAllDay bool, storyPoints *int) (*models.Task, error)
 	deleteFunc                    func(ctx context.Context, taskID, userID string) error
 	moveFunc                      func(ctx context.Context, taskID, columnID, userID string) error
+	archiveFunc                   func(ctx context.Context, taskID, userID string, archived bool) (*models.Task, error)
 }
 
-func (m *mockTaskService) Create(ctx context.Context, userID, columnID, title, description string, deadline *time.Time) (*models.Task, error) {
+func (m *mockTaskService) Create(ctx context.Context, userID, columnID, title, description string, deadline *time.Time, deadlineAllDay bool) (*models.Task, error) {
 	if m.createFunc != nil {
-		return m.createFunc(ctx, userID, columnID, title, description, deadline)
+		return m.createFunc(ctx, userID, columnID, title, description, deadline, deadlineAllDay)
 	}
 	task := &models.Task{
 		ID:          "task-123",
@@ -58,6 +60,20 @@ func (m *mockTaskService) FindByID(ctx context.Context, taskID, userID string) (
 	return task, nil
 }
 
+func (m *mockTaskService) FindByKey(ctx context.Context, key, userID string) (*models.Task, error) {
+	if m.findByKeyFunc != nil {
+		return m.findByKeyFunc(ctx, key, userID)
+	}
+	task := &models.Task{
+		ID:       "task-123",
+		Number:   123,
+		Key:      key,
+		ColumnID: "col-123",
+		Title:    "Test Task",
+	}
+	return task, nil
+}
+
 func (m *mockTaskService) FindByColumnID(ctx context.Context, columnID, userID string) ([]*models.Task, error) {
 	if m.findByColumnIDFunc != nil {
 		return m.findByColumnIDFunc(ctx, columnID, userID)
@@ -83,9 +99,9 @@ func (m *mockTaskService) FindByColumnID(ctx context.Context, columnID, userID s
 	return tasks, nil
 }
 
-func (m *mockTaskService) Update(ctx context.Context, taskID, userID, title, description string, deadline *time.Time) (*models.Task, error) {
+func (m *mockTaskService) Update(ctx context.Context, taskID, userID, title, description string, deadline *time.Time, deadlineAllDay bool, storyPoints *int) (*models.Task, error) {
 	if m.updateFunc != nil {
-		return m.updateFunc(ctx, taskID, userID, title, description, deadline)
+		return m.updateFunc(ctx, taskID, userID, title, description, deadline, deadlineAllDay, storyPoints)
 	}
 	task := &models.Task{
 		ID:          taskID,
@@ -113,9 +129,16 @@ func (m *mockTaskService) Move(ctx context.Context, taskID, columnID, userID str
 	return nil
 }
 
-func (m *mockTaskService) FindByColumnIDWithFilters(ctx context.Context, columnID, userID string, title string, page, limit int) ([]*models.Task, int, error) {
+func (m *mockTaskService) Archive(ctx context.Context, taskID, userID string, archived bool) (*models.Task, error) {
+	if m.archiveFunc != nil {
+		return m.archiveFunc(ctx, taskID, userID, archived)
+	}
+	return &models.Task{ID: taskID}, nil
+}
+
+func (m *mockTaskService) FindByColumnIDWithFilters(ctx context.Context, columnID, userID string, title string, archived bool, page, limit int) ([]*models.Task, int, error) {
 	if m.findByColumnIDWithFiltersFunc != nil {
-		return m.findByColumnIDWithFiltersFunc(ctx, columnID, userID, title, page, limit)
+		return m.findByColumnIDWithFiltersFunc(ctx, columnID, userID, title, archived, page, limit)
 	}
 	tasks := []*models.Task{
 		{
@@ -159,7 +182,7 @@ func TestTaskController_Create_Success(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockTaskService{
-		createFunc: func(ctx context.Context, userID, columnID, title, description string, deadline *time.Time) (*models.Task, error) {
+		createFunc: func(ctx context.Context, userID, columnID, title, description string, deadline *time.Time, deadlineAllDay bool) (*models.Task, error) {
 			task := &models.Task{
 				ID:          "task-123",
 				ColumnID:    columnID,
@@ -197,6 +220,41 @@ func TestTaskController_Create_Success(t *testing.T) {
 	assert.Contains(t, respBody, `"description":"Task description"`)
 }
 
+func TestTaskController_Create_DateOnlyDeadline(t *testing.T) {
+	app := fiber.New()
+
+	var gotDeadline *time.Time
+	var gotAllDay bool
+	mockService := &mockTaskService{
+		createFunc: func(ctx context.Context, userID, columnID, title, description string, deadline *time.Time, deadlineAllDay bool) (*models.Task, error) {
+			gotDeadline = deadline
+			gotAllDay = deadlineAllDay
+			return &models.Task{ID: "task-123", ColumnID: columnID, Title: title, Deadline: deadline, DeadlineAllDay: deadlineAllDay}, nil
+		},
+	}
+
+	ctrl := NewTaskController(mockService)
+	app.Post("/tasks", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.Create(c)
+	})
+
+	reqBody := `{"column_id":"col-123","title":"My Task","deadline":"2024-05-01"}`
+	req := httptest.NewRequest("POST", "/tasks", strings.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	assert.True(t, gotAllDay)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), *gotDeadline)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"deadline_all_day":true`)
+}
+
 func TestTaskController_Create_ValidationErrors(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -228,6 +286,12 @@ func TestTaskController_Create_ValidationErrors(t *testing.T) {
 			wantStatus: fiber.StatusBadRequest,
 			wantError:  "title is required",
 		},
+		{
+			name:       "Invalid deadline",
+			reqBody:    `{"column_id":"col-123","title":"My Task","deadline":"next friday"}`,
+			wantStatus: fiber.StatusBadRequest,
+			wantError:  "deadline must be a date",
+		},
 	}
 
 	for _, tt := range tests {
@@ -260,7 +324,7 @@ func TestTaskController_Create_ServiceError(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockTaskService{
-		createFunc: func(ctx context.Context, userID, columnID, title, description string, deadline *time.Time) (*models.Task, error) {
+		createFunc: func(ctx context.Context, userID, columnID, title, description string, deadline *time.Time, deadlineAllDay bool) (*models.Task, error) {
 			return nil, utils.NewValidation("task title must be at least 3 characters")
 		},
 	}
@@ -324,6 +388,52 @@ func TestTaskController_FindByID_Success(t *testing.T) {
 	assert.Contains(t, respBody, `"title":"Test Task"`)
 }
 
+func TestTaskController_FindByKey(t *testing.T) {
+	app := fiber.New()
+
+	mockService := &mockTaskService{
+		findByKeyFunc: func(ctx context.Context, key, userID string) (*models.Task, error) {
+			switch key {
+			case "OPS-123":
+				return &models.Task{ID: "task-123", Number: 123, Key: key, Title: "Test Task"}, nil
+			case "nope":
+				return nil, utils.NewValidation("task key must look like OPS-123")
+			case "OPS-7":
+				return nil, utils.NewConflict("task key matches more than one task")
+			}
+			return nil, utils.NewNotFound("task not found")
+		},
+	}
+
+	ctrl := NewTaskController(mockService)
+	app.Get("/tasks/key/:key", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.FindByKey(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/tasks/key/OPS-123", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"key":"OPS-123"`)
+	assert.Contains(t, string(body), `"number":123`)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/tasks/key/OPS-999", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/tasks/key/nope", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/tasks/key/OPS-7", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	body, _ = io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "more than one task")
+}
+
 func TestTaskController_FindByID_NotFound(t *testing.T) {
 	app := fiber.New()
 
@@ -384,7 +494,7 @@ func TestTaskController_FindByColumnID_Success(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockTaskService{
-		findByColumnIDWithFiltersFunc: func(ctx context.Context, columnID, userID string, title string, page, limit int) ([]*models.Task, int, error) {
+		findByColumnIDWithFiltersFunc: func(ctx context.Context, columnID, userID string, title string, archived bool, page, limit int) ([]*models.Task, int, error) {
 			tasks := []*models.Task{
 				{
 					ID:          "task-1",
@@ -435,7 +545,7 @@ func TestTaskController_FindByColumnID_NotFound(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockTaskService{
-		findByColumnIDWithFiltersFunc: func(ctx context.Context, columnID, userID string, title string, page, limit int) ([]*models.Task, int, error) {
+		findByColumnIDWithFiltersFunc: func(ctx context.Context, columnID, userID string, title string, archived bool, page, limit int) ([]*models.Task, int, error) {
 			return nil, 0, utils.NewNotFound("column not found")
 		},
 	}
@@ -464,7 +574,7 @@ func TestTaskController_Update_Success(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockTaskService{
-		updateFunc: func(ctx context.Context, taskID, userID, title, description string, deadline *time.Time) (*models.Task, error) {
+		updateFunc: func(ctx context.Context, taskID, userID, title, description string, deadline *time.Time, deadlineAllDay bool, storyPoints *int) (*models.Task, error) {
 			task := &models.Task{
 				ID:          taskID,
 				ColumnID:    "col-123",
@@ -506,7 +616,7 @@ func TestTaskController_Update_NotFound(t *testing.T) {
 	app := fiber.New()
 
 	mockService := &mockTaskService{
-		updateFunc: func(ctx context.Context, taskID, userID, title, description string, deadline *time.Time) (*models.Task, error) {
+		updateFunc: func(ctx context.Context, taskID, userID, title, description string, deadline *time.Time, deadlineAllDay bool, storyPoints *int) (*models.Task, error) {
 			return nil, utils.NewNotFound("task not found")
 		},
 	}
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TaskCSVController struct {
+	csvService services.TaskCSVService
+}
+
+func NewTaskCSVController(csvService services.TaskCSVService) *TaskCSVController {
+	return &TaskCSVController{
+		csvService: csvService,
+	}
+}
+
+func (ctrl *TaskCSVController) Export(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	var buf bytes.Buffer
+	if err := ctrl.csvService.Export(c.UserContext(), boardID, userID, &buf); err != nil {
+		return taskCSVError(c, err, "Failed to export tasks")
+	}
+
+	c.Attachment(fmt.Sprintf("board-%s-tasks.csv", boardID))
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	return c.Send(buf.Bytes())
+}
+
+// Import creates tasks from a CSV file sent as a multipart "file" upload or as
+// the request body. An optional "mapping" form value holds a JSON object
+// mapping task fields to CSV column names. With ?dry_run=true the rows are
+// only validated. Invalid rows fail the whole import with 400 and the row
+// errors in the response data.
+func (ctrl *TaskCSVController) Import(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	var mapping map[string]string
+	if raw := c.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			return utils.ValidationError(c, "mapping", "mapping must be a JSON object of task fields to CSV columns")
+		}
+	}
+
+	var data io.Reader = bytes.NewReader(c.Body())
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			return utils.Error(c, "Failed to read uploaded file", fiber.StatusBadRequest)
+		}
+		defer f.Close()
+		data = f
+	} else if len(c.Body()) == 0 {
+		return utils.ValidationError(c, "file", "a CSV file is required")
+	}
+
+	dryRun := c.QueryBool("dry_run")
+	result, err := ctrl.csvService.Import(c.UserContext(), boardID, userID, data, mapping, dryRun)
+	if err != nil {
+		return taskCSVError(c, err, "Failed to import tasks")
+	}
+
+	if !dryRun && len(result.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(utils.Response{
+			Success: false,
+			Data:    result,
+			Error: fiber.Map{
+				"message": "CSV has invalid rows, nothing was imported",
+			},
+		})
+	}
+
+	return utils.Success(c, result)
+}
+
+func taskCSVError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
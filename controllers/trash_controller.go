@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"errors"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TrashController struct {
+	trashService services.TrashService
+}
+
+func NewTrashController(trashService services.TrashService) *TrashController {
+	return &TrashController{
+		trashService: trashService,
+	}
+}
+
+func (ctrl *TrashController) FindAll(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	items, err := ctrl.trashService.FindAll(c.UserContext(), userID)
+	if err != nil {
+		return trashError(c, err, "Failed to list trash")
+	}
+
+	return utils.Success(c, items)
+}
+
+// Restore takes an item out of the trash. The type path segment is one of
+// board, task, comment or attachment.
+func (ctrl *TrashController) Restore(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	itemType := c.Params("type")
+	id := c.Params("id")
+
+	if id == "" {
+		return utils.ValidationError(c, "id", "id is required")
+	}
+
+	if err := ctrl.trashService.Restore(c.UserContext(), itemType, id, userID); err != nil {
+		return trashError(c, err, "Failed to restore item")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Item restored successfully",
+	})
+}
+
+func trashError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
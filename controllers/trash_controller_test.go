@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockTrashService struct {
+	restoreFunc func(ctx context.Context, itemType, id, userID string) error
+}
+
+func (m *mockTrashService) FindAll(ctx context.Context, userID string) ([]services.TrashItem, error) {
+	return []services.TrashItem{}, nil
+}
+
+func (m *mockTrashService) Restore(ctx context.Context, itemType, id, userID string) error {
+	if m.restoreFunc != nil {
+		return m.restoreFunc(ctx, itemType, id, userID)
+	}
+	return nil
+}
+
+func (m *mockTrashService) Purge(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestTrashController_Restore(t *testing.T) {
+	tests := []struct {
+		name       string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "restored", wantStatus: fiber.StatusOK},
+		{name: "parent still deleted", serviceErr: utils.NewValidation("restore the task's board first"), wantStatus: fiber.StatusBadRequest},
+		{name: "not in trash", serviceErr: utils.NewNotFound("task not found in trash"), wantStatus: fiber.StatusNotFound},
+		{name: "another user's item", serviceErr: utils.NewUnauthorized("you do not have access to this task"), wantStatus: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			var gotType, gotID string
+			ctrl := NewTrashController(&mockTrashService{
+				restoreFunc: func(ctx context.Context, itemType, id, userID string) error {
+					gotType, gotID = itemType, id
+					return tt.serviceErr
+				},
+			})
+			app.Post("/trash/:type/:id/restore", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user-123")
+				return ctrl.Restore(c)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("POST", "/trash/task/task-1/restore", nil))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, "task", gotType)
+			assert.Equal(t, "task-1", gotID)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"errors"
+
+	"kanban-backend/models"
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type UserController struct {
+	userService services.UserService
+}
+
+func NewUserController(userService services.UserService) *UserController {
+	return &UserController{
+		userService: userService,
+	}
+}
+
+type UpdateSettingsRequest struct {
+	ReminderLeadTimes string `json:"reminder_lead_times"`
+	Timezone          string `json:"timezone"`
+}
+
+type SettingsResponse struct {
+	ReminderLeadTimes string `json:"reminder_lead_times"`
+	Timezone          string `json:"timezone"`
+}
+
+func toSettingsResponse(user *models.User) SettingsResponse {
+	leadTimes := user.ReminderLeadTimes
+	if leadTimes == "" {
+		leadTimes = models.DefaultReminderLeadTimes
+	}
+
+	return SettingsResponse{
+		ReminderLeadTimes: leadTimes,
+		Timezone:          user.Location().String(),
+	}
+}
+
+func (ctrl *UserController) GetSettings(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	user, err := ctrl.userService.GetSettings(c.UserContext(), userID)
+	if err != nil {
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		return utils.Error(c, "Failed to get settings", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, toSettingsResponse(user))
+}
+
+func (ctrl *UserController) UpdateSettings(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	var req UpdateSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+	}
+
+	user, err := ctrl.userService.UpdateSettings(c.UserContext(), userID, req.ReminderLeadTimes, req.Timezone)
+	if err != nil {
+		var notFoundErr utils.ErrNotFound
+		if errors.As(err, &notFoundErr) {
+			return utils.Error(c, err.Error(), fiber.StatusNotFound)
+		}
+		var validationErr utils.ErrValidation
+		if errors.As(err, &validationErr) {
+			return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+		}
+		return utils.Error(c, "Failed to update settings", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, toSettingsResponse(user))
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockUserService struct {
+	getSettingsFunc    func(ctx context.Context, userID string) (*models.User, error)
+	updateSettingsFunc func(ctx context.Context, userID, reminderLeadTimes, timezone string) (*models.User, error)
+}
+
+func (m *mockUserService) GetSettings(ctx context.Context, userID string) (*models.User, error) {
+	if m.getSettingsFunc != nil {
+		return m.getSettingsFunc(ctx, userID)
+	}
+	return &models.User{ID: userID}, nil
+}
+
+func (m *mockUserService) UpdateSettings(ctx context.Context, userID, reminderLeadTimes, timezone string) (*models.User, error) {
+	if m.updateSettingsFunc != nil {
+		return m.updateSettingsFunc(ctx, userID, reminderLeadTimes, timezone)
+	}
+	return &models.User{ID: userID, ReminderLeadTimes: reminderLeadTimes, Timezone: timezone}, nil
+}
+
+func TestUserController_GetSettings_DefaultLeadTimes(t *testing.T) {
+	app := fiber.New()
+
+	ctrl := NewUserController(&mockUserService{})
+	app.Get("/me/settings", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.GetSettings(c)
+	})
+
+	req := httptest.NewRequest("GET", "/me/settings", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"reminder_lead_times":"1440,60"`)
+	assert.Contains(t, string(body), `"timezone":"UTC"`)
+}
+
+func TestUserController_UpdateSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{name: "valid lead times", body: `{"reminder_lead_times":"120,30"}`, wantStatus: fiber.StatusOK},
+		{name: "valid timezone", body: `{"timezone":"Asia/Tokyo"}`, wantStatus: fiber.StatusOK},
+		{name: "invalid body", body: `{invalid`, wantStatus: fiber.StatusBadRequest},
+		{name: "validation error", body: `{"reminder_lead_times":"abc"}`, err: utils.NewValidation("invalid reminder lead time"), wantStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			ctrl := NewUserController(&mockUserService{
+				updateSettingsFunc: func(ctx context.Context, userID, reminderLeadTimes, timezone string) (*models.User, error) {
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &models.User{ID: userID, ReminderLeadTimes: reminderLeadTimes, Timezone: timezone}, nil
+				},
+			})
+			app.Put("/me/settings", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user-123")
+				return ctrl.UpdateSettings(c)
+			})
+
+			req := httptest.NewRequest("PUT", "/me/settings", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
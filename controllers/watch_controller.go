@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"errors"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WatchController struct {
+	watchService services.WatchService
+}
+
+func NewWatchController(watchService services.WatchService) *WatchController {
+	return &WatchController{
+		watchService: watchService,
+	}
+}
+
+func (ctrl *WatchController) WatchTask(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	taskID := c.Params("id")
+
+	if taskID == "" {
+		return utils.ValidationError(c, "id", "task id is required")
+	}
+
+	if err := ctrl.watchService.WatchTask(c.UserContext(), taskID, userID); err != nil {
+		return watchError(c, err, "Failed to watch task")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Watching task",
+	})
+}
+
+func (ctrl *WatchController) UnwatchTask(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	taskID := c.Params("id")
+
+	if taskID == "" {
+		return utils.ValidationError(c, "id", "task id is required")
+	}
+
+	if err := ctrl.watchService.UnwatchTask(c.UserContext(), taskID, userID); err != nil {
+		return watchError(c, err, "Failed to unwatch task")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Stopped watching task",
+	})
+}
+
+func (ctrl *WatchController) WatchBoard(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	if err := ctrl.watchService.WatchBoard(c.UserContext(), boardID, userID); err != nil {
+		return watchError(c, err, "Failed to watch board")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Watching board",
+	})
+}
+
+func (ctrl *WatchController) UnwatchBoard(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	if err := ctrl.watchService.UnwatchBoard(c.UserContext(), boardID, userID); err != nil {
+		return watchError(c, err, "Failed to unwatch board")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Stopped watching board",
+	})
+}
+
+func (ctrl *WatchController) FindWatching(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	watches, err := ctrl.watchService.FindByUserID(c.UserContext(), userID)
+	if err != nil {
+		return utils.Error(c, "Failed to find subscriptions", fiber.StatusInternalServerError)
+	}
+
+	return utils.Success(c, watches)
+}
+
+func watchError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockWatchService struct {
+	watchTaskFunc  func(ctx context.Context, taskID, userID string) error
+	watchBoardFunc func(ctx context.Context, boardID, userID string) error
+}
+
+func (m *mockWatchService) WatchTask(ctx context.Context, taskID, userID string) error {
+	if m.watchTaskFunc != nil {
+		return m.watchTaskFunc(ctx, taskID, userID)
+	}
+	return nil
+}
+
+func (m *mockWatchService) UnwatchTask(ctx context.Context, taskID, userID string) error {
+	return nil
+}
+
+func (m *mockWatchService) WatchBoard(ctx context.Context, boardID, userID string) error {
+	if m.watchBoardFunc != nil {
+		return m.watchBoardFunc(ctx, boardID, userID)
+	}
+	return nil
+}
+
+func (m *mockWatchService) UnwatchBoard(ctx context.Context, boardID, userID string) error {
+	return nil
+}
+
+func (m *mockWatchService) FindByUserID(ctx context.Context, userID string) ([]*models.Watch, error) {
+	return []*models.Watch{{UserID: userID, TargetType: models.WatchTargetBoard, TargetID: "board-1"}}, nil
+}
+
+func (m *mockWatchService) HandleEvent(ctx context.Context, event events.Event) {}
+
+func TestWatchController_WatchTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: fiber.StatusOK},
+		{name: "task not found", err: utils.NewNotFound("task not found"), wantStatus: fiber.StatusNotFound},
+		{name: "no access", err: utils.NewUnauthorized("you do not have access to this task"), wantStatus: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+
+			ctrl := NewWatchController(&mockWatchService{
+				watchTaskFunc: func(ctx context.Context, taskID, userID string) error {
+					return tt.err
+				},
+			})
+			app.Post("/tasks/:id/watch", func(c *fiber.Ctx) error {
+				c.Locals("user_id", "user-123")
+				return ctrl.WatchTask(c)
+			})
+
+			req := httptest.NewRequest("POST", "/tasks/task-1/watch", nil)
+
+			resp, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestWatchController_FindWatching(t *testing.T) {
+	app := fiber.New()
+
+	ctrl := NewWatchController(&mockWatchService{})
+	app.Get("/me/watching", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-123")
+		return ctrl.FindWatching(c)
+	})
+
+	req := httptest.NewRequest("GET", "/me/watching", nil)
+
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `"target_type":"board"`)
+	assert.Contains(t, string(body), `"target_id":"board-1"`)
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"errors"
+
+	"kanban-backend/services"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WebhookController struct {
+	webhookService services.WebhookService
+}
+
+func NewWebhookController(webhookService services.WebhookService) *WebhookController {
+	return &WebhookController{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhookRequest subscribes a URL to board events. Omitted event types
+// subscribe to every event; an omitted secret is generated and returned once.
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+}
+
+type UpdateWebhookRequest struct {
+	URL        string   `json:"url,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+func (ctrl *WebhookController) Create(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	var req CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+	}
+
+	if req.URL == "" {
+		return utils.ValidationError(c, "url", "url is required")
+	}
+
+	webhook, err := ctrl.webhookService.Create(c.UserContext(), boardID, userID, req.URL, req.Secret, req.EventTypes)
+	if err != nil {
+		return webhookError(c, err, "Failed to create webhook")
+	}
+
+	return utils.Success(c, webhook)
+}
+
+func (ctrl *WebhookController) FindByBoardID(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	boardID := c.Params("id")
+
+	if boardID == "" {
+		return utils.ValidationError(c, "id", "board id is required")
+	}
+
+	webhooks, err := ctrl.webhookService.FindByBoardID(c.UserContext(), boardID, userID)
+	if err != nil {
+		return webhookError(c, err, "Failed to find webhooks")
+	}
+
+	return utils.Success(c, webhooks)
+}
+
+func (ctrl *WebhookController) Update(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	webhookID := c.Params("id")
+
+	if webhookID == "" {
+		return utils.ValidationError(c, "id", "webhook id is required")
+	}
+
+	var req UpdateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, "Invalid request body", fiber.StatusBadRequest)
+	}
+
+	webhook, err := ctrl.webhookService.Update(c.UserContext(), webhookID, userID, req.URL, req.EventTypes, req.Active)
+	if err != nil {
+		return webhookError(c, err, "Failed to update webhook")
+	}
+
+	return utils.Success(c, webhook)
+}
+
+func (ctrl *WebhookController) Delete(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	webhookID := c.Params("id")
+
+	if webhookID == "" {
+		return utils.ValidationError(c, "id", "webhook id is required")
+	}
+
+	if err := ctrl.webhookService.Delete(c.UserContext(), webhookID, userID); err != nil {
+		return webhookError(c, err, "Failed to delete webhook")
+	}
+
+	return utils.Success(c, fiber.Map{
+		"message": "Webhook deleted successfully",
+	})
+}
+
+func (ctrl *WebhookController) FindDeliveries(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	webhookID := c.Params("id")
+
+	if webhookID == "" {
+		return utils.ValidationError(c, "id", "webhook id is required")
+	}
+
+	deliveries, err := ctrl.webhookService.FindDeliveries(c.UserContext(), webhookID, userID)
+	if err != nil {
+		return webhookError(c, err, "Failed to find webhook deliveries")
+	}
+
+	return utils.Success(c, deliveries)
+}
+
+func webhookError(c *fiber.Ctx, err error, fallback string) error {
+	var notFoundErr utils.ErrNotFound
+	if errors.As(err, &notFoundErr) {
+		return utils.Error(c, err.Error(), fiber.StatusNotFound)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if errors.As(err, &unauthorizedErr) {
+		return utils.Error(c, err.Error(), fiber.StatusUnauthorized)
+	}
+	var validationErr utils.ErrValidation
+	if errors.As(err, &validationErr) {
+		return utils.Error(c, err.Error(), fiber.StatusBadRequest)
+	}
+	return utils.Error(c, fallback, fiber.StatusInternalServerError)
+}
@@ -0,0 +1,177 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"kanban-backend/models"
+)
+
+// Event types published by the services
+const (
+	TaskCreated       = "task.created"
+	TaskUpdated       = "task.updated"
+	TaskMoved         = "task.moved"
+	TaskDeleted       = "task.deleted"
+	TaskArchived      = "task.archived"
+	TaskUnarchived    = "task.unarchived"
+	TaskRestored      = "task.restored"
+	BoardArchived     = "board.archived"
+	BoardUnarchived   = "board.unarchived"
+	BoardRestored     = "board.restored"
+	CommentCreated    = "comment.created"
+	AttachmentCreated = "attachment.created"
+	AttachmentDeleted = "attachment.deleted"
+	AssigneeAdded     = "assignee.added"
+	AssigneeRemoved   = "assignee.removed"
+	LabelAdded        = "label.added"
+	LabelRemoved      = "label.removed"
+)
+
+// Types lists every event type, for validating subscriptions
+var Types = []string{
+	TaskCreated,
+	TaskUpdated,
+	TaskMoved,
+	TaskDeleted,
+	TaskArchived,
+	TaskUnarchived,
+	TaskRestored,
+	BoardArchived,
+	BoardUnarchived,
+	BoardRestored,
+	CommentCreated,
+	AttachmentCreated,
+	AttachmentDeleted,
+	AssigneeAdded,
+	AssigneeRemoved,
+	LabelAdded,
+	LabelRemoved,
+}
+
+// IsValidType reports whether eventType is a known event type
+func IsValidType(eventType string) bool {
+	for _, known := range Types {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Change records a single field edit carried by task.updated and task.moved
+type Change struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+// Event describes something that happened to a task, or to a whole board
+// when TaskID is empty. Task is a snapshot taken after the change and may be
+// nil for deletions.
+type Event struct {
+	Type       string
+	TaskID     string
+	BoardID    string
+	ActorID    string
+	Task       *models.Task
+	Data       map[string]string
+	Changes    []Change
+	OccurredAt time.Time
+}
+
+// Changed returns the change recorded for a field, if any
+func (e Event) Changed(field string) (Change, bool) {
+	for _, change := range e.Changes {
+		if change.Field == field {
+			return change, true
+		}
+	}
+	return Change{}, false
+}
+
+// Handler reacts to a published event
+type Handler func(ctx context.Context, event Event)
+
+// Bus delivers events synchronously to every subscribed handler
+type Bus struct {
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers a handler for all future events
+func (b *Bus) Subscribe(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, handler)
+}
+
+// Publish delivers the event to every handler in subscription order. A
+// panicking handler is logged and does not stop delivery to the others.
+func (b *Bus) Publish(ctx context.Context, event Event) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	b.mu.RLock()
+	handlers := make([]Handler, len(b.handlers))
+	copy(handlers, b.handlers)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		deliver(ctx, handler, event)
+	}
+}
+
+func deliver(ctx context.Context, handler Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "event handler panicked", "event", event.Type, "panic", r)
+		}
+	}()
+	handler(ctx, event)
+}
+
+// Default is the application-wide bus used by the services
+var Default = NewBus()
+
+// Subscribe registers a handler on the default bus
+func Subscribe(handler Handler) {
+	Default.Subscribe(handler)
+}
+
+// Publish delivers an event on the default bus
+func Publish(ctx context.Context, event Event) {
+	Default.Publish(ctx, event)
+}
+
+// ForTask builds an event for a task, filling in the board from its column
+func ForTask(eventType, actorID string, task *models.Task) Event {
+	event := Event{
+		Type:    eventType,
+		TaskID:  task.ID,
+		ActorID: actorID,
+		Task:    task,
+		Data:    map[string]string{},
+	}
+	if task.Column != nil {
+		event.BoardID = task.Column.BoardID
+	}
+	return event
+}
+
+// ForBoard builds an event for a board as a whole
+func ForBoard(eventType, actorID string, board *models.Board) Event {
+	return Event{
+		Type:    eventType,
+		BoardID: board.ID,
+		ActorID: actorID,
+		Data:    map[string]string{"board_title": board.Title},
+	}
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"kanban-backend/models"
+)
+
+func TestBus_PublishDeliversInOrder(t *testing.T) {
+	bus := NewBus()
+
+	var got []string
+	bus.Subscribe(func(ctx context.Context, event Event) {
+		got = append(got, "first:"+event.Type)
+	})
+	bus.Subscribe(func(ctx context.Context, event Event) {
+		got = append(got, "second:"+event.Type)
+	})
+
+	bus.Publish(context.Background(), Event{Type: TaskCreated})
+
+	if len(got) != 2 || got[0] != "first:task.created" || got[1] != "second:task.created" {
+		t.Errorf("unexpected delivery order: %v", got)
+	}
+}
+
+func TestBus_PublishRecoversFromPanics(t *testing.T) {
+	bus := NewBus()
+
+	delivered := false
+	bus.Subscribe(func(ctx context.Context, event Event) {
+		panic("boom")
+	})
+	bus.Subscribe(func(ctx context.Context, event Event) {
+		delivered = true
+		if event.OccurredAt.IsZero() {
+			t.Error("expected OccurredAt to be set")
+		}
+	})
+
+	bus.Publish(context.Background(), Event{Type: TaskMoved})
+
+	if !delivered {
+		t.Error("expected second handler to receive the event")
+	}
+}
+
+func TestForTask(t *testing.T) {
+	task := &models.Task{ID: "task-1", Column: &models.Column{ID: "col-1", BoardID: "board-1"}}
+
+	event := ForTask(TaskUpdated, "user-1", task)
+
+	if event.TaskID != "task-1" || event.BoardID != "board-1" || event.ActorID != "user-1" {
+		t.Errorf("unexpected event: %+v", event)
+	}
+	if event.Data == nil {
+		t.Error("expected Data to be initialized")
+	}
+}
@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"kanban-backend/services"
+)
+
+// NewNotificationEmailJob returns a job that emails instant notifications and
+// sends hourly and daily digests once they are due
+func NewNotificationEmailJob(emailService services.NotificationEmailService, interval time.Duration) Job {
+	return Job{
+		Name:     "notification-email",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := emailService.SendDue(ctx, time.Now())
+			return err
+		},
+	}
+}
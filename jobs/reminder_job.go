@@ -0,0 +1,24 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"kanban-backend/services"
+)
+
+// NewReminderJob returns a job that sends deadline reminders on every tick
+func NewReminderJob(reminderService services.ReminderService, interval time.Duration) Job {
+	return Job{
+		Name:     "deadline-reminders",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			sent, err := reminderService.SendDueReminders(ctx, time.Now())
+			if sent > 0 {
+				slog.InfoContext(ctx, "sent deadline reminders", "count", sent)
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"kanban-backend/services"
+)
+
+// NewRuleDueJob returns a job that fires task_due automation rules for tasks
+// whose deadline has passed
+func NewRuleDueJob(ruleService services.RuleService, interval time.Duration) Job {
+	return Job{
+		Name:     "rule-due",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			ran, err := ruleService.RunDue(ctx, time.Now())
+			if ran > 0 {
+				slog.InfoContext(ctx, "ran rules on overdue tasks", "count", ran)
+			}
+			return err
+		},
+	}
+}
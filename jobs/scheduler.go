@@ -0,0 +1,103 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"kanban-backend/logging"
+	"kanban-backend/metrics"
+	"kanban-backend/tracing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Job is a unit of background work that runs on a fixed interval
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs in the background until it is stopped
+type Scheduler struct {
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Register adds a job to the scheduler. Jobs must be registered before Start.
+func (s *Scheduler) Register(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+// Start launches every registered job in its own goroutine. Each job runs once
+// immediately and then on every tick of its interval.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func(job Job) {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+}
+
+// Stop cancels all running jobs and waits for in-flight runs to finish
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ctx = logging.WithJob(ctx, job.Name)
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context, job Job) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "job "+job.Name, trace.WithNewRoot())
+	defer span.End()
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "job panicked", "panic", r)
+			span.SetStatus(codes.Error, fmt.Sprint(r))
+			metrics.ObserveJob(job.Name, metrics.JobPanicked, time.Since(start))
+		}
+	}()
+
+	err := job.Run(ctx)
+	switch {
+	case ctx.Err() != nil:
+		// Runs cut short by shutdown are neither successes nor failures
+	case err != nil:
+		slog.ErrorContext(ctx, "job failed", "error", err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		metrics.ObserveJob(job.Name, metrics.JobFailed, time.Since(start))
+	default:
+		metrics.ObserveJob(job.Name, metrics.JobSucceeded, time.Since(start))
+	}
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestScheduler_RunsJobsUntilStopped(t *testing.T) {
+	var runs atomic.Int32
+
+	scheduler := NewScheduler()
+	scheduler.Register(Job{
+		Name:     "counter",
+		Interval: 10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			runs.Add(1)
+			return nil
+		},
+	})
+
+	scheduler.Start(context.Background())
+	time.Sleep(55 * time.Millisecond)
+	scheduler.Stop()
+
+	stopped := runs.Load()
+	if stopped < 2 {
+		t.Fatalf("expected job to run at least twice, ran %d times", stopped)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if runs.Load() != stopped {
+		t.Errorf("job kept running after Stop: %d runs before, %d after", stopped, runs.Load())
+	}
+}
+
+func TestScheduler_SurvivesFailingJobs(t *testing.T) {
+	var runs atomic.Int32
+
+	scheduler := NewScheduler()
+	scheduler.Register(Job{
+		Name:     "failing",
+		Interval: 10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			if runs.Add(1) == 1 {
+				panic("boom")
+			}
+			return errors.New("still failing")
+		},
+	})
+
+	scheduler.Start(context.Background())
+	time.Sleep(35 * time.Millisecond)
+	scheduler.Stop()
+
+	if runs.Load() < 2 {
+		t.Errorf("expected job to keep running after a panic, ran %d times", runs.Load())
+	}
+}
+
+func TestScheduler_StopWithoutStart(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.Stop()
+}
@@ -0,0 +1,25 @@
+package jobs
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"kanban-backend/services"
+)
+
+// NewTrashPurgeJob returns a job that permanently deletes items whose trash
+// retention period has run out
+func NewTrashPurgeJob(trashService services.TrashService, interval time.Duration) Job {
+	return Job{
+		Name:     "trash-purge",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			purged, err := trashService.Purge(ctx, time.Now())
+			if purged > 0 {
+				slog.InfoContext(ctx, "purged trash", "count", purged)
+			}
+			return err
+		},
+	}
+}
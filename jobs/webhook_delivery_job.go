@@ -0,0 +1,21 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"kanban-backend/services"
+)
+
+// NewWebhookDeliveryJob returns a job that sends queued webhook deliveries and
+// retries failed ones once their backoff has passed
+func NewWebhookDeliveryJob(webhookService services.WebhookService, interval time.Duration) Job {
+	return Job{
+		Name:     "webhook-delivery",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			_, err := webhookService.DeliverDue(ctx, time.Now())
+			return err
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// Context keys read by the log handler
+type contextKey string
+
+const (
+	RequestIDKey contextKey = "request_id"
+	UserIDKey    contextKey = "user_id"
+	JobKey       contextKey = "job"
+)
+
+// contextAttrs lists the context values added to every record, in order
+var contextAttrs = []contextKey{RequestIDKey, UserIDKey, JobKey}
+
+// level is shared by the default logger and the database logger so both
+// follow the configured level
+var level = new(slog.LevelVar)
+
+// Setup makes a JSON logger writing to out the default for slog and for the
+// standard log package. Records below the given level ("debug", "info",
+// "warn" or "error") are dropped.
+func Setup(out io.Writer, logLevel string) {
+	level.Set(ParseLevel(logLevel))
+	handler := slog.NewJSONHandler(out, &slog.HandlerOptions{Level: level})
+	slog.SetDefault(slog.New(contextHandler{handler}))
+}
+
+// ParseLevel reads a level name, falling back to info for unknown names
+func ParseLevel(name string) slog.Level {
+	var parsed slog.Level
+	if err := parsed.UnmarshalText([]byte(strings.TrimSpace(name))); err != nil {
+		return slog.LevelInfo
+	}
+	return parsed
+}
+
+// WithRequestID returns a context whose log records carry the request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+// WithUserID returns a context whose log records carry the signed-in user's ID
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, UserIDKey, userID)
+}
+
+// WithJob returns a context whose log records carry the background job's name
+func WithJob(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, JobKey, name)
+}
+
+// RequestID returns the request ID stored in the context, if any
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
+
+// GormLogger logs database errors, and slow queries, through slog. At debug
+// level every query is logged.
+func GormLogger() gormlogger.Interface {
+	gormLevel := gormlogger.Warn
+	if level.Level() <= slog.LevelDebug {
+		gormLevel = gormlogger.Info
+	}
+	return gormlogger.NewSlogLogger(slog.Default(), gormlogger.Config{
+		SlowThreshold:             200 * time.Millisecond,
+		LogLevel:                  gormLevel,
+		IgnoreRecordNotFoundError: true,
+	})
+}
+
+// contextHandler adds the request ID, user ID, job name and trace found in
+// the context to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		for _, key := range contextAttrs {
+			if value, ok := ctx.Value(key).(string); ok && value != "" {
+				record.AddAttrs(slog.String(string(key), value))
+			}
+		}
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			record.AddAttrs(
+				slog.String("trace_id", spanContext.TraceID().String()),
+				slog.String("span_id", spanContext.SpanID().String()),
+			)
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// Detach returns a context that is never canceled but keeps the request ID,
+// user ID, job name and trace of ctx, for work that outlives the request
+func Detach(ctx context.Context) context.Context {
+	detached := trace.ContextWithSpanContext(context.Background(), trace.SpanContextFromContext(ctx))
+	for _, key := range contextAttrs {
+		if value, ok := ctx.Value(key).(string); ok && value != "" {
+			detached = context.WithValue(detached, key, value)
+		}
+	}
+	return detached
+}
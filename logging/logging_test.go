@@ -0,0 +1,61 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"debug":   slog.LevelDebug,
+		"WARN":    slog.LevelWarn,
+		" error ": slog.LevelError,
+		"":        slog.LevelInfo,
+		"verbose": slog.LevelInfo,
+	}
+	for name, want := range tests {
+		if got := ParseLevel(name); got != want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestSetup_AddsContextValues(t *testing.T) {
+	var out bytes.Buffer
+	Setup(&out, "warn")
+	defer Setup(os.Stderr, "info")
+
+	ctx := WithJob(WithRequestID(context.Background(), "req-1"), "reminders")
+	ctx = WithUserID(ctx, "user-1")
+	slog.InfoContext(ctx, "dropped below the level")
+	slog.WarnContext(ctx, "job slow", "count", 3)
+
+	var record map[string]any
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("output is not one JSON record: %v\n%s", err, out.String())
+	}
+	want := map[string]any{"msg": "job slow", "request_id": "req-1", "user_id": "user-1", "job": "reminders", "count": float64(3)}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("%s = %v, want %v", key, record[key], value)
+		}
+	}
+}
+
+func TestDetach(t *testing.T) {
+	ctx, cancel := context.WithCancel(WithRequestID(context.Background(), "req-1"))
+	ctx = WithUserID(ctx, "user-1")
+	cancel()
+
+	detached := Detach(ctx)
+	if detached.Err() != nil {
+		t.Error("detached context should not be canceled")
+	}
+	if RequestID(detached) != "req-1" || detached.Value(UserIDKey) != "user-1" {
+		t.Error("detached context lost the request and user IDs")
+	}
+}
@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type fileMailer struct {
+	dir   string
+	from  string
+	count atomic.Int64
+}
+
+// NewFileMailer writes each message to dir as an .eml file instead of sending
+// it, for development and for inspecting mail without a mail server
+func NewFileMailer(dir, from string) Mailer {
+	return &fileMailer{
+		dir:  dir,
+		from: from,
+	}
+}
+
+func (m *fileMailer) Send(ctx context.Context, message Message) error {
+	now := time.Now()
+	body, err := message.build(m.from, now)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return err
+	}
+
+	recipient, err := envelopeAddress(message.To)
+	if err != nil {
+		return err
+	}
+	recipient = strings.NewReplacer("@", "_at_", "/", "_", "\\", "_").Replace(recipient)
+	name := fmt.Sprintf("%s-%03d-%s.eml", now.UTC().Format("20060102T150405.000"), m.count.Add(1)%1000, recipient)
+	return os.WriteFile(filepath.Join(m.dir, name), body, 0o644)
+}
@@ -0,0 +1,141 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"mime"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testMessage = Message{
+	To:      "Sam <sam@example.com>",
+	ReplyTo: "reply+task@in.example.com",
+	Subject: "Café menu",
+	Text:    "Please add a flat white.",
+	HTML:    "<p>Please add a flat white.</p>",
+}
+
+func TestFileMailer_WritesMessage(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewFileMailer(dir, "Kanban <noreply@example.com>")
+
+	if err := mailer.Send(context.Background(), testMessage); err != nil {
+		t.Fatalf("Send() unexpected error = %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*sam_at_example.com.eml"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("expected one .eml file, got %v (%v)", files, err)
+	}
+	raw, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("written file is not a valid message: %v", err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if subject != "Café menu" || message.Header.Get("Reply-To") != "reply+task@in.example.com" {
+		t.Errorf("unexpected headers %v", message.Header)
+	}
+	if !strings.HasPrefix(message.Header.Get("Message-Id"), "<") || !strings.HasSuffix(message.Header.Get("Message-Id"), "@example.com>") {
+		t.Errorf("unexpected Message-ID %q", message.Header.Get("Message-Id"))
+	}
+	for _, part := range []string{"text/plain; charset=utf-8", "text/html; charset=utf-8", "Please add a flat white."} {
+		if !strings.Contains(string(raw), part) {
+			t.Errorf("message misses %q", part)
+		}
+	}
+}
+
+func TestMessage_RejectsInvalidRecipient(t *testing.T) {
+	mailer := NewFileMailer(t.TempDir(), "noreply@example.com")
+	if err := mailer.Send(context.Background(), Message{To: "not an address", Subject: "Hi", Text: "Hi"}); err == nil {
+		t.Error("expected an error for an invalid recipient")
+	}
+}
+
+func TestSMTPMailer_SendsToServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go serveSMTP(listener, received)
+
+	mailer := NewSMTPMailer(listener.Addr().String(), "", "", "Kanban <noreply@example.com>")
+	if err := mailer.Send(context.Background(), testMessage); err != nil {
+		t.Fatalf("Send() unexpected error = %v", err)
+	}
+
+	commands := <-received
+	for _, want := range []string{"MAIL FROM:<noreply@example.com>", "RCPT TO:<sam@example.com>", "DATA", "QUIT"} {
+		found := false
+		for _, command := range commands {
+			if strings.HasPrefix(command, want) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("server did not receive %q in %v", want, commands)
+		}
+	}
+}
+
+// serveSMTP answers a single SMTP session without authentication or TLS, as
+// local catchers such as Mailpit do, and reports the commands it received
+func serveSMTP(listener net.Listener, received chan<- []string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	reply("220 localhost ESMTP")
+
+	var commands []string
+	inData := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			received <- commands
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		if inData {
+			if line == "." {
+				inData = false
+				reply("250 OK")
+			}
+			continue
+		}
+
+		commands = append(commands, line)
+		switch {
+		case strings.HasPrefix(line, "EHLO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case line == "DATA":
+			inData = true
+			reply("354 Go ahead")
+		case line == "QUIT":
+			reply("221 Bye")
+			received <- commands
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
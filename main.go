@@ -1,14 +1,29 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"kanban-backend/config"
 	"kanban-backend/controllers"
+	"kanban-backend/events"
+	"kanban-backend/jobs"
+	"kanban-backend/logging"
+	"kanban-backend/mailer"
+	"kanban-backend/metrics"
 	"kanban-backend/repositories"
 	"kanban-backend/routes"
 	"kanban-backend/services"
+	"kanban-backend/tracing"
 	"kanban-backend/utils"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,12 +31,37 @@ import (
 )
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("Error loading .env file")
+	logging.Setup(os.Stdout, "")
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		slog.Error("failed to load .env file", "error", err)
+		os.Exit(1)
 	}
 
-	config.ConnectDB()
-	config.ConnectS3()
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(cfg, os.Args[1:]))
+	}
+
+	if err := cfg.Validate(); err != nil {
+		slog.Error("invalid config", "error", err)
+		os.Exit(1)
+	}
+	logging.Setup(os.Stdout, cfg.Server.LogLevel)
+	utils.SetJWTSecret(cfg.JWT.Secret)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing.Exporter, os.Stdout)
+	if err != nil {
+		slog.Error("failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	config.ConnectDB(cfg.Database)
+	config.ConnectS3(cfg.S3)
 
 	userRepo := repositories.NewUserRepository()
 	boardRepo := repositories.NewBoardRepository()
@@ -30,29 +70,189 @@ func main() {
 	commentRepo := repositories.NewCommentRepository()
 	labelRepo := repositories.NewLabelRepository()
 	attachmentRepo := repositories.NewAttachmentRepository()
+	assigneeRepo := repositories.NewTaskAssigneeRepository()
+	reminderRepo := repositories.NewReminderRepository()
+	watchRepo := repositories.NewWatchRepository()
+	notificationRepo := repositories.NewNotificationRepository()
+	activityRepo := repositories.NewActivityRepository()
+	analyticsRepo := repositories.NewAnalyticsRepository()
+	sprintRepo := repositories.NewSprintRepository()
+	templateRepo := repositories.NewBoardTemplateRepository()
+	trashRepo := repositories.NewTrashRepository()
+	exportRepo := repositories.NewBoardExportRepository()
+	importJobRepo := repositories.NewImportJobRepository()
+	csvRepo := repositories.NewTaskCSVRepository()
+	calendarRepo := repositories.NewCalendarRepository()
+	webhookRepo := repositories.NewWebhookRepository()
+	ruleRepo := repositories.NewRuleRepository()
+	gitRepo := repositories.NewGitIntegrationRepository()
+	inboxRepo := repositories.NewBoardInboxRepository()
+	notificationEmailRepo := repositories.NewNotificationEmailRepository()
+	healthRepo := repositories.NewHealthRepository()
 
-	authService := services.NewAuthService(userRepo)
-	boardService := services.NewBoardService(boardRepo, columnRepo)
+	if err := metrics.RegisterCounts(repositories.NewMetricsRepository()); err != nil {
+		slog.Error("failed to register metrics", "error", err)
+		os.Exit(1)
+	}
+
+	authService := services.NewAuthService(userRepo, cfg.JWT.Expiry)
+	boardService := services.NewBoardService(boardRepo, columnRepo, templateRepo)
 	taskService := services.NewTaskService(taskRepo, columnRepo)
 	commentService := services.NewCommentService(commentRepo, taskRepo)
 	labelService := services.NewLabelService(labelRepo, taskRepo)
 	attachmentService := services.NewAttachmentService(attachmentRepo, taskRepo)
+	assigneeService := services.NewAssigneeService(assigneeRepo, taskRepo, userRepo)
+	userService := services.NewUserService(userRepo)
+	reminderService := services.NewReminderService(reminderRepo, userRepo, watchRepo)
+	watchService := services.NewWatchService(watchRepo, notificationRepo, taskRepo, boardRepo)
+	activityService := services.NewActivityService(activityRepo, taskRepo)
+	analyticsService := services.NewAnalyticsService(analyticsRepo, boardRepo, columnRepo)
+	sprintService := services.NewSprintService(sprintRepo, boardRepo, taskRepo, analyticsRepo)
+	templateService := services.NewBoardTemplateService(templateRepo, boardRepo)
+	exportService := services.NewBoardExportService(exportRepo, boardRepo, sprintRepo)
+	importService := services.NewImportService(importJobRepo, exportRepo)
+	csvService := services.NewTaskCSVService(csvRepo, boardRepo, exportRepo)
+	calendarService := services.NewCalendarService(calendarRepo)
+	storage := services.NewS3Service(cfg.S3.Bucket, cfg.S3.Region)
+	trashService := services.NewTrashService(trashRepo, storage, time.Duration(cfg.Trash.RetentionDays)*24*time.Hour)
+	webhookService := services.NewWebhookService(webhookRepo, boardRepo, services.NewWebhookClient(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateTargets), cfg.Webhooks.AllowPrivateTargets)
+
+	ruleService := services.NewRuleService(ruleRepo, boardRepo, taskRepo, notificationRepo, taskService, labelService, assigneeService, commentService)
+	gitService := services.NewGitIntegrationService(gitRepo, boardRepo, activityRepo, taskService)
+	inboxService := services.NewEmailInboxService(inboxRepo, boardRepo, taskRepo, commentRepo, attachmentRepo, userRepo, taskService, storage, cfg.InboundEmail.Domain, cfg.InboundEmail.Secret)
+	notificationMailer := newMailer(cfg.Mail)
+	notificationEmailService := services.NewNotificationEmailService(notificationEmailRepo, notificationMailer, inboxService, cfg.Server.AppURL)
+	healthService := services.NewHealthService(healthRepo, storage, cfg.Server.MigrationsPath)
+
+	events.Subscribe(activityService.HandleEvent)
+	events.Subscribe(watchService.HandleEvent)
+	events.Subscribe(webhookService.HandleEvent)
+	// Rules run last so the change that triggered them is recorded first
+	events.Subscribe(ruleService.HandleEvent)
 
-	authController := controllers.NewAuthController(authService)
-	boardController := controllers.NewBoardController(boardService)
-	taskController := controllers.NewTaskController(taskService)
-	commentController := controllers.NewCommentController(commentService)
-	labelController := controllers.NewLabelController(labelService)
-	attachmentController := controllers.NewAttachmentController(attachmentService)
+	scheduler := jobs.NewScheduler()
+	scheduler.Register(jobs.NewReminderJob(reminderService, cfg.Jobs.ReminderInterval))
+	scheduler.Register(jobs.NewTrashPurgeJob(trashService, cfg.Jobs.TrashPurgeInterval))
+	scheduler.Register(jobs.NewWebhookDeliveryJob(webhookService, cfg.Jobs.WebhookDeliveryInterval))
+	scheduler.Register(jobs.NewRuleDueJob(ruleService, cfg.Jobs.RuleDueInterval))
+	if notificationMailer != nil {
+		scheduler.Register(jobs.NewNotificationEmailJob(notificationEmailService, cfg.Jobs.NotificationEmailInterval))
+	}
+	scheduler.Start(context.Background())
 
 	app := fiber.New(fiber.Config{
 		AppName:      "Kanban API v1.0",
 		ErrorHandler: utils.ErrorHandler,
 	})
 
-	routes.Setup(app, authService, authController, boardController, taskController, commentController, labelController, attachmentController)
+	routes.Setup(app, authService, routes.Controllers{
+		Auth:              controllers.NewAuthController(authService),
+		Board:             controllers.NewBoardController(boardService),
+		Task:              controllers.NewTaskController(taskService),
+		Comment:           controllers.NewCommentController(commentService),
+		Label:             controllers.NewLabelController(labelService),
+		Attachment:        controllers.NewAttachmentController(attachmentService),
+		Assignee:          controllers.NewAssigneeController(assigneeService),
+		User:              controllers.NewUserController(userService),
+		Watch:             controllers.NewWatchController(watchService),
+		Activity:          controllers.NewActivityController(activityService),
+		Analytics:         controllers.NewAnalyticsController(analyticsService),
+		Sprint:            controllers.NewSprintController(sprintService),
+		Template:          controllers.NewBoardTemplateController(templateService),
+		Trash:             controllers.NewTrashController(trashService),
+		Export:            controllers.NewBoardExportController(exportService),
+		Import:            controllers.NewImportController(importService),
+		TaskCSV:           controllers.NewTaskCSVController(csvService),
+		Calendar:          controllers.NewCalendarController(calendarService),
+		Webhook:           controllers.NewWebhookController(webhookService),
+		Rule:              controllers.NewRuleController(ruleService),
+		Git:               controllers.NewGitIntegrationController(gitService),
+		EmailInbox:        controllers.NewEmailInboxController(inboxService),
+		NotificationEmail: controllers.NewNotificationEmailController(notificationEmailService),
+		Health:            controllers.NewHealthController(healthService),
+	}, cfg.Server)
+
+	port := cfg.Server.Port
+	listenErr := make(chan error, 1)
+	go func() {
+		slog.Info("server starting", "port", port)
+		listenErr <- app.Listen(":" + port)
+	}()
 
-	port := os.Getenv("PORT")
-	log.Printf("🚀 Server running on port %s", port)
-	log.Fatal(app.Listen(":" + port))
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case err := <-listenErr:
+		slog.Error("server stopped", "error", err)
+		os.Exit(1)
+	case sig := <-signals:
+		slog.Info("shutting down", "signal", sig.String())
+	}
+
+	// Fail readiness first and give load balancers the shutdown delay to notice
+	// before the listener closes
+	healthService.Drain()
+	time.Sleep(cfg.Server.ShutdownDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := app.ShutdownWithContext(ctx); err != nil {
+		slog.Error("failed to drain requests", "error", err)
+	}
+
+	workersDone := make(chan struct{})
+	go func() {
+		scheduler.Stop()
+		importService.Wait()
+		close(workersDone)
+	}()
+	select {
+	case <-workersDone:
+	case <-ctx.Done():
+		slog.Error("background workers did not stop in time")
+	}
+
+	if err := shutdownTracing(ctx); err != nil {
+		slog.Error("failed to flush traces", "error", err)
+	}
+	if sqlDB, err := config.DB.DB(); err == nil {
+		sqlDB.Close()
+	}
+	slog.Info("server stopped")
+}
+
+// runCommand runs a maintenance command instead of the server and returns
+// the exit code
+func runCommand(cfg *config.AppConfig, args []string) int {
+	switch strings.Join(args, " ") {
+	case "config print":
+		if err := cfg.Print(os.Stdout); err != nil {
+			slog.Error("failed to print config", "error", err)
+			return 1
+		}
+		if err := cfg.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "config is invalid:\n%v\n", err)
+			return 1
+		}
+		return 0
+	default:
+		fmt.Fprintln(os.Stderr, "usage: kanban-backend [config print]")
+		return 2
+	}
+}
+
+// newMailer picks the mailer named by the mail driver: "smtp" sends through
+// the SMTP server, "file" writes .eml files to the mail directory. Without a
+// driver notifications are not emailed.
+func newMailer(cfg config.MailConfig) mailer.Mailer {
+	switch cfg.Driver {
+	case "smtp":
+		return mailer.NewSMTPMailer(net.JoinHostPort(cfg.SMTPHost, cfg.SMTPPort), cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	case "file":
+		return mailer.NewFileMailer(cfg.Dir, cfg.From)
+	default:
+		return nil
+	}
 }
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// ActiveUserWindow is how recently a user must have changed or commented
+	// on a task to count as active
+	ActiveUserWindow = 24 * time.Hour
+	// countsTTL keeps frequent or concurrent scrapes from each counting rows
+	countsTTL     = 30 * time.Second
+	countsTimeout = 5 * time.Second
+)
+
+// Counts are the business figures exported as gauges
+type Counts struct {
+	ActiveBoards   int64
+	ArchivedBoards int64
+	ActiveTasks    int64
+	ArchivedTasks  int64
+	ActiveUsers    int64
+}
+
+// CountSource reads the current counts from the database
+type CountSource interface {
+	Counts(ctx context.Context, activeSince time.Time) (*Counts, error)
+}
+
+var (
+	boardsDesc      = prometheus.NewDesc(namespace+"_boards", "Boards that are not deleted, by state.", []string{"state"}, nil)
+	tasksDesc       = prometheus.NewDesc(namespace+"_tasks", "Tasks that are not deleted, by state.", []string{"state"}, nil)
+	activeUsersDesc = prometheus.NewDesc(namespace+"_active_users", "Users who changed or commented on a task in the last 24 hours.", nil, nil)
+)
+
+// RegisterCounts exports the boards, tasks and active users gauges, read
+// from source when scraped
+func RegisterCounts(source CountSource) error {
+	return Registry.Register(&countsCollector{source: source})
+}
+
+type countsCollector struct {
+	source CountSource
+
+	mu        sync.Mutex
+	counts    *Counts
+	countedAt time.Time
+}
+
+func (c *countsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- boardsDesc
+	ch <- tasksDesc
+	ch <- activeUsersDesc
+}
+
+// Collect sends the cached counts, refreshing them once they are older than
+// countsTTL. If counting fails the gauges are left out of the scrape.
+func (c *countsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.counts == nil || time.Since(c.countedAt) > countsTTL {
+		ctx, cancel := context.WithTimeout(context.Background(), countsTimeout)
+		defer cancel()
+
+		counts, err := c.source.Counts(ctx, time.Now().Add(-ActiveUserWindow))
+		if err != nil {
+			slog.WarnContext(ctx, "failed to count boards, tasks and users for metrics", "error", err)
+			return
+		}
+		c.counts = counts
+		c.countedAt = time.Now()
+	}
+
+	ch <- prometheus.MustNewConstMetric(boardsDesc, prometheus.GaugeValue, float64(c.counts.ActiveBoards), "active")
+	ch <- prometheus.MustNewConstMetric(boardsDesc, prometheus.GaugeValue, float64(c.counts.ArchivedBoards), "archived")
+	ch <- prometheus.MustNewConstMetric(tasksDesc, prometheus.GaugeValue, float64(c.counts.ActiveTasks), "active")
+	ch <- prometheus.MustNewConstMetric(tasksDesc, prometheus.GaugeValue, float64(c.counts.ArchivedTasks), "archived")
+	ch <- prometheus.MustNewConstMetric(activeUsersDesc, prometheus.GaugeValue, float64(c.counts.ActiveUsers))
+}
@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestHandler_ServesRecordedMetrics(t *testing.T) {
+	ObserveRequest("GET", "/api/v1/boards/:id", 200, 20*time.Millisecond)
+	ObserveJob("reminders", JobSucceeded, time.Second)
+
+	app := fiber.New()
+	app.Get("/metrics", Handler())
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+
+	for _, want := range []string{
+		`kanban_http_requests_total{method="GET",route="/api/v1/boards/:id",status="200"}`,
+		`kanban_http_request_duration_seconds_bucket{method="GET",route="/api/v1/boards/:id",status="200",le="0.025"}`,
+		`kanban_job_runs_total{job="reminders",result="success"}`,
+		`kanban_job_last_success_timestamp_seconds{job="reminders"}`,
+		"go_goroutines",
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics output misses %s", want)
+		}
+	}
+}
+
+func TestInstrumentDB_TimesQueries(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := InstrumentDB(db); err != nil {
+		t.Fatalf("InstrumentDB() unexpected error = %v", err)
+	}
+
+	type widget struct {
+		ID   int
+		Name string
+	}
+	if err := db.AutoMigrate(&widget{}); err != nil {
+		t.Fatal(err)
+	}
+	db.Create(&widget{Name: "gear"})
+	var found []widget
+	db.Find(&found)
+
+	if got := testutil.CollectAndCount(dbQueryDuration, "kanban_db_query_duration_seconds"); got < 2 {
+		t.Errorf("got %d query series, want create and query", got)
+	}
+	if got := testutil.CollectAndCount(Registry, "go_sql_max_open_connections"); got != 1 {
+		t.Errorf("got %d pool stats series, want 1", got)
+	}
+}
+
+type fakeCountSource struct {
+	calls int
+	err   error
+}
+
+func (f *fakeCountSource) Counts(ctx context.Context, activeSince time.Time) (*Counts, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &Counts{ActiveBoards: 3, ArchivedBoards: 1, ActiveTasks: 12, ArchivedTasks: 4, ActiveUsers: 2}, nil
+}
+
+func TestCountsCollector(t *testing.T) {
+	source := &fakeCountSource{}
+	collector := &countsCollector{source: source}
+
+	expected := `
+# HELP kanban_active_users Users who changed or commented on a task in the last 24 hours.
+# TYPE kanban_active_users gauge
+kanban_active_users 2
+# HELP kanban_boards Boards that are not deleted, by state.
+# TYPE kanban_boards gauge
+kanban_boards{state="active"} 3
+kanban_boards{state="archived"} 1
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "kanban_active_users", "kanban_boards"); err != nil {
+		t.Error(err)
+	}
+	if got := testutil.CollectAndCount(collector, "kanban_tasks"); got != 2 {
+		t.Errorf("got %d task series, want active and archived", got)
+	}
+	if source.calls != 1 {
+		t.Errorf("counted %d times, want the counts cached between scrapes", source.calls)
+	}
+
+	failing := &countsCollector{source: &fakeCountSource{err: errors.New("database down")}}
+	if got := testutil.CollectAndCount(failing); got != 0 {
+		t.Errorf("got %d series from a failing source, want none", got)
+	}
+}
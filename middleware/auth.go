@@ -3,6 +3,7 @@ package middleware
 import (
 	"strings"
 
+	"kanban-backend/logging"
 	"kanban-backend/services"
 	"kanban-backend/utils"
 
@@ -30,6 +31,7 @@ func AuthMiddleware(authService services.AuthService) fiber.Handler {
 		}
 
 		c.Locals("user_id", userID)
+		c.SetUserContext(logging.WithUserID(c.UserContext(), userID))
 
 		return c.Next()
 	}
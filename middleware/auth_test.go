@@ -37,6 +37,10 @@ func (m *mockAuthServiceForAuth) ValidateToken(token string) (string, error) {
 	return "user-123", nil
 }
 
+func (m *mockAuthServiceForAuth) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	return &models.User{ID: userID}, nil
+}
+
 func (m *mockAuthServiceForAuth) HashPassword(password string) (string, error) {
 	return "hashed-password", nil
 }
@@ -1,15 +1,12 @@
 package middleware
 
 import (
-	"os"
-
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
-// CORSConfig returns configured CORS middleware
-func CORSConfig() cors.Config {
-	// Get allowed origins from environment or default to "*" for development
-	allowedOrigins := os.Getenv("CORS_ALLOWED_ORIGINS")
+// CORSConfig returns configured CORS middleware for a comma-separated list
+// of allowed origins
+func CORSConfig(allowedOrigins string) cors.Config {
 	if allowedOrigins == "" {
 		// Default to allow all origins in development
 		allowedOrigins = "*"
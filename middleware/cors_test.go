@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"net/http/httptest"
-	"os"
 	"strings"
 	"testing"
 
@@ -11,10 +10,7 @@ import (
 )
 
 func TestCORSConfig_Default(t *testing.T) {
-	// Clear CORS_ALLOWED_ORIGINS environment variable to test default
-	os.Unsetenv("CORS_ALLOWED_ORIGINS")
-
-	config := CORSConfig()
+	config := CORSConfig("")
 
 	if config.AllowOrigins != "*" {
 		t.Errorf("Expected AllowOrigins to be '*', got %s", config.AllowOrigins)
@@ -35,11 +31,7 @@ func TestCORSConfig_Default(t *testing.T) {
 }
 
 func TestCORSConfig_WithCustomOrigins(t *testing.T) {
-	// Set custom origins
-	os.Setenv("CORS_ALLOWED_ORIGINS", "http://localhost:3000,http://example.com")
-	defer os.Unsetenv("CORS_ALLOWED_ORIGINS")
-
-	config := CORSConfig()
+	config := CORSConfig("http://localhost:3000,http://example.com")
 
 	if config.AllowOrigins != "http://localhost:3000,http://example.com" {
 		t.Errorf("Expected AllowOrigins to be 'http://localhost:3000,http://example.com', got %s", config.AllowOrigins)
@@ -56,7 +48,7 @@ func TestCORSConfig_WithCustomOrigins(t *testing.T) {
 }
 
 func TestCORSHeaders(t *testing.T) {
-	config := CORSConfig()
+	config := CORSConfig("")
 
 	if !strings.Contains(config.AllowMethods, "GET") {
 		t.Error("Expected GET in AllowMethods")
@@ -90,7 +82,7 @@ func TestCORSHeaders(t *testing.T) {
 
 func TestCORSActualRequest(t *testing.T) {
 	app := fiber.New()
-	app.Use(cors.New(CORSConfig()))
+	app.Use(cors.New(CORSConfig("")))
 
 	app.Get("/test", func(c *fiber.Ctx) error {
 		return c.SendString("test")
@@ -1,43 +1,86 @@
 package middleware
 
 import (
+	"log/slog"
+	"regexp"
 	"time"
 
+	"kanban-backend/logging"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
-// Logger middleware untuk log HTTP request details
-// Log: HTTP method, path, status code, duration, request ID
+// RequestIDHeader carries the request ID. An ID sent by the client or a proxy
+// is kept; otherwise one is generated. Either way it is echoed in the response.
+const RequestIDHeader = "X-Request-ID"
+
+var (
+	requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+	// secretPathPatterns match path segments that act as credentials
+	secretPathPatterns = []struct {
+		pattern     *regexp.Regexp
+		replacement string
+	}{
+		{regexp.MustCompile(`^/calendar/[^/]+\.ics$`), "/calendar/[redacted].ics"},
+		{regexp.MustCompile(`^/hooks/git/[^/]+$`), "/hooks/git/[redacted]"},
+	}
+)
+
+// Logger writes one structured log record per request with its request ID,
+// user ID, method, path, status, latency and error. Errors returned by
+// handlers are passed to the app's error handler first so the logged status
+// is the one sent.
 func Logger() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// Generate unique request ID
-		requestID := uuid.New().String()
+		start := time.Now()
 
-		// Set request ID dalam context untuk use dalam handler lain
+		requestID := c.Get(RequestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = uuid.New().String()
+		}
+		c.Set(RequestIDHeader, requestID)
 		c.Locals("requestID", requestID)
+		c.SetUserContext(logging.WithRequestID(c.UserContext(), requestID))
 
-		// Capture start time
-		start := time.Now()
-
-		// Continue ke next handler
 		err := c.Next()
+		if err != nil {
+			if handlerErr := c.App().Config().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
 
-		// Capture response details
-		duration := time.Since(start)
-		method := c.Method()
-		path := c.Path()
 		status := c.Response().StatusCode()
+		attrs := []slog.Attr{
+			slog.String("method", c.Method()),
+			slog.String("path", redactPath(c.Path())),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		slog.LogAttrs(c.UserContext(), level, "request", attrs...)
 
-		// Log request details
-		c.Locals("log", fiber.Map{
-			"request_id": requestID,
-			"method":     method,
-			"path":       path,
-			"status":     status,
-			"duration":   duration.String(),
-		})
+		return nil
+	}
+}
 
-		return err
+// redactPath hides path segments that are secrets, such as calendar feed
+// tokens, so they do not end up in logs
+func redactPath(path string) string {
+	for _, secret := range secretPathPatterns {
+		if secret.pattern.MatchString(path) {
+			return secret.replacement
+		}
 	}
+	return path
 }
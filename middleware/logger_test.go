@@ -1,10 +1,17 @@
 package middleware
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
+	"os"
+	"strings"
 	"testing"
 
+	"kanban-backend/logging"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -115,3 +122,79 @@ func TestRequestID(t *testing.T) {
 		t.Errorf("request_id length is %d, want 36", len(requestID))
 	}
 }
+
+// Test the request ID is honored from and echoed in X-Request-ID
+func TestRequestIDHeader(t *testing.T) {
+	app := fiber.New()
+	app.Use(Logger())
+	app.Get("/test", func(c *fiber.Ctx) error {
+		return c.SendString(logging.RequestID(c.UserContext()))
+	})
+
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set(RequestIDHeader, "edge-1234")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resp.Header.Get(RequestIDHeader); got != "edge-1234" {
+		t.Errorf("echoed request ID = %q, want edge-1234", got)
+	}
+
+	// IDs that could forge log lines are replaced
+	req, _ = http.NewRequest("GET", "/test", nil)
+	req.Header.Set(RequestIDHeader, "bad id\nlevel=ERROR")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resp.Header.Get(RequestIDHeader); len(got) != 36 {
+		t.Errorf("request ID = %q, want a generated UUID", got)
+	}
+}
+
+// Test one JSON record is written per request with the final status
+func TestLoggerWritesRecord(t *testing.T) {
+	var out bytes.Buffer
+	logging.Setup(&out, "info")
+	defer logging.Setup(os.Stderr, "info")
+
+	app := fiber.New()
+	app.Use(Logger())
+	app.Get("/calendar/:token", func(c *fiber.Ctx) error {
+		c.SetUserContext(logging.WithUserID(c.UserContext(), "user-1"))
+		return errors.New("feed unavailable")
+	})
+
+	req, _ := http.NewRequest("GET", "/calendar/secret-token.ics", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != 500 {
+		t.Fatalf("got status %d, want 500", resp.StatusCode)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("log output is not one JSON record: %v\n%s", err, out.String())
+	}
+	want := map[string]any{
+		"level":      slog.LevelError.String(),
+		"request_id": "req-1",
+		"user_id":    "user-1",
+		"method":     "GET",
+		"path":       "/calendar/[redacted].ics",
+		"status":     float64(500),
+		"error":      "feed unavailable",
+	}
+	for key, value := range want {
+		if record[key] != value {
+			t.Errorf("%s = %v, want %v", key, record[key], value)
+		}
+	}
+	if _, ok := record["latency_ms"]; !ok || strings.Contains(out.String(), "secret-token") {
+		t.Errorf("unexpected record %s", out.String())
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"kanban-backend/metrics"
+	"kanban-backend/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// unmatchedRoute labels requests that matched no route, so scanners probing
+// random paths do not each create new series
+const unmatchedRoute = "unmatched"
+
+// Metrics records the count and latency of every request by method, route
+// template and status. It must be registered before Logger so the status it
+// records is the one sent after errors are handled.
+func Metrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		own := c.Route()
+
+		err := c.Next()
+
+		status := responseStatus(c, err)
+		route := c.Route().Path
+		if c.Route() == own {
+			route = unmatchedRoute
+		}
+		metrics.ObserveRequest(c.Method(), route, status, time.Since(start))
+
+		return err
+	}
+}
+
+// responseStatus is the status the request ends with, including errors not
+// yet turned into a response by the app's error handler
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
+
+// MetricsAuth protects /metrics with a bearer token. When the token is empty
+// the endpoint is open, which suits scraping on a private network.
+func MetricsAuth(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token == "" {
+			return c.Next()
+		}
+
+		provided, found := strings.CutPrefix(c.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			return utils.AuthError(c, "Invalid metrics token")
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_assignees_user_id;
+DROP INDEX IF EXISTS idx_task_assignees_task_id;
+DROP TABLE IF EXISTS task_assignees CASCADE;
@@ -0,0 +1,11 @@
+CREATE TABLE task_assignees (
+    task_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id),
+    CONSTRAINT fk_task_assignees_task_id FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    CONSTRAINT fk_task_assignees_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_assignees_task_id ON task_assignees(task_id);
+CREATE INDEX idx_task_assignees_user_id ON task_assignees(user_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_reminders_user_id;
+DROP INDEX IF EXISTS idx_task_reminders_unique;
+DROP TABLE IF EXISTS task_reminders CASCADE;
@@ -0,0 +1,14 @@
+CREATE TABLE task_reminders (
+    id VARCHAR(36) PRIMARY KEY,
+    task_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    lead_minutes INTEGER NOT NULL DEFAULT 0,
+    deadline TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_task_reminders_task_id FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+    CONSTRAINT fk_task_reminders_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_task_reminders_unique ON task_reminders(task_id, user_id, kind, lead_minutes, deadline);
+CREATE INDEX idx_task_reminders_user_id ON task_reminders(user_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_notifications_task_id;
+DROP INDEX IF EXISTS idx_notifications_type;
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS task_id;
+ALTER TABLE notifications DROP COLUMN IF EXISTS type;
+
+ALTER TABLE users DROP COLUMN IF EXISTS reminder_lead_times;
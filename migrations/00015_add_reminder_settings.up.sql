@@ -0,0 +1,7 @@
+ALTER TABLE users ADD COLUMN reminder_lead_times VARCHAR(255) DEFAULT '1440,60';
+
+ALTER TABLE notifications ADD COLUMN type VARCHAR(50) NOT NULL DEFAULT 'general';
+ALTER TABLE notifications ADD COLUMN task_id VARCHAR(36) NULL;
+
+CREATE INDEX idx_notifications_type ON notifications(type);
+CREATE INDEX idx_notifications_task_id ON notifications(task_id);
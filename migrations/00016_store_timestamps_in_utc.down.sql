@@ -0,0 +1,68 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS deadline_all_day;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
+
+ALTER TABLE users
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE boards
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE columns
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE tasks
+    ALTER COLUMN deadline TYPE TIMESTAMP USING deadline AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE comments
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE labels
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE attachments
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE notifications
+    ALTER COLUMN read_at TYPE TIMESTAMP USING read_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE task_labels
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE members
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE refresh_tokens
+    ALTER COLUMN expires_at TYPE TIMESTAMP USING expires_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE audit_logs
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE task_assignees
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Kuala_Lumpur';
+
+ALTER TABLE task_reminders
+    ALTER COLUMN deadline TYPE TIMESTAMP USING deadline AT TIME ZONE 'Asia/Kuala_Lumpur',
+    ALTER COLUMN sent_at TYPE TIMESTAMP USING sent_at AT TIME ZONE 'Asia/Kuala_Lumpur';
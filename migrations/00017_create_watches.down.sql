@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_watches_target;
+DROP TABLE IF EXISTS watches CASCADE;
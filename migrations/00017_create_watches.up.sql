@@ -0,0 +1,11 @@
+CREATE TABLE watches (
+    user_id VARCHAR(36) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, target_type, target_id),
+    CONSTRAINT fk_watches_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_watches_target_type CHECK (target_type IN ('task', 'board'))
+);
+
+CREATE INDEX idx_watches_target ON watches(target_type, target_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_task_activities_user_id;
+DROP INDEX IF EXISTS idx_task_activities_task;
+DROP TABLE IF EXISTS task_activities CASCADE;
@@ -0,0 +1,2 @@
+ALTER TABLE columns DROP CONSTRAINT IF EXISTS chk_columns_stage;
+ALTER TABLE columns DROP COLUMN IF EXISTS stage;
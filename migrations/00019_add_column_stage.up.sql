@@ -0,0 +1,10 @@
+ALTER TABLE columns ADD COLUMN stage VARCHAR(20) NOT NULL DEFAULT 'in_progress';
+
+ALTER TABLE columns ADD CONSTRAINT chk_columns_stage CHECK (stage IN ('todo', 'in_progress', 'done'));
+
+-- Backfill: columns titled "Done" are done, the first column of each board is todo
+UPDATE columns SET stage = 'done' WHERE LOWER(TRIM(title)) = 'done';
+
+UPDATE columns c SET stage = 'todo'
+WHERE c.stage <> 'done'
+  AND c.order_num = (SELECT MIN(order_num) FROM columns WHERE board_id = c.board_id);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tasks_sprint_id;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS chk_tasks_story_points;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS fk_tasks_sprint_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS story_points;
+ALTER TABLE tasks DROP COLUMN IF EXISTS sprint_id;
+
+DROP TABLE IF EXISTS sprints;
@@ -0,0 +1,30 @@
+CREATE TABLE sprints (
+    id VARCHAR(36) PRIMARY KEY,
+    board_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    goal TEXT,
+    start_date TIMESTAMPTZ NOT NULL,
+    end_date TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'planned',
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT fk_sprints_board_id FOREIGN KEY (board_id) REFERENCES boards(id) ON DELETE CASCADE,
+    CONSTRAINT chk_sprints_status CHECK (status IN ('planned', 'active', 'completed')),
+    CONSTRAINT chk_sprints_dates CHECK (end_date >= start_date)
+);
+
+CREATE INDEX idx_sprints_board_id ON sprints(board_id);
+CREATE INDEX idx_sprints_deleted_at ON sprints(deleted_at);
+
+-- At most one running sprint per board
+CREATE UNIQUE INDEX idx_sprints_one_active ON sprints(board_id) WHERE status = 'active' AND deleted_at IS NULL;
+
+ALTER TABLE tasks ADD COLUMN sprint_id VARCHAR(36);
+ALTER TABLE tasks ADD COLUMN story_points INTEGER;
+ALTER TABLE tasks ADD CONSTRAINT fk_tasks_sprint_id FOREIGN KEY (sprint_id) REFERENCES sprints(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD CONSTRAINT chk_tasks_story_points CHECK (story_points IS NULL OR story_points >= 0);
+
+CREATE INDEX idx_tasks_sprint_id ON tasks(sprint_id);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_board_templates_user_id;
+DROP TABLE IF EXISTS board_templates;
+
+DROP INDEX IF EXISTS idx_custom_fields_board_id;
+DROP TABLE IF EXISTS custom_fields;
+
+ALTER TABLE columns DROP CONSTRAINT IF EXISTS chk_columns_wip_limit;
+ALTER TABLE columns DROP COLUMN IF EXISTS wip_limit;
@@ -0,0 +1,31 @@
+ALTER TABLE columns ADD COLUMN wip_limit INTEGER;
+ALTER TABLE columns ADD CONSTRAINT chk_columns_wip_limit CHECK (wip_limit IS NULL OR wip_limit > 0);
+
+CREATE TABLE custom_fields (
+    id VARCHAR(36) PRIMARY KEY,
+    board_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    options JSONB,
+    order_num INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_custom_fields_board_id FOREIGN KEY (board_id) REFERENCES boards(id) ON DELETE CASCADE,
+    CONSTRAINT chk_custom_fields_type CHECK (type IN ('text', 'number', 'date', 'select')),
+    CONSTRAINT uq_custom_fields_board_name UNIQUE (board_id, name)
+);
+
+CREATE INDEX idx_custom_fields_board_id ON custom_fields(board_id);
+
+CREATE TABLE board_templates (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    definition JSONB NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_board_templates_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_board_templates_user_id ON board_templates(user_id);
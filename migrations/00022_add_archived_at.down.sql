@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_archived_at;
+DROP INDEX IF EXISTS idx_boards_archived_at;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE boards DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,5 @@
+ALTER TABLE boards ADD COLUMN archived_at TIMESTAMPTZ NULL;
+ALTER TABLE tasks ADD COLUMN archived_at TIMESTAMPTZ NULL;
+
+CREATE INDEX idx_boards_archived_at ON boards(archived_at);
+CREATE INDEX idx_tasks_archived_at ON tasks(archived_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_import_jobs_user_id;
+DROP TABLE IF EXISTS import_jobs;
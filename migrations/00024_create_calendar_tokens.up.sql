@@ -0,0 +1,12 @@
+CREATE TABLE calendar_tokens (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255),
+    token_hash VARCHAR(64) NOT NULL,
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_calendar_tokens_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT uq_calendar_tokens_token_hash UNIQUE (token_hash)
+);
+
+CREATE INDEX idx_calendar_tokens_user_id ON calendar_tokens(user_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_rule_executions_task_id;
+DROP INDEX IF EXISTS idx_rule_executions_rule_id;
+DROP TABLE IF EXISTS rule_executions;
+
+DROP INDEX IF EXISTS idx_rules_board_id;
+DROP TABLE IF EXISTS rules;
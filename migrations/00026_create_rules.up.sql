@@ -0,0 +1,30 @@
+CREATE TABLE rules (
+    id VARCHAR(36) PRIMARY KEY,
+    board_id VARCHAR(36) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    trigger JSONB NOT NULL,
+    conditions JSONB NOT NULL,
+    actions JSONB NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_rules_board_id FOREIGN KEY (board_id) REFERENCES boards(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_rules_board_id ON rules(board_id);
+
+CREATE TABLE rule_executions (
+    id VARCHAR(36) PRIMARY KEY,
+    rule_id VARCHAR(36) NOT NULL,
+    task_id VARCHAR(36) NOT NULL,
+    trigger VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    actions INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_rule_executions_rule_id FOREIGN KEY (rule_id) REFERENCES rules(id) ON DELETE CASCADE,
+    CONSTRAINT chk_rule_executions_status CHECK (status IN ('succeeded', 'failed', 'skipped'))
+);
+
+CREATE INDEX idx_rule_executions_rule_id ON rule_executions(rule_id, created_at);
+CREATE INDEX idx_rule_executions_task_id ON rule_executions(task_id);
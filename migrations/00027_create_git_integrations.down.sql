@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_git_integrations_board_id;
+DROP TABLE IF EXISTS git_integrations;
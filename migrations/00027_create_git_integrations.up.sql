@@ -0,0 +1,15 @@
+CREATE TABLE git_integrations (
+    id VARCHAR(36) PRIMARY KEY,
+    board_id VARCHAR(36) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    opened_column_id VARCHAR(36),
+    merged_column_id VARCHAR(36),
+    last_delivery_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_git_integrations_board_id FOREIGN KEY (board_id) REFERENCES boards(id) ON DELETE CASCADE,
+    CONSTRAINT fk_git_integrations_opened_column_id FOREIGN KEY (opened_column_id) REFERENCES columns(id) ON DELETE SET NULL,
+    CONSTRAINT fk_git_integrations_merged_column_id FOREIGN KEY (merged_column_id) REFERENCES columns(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX idx_git_integrations_board_id ON git_integrations(board_id);
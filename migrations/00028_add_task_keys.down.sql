@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_tasks_key;
+DROP INDEX IF EXISTS idx_boards_task_key_prefix;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS key;
+ALTER TABLE tasks DROP COLUMN IF EXISTS number;
+
+ALTER TABLE boards DROP COLUMN IF EXISTS next_task_number;
+ALTER TABLE boards DROP COLUMN IF EXISTS task_key_prefix;
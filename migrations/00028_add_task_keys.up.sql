@@ -0,0 +1,34 @@
+ALTER TABLE boards ADD COLUMN task_key_prefix VARCHAR(10) NOT NULL DEFAULT '';
+ALTER TABLE boards ADD COLUMN next_task_number INTEGER NOT NULL DEFAULT 1;
+
+ALTER TABLE tasks ADD COLUMN number INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN key VARCHAR(32) NOT NULL DEFAULT '';
+
+-- Backfill: prefixes come from the first letters of the board title, made
+-- unique per owner with a running suffix; tasks are numbered by creation time
+UPDATE boards SET task_key_prefix = COALESCE(NULLIF(UPPER(LEFT(REGEXP_REPLACE(REGEXP_REPLACE(title, '[^A-Za-z0-9]', '', 'g'), '^[0-9]+', ''), 3)), ''), 'TASK');
+UPDATE boards SET task_key_prefix = task_key_prefix || 'X' WHERE LENGTH(task_key_prefix) = 1;
+
+UPDATE boards b SET task_key_prefix = b.task_key_prefix || ranked.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id, task_key_prefix ORDER BY created_at, id) AS position
+    FROM boards
+) ranked
+WHERE ranked.id = b.id AND ranked.position > 1;
+
+UPDATE tasks t SET number = numbered.number, key = numbered.task_key_prefix || '-' || numbered.number
+FROM (
+    SELECT tasks.id, boards.task_key_prefix,
+           ROW_NUMBER() OVER (PARTITION BY boards.id ORDER BY tasks.created_at, tasks.id) AS number
+    FROM tasks
+    JOIN columns ON columns.id = tasks.column_id
+    JOIN boards ON boards.id = columns.board_id
+) numbered
+WHERE numbered.id = t.id;
+
+UPDATE boards b SET next_task_number = COALESCE((
+    SELECT MAX(tasks.number) FROM tasks JOIN columns ON columns.id = tasks.column_id WHERE columns.board_id = b.id
+), 0) + 1;
+
+CREATE UNIQUE INDEX idx_boards_task_key_prefix ON boards(user_id, task_key_prefix);
+CREATE INDEX idx_tasks_key ON tasks(key);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_board_inboxes_local_part;
+DROP INDEX IF EXISTS idx_board_inboxes_board_id;
+DROP TABLE IF EXISTS board_inboxes;
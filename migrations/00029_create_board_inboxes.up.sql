@@ -0,0 +1,14 @@
+CREATE TABLE board_inboxes (
+    id VARCHAR(36) PRIMARY KEY,
+    board_id VARCHAR(36) NOT NULL,
+    local_part VARCHAR(64) NOT NULL,
+    column_id VARCHAR(36),
+    last_received_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_board_inboxes_board_id FOREIGN KEY (board_id) REFERENCES boards(id) ON DELETE CASCADE,
+    CONSTRAINT fk_board_inboxes_column_id FOREIGN KEY (column_id) REFERENCES columns(id) ON DELETE SET NULL
+);
+
+CREATE UNIQUE INDEX idx_board_inboxes_board_id ON board_inboxes(board_id);
+CREATE UNIQUE INDEX idx_board_inboxes_local_part ON board_inboxes(local_part);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_notifications_emailed_at;
+ALTER TABLE notifications DROP COLUMN IF EXISTS emailed_at;
+
+DROP TABLE IF EXISTS notification_preferences;
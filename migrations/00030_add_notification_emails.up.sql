@@ -0,0 +1,18 @@
+CREATE TABLE notification_preferences (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    delivery VARCHAR(20) NOT NULL DEFAULT 'instant',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_notification_preferences_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX idx_notification_preferences_user_type ON notification_preferences(user_id, type);
+
+ALTER TABLE notifications ADD COLUMN emailed_at TIMESTAMPTZ NULL;
+
+-- Notifications from before email delivery existed are not mailed
+UPDATE notifications SET emailed_at = created_at;
+
+CREATE INDEX idx_notifications_emailed_at ON notifications(emailed_at);
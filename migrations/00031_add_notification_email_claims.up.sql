@@ -0,0 +1,2 @@
+-- Replicas claim notifications before emailing them so each is sent once
+ALTER TABLE notifications ADD COLUMN email_claimed_until TIMESTAMPTZ NULL;
@@ -36,7 +36,11 @@ type MigrationRunner struct {
 func NewMigrationRunnerFromConfig(migrationsPath string) (*MigrationRunner, error) {
 	if hasPostgresConfig() {
 		if config.DB == nil {
-			config.ConnectDB()
+			cfg, err := config.Load()
+			if err != nil {
+				return nil, fmt.Errorf("load config: %w", err)
+			}
+			config.ConnectDB(cfg.Database)
 		}
 
 		return NewPostgresMigrationRunner(config.DB, migrationsPath)
@@ -29,18 +29,35 @@ func (m *Member) BeforeCreate(tx *gorm.DB) error {
 
 // Board represents a Kanban board
 type Board struct {
-	ID        string         `gorm:"type:uuid;primaryKey" json:"id"`
-	Title     string         `gorm:"size:255;not null" json:"title"`
-	UserID    string         `gorm:"type:uuid;not null;index" json:"user_id"`
-	Color     string         `gorm:"size:255" json:"color"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID     string `gorm:"type:uuid;primaryKey" json:"id"`
+	Title  string `gorm:"size:255;not null" json:"title"`
+	UserID string `gorm:"type:uuid;not null;index;uniqueIndex:idx_boards_task_key_prefix,priority:1" json:"user_id"`
+	Color  string `gorm:"size:255" json:"color"`
+	// TaskKeyPrefix starts the board's task keys, e.g. OPS in OPS-123. It is
+	// unique among the owner's boards, trashed ones included.
+	TaskKeyPrefix string `gorm:"size:10;not null;default:'';uniqueIndex:idx_boards_task_key_prefix,priority:2" json:"task_key_prefix"`
+	// NextTaskNumber is the number the next task on the board will get
+	NextTaskNumber int            `gorm:"not null;default:1" json:"-"`
+	ArchivedAt     *time.Time     `gorm:"index" json:"archived_at,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
 	// Relationships
-	Columns []Column `gorm:"foreignKey:BoardID" json:"columns,omitempty"`
-	Members []Member `gorm:"foreignKey:BoardID" json:"members,omitempty"`
-	User    *User    `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Columns      []Column      `gorm:"foreignKey:BoardID" json:"columns,omitempty"`
+	Members      []Member      `gorm:"foreignKey:BoardID" json:"members,omitempty"`
+	CustomFields []CustomField `gorm:"foreignKey:BoardID" json:"custom_fields,omitempty"`
+	User         *User         `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// BoardCopyOptions selects what is copied when duplicating a board besides its
+// columns and custom fields. Comments, labels and attachments are only copied
+// together with tasks.
+type BoardCopyOptions struct {
+	Tasks       bool
+	Comments    bool
+	Labels      bool
+	Attachments bool
 }
 
 // BeforeCreate is a GORM hook called before creating a board
@@ -48,6 +65,16 @@ func (b *Board) BeforeCreate(tx *gorm.DB) error {
 	if b.ID == "" {
 		b.ID = uuid.New().String()
 	}
+	if b.NextTaskNumber == 0 {
+		b.NextTaskNumber = 1
+	}
+	if b.TaskKeyPrefix == "" && tx != nil {
+		prefix, err := uniqueTaskKeyPrefix(tx, b.UserID, DefaultTaskKeyPrefix(b.Title))
+		if err != nil {
+			return err
+		}
+		b.TaskKeyPrefix = prefix
+	}
 	return nil
 }
 
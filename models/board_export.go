@@ -0,0 +1,89 @@
+package models
+
+import "time"
+
+// BoardExportVersion is the version of the board export format this server
+// writes. Imports of newer versions are rejected.
+const BoardExportVersion = 1
+
+// ExportedBoard holds the board's own attributes. OwnerEmail is informational;
+// imported boards belong to the importing user.
+type ExportedBoard struct {
+	Title      string    `json:"title"`
+	Color      string    `json:"color"`
+	OwnerEmail string    `json:"owner_email,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ExportedColumn is a board column. Its ID is only used to reference the
+// column from tasks within the same document.
+type ExportedColumn struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Order    int    `json:"order"`
+	Stage    string `json:"stage"`
+	WipLimit *int   `json:"wip_limit,omitempty"`
+}
+
+// ExportedSprint is a board sprint; like columns, its ID is document-local
+type ExportedSprint struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Goal        string     `json:"goal,omitempty"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+	Status      string     `json:"status"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ExportedComment names its author by email. The email is informational:
+// imported comments belong to the importing user.
+type ExportedComment struct {
+	AuthorEmail string    `json:"author_email"`
+	Content     string    `json:"content"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ExportedAttachment is attachment metadata; the file itself stays where
+// FileURL points
+type ExportedAttachment struct {
+	FileName  string    `json:"file_name"`
+	FileURL   string    `json:"file_url"`
+	FileSize  int64     `json:"file_size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExportedTask references its column and sprint by document-local ID, its
+// labels by name and its assignees by email
+type ExportedTask struct {
+	ID             string               `json:"id"`
+	ColumnID       string               `json:"column_id"`
+	SprintID       *string              `json:"sprint_id,omitempty"`
+	Title          string               `json:"title"`
+	Description    string               `json:"description,omitempty"`
+	Deadline       *time.Time           `json:"deadline,omitempty"`
+	DeadlineAllDay bool                 `json:"deadline_all_day,omitempty"`
+	StoryPoints    *int                 `json:"story_points,omitempty"`
+	ArchivedAt     *time.Time           `json:"archived_at,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	Labels         []string             `json:"labels,omitempty"`
+	Assignees      []string             `json:"assignees,omitempty"`
+	Comments       []ExportedComment    `json:"comments,omitempty"`
+	Attachments    []ExportedAttachment `json:"attachments,omitempty"`
+}
+
+// BoardExport is a self-contained, versioned snapshot of a board that can be
+// imported on any server. Checklists are not part of it because tasks have no
+// checklists in this model; checklist items written into a description as a
+// Markdown task list travel with the description.
+type BoardExport struct {
+	Version      int                   `json:"version"`
+	ExportedAt   time.Time             `json:"exported_at"`
+	Board        ExportedBoard         `json:"board"`
+	Columns      []ExportedColumn      `json:"columns"`
+	CustomFields []TemplateCustomField `json:"custom_fields,omitempty"`
+	Labels       []TemplateLabel       `json:"labels,omitempty"`
+	Sprints      []ExportedSprint      `json:"sprints,omitempty"`
+	Tasks        []ExportedTask        `json:"tasks,omitempty"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BoardInbox is a board's inbound email address. Mail sent to
+// LocalPart@<inbound domain> becomes a task in ColumnID, or in the board's
+// first column when no column is set.
+type BoardInbox struct {
+	ID             string     `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	BoardID        string     `gorm:"not null;type:varchar(36);uniqueIndex" json:"board_id"`
+	LocalPart      string     `gorm:"not null;type:varchar(64);uniqueIndex" json:"local_part"`
+	ColumnID       *string    `gorm:"type:varchar(36)" json:"column_id,omitempty"`
+	LastReceivedAt *time.Time `json:"last_received_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Address is the full address, filled in by the service
+	Address string `gorm:"-" json:"address"`
+}
+
+// TableName specifies the table name for BoardInbox model
+func (BoardInbox) TableName() string {
+	return "board_inboxes"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (b *BoardInbox) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == "" {
+		b.ID = uuid.NewString()
+	}
+	return nil
+}
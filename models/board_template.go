@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TemplateColumn is a column created from a board template
+type TemplateColumn struct {
+	Title    string `json:"title"`
+	Stage    string `json:"stage"`
+	WipLimit *int   `json:"wip_limit,omitempty"`
+}
+
+// TemplateLabel is a label a template makes sure exists
+type TemplateLabel struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// TemplateCustomField is a custom field definition added to boards created from a template
+type TemplateCustomField struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Options []string `json:"options,omitempty"`
+}
+
+// TemplateTask is a sample task placed in the column with the given title
+type TemplateTask struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Column      string   `json:"column"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+// TemplateDefinition is everything a board template creates
+type TemplateDefinition struct {
+	Columns      []TemplateColumn      `json:"columns"`
+	Labels       []TemplateLabel       `json:"labels,omitempty"`
+	CustomFields []TemplateCustomField `json:"custom_fields,omitempty"`
+	SampleTasks  []TemplateTask        `json:"sample_tasks,omitempty"`
+}
+
+// BoardTemplate is a reusable board layout. User templates are stored; the
+// built-in ones are defined in code and have no owner.
+type BoardTemplate struct {
+	ID          string             `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	UserID      string             `gorm:"not null;type:varchar(36);index:board_template_user" json:"user_id,omitempty"`
+	Name        string             `gorm:"not null;type:varchar(255)" json:"name"`
+	Description string             `gorm:"type:text" json:"description"`
+	Definition  TemplateDefinition `gorm:"type:text;serializer:json;not null" json:"definition"`
+	BuiltIn     bool               `gorm:"-" json:"built_in"`
+	CreatedAt   time.Time          `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time          `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for BoardTemplate model
+func (BoardTemplate) TableName() string {
+	return "board_templates"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (t *BoardTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.NewString()
+	}
+	return nil
+}
@@ -309,7 +309,7 @@ func TestBoardGORMTags(t *testing.T) {
 	}
 
 	// Verify relationships
-	assert.Len(t, stmt.Schema.Relationships.Relations, 3, "Board should have 3 relationships")
+	assert.Len(t, stmt.Schema.Relationships.Relations, 4, "Board should have 4 relationships")
 
 	// Verify foreign keys
 	for _, rel := range stmt.Schema.Relationships.Relations {
@@ -320,6 +320,8 @@ func TestBoardGORMTags(t *testing.T) {
 			assert.Equal(t, "BoardID", rel.References[0].ForeignKey.Name, "Tasks foreign key should be BoardID")
 		case "Members":
 			assert.Equal(t, "BoardID", rel.References[0].ForeignKey.Name, "Members foreign key should be BoardID")
+		case "CustomFields":
+			assert.Equal(t, "BoardID", rel.References[0].ForeignKey.Name, "CustomFields foreign key should be BoardID")
 		case "User":
 			assert.Equal(t, "UserID", rel.References[0].ForeignKey.Name, "User foreign key should be UserID")
 		}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CalendarToken grants read access to a user's iCalendar feed. Only the
+// SHA-256 hash of the secret is stored; the secret is shown once on creation.
+type CalendarToken struct {
+	ID         string     `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	UserID     string     `gorm:"not null;type:varchar(36);index:calendar_token_user" json:"user_id"`
+	Name       string     `gorm:"type:varchar(255)" json:"name"`
+	TokenHash  string     `gorm:"not null;type:varchar(64);uniqueIndex" json:"-"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for CalendarToken model
+func (CalendarToken) TableName() string {
+	return "calendar_tokens"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (t *CalendarToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuid.NewString()
+	}
+	return nil
+}
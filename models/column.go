@@ -1,18 +1,28 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// Column stages used by analytics and reminders
+const (
+	ColumnStageTodo       = "todo"
+	ColumnStageInProgress = "in_progress"
+	ColumnStageDone       = "done"
+)
+
 // Column represents a column in a kanban board
 type Column struct {
 	ID        string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
 	BoardID   string         `gorm:"not null;type:varchar(36);index:column_board" json:"board_id"`
 	Title     string         `gorm:"not null;type:varchar(255)" json:"title"`
 	OrderNum  int            `gorm:"not null;column:order_num" json:"order"`
+	Stage     string         `gorm:"type:varchar(20);not null;default:in_progress" json:"stage"`
+	WipLimit  *int           `gorm:"column:wip_limit" json:"wip_limit,omitempty"`
 	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
@@ -39,3 +49,22 @@ func (c *Column) BeforeCreate(tx *gorm.DB) error {
 
 	return nil
 }
+
+// IsDone reports whether tasks in the column count as finished. Columns
+// without a stage fall back to matching the title "Done".
+func (c *Column) IsDone() bool {
+	if c.Stage == "" {
+		return strings.EqualFold(strings.TrimSpace(c.Title), "done")
+	}
+	return c.Stage == ColumnStageDone
+}
+
+// IsValidColumnStage reports whether stage is one of the known column stages
+func IsValidColumnStage(stage string) bool {
+	switch stage {
+	case ColumnStageTodo, ColumnStageInProgress, ColumnStageDone:
+		return true
+	default:
+		return false
+	}
+}
@@ -277,3 +277,22 @@ func TestColumnGORMTags(t *testing.T) {
 	assert.True(t, foundTasks, "Tasks relationship should exist")
 	assert.True(t, foundBoard, "Board relationship should exist")
 }
+
+func TestColumnIsDone(t *testing.T) {
+	tests := []struct {
+		column Column
+		want   bool
+	}{
+		{Column{Title: "Done"}, true},
+		{Column{Title: " done "}, true},
+		{Column{Title: "Review"}, false},
+		{Column{Title: "Done", Stage: ColumnStageInProgress}, false},
+		{Column{Title: "Shipped", Stage: ColumnStageDone}, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.column.IsDone(); got != tt.want {
+			t.Errorf("IsDone() for %q/%q = %v, want %v", tt.column.Title, tt.column.Stage, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Custom field types
+const (
+	CustomFieldText   = "text"
+	CustomFieldNumber = "number"
+	CustomFieldDate   = "date"
+	CustomFieldSelect = "select"
+)
+
+// CustomField is an extra task attribute defined for a board. Options lists
+// the allowed values of select fields.
+type CustomField struct {
+	ID        string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	BoardID   string    `gorm:"not null;type:varchar(36);index:custom_field_board" json:"board_id"`
+	Name      string    `gorm:"not null;type:varchar(255)" json:"name"`
+	Type      string    `gorm:"not null;type:varchar(20)" json:"type"`
+	Options   []string  `gorm:"type:text;serializer:json" json:"options,omitempty"`
+	OrderNum  int       `gorm:"not null;column:order_num" json:"order"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for CustomField model
+func (CustomField) TableName() string {
+	return "custom_fields"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (f *CustomField) BeforeCreate(tx *gorm.DB) error {
+	if f.ID == "" {
+		f.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// IsValidCustomFieldType reports whether fieldType is one of the known custom field types
+func IsValidCustomFieldType(fieldType string) bool {
+	switch fieldType {
+	case CustomFieldText, CustomFieldNumber, CustomFieldDate, CustomFieldSelect:
+		return true
+	default:
+		return false
+	}
+}
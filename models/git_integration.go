@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GitIntegration receives push and pull request webhooks from GitHub or
+// GitLab for a board. Secret verifies the deliveries. Pull requests move the
+// tasks they reference to OpenedColumnID and MergedColumnID when those are set.
+type GitIntegration struct {
+	ID             string     `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	BoardID        string     `gorm:"not null;type:varchar(36);uniqueIndex" json:"board_id"`
+	Secret         string     `gorm:"not null;type:varchar(255)" json:"-"`
+	OpenedColumnID *string    `gorm:"type:varchar(36)" json:"opened_column_id,omitempty"`
+	MergedColumnID *string    `gorm:"type:varchar(36)" json:"merged_column_id,omitempty"`
+	LastDeliveryAt *time.Time `json:"last_delivery_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for GitIntegration model
+func (GitIntegration) TableName() string {
+	return "git_integrations"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (g *GitIntegration) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == "" {
+		g.ID = uuid.NewString()
+	}
+	return nil
+}
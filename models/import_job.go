@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Import job sources
+const (
+	ImportSourceTrello = "trello"
+)
+
+// Import job statuses
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// ImportSkippedItem records something from the source that was not imported
+type ImportSkippedItem struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ImportJob tracks a board import running in the background. Processed counts
+// the imported tasks out of Total; BoardID is set once the board exists.
+type ImportJob struct {
+	ID         string              `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	UserID     string              `gorm:"not null;type:varchar(36);index:import_job_user" json:"user_id"`
+	Source     string              `gorm:"not null;type:varchar(20)" json:"source"`
+	Status     string              `gorm:"not null;type:varchar(20);default:pending" json:"status"`
+	Total      int                 `gorm:"not null;default:0" json:"total"`
+	Processed  int                 `gorm:"not null;default:0" json:"processed"`
+	BoardID    *string             `gorm:"type:varchar(36)" json:"board_id,omitempty"`
+	Skipped    []ImportSkippedItem `gorm:"type:text;serializer:json" json:"skipped"`
+	Error      string              `gorm:"type:text" json:"error,omitempty"`
+	FinishedAt *time.Time          `json:"finished_at,omitempty"`
+	CreatedAt  time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for ImportJob model
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (j *ImportJob) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == "" {
+		j.ID = uuid.NewString()
+	}
+	return nil
+}
@@ -7,15 +7,47 @@ import (
 	"gorm.io/gorm"
 )
 
+// Notification types
+const (
+	NotificationTypeGeneral          = "general"
+	NotificationTypeDeadlineReminder = "deadline_reminder"
+	NotificationTypeDeadlineOverdue  = "deadline_overdue"
+	NotificationTypeTaskComment      = "task_comment"
+	NotificationTypeTaskMoved        = "task_moved"
+	NotificationTypeDeadlineChanged  = "deadline_changed"
+	NotificationTypeTaskAttachment   = "task_attachment"
+	NotificationTypeRule             = "rule"
+)
+
+// NotificationTypes lists every notification type, for validating preferences
+var NotificationTypes = []string{
+	NotificationTypeGeneral,
+	NotificationTypeDeadlineReminder,
+	NotificationTypeDeadlineOverdue,
+	NotificationTypeTaskComment,
+	NotificationTypeTaskMoved,
+	NotificationTypeDeadlineChanged,
+	NotificationTypeTaskAttachment,
+	NotificationTypeRule,
+}
+
 // Notification represents a user notification in the system
 type Notification struct {
-	ID        string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
-	UserID    string         `gorm:"not null;type:varchar(36);index" json:"user_id"`
-	Message   string         `gorm:"not null;type:text" json:"message"`
-	ReadAt    *time.Time     `gorm:"index" json:"read_at,omitempty"`
-	CreatedAt time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID      string     `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	UserID  string     `gorm:"not null;type:varchar(36);index" json:"user_id"`
+	Type    string     `gorm:"not null;type:varchar(50);default:general;index" json:"type"`
+	TaskID  *string    `gorm:"type:varchar(36);index" json:"task_id,omitempty"`
+	Message string     `gorm:"not null;type:text" json:"message"`
+	ReadAt  *time.Time `gorm:"index" json:"read_at,omitempty"`
+	// EmailedAt is when the notification was emailed, alone or in a digest,
+	// or skipped because the user turned email off for its type
+	EmailedAt *time.Time `gorm:"index" json:"emailed_at,omitempty"`
+	// EmailClaimedUntil keeps other workers from emailing the notification
+	// while one is sending it
+	EmailClaimedUntil *time.Time     `json:"-"`
+	CreatedAt         time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt         time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relationships
 	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"user,omitempty"`
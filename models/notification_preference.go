@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Email delivery options for a notification type
+const (
+	EmailDeliveryInstant = "instant"
+	EmailDeliveryHourly  = "hourly"
+	EmailDeliveryDaily   = "daily"
+	EmailDeliveryOff     = "off"
+)
+
+// DefaultEmailDelivery applies to notification types a user has not set a
+// preference for
+const DefaultEmailDelivery = EmailDeliveryInstant
+
+// NotificationPreference is how a user wants notifications of one type emailed
+type NotificationPreference struct {
+	ID        string    `gorm:"primaryKey;type:varchar(36)" json:"-"`
+	UserID    string    `gorm:"not null;type:varchar(36);uniqueIndex:idx_notification_preferences_user_type" json:"-"`
+	Type      string    `gorm:"not null;type:varchar(50);uniqueIndex:idx_notification_preferences_user_type" json:"type"`
+	Delivery  string    `gorm:"not null;type:varchar(20);default:instant" json:"delivery"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"-"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"-"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for NotificationPreference model
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (p *NotificationPreference) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// ValidEmailDelivery reports whether delivery is one of the email delivery options
+func ValidEmailDelivery(delivery string) bool {
+	switch delivery {
+	case EmailDeliveryInstant, EmailDeliveryHourly, EmailDeliveryDaily, EmailDeliveryOff:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Rule triggers
+const (
+	RuleTriggerTaskCreated = "task_created"
+	RuleTriggerTaskMoved   = "task_moved"
+	RuleTriggerTaskLabeled = "task_labeled"
+	RuleTriggerTaskDue     = "task_due"
+)
+
+// Rule condition types
+const (
+	RuleConditionLabel    = "label"
+	RuleConditionColumn   = "column"
+	RuleConditionAssignee = "assignee"
+	RuleConditionField    = "field"
+)
+
+// Rule condition operators. Label, column and assignee conditions use is and
+// is_not; field conditions use the others.
+const (
+	RuleOperatorIs        = "is"
+	RuleOperatorIsNot     = "is_not"
+	RuleOperatorEquals    = "equals"
+	RuleOperatorNotEquals = "not_equals"
+	RuleOperatorContains  = "contains"
+	RuleOperatorEmpty     = "empty"
+	RuleOperatorNotEmpty  = "not_empty"
+	RuleOperatorGreater   = "gt"
+	RuleOperatorLess      = "lt"
+)
+
+// Rule action types
+const (
+	RuleActionMove        = "move"
+	RuleActionAddLabel    = "add_label"
+	RuleActionRemoveLabel = "remove_label"
+	RuleActionAssign      = "assign"
+	RuleActionComment     = "comment"
+	RuleActionSetDeadline = "set_deadline"
+	RuleActionNotify      = "notify"
+)
+
+// Notify action recipients
+const (
+	RuleNotifyCreator   = "creator"
+	RuleNotifyAssignees = "assignees"
+	RuleNotifyOwner     = "owner"
+)
+
+// Rule execution statuses
+const (
+	RuleExecutionSucceeded = "succeeded"
+	RuleExecutionFailed    = "failed"
+	RuleExecutionSkipped   = "skipped"
+)
+
+// RuleTrigger selects the events a rule reacts to. ColumnID narrows created
+// and moved triggers to tasks landing in that column; Label narrows labeled
+// triggers to one label name.
+type RuleTrigger struct {
+	Type     string `json:"type"`
+	ColumnID string `json:"column_id,omitempty"`
+	Label    string `json:"label,omitempty"`
+}
+
+// RuleCondition must hold for the task when the rule fires. Field names one
+// of the task fields title, description, story_points, deadline or sprint_id.
+type RuleCondition struct {
+	Type     string `json:"type"`
+	Operator string `json:"operator,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Value    string `json:"value,omitempty"`
+}
+
+// RuleAction is one step a rule performs. Days is the deadline offset from the
+// moment the rule fires; Target picks who a notification goes to.
+type RuleAction struct {
+	Type     string `json:"type"`
+	ColumnID string `json:"column_id,omitempty"`
+	Label    string `json:"label,omitempty"`
+	UserID   string `json:"user_id,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Days     *int   `json:"days,omitempty"`
+	Target   string `json:"target,omitempty"`
+}
+
+// Rule automates work on a board: when the trigger fires and every condition
+// holds, the actions run in order on behalf of the board owner
+type Rule struct {
+	ID         string          `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	BoardID    string          `gorm:"not null;type:varchar(36);index:rule_board" json:"board_id"`
+	Name       string          `gorm:"not null;type:varchar(255)" json:"name"`
+	Trigger    RuleTrigger     `gorm:"type:text;serializer:json;not null" json:"trigger"`
+	Conditions []RuleCondition `gorm:"type:text;serializer:json;not null" json:"conditions"`
+	Actions    []RuleAction    `gorm:"type:text;serializer:json;not null" json:"actions"`
+	Enabled    bool            `gorm:"not null;default:true" json:"enabled"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Rule model
+func (Rule) TableName() string {
+	return "rules"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (r *Rule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// RuleExecution records one run of a rule against a task
+type RuleExecution struct {
+	ID        string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	RuleID    string    `gorm:"not null;type:varchar(36);index:rule_execution_rule" json:"rule_id"`
+	TaskID    string    `gorm:"not null;type:varchar(36)" json:"task_id"`
+	Trigger   string    `gorm:"not null;type:varchar(50)" json:"trigger"`
+	Status    string    `gorm:"not null;type:varchar(20)" json:"status"`
+	Actions   int       `gorm:"not null;default:0" json:"actions"`
+	Error     string    `gorm:"type:text" json:"error,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+
+	// Relations
+	Rule *Rule `gorm:"foreignKey:RuleID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for RuleExecution model
+func (RuleExecution) TableName() string {
+	return "rule_executions"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (e *RuleExecution) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.NewString()
+	}
+	return nil
+}
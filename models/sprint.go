@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Sprint statuses
+const (
+	SprintStatusPlanned   = "planned"
+	SprintStatusActive    = "active"
+	SprintStatusCompleted = "completed"
+)
+
+// Sprint is a time-boxed iteration on a board. StartDate and EndDate are
+// calendar dates stored as midnight UTC; EndDate is the last day of the sprint.
+type Sprint struct {
+	ID          string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	BoardID     string         `gorm:"not null;type:varchar(36);index:sprint_board" json:"board_id"`
+	Name        string         `gorm:"not null;type:varchar(255)" json:"name"`
+	Goal        string         `gorm:"type:text" json:"goal"`
+	StartDate   time.Time      `gorm:"not null" json:"start_date"`
+	EndDate     time.Time      `gorm:"not null" json:"end_date"`
+	Status      string         `gorm:"type:varchar(20);not null;default:planned" json:"status"`
+	StartedAt   *time.Time     `json:"started_at,omitempty"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Relations
+	Board *Board `gorm:"foreignKey:BoardID;constraint:OnDelete:CASCADE" json:"board,omitempty"`
+}
+
+// TableName specifies the table name for Sprint model
+func (Sprint) TableName() string {
+	return "sprints"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (s *Sprint) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.NewString()
+	}
+	return nil
+}
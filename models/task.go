@@ -9,20 +9,30 @@ import (
 
 // Task represents a kanban task within a column
 type Task struct {
-	ID          string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
-	ColumnID    string         `gorm:"not null;type:varchar(36);index:task_column" json:"column_id"`
-	Title       string         `gorm:"not null;type:varchar(255)" json:"title"`
-	Description string         `gorm:"type:text" json:"description"`
-	Deadline    *time.Time     `gorm:"index:task_deadline" json:"deadline,omitempty"`
+	ID       string `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	ColumnID string `gorm:"not null;type:varchar(36);index:task_column" json:"column_id"`
+	// Number counts tasks per board; Key is the board prefix and number, e.g. OPS-123
+	Number      int        `gorm:"not null;default:0" json:"number"`
+	Key         string     `gorm:"type:varchar(32);not null;default:'';index:task_key" json:"key"`
+	Title       string     `gorm:"not null;type:varchar(255)" json:"title"`
+	Description string     `gorm:"type:text" json:"description"`
+	Deadline    *time.Time `gorm:"index:task_deadline" json:"deadline,omitempty"`
+	// DeadlineAllDay marks date-only deadlines; Deadline then holds midnight UTC of that date
+	DeadlineAllDay bool `gorm:"not null;default:false" json:"deadline_all_day"`
+	// SprintID is the sprint the task is planned into, nil for the backlog
+	SprintID    *string        `gorm:"type:varchar(36);index:task_sprint" json:"sprint_id,omitempty"`
+	StoryPoints *int           `json:"story_points,omitempty"`
+	ArchivedAt  *time.Time     `gorm:"index" json:"archived_at,omitempty"`
 	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 
 	// Relationships
-	Comments    []Comment    `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"comments,omitempty"`
-	Labels      []Label      `gorm:"many2many:task_labels;constraint:OnDelete:CASCADE" json:"labels,omitempty"`
-	Attachments []Attachment `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"attachments,omitempty"`
-	Column      *Column      `gorm:"foreignKey:ColumnID;constraint:OnDelete:CASCADE" json:"column,omitempty"`
+	Comments    []Comment      `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"comments,omitempty"`
+	Labels      []Label        `gorm:"many2many:task_labels;constraint:OnDelete:CASCADE" json:"labels,omitempty"`
+	Attachments []Attachment   `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"attachments,omitempty"`
+	Assignees   []TaskAssignee `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"assignees,omitempty"`
+	Column      *Column        `gorm:"foreignKey:ColumnID;constraint:OnDelete:CASCADE" json:"column,omitempty"`
 }
 
 // TableName specifies the table name for Task model
@@ -30,10 +40,38 @@ func (Task) TableName() string {
 	return "tasks"
 }
 
-// BeforeCreate hook to generate UUID before insertion
+// BeforeCreate hook to generate UUID and allocate the task key before insertion
 func (t *Task) BeforeCreate(tx *gorm.DB) error {
 	if t.ID == "" {
 		t.ID = uuid.NewString()
 	}
+	if t.Number == 0 && tx != nil {
+		number, prefix, err := allocateTaskNumber(tx, t.ColumnID)
+		if err != nil {
+			return err
+		}
+		if number > 0 {
+			t.Number = number
+			t.Key = FormatTaskKey(prefix, number)
+		}
+	}
 	return nil
 }
+
+// DeadlineIn returns the moment the task falls due for someone in loc.
+// Date-only deadlines fall due at the end of that calendar day in loc, while
+// date-time deadlines are the same instant everywhere.
+func (t *Task) DeadlineIn(loc *time.Location) *time.Time {
+	if t.Deadline == nil {
+		return nil
+	}
+
+	if !t.DeadlineAllDay {
+		deadline := *t.Deadline
+		return &deadline
+	}
+
+	year, month, day := t.Deadline.UTC().Date()
+	endOfDay := time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+	return &endOfDay
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Task activity actions
+const (
+	ActivityCreated           = "created"
+	ActivityUpdated           = "updated"
+	ActivityMoved             = "moved"
+	ActivityArchived          = "archived"
+	ActivityUnarchived        = "unarchived"
+	ActivityRestored          = "restored"
+	ActivityLabelAdded        = "label_added"
+	ActivityLabelRemoved      = "label_removed"
+	ActivityAttachmentAdded   = "attachment_added"
+	ActivityAttachmentRemoved = "attachment_removed"
+	ActivityAssigneeAdded     = "assignee_added"
+	ActivityAssigneeRemoved   = "assignee_removed"
+	ActivityCommitLinked      = "commit_linked"
+	ActivityPullRequestLinked = "pull_request_linked"
+)
+
+// TaskActivity is an entry in a task's change history. Moves record the old
+// and new column IDs, which is what cycle-time metrics are computed from.
+type TaskActivity struct {
+	ID        string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	TaskID    string    `gorm:"not null;type:varchar(36);index:idx_task_activities_task,priority:1" json:"task_id"`
+	UserID    string    `gorm:"not null;type:varchar(36);index" json:"user_id"`
+	Action    string    `gorm:"not null;type:varchar(50)" json:"action"`
+	Field     string    `gorm:"type:varchar(50)" json:"field,omitempty"`
+	OldValue  *string   `gorm:"type:text" json:"old_value,omitempty"`
+	NewValue  *string   `gorm:"type:text" json:"new_value,omitempty"`
+	CreatedAt time.Time `gorm:"autoCreateTime;index:idx_task_activities_task,priority:2" json:"created_at"`
+}
+
+// TableName specifies the table name for TaskActivity model
+func (TaskActivity) TableName() string {
+	return "task_activities"
+}
+
+// BeforeCreate is a GORM hook called before creating a task activity
+func (a *TaskActivity) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// TaskAssignee is a join model linking a task to the users responsible for it
+type TaskAssignee struct {
+	TaskID    string    `gorm:"primaryKey;type:varchar(36);not null" json:"task_id"`
+	UserID    string    `gorm:"primaryKey;type:varchar(36);not null;index" json:"user_id"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for TaskAssignee model
+func (TaskAssignee) TableName() string {
+	return "task_assignees"
+}
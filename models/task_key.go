@@ -0,0 +1,108 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Task keys read like OPS-123: the board's prefix and the task's number on
+// that board. Prefixes are 2-10 upper-case letters or digits, starting with a
+// letter.
+var (
+	taskKeyPrefixPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,9}$`)
+	taskKeyPattern       = regexp.MustCompile(`^([A-Z][A-Z0-9]{1,9})-([1-9][0-9]*)$`)
+)
+
+// ValidTaskKeyPrefix reports whether prefix can be used as a board's task key prefix
+func ValidTaskKeyPrefix(prefix string) bool {
+	return taskKeyPrefixPattern.MatchString(prefix)
+}
+
+// FormatTaskKey joins a board prefix and task number into a key
+func FormatTaskKey(prefix string, number int) string {
+	return fmt.Sprintf("%s-%d", prefix, number)
+}
+
+// ParseTaskKey normalises a key such as "ops-123" to "OPS-123" and reports
+// whether it is well formed
+func ParseTaskKey(key string) (string, bool) {
+	key = strings.ToUpper(strings.TrimSpace(key))
+	return key, taskKeyPattern.MatchString(key)
+}
+
+// DefaultTaskKeyPrefix derives a prefix from a board title: the first three
+// letters or digits, upper-cased, falling back to TASK
+func DefaultTaskKeyPrefix(title string) string {
+	var prefix strings.Builder
+	for _, r := range strings.ToUpper(title) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9' && prefix.Len() > 0) {
+			prefix.WriteRune(r)
+			if prefix.Len() == 3 {
+				break
+			}
+		}
+	}
+
+	switch prefix.Len() {
+	case 0:
+		return "TASK"
+	case 1:
+		return prefix.String() + "X"
+	}
+	return prefix.String()
+}
+
+// uniqueTaskKeyPrefix appends a running number to base until no other board
+// of the owner, including ones in the trash, uses it
+func uniqueTaskKeyPrefix(tx *gorm.DB, userID, base string) (string, error) {
+	var taken []string
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Unscoped().
+		Model(&Board{}).
+		Where("user_id = ? AND task_key_prefix LIKE ?", userID, base+"%").
+		Pluck("task_key_prefix", &taken).Error
+	if err != nil {
+		return "", err
+	}
+
+	used := make(map[string]bool, len(taken))
+	for _, prefix := range taken {
+		used[prefix] = true
+	}
+
+	prefix := base
+	for n := 2; used[prefix]; n++ {
+		suffix := strconv.Itoa(n)
+		if len(base)+len(suffix) > 10 {
+			base = base[:10-len(suffix)]
+		}
+		prefix = base + suffix
+	}
+	return prefix, nil
+}
+
+// allocateTaskNumber takes the next number from the board owning the column.
+// The increment and read are one statement, so concurrent inserts on the same
+// board never share a number.
+func allocateTaskNumber(tx *gorm.DB, columnID string) (int, string, error) {
+	var allocated struct {
+		TaskKeyPrefix  string
+		NextTaskNumber int
+	}
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Raw(`UPDATE boards SET next_task_number = next_task_number + 1
+			WHERE id = (SELECT board_id FROM columns WHERE id = ?)
+			RETURNING task_key_prefix, next_task_number`, columnID).
+		Scan(&allocated).Error
+	if err != nil {
+		return 0, "", err
+	}
+	if allocated.NextTaskNumber == 0 {
+		return 0, "", nil
+	}
+	return allocated.NextTaskNumber - 1, allocated.TaskKeyPrefix, nil
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reminder kinds recorded in the task_reminders table
+const (
+	ReminderKindUpcoming = "upcoming"
+	ReminderKindOverdue  = "overdue"
+)
+
+// TaskReminder records a deadline reminder that has already been sent.
+// The unique index makes sending idempotent: a reminder for the same task,
+// recipient, kind, lead time and deadline can only ever be claimed once.
+type TaskReminder struct {
+	ID          string    `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	TaskID      string    `gorm:"not null;type:varchar(36);uniqueIndex:idx_task_reminders_unique" json:"task_id"`
+	UserID      string    `gorm:"not null;type:varchar(36);uniqueIndex:idx_task_reminders_unique;index" json:"user_id"`
+	Kind        string    `gorm:"not null;type:varchar(20);uniqueIndex:idx_task_reminders_unique" json:"kind"`
+	LeadMinutes int       `gorm:"not null;default:0;uniqueIndex:idx_task_reminders_unique" json:"lead_minutes"`
+	Deadline    time.Time `gorm:"not null;uniqueIndex:idx_task_reminders_unique" json:"deadline"`
+	SentAt      time.Time `gorm:"autoCreateTime" json:"sent_at"`
+}
+
+// TableName specifies the table name for TaskReminder model
+func (TaskReminder) TableName() string {
+	return "task_reminders"
+}
+
+// BeforeCreate is a GORM hook called before creating a task reminder
+func (r *TaskReminder) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.NewString()
+	}
+	return nil
+}
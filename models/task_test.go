@@ -452,3 +452,28 @@ func TestTaskEmptyValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskDeadlineIn(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("Failed to load timezone: %v", err)
+	}
+
+	deadline := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	allDay := Task{Deadline: &deadline, DeadlineAllDay: true}
+	got := allDay.DeadlineIn(tokyo)
+	want := time.Date(2024, 5, 2, 0, 0, 0, 0, tokyo)
+	if !got.Equal(want) {
+		t.Errorf("Expected all-day deadline %v, got %v", want, got)
+	}
+
+	exact := Task{Deadline: &deadline}
+	if got := exact.DeadlineIn(tokyo); !got.Equal(deadline) {
+		t.Errorf("Expected exact deadline %v, got %v", deadline, got)
+	}
+
+	if got := (&Task{}).DeadlineIn(tokyo); got != nil {
+		t.Errorf("Expected nil deadline, got %v", got)
+	}
+}
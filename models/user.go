@@ -2,6 +2,9 @@ package models
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"kanban-backend/utils"
@@ -20,10 +23,18 @@ type User struct {
 	UpdatedAt time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
+	// Comma-separated list of minutes before a deadline at which reminders are sent
+	ReminderLeadTimes string `gorm:"type:varchar(255);default:'1440,60'" json:"reminder_lead_times"`
+	// IANA timezone name used to interpret date-only deadlines and schedule reminders
+	Timezone string `gorm:"type:varchar(64);not null;default:'UTC'" json:"timezone"`
+
 	// Relations
 	RefreshTokens []RefreshToken `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"refresh_tokens,omitempty"`
 }
 
+// DefaultReminderLeadTimes is used when a user has not configured their own lead times
+const DefaultReminderLeadTimes = "1440,60"
+
 // TableName specifies the table name for User model
 func (User) TableName() string {
 	return "users"
@@ -102,3 +113,54 @@ func (u *User) SetPassword(password string) error {
 func (u *User) CheckPassword(password string) error {
 	return utils.CheckPassword(u.Password, password)
 }
+
+// Location returns the user's timezone, falling back to UTC when unset or invalid
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ReminderLeadDurations returns the user's reminder lead times sorted from
+// largest to smallest, falling back to DefaultReminderLeadTimes
+func (u *User) ReminderLeadDurations() []time.Duration {
+	leads, err := ParseReminderLeadTimes(u.ReminderLeadTimes)
+	if err != nil || len(leads) == 0 {
+		leads, _ = ParseReminderLeadTimes(DefaultReminderLeadTimes)
+	}
+	return leads
+}
+
+// ParseReminderLeadTimes parses a comma-separated list of minutes into
+// durations sorted from largest to smallest, dropping duplicates
+func ParseReminderLeadTimes(value string) ([]time.Duration, error) {
+	seen := make(map[int]bool)
+	leads := make([]time.Duration, 0)
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		minutes, err := strconv.Atoi(part)
+		if err != nil || minutes <= 0 {
+			return nil, fmt.Errorf("invalid reminder lead time: %q", part)
+		}
+
+		if seen[minutes] {
+			continue
+		}
+		seen[minutes] = true
+		leads = append(leads, time.Duration(minutes)*time.Minute)
+	}
+
+	sort.Slice(leads, func(i, j int) bool { return leads[i] > leads[j] })
+	return leads, nil
+}
@@ -544,3 +544,22 @@ func TestUserUniqueConstraints(t *testing.T) {
 		t.Error("Expected error when creating user with duplicate email")
 	}
 }
+
+// TestParseReminderLeadTimes tests parsing of the reminder lead time setting
+func TestParseReminderLeadTimes(t *testing.T) {
+	leads, err := ParseReminderLeadTimes("60, 1440,60")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{24 * time.Hour, time.Hour}, leads)
+
+	_, err = ParseReminderLeadTimes("soon")
+	assert.Error(t, err)
+
+	user := &User{}
+	assert.Equal(t, []time.Duration{24 * time.Hour, time.Hour}, user.ReminderLeadDurations())
+}
+
+func TestUserLocation(t *testing.T) {
+	assert.Equal(t, "UTC", (&User{}).Location().String())
+	assert.Equal(t, "UTC", (&User{Timezone: "Not/AZone"}).Location().String())
+	assert.Equal(t, "Europe/Berlin", (&User{Timezone: "Europe/Berlin"}).Location().String())
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// Watch targets
+const (
+	WatchTargetTask  = "task"
+	WatchTargetBoard = "board"
+)
+
+// Watch subscribes a user to notifications about a task or a whole board
+type Watch struct {
+	UserID     string    `gorm:"primaryKey;type:varchar(36);not null" json:"user_id"`
+	TargetType string    `gorm:"primaryKey;type:varchar(20);not null;index:idx_watches_target,priority:1" json:"target_type"`
+	TargetID   string    `gorm:"primaryKey;type:varchar(36);not null;index:idx_watches_target,priority:2" json:"target_id"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// TableName specifies the table name for Watch model
+func (Watch) TableName() string {
+	return "watches"
+}
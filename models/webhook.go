@@ -0,0 +1,87 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryStatusPending   = "pending"
+	DeliveryStatusSucceeded = "succeeded"
+	DeliveryStatusFailed    = "failed"
+)
+
+// Webhook subscribes a URL to a board's events. Secret signs every delivery.
+// Webhooks are disabled automatically after too many failed attempts in a row.
+type Webhook struct {
+	ID                  string     `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	BoardID             string     `gorm:"not null;type:varchar(36);index:webhook_board" json:"board_id"`
+	URL                 string     `gorm:"not null;type:varchar(2048)" json:"url"`
+	Secret              string     `gorm:"not null;type:varchar(255)" json:"-"`
+	EventTypes          []string   `gorm:"type:text;serializer:json;not null" json:"event_types"`
+	Active              bool       `gorm:"not null;default:true" json:"active"`
+	ConsecutiveFailures int        `gorm:"not null;default:0" json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// TableName specifies the table name for Webhook model
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (w *Webhook) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// Subscribes reports whether the webhook wants events of the given type
+func (w *Webhook) Subscribes(eventType string) bool {
+	for _, subscribed := range w.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDelivery is one event sent, or still to be sent, to a webhook.
+// Pending deliveries are retried at NextAttemptAt.
+type WebhookDelivery struct {
+	ID             string          `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	WebhookID      string          `gorm:"not null;type:varchar(36);index:webhook_delivery_webhook" json:"webhook_id"`
+	EventType      string          `gorm:"not null;type:varchar(50)" json:"event_type"`
+	Payload        json.RawMessage `gorm:"type:text;serializer:json;not null" json:"payload"`
+	Status         string          `gorm:"not null;type:varchar(20);default:pending" json:"status"`
+	Attempts       int             `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  *time.Time      `gorm:"index" json:"next_attempt_at,omitempty"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	Error          string          `gorm:"type:text" json:"error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time       `gorm:"autoUpdateTime" json:"updated_at"`
+
+	// Relations
+	Webhook *Webhook `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for WebhookDelivery model
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// BeforeCreate hook to generate UUID before insertion
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuid.NewString()
+	}
+	return nil
+}
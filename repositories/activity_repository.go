@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type ActivityRepository interface {
+	CreateBatch(ctx context.Context, activities []*models.TaskActivity) error
+	CreateForBoardTasks(ctx context.Context, boardID string, activities []*models.TaskActivity) error
+	FindByTaskIDWithPagination(ctx context.Context, taskID string, page, limit int) ([]*models.TaskActivity, int, error)
+}
+
+type activityRepository struct {
+	db *gorm.DB
+}
+
+func NewActivityRepository() ActivityRepository {
+	return &activityRepository{
+		db: config.DB,
+	}
+}
+
+func (r *activityRepository) CreateBatch(ctx context.Context, activities []*models.TaskActivity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&activities).Error
+}
+
+// CreateForBoardTasks records a copy of the activities on every task of the
+// board that is not in the trash, for changes to the board as a whole
+func (r *activityRepository) CreateForBoardTasks(ctx context.Context, boardID string, activities []*models.TaskActivity) error {
+	if len(activities) == 0 {
+		return nil
+	}
+
+	var taskIDs []string
+	err := r.db.WithContext(ctx).
+		Model(&models.Task{}).
+		Joins("JOIN columns ON columns.id = tasks.column_id").
+		Where("columns.board_id = ? AND columns.deleted_at IS NULL", boardID).
+		Pluck("tasks.id", &taskIDs).Error
+	if err != nil {
+		return err
+	}
+
+	entries := make([]*models.TaskActivity, 0, len(taskIDs)*len(activities))
+	for _, taskID := range taskIDs {
+		for _, activity := range activities {
+			entry := *activity
+			entry.TaskID = taskID
+			entries = append(entries, &entry)
+		}
+	}
+	return r.CreateBatch(ctx, entries)
+}
+
+// FindByTaskIDWithPagination returns a task's history, newest first
+func (r *activityRepository) FindByTaskIDWithPagination(ctx context.Context, taskID string, page, limit int) ([]*models.TaskActivity, int, error) {
+	var activities []*models.TaskActivity
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.TaskActivity{}).Where("task_id = ?", taskID)
+
+	query.Count(&total)
+
+	offset := (page - 1) * limit
+	err := query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&activities).Error
+
+	return activities, int(total), err
+}
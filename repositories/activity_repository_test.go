@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestActivityRepository_FindByTaskIDWithPagination(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &activityRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+
+	task := &models.Task{ColumnID: column.ID, Title: "Task"}
+	require.NoError(t, db.Create(task).Error)
+
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	var activities []*models.TaskActivity
+	for i, action := range []string{models.ActivityCreated, models.ActivityUpdated, models.ActivityMoved} {
+		activities = append(activities, &models.TaskActivity{
+			TaskID:    task.ID,
+			UserID:    user.ID,
+			Action:    action,
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	require.NoError(t, repo.CreateBatch(ctx, activities))
+	require.NoError(t, repo.CreateBatch(ctx, nil))
+
+	page, total, err := repo.FindByTaskIDWithPagination(ctx, task.ID, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, page, 2)
+	assert.Equal(t, models.ActivityMoved, page[0].Action)
+	assert.Equal(t, models.ActivityUpdated, page[1].Action)
+
+	page, _, err = repo.FindByTaskIDWithPagination(ctx, task.ID, 2, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, models.ActivityCreated, page[0].Action)
+}
+
+func TestActivityRepository_CreateForBoardTasks(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &activityRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+	otherColumn := createTestColumn(db, createTestBoard(db, user.ID).ID)
+
+	live := &models.Task{ColumnID: column.ID, Title: "Live"}
+	trashed := &models.Task{ColumnID: column.ID, Title: "Trashed"}
+	elsewhere := &models.Task{ColumnID: otherColumn.ID, Title: "Elsewhere"}
+	for _, task := range []*models.Task{live, trashed, elsewhere} {
+		require.NoError(t, db.Create(task).Error)
+	}
+	require.NoError(t, db.Delete(trashed).Error)
+
+	archived := &models.TaskActivity{UserID: user.ID, Action: models.ActivityArchived, Field: "board"}
+	require.NoError(t, repo.CreateForBoardTasks(ctx, board.ID, []*models.TaskActivity{archived}))
+
+	var activities []*models.TaskActivity
+	require.NoError(t, db.Find(&activities).Error)
+	require.Len(t, activities, 1)
+	assert.Equal(t, live.ID, activities[0].TaskID)
+	assert.Equal(t, models.ActivityArchived, activities[0].Action)
+	assert.Equal(t, "board", activities[0].Field)
+}
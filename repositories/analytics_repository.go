@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type AnalyticsRepository interface {
+	FindBoardColumns(ctx context.Context, boardID string) ([]*models.Column, error)
+	FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error)
+	FindColumnTransitions(ctx context.Context, boardID string, until time.Time) ([]*models.TaskActivity, error)
+	FindFieldChanges(ctx context.Context, boardID string, fields []string, until time.Time) ([]*models.TaskActivity, error)
+}
+
+type analyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository() AnalyticsRepository {
+	return &analyticsRepository{
+		db: config.DB,
+	}
+}
+
+// FindBoardColumns returns the board's columns including soft-deleted ones, so
+// history that references a removed column can still be labelled
+func (r *analyticsRepository) FindBoardColumns(ctx context.Context, boardID string) ([]*models.Column, error) {
+	var columns []*models.Column
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Where("board_id = ?", boardID).
+		Order("order_num ASC").
+		Find(&columns).Error
+	if err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// FindBoardTasks returns every task on the board without loading relations
+func (r *analyticsRepository) FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.WithContext(ctx).
+		Joins("JOIN columns ON columns.id = tasks.column_id").
+		Where("columns.board_id = ?", boardID).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindColumnTransitions returns the column moves of the board's tasks up to
+// the given time, oldest first
+func (r *analyticsRepository) FindColumnTransitions(ctx context.Context, boardID string, until time.Time) ([]*models.TaskActivity, error) {
+	var activities []*models.TaskActivity
+	err := r.db.WithContext(ctx).
+		Joins("JOIN tasks ON tasks.id = task_activities.task_id").
+		Joins("JOIN columns ON columns.id = tasks.column_id").
+		Where("columns.board_id = ?", boardID).
+		Where("task_activities.action = ? AND task_activities.field = ?", models.ActivityMoved, "column_id").
+		Where("task_activities.created_at <= ?", until).
+		Order("task_activities.created_at ASC").
+		Find(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
+
+// FindFieldChanges returns the recorded changes to the given task fields on
+// the board up to the given time, oldest first
+func (r *analyticsRepository) FindFieldChanges(ctx context.Context, boardID string, fields []string, until time.Time) ([]*models.TaskActivity, error) {
+	var activities []*models.TaskActivity
+	err := r.db.WithContext(ctx).
+		Joins("JOIN tasks ON tasks.id = task_activities.task_id").
+		Joins("JOIN columns ON columns.id = tasks.column_id").
+		Where("columns.board_id = ?", boardID).
+		Where("task_activities.field IN ?", fields).
+		Where("task_activities.created_at <= ?", until).
+		Order("task_activities.created_at ASC").
+		Find(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+	return activities, nil
+}
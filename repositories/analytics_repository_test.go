@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestAnalyticsRepository_BoardScopedQueries(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &analyticsRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	otherBoard := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+	otherColumn := createTestColumn(db, otherBoard.ID)
+
+	task := &models.Task{ColumnID: column.ID, Title: "Task"}
+	require.NoError(t, db.Create(task).Error)
+	otherTask := &models.Task{ColumnID: otherColumn.ID, Title: "Other"}
+	require.NoError(t, db.Create(otherTask).Error)
+
+	base := time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)
+	moved := "column_id"
+	activities := []*models.TaskActivity{
+		{TaskID: task.ID, UserID: user.ID, Action: models.ActivityMoved, Field: moved, CreatedAt: base.Add(2 * time.Hour)},
+		{TaskID: task.ID, UserID: user.ID, Action: models.ActivityMoved, Field: moved, CreatedAt: base.Add(time.Hour)},
+		{TaskID: task.ID, UserID: user.ID, Action: models.ActivityMoved, Field: moved, CreatedAt: base.Add(48 * time.Hour)},
+		{TaskID: task.ID, UserID: user.ID, Action: models.ActivityUpdated, Field: "title", CreatedAt: base},
+		{TaskID: otherTask.ID, UserID: user.ID, Action: models.ActivityMoved, Field: moved, CreatedAt: base},
+	}
+	require.NoError(t, db.Create(&activities).Error)
+
+	tasks, err := repo.FindBoardTasks(ctx, board.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, task.ID, tasks[0].ID)
+
+	transitions, err := repo.FindColumnTransitions(ctx, board.ID, base.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, transitions, 2)
+	assert.True(t, transitions[0].CreatedAt.Before(transitions[1].CreatedAt))
+
+	changes, err := repo.FindFieldChanges(ctx, board.ID, []string{"title", moved}, base.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	assert.Equal(t, "title", changes[0].Field)
+}
+
+func TestAnalyticsRepository_FindBoardColumnsIncludesDeleted(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &analyticsRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	kept := createTestColumn(db, board.ID)
+	removed := &models.Column{BoardID: board.ID, Title: "Removed", OrderNum: 2}
+	require.NoError(t, db.Create(removed).Error)
+	require.NoError(t, db.Delete(removed).Error)
+
+	columns, err := repo.FindBoardColumns(ctx, board.ID)
+	require.NoError(t, err)
+	require.Len(t, columns, 2)
+	assert.Equal(t, kept.ID, columns[0].ID)
+	assert.True(t, columns[1].DeletedAt.Valid)
+}
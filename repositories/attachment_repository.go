@@ -37,7 +37,7 @@ func (r *attachmentRepository) Create(ctx context.Context, attachment *models.At
 func (r *attachmentRepository) FindByID(ctx context.Context, id string) (*models.Attachment, error) {
 	var attachment models.Attachment
 	err := r.db.WithContext(ctx).
-		Preload("Task").
+		Preload("Task.Column.Board").
 		Where("id = ?", id).
 		First(&attachment).Error
 	if err != nil {
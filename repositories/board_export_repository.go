@@ -0,0 +1,262 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type BoardExportRepository interface {
+	FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error)
+	FindUsersByID(ctx context.Context, ids []string) ([]*models.User, error)
+	FindUsersByEmail(ctx context.Context, emails []string) ([]*models.User, error)
+	FindUsersByUsername(ctx context.Context, usernames []string) ([]*models.User, error)
+	Import(ctx context.Context, board *models.Board, export *models.BoardExport, onTask func(imported int)) error
+}
+
+type boardExportRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardExportRepository() BoardExportRepository {
+	return &boardExportRepository{
+		db: config.DB,
+	}
+}
+
+// FindBoardTasks returns every task on the board, archived ones included, with
+// the labels, comments, attachments and assignees an export carries
+func (r *boardExportRepository) FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.WithContext(ctx).
+		Preload("Labels").
+		Preload("Comments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("Comments.User").
+		Preload("Attachments").
+		Preload("Assignees").
+		Joins("JOIN columns ON columns.id = tasks.column_id").
+		Where("columns.board_id = ? AND columns.deleted_at IS NULL", boardID).
+		Order("columns.order_num ASC, tasks.created_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func (r *boardExportRepository) FindUsersByID(ctx context.Context, ids []string) ([]*models.User, error) {
+	var users []*models.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// FindUsersByEmail finds the users with any of the emails, ignoring case
+func (r *boardExportRepository) FindUsersByEmail(ctx context.Context, emails []string) ([]*models.User, error) {
+	var users []*models.User
+	if len(emails) == 0 {
+		return users, nil
+	}
+	lowered := make([]string, len(emails))
+	for i, email := range emails {
+		lowered[i] = strings.ToLower(email)
+	}
+	err := r.db.WithContext(ctx).Where("LOWER(email) IN ?", lowered).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *boardExportRepository) FindUsersByUsername(ctx context.Context, usernames []string) ([]*models.User, error) {
+	var users []*models.User
+	if len(usernames) == 0 {
+		return users, nil
+	}
+	err := r.db.WithContext(ctx).Where("username IN ?", usernames).Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// Import creates board with everything in the export in one transaction. Rows
+// get new IDs and document-local references are remapped. Emails in the
+// document are not trusted: every comment is attributed to the importing
+// board owner, naming the original author in its text, and the owner is the
+// only assignee kept, since nobody else is a member of the new board. onTask,
+// if set, is called with the number of tasks imported so far.
+func (r *boardExportRepository) Import(ctx context.Context, board *models.Board, export *models.BoardExport, onTask func(imported int)) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var owner models.User
+		if err := tx.Select("id", "email").First(&owner, "id = ?", board.UserID).Error; err != nil {
+			return err
+		}
+
+		if err := createBoard(tx, board); err != nil {
+			return err
+		}
+
+		columnIDs := make(map[string]string, len(export.Columns))
+		board.Columns = make([]models.Column, 0, len(export.Columns))
+		for _, exported := range export.Columns {
+			column := models.Column{
+				BoardID:  board.ID,
+				Title:    exported.Title,
+				OrderNum: exported.Order,
+				Stage:    exported.Stage,
+				WipLimit: exported.WipLimit,
+			}
+			if err := tx.Create(&column).Error; err != nil {
+				return err
+			}
+			columnIDs[exported.ID] = column.ID
+			board.Columns = append(board.Columns, column)
+		}
+
+		board.CustomFields = make([]models.CustomField, 0, len(export.CustomFields))
+		for i, exported := range export.CustomFields {
+			field := models.CustomField{
+				BoardID:  board.ID,
+				Name:     exported.Name,
+				Type:     exported.Type,
+				Options:  exported.Options,
+				OrderNum: i + 1,
+			}
+			if err := tx.Create(&field).Error; err != nil {
+				return err
+			}
+			board.CustomFields = append(board.CustomFields, field)
+		}
+
+		sprintIDs := make(map[string]string, len(export.Sprints))
+		for _, exported := range export.Sprints {
+			sprint := models.Sprint{
+				BoardID:     board.ID,
+				Name:        exported.Name,
+				Goal:        exported.Goal,
+				StartDate:   exported.StartDate,
+				EndDate:     exported.EndDate,
+				Status:      exported.Status,
+				StartedAt:   exported.StartedAt,
+				CompletedAt: exported.CompletedAt,
+			}
+			if err := tx.Create(&sprint).Error; err != nil {
+				return err
+			}
+			sprintIDs[exported.ID] = sprint.ID
+		}
+
+		labels := make(map[string]*models.Label, len(export.Labels))
+		for _, exported := range export.Labels {
+			label, err := findOrCreateLabel(tx, exported.Name, exported.Color)
+			if err != nil {
+				return err
+			}
+			labels[label.Name] = label
+		}
+
+		for i, exported := range export.Tasks {
+			if err := importTask(tx, &owner, exported, columnIDs, sprintIDs, labels); err != nil {
+				return err
+			}
+			if onTask != nil {
+				onTask(i + 1)
+			}
+		}
+
+		return nil
+	})
+}
+
+func importTask(tx *gorm.DB, owner *models.User, exported models.ExportedTask, columnIDs, sprintIDs map[string]string, labels map[string]*models.Label) error {
+	columnID, ok := columnIDs[exported.ColumnID]
+	if !ok {
+		return fmt.Errorf("task %q references unknown column %q", exported.Title, exported.ColumnID)
+	}
+
+	task := models.Task{
+		ColumnID:       columnID,
+		Title:          exported.Title,
+		Description:    exported.Description,
+		Deadline:       exported.Deadline,
+		DeadlineAllDay: exported.DeadlineAllDay,
+		StoryPoints:    exported.StoryPoints,
+		ArchivedAt:     exported.ArchivedAt,
+		CreatedAt:      exported.CreatedAt,
+	}
+	if exported.SprintID != nil {
+		if sprintID, ok := sprintIDs[*exported.SprintID]; ok {
+			task.SprintID = &sprintID
+		}
+	}
+	if err := tx.Create(&task).Error; err != nil {
+		return err
+	}
+
+	for _, name := range exported.Labels {
+		label, ok := labels[name]
+		if !ok {
+			var err error
+			if label, err = findOrCreateLabel(tx, name, ""); err != nil {
+				return err
+			}
+			labels[name] = label
+		}
+		if err := tx.Model(&task).Association("Labels").Append(label); err != nil {
+			return err
+		}
+	}
+
+	for _, email := range exported.Assignees {
+		if strings.EqualFold(email, owner.Email) {
+			if err := tx.Create(&models.TaskAssignee{TaskID: task.ID, UserID: owner.ID}).Error; err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	for _, exportedComment := range exported.Comments {
+		content := exportedComment.Content
+		if exportedComment.AuthorEmail != "" && !strings.EqualFold(exportedComment.AuthorEmail, owner.Email) {
+			content = fmt.Sprintf("Originally posted by %s:\n\n%s", exportedComment.AuthorEmail, content)
+		}
+		comment := models.Comment{
+			TaskID:    task.ID,
+			UserID:    owner.ID,
+			Content:   content,
+			CreatedAt: exportedComment.CreatedAt,
+		}
+		if err := tx.Create(&comment).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, exportedAttachment := range exported.Attachments {
+		attachment := models.Attachment{
+			TaskID:    task.ID,
+			FileName:  exportedAttachment.FileName,
+			FileURL:   exportedAttachment.FileURL,
+			FileSize:  exportedAttachment.FileSize,
+			CreatedAt: exportedAttachment.CreatedAt,
+		}
+		if err := tx.Create(&attachment).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestBoardExportRepository_Import(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &boardExportRepository{db: db}
+	ctx := context.Background()
+
+	owner := createTestUser(db, "owner", "owner@example.com")
+	createTestUser(db, "teammate", "teammate@example.com")
+
+	limit := 2
+	sprintID := "sprint-a"
+	created := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	export := &models.BoardExport{
+		Version: models.BoardExportVersion,
+		Board:   models.ExportedBoard{Title: "Imported", Color: "#123456"},
+		Columns: []models.ExportedColumn{
+			{ID: "col-a", Title: "Todo", Order: 1, Stage: models.ColumnStageTodo},
+			{ID: "col-b", Title: "Doing", Order: 2, Stage: models.ColumnStageInProgress, WipLimit: &limit},
+		},
+		CustomFields: []models.TemplateCustomField{{Name: "Severity", Type: models.CustomFieldText}},
+		Labels:       []models.TemplateLabel{{Name: "Bug", Color: "#ff0000"}},
+		Sprints: []models.ExportedSprint{
+			{ID: sprintID, Name: "Sprint 1", StartDate: created, EndDate: created.AddDate(0, 0, 13), Status: models.SprintStatusActive},
+		},
+		Tasks: []models.ExportedTask{
+			{
+				ID:          "task-a",
+				ColumnID:    "col-b",
+				SprintID:    &sprintID,
+				Title:       "Fix login",
+				CreatedAt:   created,
+				Labels:      []string{"Bug", "Urgent"},
+				Assignees:   []string{"teammate@example.com", "OWNER@example.com", "stranger@example.com"},
+				Comments:    []models.ExportedComment{{AuthorEmail: "teammate@example.com", Content: "On it", CreatedAt: created}, {AuthorEmail: "owner@example.com", Content: "Thanks", CreatedAt: created.Add(time.Hour)}},
+				Attachments: []models.ExportedAttachment{{FileName: "trace.txt", FileURL: "https://files.example.com/trace.txt", FileSize: 42}},
+			},
+		},
+	}
+	board := &models.Board{UserID: owner.ID, Title: export.Board.Title, Color: export.Board.Color}
+	require.NoError(t, repo.Import(ctx, board, export, nil))
+	require.Len(t, board.Columns, 2)
+	require.Len(t, board.CustomFields, 1)
+
+	tasks, err := repo.FindBoardTasks(ctx, board.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+
+	task := tasks[0]
+	assert.Equal(t, board.Columns[1].ID, task.ColumnID)
+	assert.True(t, task.CreatedAt.Equal(created))
+	assert.Len(t, task.Labels, 2)
+	require.Len(t, task.Assignees, 1, "only the importer is a member of the new board")
+	assert.Equal(t, owner.ID, task.Assignees[0].UserID)
+	require.Len(t, task.Comments, 2)
+	assert.Equal(t, owner.ID, task.Comments[0].UserID, "comments are never attributed to other users")
+	assert.Equal(t, "Originally posted by teammate@example.com:\n\nOn it", task.Comments[0].Content)
+	assert.Equal(t, owner.ID, task.Comments[1].UserID)
+	assert.Equal(t, "Thanks", task.Comments[1].Content)
+	require.Len(t, task.Attachments, 1)
+	assert.Equal(t, "https://files.example.com/trace.txt", task.Attachments[0].FileURL)
+
+	require.NotNil(t, task.SprintID)
+	var sprint models.Sprint
+	require.NoError(t, db.First(&sprint, "id = ?", *task.SprintID).Error)
+	assert.Equal(t, board.ID, sprint.BoardID)
+	assert.NotEqual(t, sprintID, sprint.ID)
+
+	users, err := repo.FindUsersByEmail(ctx, []string{"Teammate@Example.com", "stranger@example.com"})
+	require.NoError(t, err)
+	assert.Len(t, users, 1)
+}
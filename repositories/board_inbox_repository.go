@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type BoardInboxRepository interface {
+	Create(ctx context.Context, inbox *models.BoardInbox) error
+	FindByBoardID(ctx context.Context, boardID string) (*models.BoardInbox, error)
+	FindByLocalPart(ctx context.Context, localPart string) (*models.BoardInbox, error)
+	Update(ctx context.Context, inbox *models.BoardInbox) error
+	Delete(ctx context.Context, id string) error
+	TouchReceived(ctx context.Context, id string, receivedAt time.Time) error
+}
+
+type boardInboxRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardInboxRepository() BoardInboxRepository {
+	return &boardInboxRepository{
+		db: config.DB,
+	}
+}
+
+func (r *boardInboxRepository) Create(ctx context.Context, inbox *models.BoardInbox) error {
+	return r.db.WithContext(ctx).Create(inbox).Error
+}
+
+func (r *boardInboxRepository) FindByBoardID(ctx context.Context, boardID string) (*models.BoardInbox, error) {
+	var inbox models.BoardInbox
+	err := r.db.WithContext(ctx).
+		Where("board_id = ?", boardID).
+		First(&inbox).Error
+	if err != nil {
+		return nil, err
+	}
+	return &inbox, nil
+}
+
+func (r *boardInboxRepository) FindByLocalPart(ctx context.Context, localPart string) (*models.BoardInbox, error) {
+	var inbox models.BoardInbox
+	err := r.db.WithContext(ctx).
+		Where("local_part = ?", localPart).
+		First(&inbox).Error
+	if err != nil {
+		return nil, err
+	}
+	return &inbox, nil
+}
+
+func (r *boardInboxRepository) Update(ctx context.Context, inbox *models.BoardInbox) error {
+	return r.db.WithContext(ctx).Save(inbox).Error
+}
+
+func (r *boardInboxRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.BoardInbox{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("board inbox with id %s not found", id)
+	}
+	return nil
+}
+
+func (r *boardInboxRepository) TouchReceived(ctx context.Context, id string, receivedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.BoardInbox{}).
+		Where("id = ?", id).
+		Update("last_received_at", receivedAt).Error
+}
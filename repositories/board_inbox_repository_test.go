@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestBoardInboxRepository_FindAndTouch(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &boardInboxRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "inbox", "inbox@example.com")
+	board := createTestBoard(db, user.ID)
+
+	inbox := &models.BoardInbox{BoardID: board.ID, LocalPart: "ops-k2x7q9m4tbab"}
+	require.NoError(t, repo.Create(ctx, inbox))
+
+	found, err := repo.FindByLocalPart(ctx, "ops-k2x7q9m4tbab")
+	require.NoError(t, err)
+	assert.Equal(t, inbox.ID, found.ID)
+
+	_, err = repo.FindByLocalPart(ctx, "ops-unknown")
+	assert.Error(t, err)
+
+	receivedAt := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, repo.TouchReceived(ctx, inbox.ID, receivedAt))
+
+	found, err = repo.FindByBoardID(ctx, board.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found.LastReceivedAt)
+	assert.True(t, found.LastReceivedAt.Equal(receivedAt))
+
+	duplicate := &models.BoardInbox{BoardID: board.ID, LocalPart: "ops-other"}
+	assert.Error(t, repo.Create(ctx, duplicate), "a board has one inbox")
+
+	require.NoError(t, repo.Delete(ctx, inbox.ID))
+	assert.Error(t, repo.Delete(ctx, inbox.ID))
+}
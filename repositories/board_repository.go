@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"kanban-backend/config"
@@ -14,13 +15,24 @@ type BoardRepository interface {
 	Create(ctx context.Context, board *models.Board) error
 	FindByID(ctx context.Context, id string) (*models.Board, error)
 	FindByUserID(ctx context.Context, userID string) ([]*models.Board, error)
-	FindByUserIDWithFilters(ctx context.Context, userID string, title string, page, limit int) ([]*models.Board, int, error)
+	FindByUserIDWithFilters(ctx context.Context, userID string, title string, archived bool, page, limit int) ([]*models.Board, int, error)
 	Search(ctx context.Context, userID string, keyword string, page, limit int) ([]*models.Board, int, error)
 	Update(ctx context.Context, board *models.Board) error
 	Delete(ctx context.Context, id string) error
 	SoftDelete(ctx context.Context, id string) error
+	Duplicate(ctx context.Context, sourceID string, board *models.Board, options models.BoardCopyOptions) error
+	TaskKeyPrefixTaken(ctx context.Context, userID, prefix, exceptBoardID string) (bool, error)
+	UpdateTaskKeyPrefix(ctx context.Context, boardID, prefix string) error
 }
 
+// ErrTaskKeyPrefixTaken is returned when another board of the owner already
+// uses the task key prefix
+var ErrTaskKeyPrefixTaken = errors.New("task key prefix is already taken")
+
+// maxTaskKeyPrefixAttempts bounds how often a board insert derives a new
+// prefix after losing a race for one
+const maxTaskKeyPrefixAttempts = 5
+
 type boardRepository struct {
 	db *gorm.DB
 }
@@ -32,7 +44,50 @@ func NewBoardRepository() BoardRepository {
 }
 
 func (r *boardRepository) Create(ctx context.Context, board *models.Board) error {
-	return r.db.WithContext(ctx).Create(board).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return createBoard(tx, board)
+	})
+}
+
+// createBoard inserts the board inside tx. The task key prefix derived by the
+// model hook can be claimed by a concurrent insert before ours commits; the
+// unique index then rejects the row and we derive the next free prefix. An
+// explicitly chosen prefix is never changed.
+func createBoard(tx *gorm.DB, board *models.Board) error {
+	derived := board.TaskKeyPrefix == ""
+	for attempt := 1; ; attempt++ {
+		if err := tx.SavePoint("create_board").Error; err != nil {
+			return err
+		}
+
+		err := tx.Create(board).Error
+		if err == nil {
+			return nil
+		}
+		if !isUniqueViolation(tx, err) {
+			return err
+		}
+		if !derived || attempt == maxTaskKeyPrefixAttempts {
+			return ErrTaskKeyPrefixTaken
+		}
+
+		if err := tx.RollbackTo("create_board").Error; err != nil {
+			return err
+		}
+		board.TaskKeyPrefix = ""
+	}
+}
+
+// isUniqueViolation reports whether err is the database rejecting a row for
+// a duplicate unique key
+func isUniqueViolation(db *gorm.DB, err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		return errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey)
+	}
+	return false
 }
 
 func (r *boardRepository) FindByID(ctx context.Context, id string) (*models.Board, error) {
@@ -40,6 +95,9 @@ func (r *boardRepository) FindByID(ctx context.Context, id string) (*models.Boar
 	err := r.db.WithContext(ctx).
 		Preload("Columns").
 		Preload("Members").
+		Preload("CustomFields", func(db *gorm.DB) *gorm.DB {
+			return db.Order("order_num ASC")
+		}).
 		Preload("User").
 		Where("id = ?", id).
 		First(&board).Error
@@ -55,7 +113,7 @@ func (r *boardRepository) FindByUserID(ctx context.Context, userID string) ([]*m
 		Preload("Columns").
 		Preload("Members").
 		Preload("User").
-		Where("user_id = ?", userID).
+		Where("user_id = ? AND archived_at IS NULL", userID).
 		Find(&boards).Error
 	if err != nil {
 		return nil, err
@@ -63,8 +121,43 @@ func (r *boardRepository) FindByUserID(ctx context.Context, userID string) ([]*m
 	return boards, nil
 }
 
+// Update saves the board's own fields. The task key prefix changes through
+// UpdateTaskKeyPrefix, and the task counter is only ever advanced by task
+// inserts, so a stale copy cannot hand out a number twice.
 func (r *boardRepository) Update(ctx context.Context, board *models.Board) error {
-	return r.db.WithContext(ctx).Save(board).Error
+	return r.db.WithContext(ctx).Omit("task_key_prefix", "next_task_number").Save(board).Error
+}
+
+// TaskKeyPrefixTaken reports whether another board of the user, including
+// boards in the trash, already uses the prefix
+func (r *boardRepository) TaskKeyPrefixTaken(ctx context.Context, userID, prefix, exceptBoardID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Model(&models.Board{}).
+		Where("user_id = ? AND task_key_prefix = ? AND id <> ?", userID, prefix, exceptBoardID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateTaskKeyPrefix changes the board's prefix and rewrites the keys of all
+// its tasks, archived and trashed ones included; numbers are kept. It returns
+// ErrTaskKeyPrefixTaken if another of the owner's boards claimed the prefix
+// first.
+func (r *boardRepository) UpdateTaskKeyPrefix(ctx context.Context, boardID, prefix string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Board{}).Where("id = ?", boardID).Update("task_key_prefix", prefix).Error; err != nil {
+			if isUniqueViolation(tx, err) {
+				return ErrTaskKeyPrefixTaken
+			}
+			return err
+		}
+
+		return tx.Unscoped().
+			Model(&models.Task{}).
+			Where("number > 0 AND column_id IN (?)", tx.Unscoped().Model(&models.Column{}).Select("id").Where("board_id = ?", boardID)).
+			Update("key", gorm.Expr("CAST(? AS VARCHAR) || '-' || CAST(number AS VARCHAR)", prefix)).Error
+	})
 }
 
 func (r *boardRepository) Delete(ctx context.Context, id string) error {
@@ -89,12 +182,20 @@ func (r *boardRepository) SoftDelete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *boardRepository) FindByUserIDWithFilters(ctx context.Context, userID string, title string, page, limit int) ([]*models.Board, int, error) {
+// FindByUserIDWithFilters lists the user's boards; archived selects archived
+// boards instead of the active ones
+func (r *boardRepository) FindByUserIDWithFilters(ctx context.Context, userID string, title string, archived bool, page, limit int) ([]*models.Board, int, error) {
 	var boards []*models.Board
 	var total int64
 
 	query := r.db.WithContext(ctx).Model(&models.Board{}).Where("user_id = ?", userID)
 
+	if archived {
+		query = query.Where("archived_at IS NOT NULL")
+	} else {
+		query = query.Where("archived_at IS NULL")
+	}
+
 	if title != "" {
 		query = query.Where("title ILIKE ?", "%"+title+"%")
 	}
@@ -117,7 +218,7 @@ func (r *boardRepository) Search(ctx context.Context, userID string, keyword str
 	var total int64
 
 	query := r.db.WithContext(ctx).Model(&models.Board{}).
-		Where("user_id = ?", userID).
+		Where("user_id = ? AND archived_at IS NULL", userID).
 		Where("title ILIKE ? OR description ILIKE ?", "%"+keyword+"%", "%"+keyword+"%")
 
 	query.Count(&total)
@@ -132,3 +233,176 @@ func (r *boardRepository) Search(ctx context.Context, userID string, keyword str
 
 	return boards, int(total), err
 }
+
+// Duplicate creates board as a deep copy of the source board in one
+// transaction. Every copied row gets a new ID and references between copied
+// rows (task columns and sprints) point at the copies. Copied tasks get new
+// keys on the new board; assignees, watchers and reminders are not copied.
+// Attachment copies share the stored file with the source, which is why trash
+// purging only deletes files that no attachment refers to any more.
+func (r *boardRepository) Duplicate(ctx context.Context, sourceID string, board *models.Board, options models.BoardCopyOptions) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createBoard(tx, board); err != nil {
+			return err
+		}
+
+		var columns []models.Column
+		if err := tx.Where("board_id = ?", sourceID).Order("order_num ASC").Find(&columns).Error; err != nil {
+			return err
+		}
+
+		columnIDs := make(map[string]string, len(columns))
+		board.Columns = make([]models.Column, 0, len(columns))
+		for _, source := range columns {
+			column := models.Column{
+				BoardID:  board.ID,
+				Title:    source.Title,
+				OrderNum: source.OrderNum,
+				Stage:    source.Stage,
+				WipLimit: source.WipLimit,
+			}
+			if err := tx.Create(&column).Error; err != nil {
+				return err
+			}
+			columnIDs[source.ID] = column.ID
+			board.Columns = append(board.Columns, column)
+		}
+
+		var fields []models.CustomField
+		if err := tx.Where("board_id = ?", sourceID).Order("order_num ASC").Find(&fields).Error; err != nil {
+			return err
+		}
+
+		board.CustomFields = make([]models.CustomField, 0, len(fields))
+		for _, source := range fields {
+			field := models.CustomField{
+				BoardID:  board.ID,
+				Name:     source.Name,
+				Type:     source.Type,
+				Options:  source.Options,
+				OrderNum: source.OrderNum,
+			}
+			if err := tx.Create(&field).Error; err != nil {
+				return err
+			}
+			board.CustomFields = append(board.CustomFields, field)
+		}
+
+		if !options.Tasks || len(columnIDs) == 0 {
+			return nil
+		}
+
+		sprintIDs, err := duplicateSprints(tx, sourceID, board.ID)
+		if err != nil {
+			return err
+		}
+
+		return duplicateTasks(tx, columnIDs, sprintIDs, options)
+	})
+}
+
+// duplicateSprints copies the source board's sprints and returns the new ID of
+// each copied sprint keyed by the old one
+func duplicateSprints(tx *gorm.DB, sourceID, boardID string) (map[string]string, error) {
+	var sprints []models.Sprint
+	if err := tx.Where("board_id = ?", sourceID).Find(&sprints).Error; err != nil {
+		return nil, err
+	}
+
+	sprintIDs := make(map[string]string, len(sprints))
+	for _, source := range sprints {
+		sprint := models.Sprint{
+			BoardID:     boardID,
+			Name:        source.Name,
+			Goal:        source.Goal,
+			StartDate:   source.StartDate,
+			EndDate:     source.EndDate,
+			Status:      source.Status,
+			StartedAt:   source.StartedAt,
+			CompletedAt: source.CompletedAt,
+		}
+		if err := tx.Create(&sprint).Error; err != nil {
+			return nil, err
+		}
+		sprintIDs[source.ID] = sprint.ID
+	}
+	return sprintIDs, nil
+}
+
+func duplicateTasks(tx *gorm.DB, columnIDs, sprintIDs map[string]string, options models.BoardCopyOptions) error {
+	sourceColumnIDs := make([]string, 0, len(columnIDs))
+	for id := range columnIDs {
+		sourceColumnIDs = append(sourceColumnIDs, id)
+	}
+
+	query := tx.Where("column_id IN ?", sourceColumnIDs).Order("created_at ASC")
+	if options.Labels {
+		query = query.Preload("Labels")
+	}
+	if options.Comments {
+		query = query.Preload("Comments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		})
+	}
+	if options.Attachments {
+		query = query.Preload("Attachments")
+	}
+
+	var tasks []models.Task
+	if err := query.Find(&tasks).Error; err != nil {
+		return err
+	}
+
+	for _, source := range tasks {
+		task := models.Task{
+			ColumnID:       columnIDs[source.ColumnID],
+			Title:          source.Title,
+			Description:    source.Description,
+			Deadline:       source.Deadline,
+			DeadlineAllDay: source.DeadlineAllDay,
+			StoryPoints:    source.StoryPoints,
+		}
+		if source.SprintID != nil {
+			if sprintID, ok := sprintIDs[*source.SprintID]; ok {
+				task.SprintID = &sprintID
+			}
+		}
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+
+		if len(source.Labels) > 0 {
+			if err := tx.Model(&task).Association("Labels").Append(source.Labels); err != nil {
+				return err
+			}
+		}
+
+		// Comments keep their author and timestamp so threads read the same
+		for _, comment := range source.Comments {
+			copied := models.Comment{
+				TaskID:    task.ID,
+				UserID:    comment.UserID,
+				Content:   comment.Content,
+				CreatedAt: comment.CreatedAt,
+			}
+			if err := tx.Create(&copied).Error; err != nil {
+				return err
+			}
+		}
+
+		// Attachment copies point at the same stored file
+		for _, attachment := range source.Attachments {
+			copied := models.Attachment{
+				TaskID:   task.ID,
+				FileName: attachment.FileName,
+				FileURL:  attachment.FileURL,
+				FileSize: attachment.FileSize,
+			}
+			if err := tx.Create(&copied).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 	"kanban-backend/models"
 )
 
@@ -367,3 +368,175 @@ func TestBoardRepository_Context(t *testing.T) {
 	_, err = repo.FindByID(ctx, testBoard.ID)
 	assert.Error(t, err)
 }
+
+func TestBoardRepository_Duplicate(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &boardRepository{db: db}
+	ctx := context.Background()
+
+	owner := createTestUser(db, "owner", "owner@example.com")
+	caller := createTestUser(db, "caller", "caller@example.com")
+	source := createTestBoard(db, owner.ID)
+
+	todo := &models.Column{BoardID: source.ID, Title: "To Do", OrderNum: 1, Stage: models.ColumnStageTodo}
+	done := &models.Column{BoardID: source.ID, Title: "Done", OrderNum: 2, Stage: models.ColumnStageDone}
+	require.NoError(t, db.Create(todo).Error)
+	require.NoError(t, db.Create(done).Error)
+	require.NoError(t, db.Create(&models.CustomField{BoardID: source.ID, Name: "Effort", Type: models.CustomFieldNumber, OrderNum: 1}).Error)
+
+	sprint := createTestSprint(db, source.ID, models.SprintStatusActive)
+	label := &models.Label{Name: "Bug", Color: "#ff0000"}
+	require.NoError(t, db.Create(label).Error)
+
+	task := &models.Task{ColumnID: todo.ID, Title: "Fix it", SprintID: &sprint.ID}
+	require.NoError(t, db.Create(task).Error)
+	require.NoError(t, db.Model(task).Association("Labels").Append(label))
+	require.NoError(t, db.Create(&models.Comment{TaskID: task.ID, UserID: owner.ID, Content: "On it"}).Error)
+	require.NoError(t, db.Create(&models.Attachment{TaskID: task.ID, FileName: "log.txt", FileURL: "https://files.example.com/log.txt"}).Error)
+
+	countCopies := func(model interface{}, taskID string) int64 {
+		var count int64
+		db.Model(model).Where("task_id = ?", taskID).Count(&count)
+		return count
+	}
+
+	t.Run("columns only", func(t *testing.T) {
+		board := &models.Board{Title: "Layout", UserID: caller.ID}
+		require.NoError(t, repo.Duplicate(ctx, source.ID, board, models.BoardCopyOptions{}))
+
+		copied, err := repo.FindByID(ctx, board.ID)
+		require.NoError(t, err)
+		assert.Equal(t, caller.ID, copied.UserID)
+		require.Len(t, copied.Columns, 2)
+		assert.NotEqual(t, todo.ID, copied.Columns[0].ID)
+		require.Len(t, copied.CustomFields, 1)
+
+		var tasks int64
+		db.Model(&models.Task{}).Where("column_id IN ?", []string{copied.Columns[0].ID, copied.Columns[1].ID}).Count(&tasks)
+		assert.Zero(t, tasks)
+	})
+
+	t.Run("tasks with everything", func(t *testing.T) {
+		board := &models.Board{Title: "Full copy", UserID: caller.ID}
+		options := models.BoardCopyOptions{Tasks: true, Comments: true, Labels: true, Attachments: true}
+		require.NoError(t, repo.Duplicate(ctx, source.ID, board, options))
+
+		var tasks []models.Task
+		require.NoError(t, db.Where("column_id = ?", board.Columns[0].ID).Find(&tasks).Error)
+		require.Len(t, tasks, 1)
+		copied := tasks[0]
+		assert.NotEqual(t, task.ID, copied.ID)
+
+		var sprints []models.Sprint
+		require.NoError(t, db.Where("board_id = ?", board.ID).Find(&sprints).Error)
+		require.Len(t, sprints, 1)
+		require.NotNil(t, copied.SprintID)
+		assert.Equal(t, sprints[0].ID, *copied.SprintID)
+
+		assert.Equal(t, int64(1), db.Model(&copied).Association("Labels").Count())
+		assert.Equal(t, int64(1), countCopies(&models.Comment{}, copied.ID))
+		assert.Equal(t, int64(1), countCopies(&models.Attachment{}, copied.ID))
+		assert.Equal(t, int64(1), countCopies(&models.Comment{}, task.ID), "source comments are untouched")
+	})
+}
+
+func TestBoardRepository_FindByUserIDWithFilters_Archived(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &boardRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	active := createTestBoard(db, user.ID)
+	archived := createTestBoard(db, user.ID)
+	archivedAt := time.Now().UTC()
+	require.NoError(t, db.Model(archived).Update("archived_at", archivedAt).Error)
+
+	boards, total, err := repo.FindByUserIDWithFilters(ctx, user.ID, "", false, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, boards, 1)
+	assert.Equal(t, active.ID, boards[0].ID)
+
+	boards, total, err = repo.FindByUserIDWithFilters(ctx, user.ID, "", true, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, boards, 1)
+	assert.Equal(t, archived.ID, boards[0].ID)
+	assert.NotNil(t, boards[0].ArchivedAt)
+}
+
+func TestBoardRepository_TaskKeyPrefixes(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &boardRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "testuser", "test@example.com")
+	other := createTestUser(db, "other", "other@example.com")
+
+	var prefixes []string
+	for _, board := range []*models.Board{
+		{Title: "Ops", UserID: user.ID},
+		{Title: "ops!", UserID: user.ID},
+		{Title: "Ops", UserID: other.ID},
+		{Title: "2024 roadmap", UserID: user.ID},
+		{Title: "???", UserID: user.ID},
+	} {
+		require.NoError(t, repo.Create(ctx, board))
+		prefixes = append(prefixes, board.TaskKeyPrefix)
+	}
+	assert.Equal(t, []string{"OPS", "OPS2", "OPS", "ROA", "TASK"}, prefixes)
+
+	taken, err := repo.TaskKeyPrefixTaken(ctx, user.ID, "OPS2", "")
+	require.NoError(t, err)
+	assert.True(t, taken)
+
+	taken, err = repo.TaskKeyPrefixTaken(ctx, other.ID, "OPS2", "")
+	require.NoError(t, err)
+	assert.False(t, taken)
+
+	// Prefixes the caller chose are rejected, not renamed
+	err = repo.Create(ctx, &models.Board{Title: "Another", UserID: user.ID, TaskKeyPrefix: "ROA"})
+	assert.ErrorIs(t, err, ErrTaskKeyPrefixTaken)
+
+	var untitled models.Board
+	require.NoError(t, db.Where("user_id = ? AND task_key_prefix = ?", user.ID, "TASK").First(&untitled).Error)
+	err = repo.UpdateTaskKeyPrefix(ctx, untitled.ID, "OPS")
+	assert.ErrorIs(t, err, ErrTaskKeyPrefixTaken)
+}
+
+func TestBoardRepository_Create_RetriesClaimedTaskKeyPrefix(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &boardRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "testuser", "test@example.com")
+
+	// Another request claims OPS after our insert derived it but before the
+	// row is written. The test database is a single connection, so the rival
+	// row goes away with our savepoint; what matters is that the violation
+	// leads to a second attempt instead of an error.
+	attempts := 0
+	err := db.Callback().Create().Before("gorm:create").Register("test:claim_prefix", func(tx *gorm.DB) {
+		board, ok := tx.Statement.Dest.(*models.Board)
+		if !ok {
+			return
+		}
+		attempts++
+		if attempts > 1 {
+			return
+		}
+		tx.Session(&gorm.Session{NewDB: true}).Exec(
+			"INSERT INTO boards (id, title, user_id, task_key_prefix, next_task_number) VALUES (?, ?, ?, ?, 1)",
+			uuid.New().String(), "Ops", board.UserID, board.TaskKeyPrefix)
+	})
+	require.NoError(t, err)
+
+	board := &models.Board{Title: "Ops", UserID: user.ID}
+	require.NoError(t, repo.Create(ctx, board))
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, "OPS", board.TaskKeyPrefix)
+
+	var count int64
+	require.NoError(t, db.Model(&models.Board{}).Where("user_id = ?", user.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type BoardTemplateRepository interface {
+	Create(ctx context.Context, template *models.BoardTemplate) error
+	FindByID(ctx context.Context, id string) (*models.BoardTemplate, error)
+	FindByUserID(ctx context.Context, userID string) ([]*models.BoardTemplate, error)
+	FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error)
+	CreateBoard(ctx context.Context, board *models.Board, definition models.TemplateDefinition) error
+	Delete(ctx context.Context, id string) error
+}
+
+type boardTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewBoardTemplateRepository() BoardTemplateRepository {
+	return &boardTemplateRepository{
+		db: config.DB,
+	}
+}
+
+func (r *boardTemplateRepository) Create(ctx context.Context, template *models.BoardTemplate) error {
+	return r.db.WithContext(ctx).Create(template).Error
+}
+
+func (r *boardTemplateRepository) FindByID(ctx context.Context, id string) (*models.BoardTemplate, error) {
+	var template models.BoardTemplate
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&template).Error
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (r *boardTemplateRepository) FindByUserID(ctx context.Context, userID string) ([]*models.BoardTemplate, error) {
+	var templates []*models.BoardTemplate
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("name ASC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// FindBoardTasks returns the board's tasks with their column and labels, for
+// saving them as sample tasks
+func (r *boardTemplateRepository) FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.WithContext(ctx).
+		Preload("Labels").
+		Preload("Column").
+		Joins("JOIN columns ON columns.id = tasks.column_id").
+		Where("columns.board_id = ? AND columns.deleted_at IS NULL", boardID).
+		Order("columns.order_num ASC, tasks.created_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CreateBoard creates the board together with the template's columns, custom
+// fields and sample tasks in one transaction. Labels are shared across boards,
+// so existing labels with the same name are reused.
+func (r *boardTemplateRepository) CreateBoard(ctx context.Context, board *models.Board, definition models.TemplateDefinition) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := createBoard(tx, board); err != nil {
+			return err
+		}
+
+		columnIDs := make(map[string]string, len(definition.Columns))
+		board.Columns = make([]models.Column, 0, len(definition.Columns))
+		for i, templateColumn := range definition.Columns {
+			column := models.Column{
+				BoardID:  board.ID,
+				Title:    templateColumn.Title,
+				OrderNum: i + 1,
+				Stage:    templateColumn.Stage,
+				WipLimit: templateColumn.WipLimit,
+			}
+			if err := tx.Create(&column).Error; err != nil {
+				return err
+			}
+			columnIDs[column.Title] = column.ID
+			board.Columns = append(board.Columns, column)
+		}
+
+		board.CustomFields = make([]models.CustomField, 0, len(definition.CustomFields))
+		for i, templateField := range definition.CustomFields {
+			field := models.CustomField{
+				BoardID:  board.ID,
+				Name:     templateField.Name,
+				Type:     templateField.Type,
+				Options:  templateField.Options,
+				OrderNum: i + 1,
+			}
+			if err := tx.Create(&field).Error; err != nil {
+				return err
+			}
+			board.CustomFields = append(board.CustomFields, field)
+		}
+
+		labels := make(map[string]*models.Label, len(definition.Labels))
+		for _, templateLabel := range definition.Labels {
+			label, err := findOrCreateLabel(tx, templateLabel.Name, templateLabel.Color)
+			if err != nil {
+				return err
+			}
+			labels[label.Name] = label
+		}
+
+		for _, sample := range definition.SampleTasks {
+			columnID, ok := columnIDs[sample.Column]
+			if !ok {
+				return fmt.Errorf("sample task %q references unknown column %q", sample.Title, sample.Column)
+			}
+
+			task := &models.Task{
+				ColumnID:    columnID,
+				Title:       sample.Title,
+				Description: sample.Description,
+			}
+			if err := tx.Create(task).Error; err != nil {
+				return err
+			}
+
+			for _, name := range sample.Labels {
+				label, ok := labels[name]
+				if !ok {
+					var err error
+					if label, err = findOrCreateLabel(tx, name, ""); err != nil {
+						return err
+					}
+					labels[name] = label
+				}
+				if err := tx.Model(task).Association("Labels").Append(label); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *boardTemplateRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.BoardTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("board template with id %s not found", id)
+	}
+	return nil
+}
+
+// findOrCreateLabel returns the label with the given name, restoring it if it
+// was soft-deleted, or creates it
+func findOrCreateLabel(tx *gorm.DB, name, color string) (*models.Label, error) {
+	var label models.Label
+	err := tx.Unscoped().Where("name = ?", name).First(&label).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		label = models.Label{Name: name, Color: color}
+		if err := tx.Create(&label).Error; err != nil {
+			return nil, err
+		}
+		return &label, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if label.DeletedAt.Valid {
+		if err := tx.Unscoped().Model(&label).Update("deleted_at", nil).Error; err != nil {
+			return nil, err
+		}
+		label.DeletedAt = gorm.DeletedAt{}
+	}
+	return &label, nil
+}
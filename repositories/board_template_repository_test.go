@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestBoardTemplateRepository_CreateBoard(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &boardTemplateRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	existing := &models.Label{Name: "Bug", Color: "#ff0000"}
+	require.NoError(t, db.Create(existing).Error)
+
+	limit := 3
+	definition := models.TemplateDefinition{
+		Columns: []models.TemplateColumn{
+			{Title: "Backlog", Stage: models.ColumnStageTodo},
+			{Title: "Doing", Stage: models.ColumnStageInProgress, WipLimit: &limit},
+			{Title: "Done", Stage: models.ColumnStageDone},
+		},
+		Labels:       []models.TemplateLabel{{Name: "Bug", Color: "#000000"}, {Name: "Feature", Color: "#00ff00"}},
+		CustomFields: []models.TemplateCustomField{{Name: "Severity", Type: models.CustomFieldSelect, Options: []string{"Low", "High"}}},
+		SampleTasks:  []models.TemplateTask{{Title: "Triage crash", Column: "Doing", Labels: []string{"Bug", "Feature"}}},
+	}
+
+	board := &models.Board{UserID: user.ID, Title: "From template", Color: "#ffffff"}
+	require.NoError(t, repo.CreateBoard(ctx, board, definition))
+	require.Len(t, board.Columns, 3)
+	assert.Equal(t, 2, board.Columns[1].OrderNum)
+
+	saved, err := (&boardRepository{db: db}).FindByID(ctx, board.ID)
+	require.NoError(t, err)
+	require.Len(t, saved.Columns, 3)
+	require.NotNil(t, saved.Columns[1].WipLimit)
+	assert.Equal(t, 3, *saved.Columns[1].WipLimit)
+	require.Len(t, saved.CustomFields, 1)
+	assert.Equal(t, []string{"Low", "High"}, saved.CustomFields[0].Options)
+
+	var labelCount int64
+	db.Model(&models.Label{}).Where("name = ?", "Bug").Count(&labelCount)
+	assert.Equal(t, int64(1), labelCount, "existing labels should be reused")
+
+	tasks, err := repo.FindBoardTasks(ctx, board.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Doing", tasks[0].Column.Title)
+	assert.Len(t, tasks[0].Labels, 2)
+}
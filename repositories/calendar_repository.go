@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type CalendarRepository interface {
+	CreateToken(ctx context.Context, token *models.CalendarToken) error
+	FindTokenByHash(ctx context.Context, tokenHash string) (*models.CalendarToken, error)
+	FindTokensByUserID(ctx context.Context, userID string) ([]*models.CalendarToken, error)
+	DeleteToken(ctx context.Context, id, userID string) error
+	TouchToken(ctx context.Context, id string, usedAt time.Time) error
+	FindDeadlineTasks(ctx context.Context, userID, boardID string, since time.Time) ([]*models.Task, error)
+}
+
+type calendarRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarRepository() CalendarRepository {
+	return &calendarRepository{
+		db: config.DB,
+	}
+}
+
+func (r *calendarRepository) CreateToken(ctx context.Context, token *models.CalendarToken) error {
+	return r.db.WithContext(ctx).Create(token).Error
+}
+
+func (r *calendarRepository) FindTokenByHash(ctx context.Context, tokenHash string) (*models.CalendarToken, error) {
+	var token models.CalendarToken
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ?", tokenHash).
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *calendarRepository) FindTokensByUserID(ctx context.Context, userID string) ([]*models.CalendarToken, error) {
+	var tokens []*models.CalendarToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func (r *calendarRepository) DeleteToken(ctx context.Context, id, userID string) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&models.CalendarToken{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("calendar token with id %s not found", id)
+	}
+	return nil
+}
+
+func (r *calendarRepository) TouchToken(ctx context.Context, id string, usedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.CalendarToken{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error
+}
+
+// FindDeadlineTasks returns unarchived tasks with a deadline since the given
+// time that the user is assigned to, watches, or that sit on a board the user
+// watches. A non-empty boardID limits the result to that board.
+func (r *calendarRepository) FindDeadlineTasks(ctx context.Context, userID, boardID string, since time.Time) ([]*models.Task, error) {
+	assigned := r.db.Model(&models.TaskAssignee{}).Select("task_id").Where("user_id = ?", userID)
+	watchedTasks := r.db.Model(&models.Watch{}).Select("target_id").Where("user_id = ? AND target_type = ?", userID, models.WatchTargetTask)
+	watchedBoards := r.db.Model(&models.Watch{}).Select("target_id").Where("user_id = ? AND target_type = ?", userID, models.WatchTargetBoard)
+
+	query := r.db.WithContext(ctx).
+		Scopes(onLiveBoard).
+		Preload("Column.Board").
+		Where("tasks.deadline IS NOT NULL AND tasks.deadline >= ?", since).
+		Where("tasks.archived_at IS NULL").
+		Where("tasks.id IN (?) OR tasks.id IN (?) OR columns.board_id IN (?)", assigned, watchedTasks, watchedBoards)
+	if boardID != "" {
+		query = query.Where("columns.board_id = ?", boardID)
+	}
+
+	var tasks []*models.Task
+	err := query.Order("tasks.deadline ASC").Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
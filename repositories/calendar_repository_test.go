@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestCalendarRepository_FindDeadlineTasks(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &calendarRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "calendar", "calendar@example.com")
+	other := createTestUser(db, "other", "other@example.com")
+	watchedBoard := createTestBoard(db, other.ID)
+	otherBoard := createTestBoard(db, other.ID)
+	watchedColumn := createTestColumn(db, watchedBoard.ID)
+	otherColumn := createTestColumn(db, otherBoard.ID)
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	due := now.Add(48 * time.Hour)
+	old := now.AddDate(0, -6, 0)
+	newTask := func(columnID, title string, deadline *time.Time) *models.Task {
+		task := &models.Task{ColumnID: columnID, Title: title, Deadline: deadline}
+		require.NoError(t, db.Create(task).Error)
+		return task
+	}
+
+	onWatchedBoard := newTask(watchedColumn.ID, "on watched board", &due)
+	assigned := newTask(otherColumn.ID, "assigned", &due)
+	watched := newTask(otherColumn.ID, "watched", &due)
+	newTask(otherColumn.ID, "unrelated", &due)
+	newTask(watchedColumn.ID, "no deadline", nil)
+	newTask(watchedColumn.ID, "long past", &old)
+	archived := newTask(watchedColumn.ID, "archived", &due)
+	require.NoError(t, db.Model(archived).Update("archived_at", now).Error)
+
+	require.NoError(t, db.Create(&models.TaskAssignee{TaskID: assigned.ID, UserID: user.ID}).Error)
+	require.NoError(t, db.Create(&models.Watch{UserID: user.ID, TargetType: models.WatchTargetTask, TargetID: watched.ID}).Error)
+	require.NoError(t, db.Create(&models.Watch{UserID: user.ID, TargetType: models.WatchTargetBoard, TargetID: watchedBoard.ID}).Error)
+
+	since := now.AddDate(0, 0, -90)
+	tasks, err := repo.FindDeadlineTasks(ctx, user.ID, "", since)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	assert.ElementsMatch(t, []string{onWatchedBoard.ID, assigned.ID, watched.ID}, ids)
+
+	tasks, err = repo.FindDeadlineTasks(ctx, user.ID, otherBoard.ID, since)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 2)
+	for _, task := range tasks {
+		require.NotNil(t, task.Column)
+		require.NotNil(t, task.Column.Board)
+		assert.Equal(t, otherBoard.ID, task.Column.Board.ID)
+	}
+}
+
+func TestCalendarRepository_Tokens(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &calendarRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "calendar", "calendar@example.com")
+	other := createTestUser(db, "other", "other@example.com")
+
+	token := &models.CalendarToken{UserID: user.ID, Name: "Phone", TokenHash: "abc123"}
+	require.NoError(t, repo.CreateToken(ctx, token))
+
+	found, err := repo.FindTokenByHash(ctx, "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, found.ID)
+
+	assert.Error(t, repo.DeleteToken(ctx, token.ID, other.ID), "tokens can only be revoked by their owner")
+	require.NoError(t, repo.DeleteToken(ctx, token.ID, user.ID))
+
+	_, err = repo.FindTokenByHash(ctx, "abc123")
+	assert.Error(t, err)
+}
@@ -36,9 +36,10 @@ func (r *columnRepository) Create(ctx context.Context, column *models.Column) er
 func (r *columnRepository) FindByID(ctx context.Context, id string) (*models.Column, error) {
 	var column models.Column
 	err := r.db.WithContext(ctx).
+		Joins("JOIN boards ON boards.id = columns.board_id AND boards.deleted_at IS NULL").
 		Preload("Tasks").
 		Preload("Board").
-		Where("id = ?", id).
+		Where("columns.id = ?", id).
 		First(&column).Error
 	if err != nil {
 		return nil, err
@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type GitIntegrationRepository interface {
+	Create(ctx context.Context, integration *models.GitIntegration) error
+	FindByID(ctx context.Context, id string) (*models.GitIntegration, error)
+	FindByBoardID(ctx context.Context, boardID string) (*models.GitIntegration, error)
+	Update(ctx context.Context, integration *models.GitIntegration) error
+	Delete(ctx context.Context, id string) error
+	TouchDelivery(ctx context.Context, id string, deliveredAt time.Time) error
+	FindBoardTasks(ctx context.Context, boardID string, taskIDs, keys []string) ([]*models.Task, error)
+	HasActivity(ctx context.Context, taskID, action, value string) (bool, error)
+}
+
+type gitIntegrationRepository struct {
+	db *gorm.DB
+}
+
+func NewGitIntegrationRepository() GitIntegrationRepository {
+	return &gitIntegrationRepository{
+		db: config.DB,
+	}
+}
+
+func (r *gitIntegrationRepository) Create(ctx context.Context, integration *models.GitIntegration) error {
+	return r.db.WithContext(ctx).Create(integration).Error
+}
+
+func (r *gitIntegrationRepository) FindByID(ctx context.Context, id string) (*models.GitIntegration, error) {
+	var integration models.GitIntegration
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&integration).Error
+	if err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+func (r *gitIntegrationRepository) FindByBoardID(ctx context.Context, boardID string) (*models.GitIntegration, error) {
+	var integration models.GitIntegration
+	err := r.db.WithContext(ctx).
+		Where("board_id = ?", boardID).
+		First(&integration).Error
+	if err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+func (r *gitIntegrationRepository) Update(ctx context.Context, integration *models.GitIntegration) error {
+	return r.db.WithContext(ctx).Save(integration).Error
+}
+
+func (r *gitIntegrationRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.GitIntegration{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("git integration with id %s not found", id)
+	}
+	return nil
+}
+
+func (r *gitIntegrationRepository) TouchDelivery(ctx context.Context, id string, deliveredAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.GitIntegration{}).
+		Where("id = ?", id).
+		Update("last_delivery_at", deliveredAt).Error
+}
+
+// FindBoardTasks returns the tasks on the board with one of the given IDs or keys
+func (r *gitIntegrationRepository) FindBoardTasks(ctx context.Context, boardID string, taskIDs, keys []string) ([]*models.Task, error) {
+	var tasks []*models.Task
+	if len(taskIDs) == 0 && len(keys) == 0 {
+		return tasks, nil
+	}
+	err := r.db.WithContext(ctx).
+		Scopes(onLiveBoard).
+		Where("columns.board_id = ?", boardID).
+		Where("tasks.id IN ? OR tasks.key IN ?", taskIDs, keys).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// HasActivity reports whether the task's history already has an entry with
+// the given action and new value, so redelivered webhooks link only once
+func (r *gitIntegrationRepository) HasActivity(ctx context.Context, taskID, action, value string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.TaskActivity{}).
+		Where("task_id = ? AND action = ? AND new_value = ?", taskID, action, value).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestGitIntegrationRepository_FindBoardTasks(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &gitIntegrationRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "git", "git@example.com")
+	board := createTestBoard(db, user.ID)
+	otherBoard := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+	otherColumn := createTestColumn(db, otherBoard.ID)
+
+	task := &models.Task{ColumnID: column.ID, Title: "On board"}
+	elsewhere := &models.Task{ColumnID: otherColumn.ID, Title: "Elsewhere"}
+	require.NoError(t, db.Create(task).Error)
+	require.NoError(t, db.Create(elsewhere).Error)
+
+	tasks, err := repo.FindBoardTasks(ctx, board.ID, []string{task.ID, elsewhere.ID}, nil)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1, "tasks on other boards must not be linked")
+	assert.Equal(t, task.ID, tasks[0].ID)
+
+	tasks, err = repo.FindBoardTasks(ctx, board.ID, nil, []string{task.Key, elsewhere.Key})
+	require.NoError(t, err)
+	require.Len(t, tasks, 1, "keys are only resolved on the integration's board")
+	assert.Equal(t, task.ID, tasks[0].ID)
+
+	tasks, err = repo.FindBoardTasks(ctx, board.ID, nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+}
+
+func TestGitIntegrationRepository_HasActivity(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &gitIntegrationRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "git", "git@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+	task := &models.Task{ColumnID: column.ID, Title: "Task"}
+	require.NoError(t, db.Create(task).Error)
+
+	url := "https://github.com/acme/kanban/pull/7"
+	require.NoError(t, db.Create(&models.TaskActivity{TaskID: task.ID, UserID: user.ID, Action: models.ActivityPullRequestLinked, Field: "pull_request", NewValue: &url}).Error)
+
+	exists, err := repo.HasActivity(ctx, task.ID, models.ActivityPullRequestLinked, url)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = repo.HasActivity(ctx, task.ID, models.ActivityCommitLinked, url)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
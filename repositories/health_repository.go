@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"kanban-backend/config"
+
+	"gorm.io/gorm"
+)
+
+// HealthRepository checks that the database is reachable and migrated
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
+
+type healthRepository struct {
+	db *gorm.DB
+}
+
+func NewHealthRepository() HealthRepository {
+	return &healthRepository{
+		db: config.DB,
+	}
+}
+
+func (r *healthRepository) Ping(ctx context.Context) error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// MigrationVersion reads the version recorded by golang-migrate
+func (r *healthRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var migration struct {
+		Version uint
+		Dirty   bool
+	}
+	result := r.db.WithContext(ctx).Raw("SELECT version, dirty FROM migrations LIMIT 1").Scan(&migration)
+	if result.Error != nil {
+		return 0, false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, false, errors.New("no migrations applied")
+	}
+	return migration.Version, migration.Dirty, nil
+}
@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthRepository(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &healthRepository{db: db}
+	ctx := context.Background()
+
+	assert.NoError(t, repo.Ping(ctx))
+
+	_, _, err := repo.MigrationVersion(ctx)
+	assert.Error(t, err, "no migrations table yet")
+
+	require.NoError(t, db.Exec("CREATE TABLE migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)").Error)
+	_, _, err = repo.MigrationVersion(ctx)
+	assert.EqualError(t, err, "no migrations applied")
+
+	require.NoError(t, db.Exec("INSERT INTO migrations (version, dirty) VALUES (12, true)").Error)
+	version, dirty, err := repo.MigrationVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint(12), version)
+	assert.True(t, dirty)
+}
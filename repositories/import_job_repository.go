@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *models.ImportJob) error
+	FindByID(ctx context.Context, id string) (*models.ImportJob, error)
+	Update(ctx context.Context, job *models.ImportJob) error
+	UpdateProgress(ctx context.Context, id string, processed int) error
+}
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository() ImportJobRepository {
+	return &importJobRepository{
+		db: config.DB,
+	}
+}
+
+func (r *importJobRepository) Create(ctx context.Context, job *models.ImportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+func (r *importJobRepository) FindByID(ctx context.Context, id string) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *importJobRepository) Update(ctx context.Context, job *models.ImportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// UpdateProgress only touches the processed count so it can run while the
+// import itself is still writing
+func (r *importJobRepository) UpdateProgress(ctx context.Context, id string, processed int) error {
+	return r.db.WithContext(ctx).
+		Model(&models.ImportJob{}).
+		Where("id = ?", id).
+		Update("processed", processed).Error
+}
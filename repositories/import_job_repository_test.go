@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestImportJobRepository_Progress(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &importJobRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "importer", "importer@example.com")
+	job := &models.ImportJob{UserID: user.ID, Source: models.ImportSourceTrello, Status: models.ImportStatusRunning, Total: 50}
+	require.NoError(t, repo.Create(ctx, job))
+
+	require.NoError(t, repo.UpdateProgress(ctx, job.ID, 25))
+
+	job.Status = models.ImportStatusFailed
+	job.Skipped = []models.ImportSkippedItem{{Type: "list", Name: "Old", Reason: "list is archived"}}
+	job.Processed = 25
+	require.NoError(t, repo.Update(ctx, job))
+
+	saved, err := repo.FindByID(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 25, saved.Processed)
+	assert.Equal(t, models.ImportStatusFailed, saved.Status)
+	require.Len(t, saved.Skipped, 1)
+	assert.Equal(t, "Old", saved.Skipped[0].Name)
+}
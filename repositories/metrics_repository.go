@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"kanban-backend/config"
+	"kanban-backend/metrics"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// MetricsRepository counts the boards, tasks and active users exported on
+// /metrics
+type MetricsRepository interface {
+	Counts(ctx context.Context, activeSince time.Time) (*metrics.Counts, error)
+}
+
+type metricsRepository struct {
+	db *gorm.DB
+}
+
+func NewMetricsRepository() MetricsRepository {
+	return &metricsRepository{
+		db: config.DB,
+	}
+}
+
+func (r *metricsRepository) Counts(ctx context.Context, activeSince time.Time) (*metrics.Counts, error) {
+	db := r.db.WithContext(ctx)
+	counts := &metrics.Counts{}
+
+	var boards struct {
+		Active   int64
+		Archived int64
+	}
+	err := db.Model(&models.Board{}).
+		Select("COUNT(CASE WHEN archived_at IS NULL THEN 1 END) AS active, COUNT(archived_at) AS archived").
+		Scan(&boards).Error
+	if err != nil {
+		return nil, err
+	}
+	counts.ActiveBoards, counts.ArchivedBoards = boards.Active, boards.Archived
+
+	var tasks struct {
+		Active   int64
+		Archived int64
+	}
+	err = db.Model(&models.Task{}).
+		Select("COUNT(CASE WHEN archived_at IS NULL THEN 1 END) AS active, COUNT(archived_at) AS archived").
+		Scan(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	counts.ActiveTasks, counts.ArchivedTasks = tasks.Active, tasks.Archived
+
+	err = db.Raw(`SELECT COUNT(*) FROM (
+		SELECT user_id FROM task_activities WHERE created_at >= ?
+		UNION
+		SELECT user_id FROM comments WHERE created_at >= ? AND deleted_at IS NULL
+	) AS active_users`, activeSince, activeSince).
+		Scan(&counts.ActiveUsers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
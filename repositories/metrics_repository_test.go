@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestMetricsRepository_Counts(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &metricsRepository{db: db}
+	ctx := context.Background()
+	now := time.Now().UTC()
+	since := now.Add(-24 * time.Hour)
+
+	owner := createTestUser(db, "owner", "owner@example.com")
+	commenter := createTestUser(db, "commenter", "commenter@example.com")
+	idle := createTestUser(db, "idle", "idle@example.com")
+
+	board := createTestBoard(db, owner.ID)
+	archived := createTestBoard(db, owner.ID)
+	require.NoError(t, db.Model(archived).Update("archived_at", now).Error)
+	deleted := createTestBoard(db, owner.ID)
+	require.NoError(t, db.Delete(deleted).Error)
+
+	column := createTestColumn(db, board.ID)
+	task := &models.Task{ColumnID: column.ID, Title: "Open"}
+	require.NoError(t, db.Create(task).Error)
+	require.NoError(t, db.Create(&models.Task{ColumnID: column.ID, Title: "Done", ArchivedAt: &now}).Error)
+
+	activities := []*models.TaskActivity{
+		{TaskID: task.ID, UserID: owner.ID, Action: models.ActivityCreated, CreatedAt: now},
+		{TaskID: task.ID, UserID: owner.ID, Action: models.ActivityUpdated, CreatedAt: now},
+		{TaskID: task.ID, UserID: idle.ID, Action: models.ActivityUpdated, CreatedAt: since.Add(-time.Hour)},
+	}
+	require.NoError(t, db.Create(&activities).Error)
+	require.NoError(t, db.Create(&models.Comment{TaskID: task.ID, UserID: commenter.ID, Content: "On it"}).Error)
+
+	counts, err := repo.Counts(ctx, since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), counts.ActiveBoards)
+	assert.Equal(t, int64(1), counts.ArchivedBoards)
+	assert.Equal(t, int64(1), counts.ActiveTasks)
+	assert.Equal(t, int64(1), counts.ArchivedTasks)
+	assert.Equal(t, int64(2), counts.ActiveUsers, "owner and commenter, not the idle user")
+}
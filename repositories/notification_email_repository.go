@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationEmailRepository interface {
+	FindUnemailed(ctx context.Context, since time.Time, afterUserID string, users int) ([]*models.Notification, error)
+	FindTasks(ctx context.Context, ids []string) ([]*models.Task, error)
+	ClaimEmail(ctx context.Context, ids []string, now, leaseUntil time.Time) ([]string, error)
+	MarkEmailed(ctx context.Context, ids []string, emailedAt time.Time) error
+	FindPreferences(ctx context.Context, userIDs []string) ([]*models.NotificationPreference, error)
+	SavePreferences(ctx context.Context, preferences []*models.NotificationPreference) error
+}
+
+type notificationEmailRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationEmailRepository() NotificationEmailRepository {
+	return &notificationEmailRepository{
+		db: config.DB,
+	}
+}
+
+// FindUnemailed returns unread notifications created since the given time
+// that have not been emailed yet, with their recipient. It pages by
+// recipient so that a digest sees all of a user's notifications: it loads
+// those of the first users, at most the given number, whose ID sorts after
+// afterUserID, ordered by user and then oldest first.
+func (r *notificationEmailRepository) FindUnemailed(ctx context.Context, since time.Time, afterUserID string, users int) ([]*models.Notification, error) {
+	var notifications []*models.Notification
+	db := r.db.WithContext(ctx)
+	pending := "emailed_at IS NULL AND read_at IS NULL AND created_at >= ?"
+	userIDs := db.Model(&models.Notification{}).
+		Distinct("user_id").
+		Where(pending, since).
+		Where("user_id > ?", afterUserID).
+		Order("user_id ASC").
+		Limit(users)
+	err := db.
+		Preload("User").
+		Where(pending, since).
+		Where("user_id IN (?)", userIDs).
+		Order("user_id ASC, created_at ASC, id ASC").
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// FindTasks loads what emails show of the tasks notifications are about
+func (r *notificationEmailRepository) FindTasks(ctx context.Context, ids []string) ([]*models.Task, error) {
+	var tasks []*models.Task
+	if len(ids) == 0 {
+		return tasks, nil
+	}
+	err := r.db.WithContext(ctx).
+		Select("id", "title", "key").
+		Where("id IN ?", ids).
+		Find(&tasks).Error
+	return tasks, err
+}
+
+// ClaimEmail claims the given notifications for emailing until leaseUntil,
+// skipping those already emailed or claimed by another worker whose claim
+// has not lapsed by now. It returns the IDs it claimed.
+func (r *notificationEmailRepository) ClaimEmail(ctx context.Context, ids []string, now, leaseUntil time.Time) ([]string, error) {
+	var claimed []*models.Notification
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	err := r.db.WithContext(ctx).
+		Model(&claimed).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+		Where("id IN ? AND emailed_at IS NULL", ids).
+		Where("email_claimed_until IS NULL OR email_claimed_until <= ?", now).
+		Update("email_claimed_until", leaseUntil).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimedIDs := make([]string, len(claimed))
+	for i, notification := range claimed {
+		claimedIDs[i] = notification.ID
+	}
+	return claimedIDs, nil
+}
+
+func (r *notificationEmailRepository) MarkEmailed(ctx context.Context, ids []string, emailedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.Notification{}).
+		Where("id IN ?", ids).
+		Update("emailed_at", emailedAt).Error
+}
+
+func (r *notificationEmailRepository) FindPreferences(ctx context.Context, userIDs []string) ([]*models.NotificationPreference, error) {
+	var preferences []*models.NotificationPreference
+	if len(userIDs) == 0 {
+		return preferences, nil
+	}
+	err := r.db.WithContext(ctx).
+		Where("user_id IN ?", userIDs).
+		Order("type ASC").
+		Find(&preferences).Error
+	return preferences, err
+}
+
+// SavePreferences creates the preferences or updates the delivery of the
+// user's existing preference for the same type
+func (r *notificationEmailRepository) SavePreferences(ctx context.Context, preferences []*models.NotificationPreference) error {
+	if len(preferences) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "type"}},
+			DoUpdates: clause.AssignmentColumns([]string{"delivery", "updated_at"}),
+		}).
+		Create(&preferences).Error
+}
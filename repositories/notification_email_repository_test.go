@@ -0,0 +1,117 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestNotificationEmailRepository_FindUnemailed(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &notificationEmailRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "mail", "mail@example.com")
+	now := time.Now().UTC()
+
+	pending := &models.Notification{UserID: user.ID, Message: "Pending"}
+	read := &models.Notification{UserID: user.ID, Message: "Read", ReadAt: &now}
+	emailed := &models.Notification{UserID: user.ID, Message: "Emailed", EmailedAt: &now}
+	for _, notification := range []*models.Notification{pending, read, emailed} {
+		require.NoError(t, db.Create(notification).Error)
+	}
+
+	notifications, err := repo.FindUnemailed(ctx, now.Add(-time.Hour), "", 10)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, pending.ID, notifications[0].ID)
+	require.NotNil(t, notifications[0].User)
+	assert.Equal(t, "mail@example.com", notifications[0].User.Email)
+
+	require.NoError(t, repo.MarkEmailed(ctx, []string{pending.ID}, now))
+	notifications, err = repo.FindUnemailed(ctx, now.Add(-time.Hour), "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+}
+
+func TestNotificationEmailRepository_FindUnemailedPagesByUser(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &notificationEmailRepository{db: db}
+	ctx := context.Background()
+
+	var users []*models.User
+	for _, name := range []string{"ann", "ben", "cat"} {
+		user := createTestUser(db, name, name+"@example.com")
+		for range 2 {
+			require.NoError(t, db.Create(&models.Notification{UserID: user.ID, Message: "Hi " + name}).Error)
+		}
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	since := time.Now().Add(-time.Hour)
+
+	notifications, err := repo.FindUnemailed(ctx, since, "", 2)
+	require.NoError(t, err)
+	require.Len(t, notifications, 4)
+	assert.Equal(t, users[0].ID, notifications[0].UserID)
+	assert.Equal(t, users[1].ID, notifications[3].UserID)
+
+	notifications, err = repo.FindUnemailed(ctx, since, users[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, users[2].ID, notifications[0].UserID)
+}
+
+func TestNotificationEmailRepository_ClaimEmail(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &notificationEmailRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "claim", "claim@example.com")
+	now := time.Now().UTC()
+	pending := &models.Notification{UserID: user.ID, Message: "Pending"}
+	emailed := &models.Notification{UserID: user.ID, Message: "Emailed", EmailedAt: &now}
+	for _, notification := range []*models.Notification{pending, emailed} {
+		require.NoError(t, db.Create(notification).Error)
+	}
+	ids := []string{pending.ID, emailed.ID}
+
+	claimed, err := repo.ClaimEmail(ctx, ids, now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []string{pending.ID}, claimed)
+
+	claimed, err = repo.ClaimEmail(ctx, ids, now.Add(time.Second), now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, claimed, "another worker's claim must hold until it lapses")
+
+	claimed, err = repo.ClaimEmail(ctx, ids, now.Add(time.Minute), now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []string{pending.ID}, claimed)
+}
+
+func TestNotificationEmailRepository_SavePreferences(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &notificationEmailRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "mail", "mail@example.com")
+
+	require.NoError(t, repo.SavePreferences(ctx, []*models.NotificationPreference{
+		{UserID: user.ID, Type: models.NotificationTypeTaskComment, Delivery: models.EmailDeliveryHourly},
+		{UserID: user.ID, Type: models.NotificationTypeRule, Delivery: models.EmailDeliveryOff},
+	}))
+	require.NoError(t, repo.SavePreferences(ctx, []*models.NotificationPreference{
+		{UserID: user.ID, Type: models.NotificationTypeTaskComment, Delivery: models.EmailDeliveryDaily},
+	}))
+
+	preferences, err := repo.FindPreferences(ctx, []string{user.ID})
+	require.NoError(t, err)
+	require.Len(t, preferences, 2, "saving a type again updates its preference")
+	assert.Equal(t, models.NotificationTypeRule, preferences[0].Type)
+	assert.Equal(t, models.EmailDeliveryDaily, preferences[1].Delivery)
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type NotificationRepository interface {
+	CreateBatch(ctx context.Context, notifications []*models.Notification) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository() NotificationRepository {
+	return &notificationRepository{
+		db: config.DB,
+	}
+}
+
+func (r *notificationRepository) CreateBatch(ctx context.Context, notifications []*models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&notifications).Error
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestNotificationRepository_CreateBatch(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &notificationRepository{db: db}
+	ctx := context.Background()
+
+	alice := createTestUser(db, "alice", "alice@example.com")
+	bob := createTestUser(db, "bob", "bob@example.com")
+
+	require.NoError(t, repo.CreateBatch(ctx, nil))
+	require.NoError(t, repo.CreateBatch(ctx, []*models.Notification{
+		{UserID: alice.ID, Type: models.NotificationTypeTaskComment, Message: "hello"},
+		{UserID: bob.ID, Type: models.NotificationTypeTaskComment, Message: "hello"},
+	}))
+
+	var count int64
+	require.NoError(t, db.Model(&models.Notification{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+}
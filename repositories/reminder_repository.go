@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReminderRepository interface {
+	FindTasksWithDeadlineBetween(ctx context.Context, from, to time.Time) ([]*models.Task, error)
+	Claim(ctx context.Context, reminder *models.TaskReminder, notification *models.Notification) (bool, error)
+}
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository() ReminderRepository {
+	return &reminderRepository{
+		db: config.DB,
+	}
+}
+
+func (r *reminderRepository) FindTasksWithDeadlineBetween(ctx context.Context, from, to time.Time) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.WithContext(ctx).
+		Scopes(onLiveBoard).
+		Preload("Assignees").
+		Preload("Column.Board").
+		Where("tasks.deadline IS NOT NULL AND tasks.deadline >= ? AND tasks.deadline <= ?", from, to).
+		Where("tasks.archived_at IS NULL").
+		Order("tasks.deadline ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// Claim records the reminder and creates its notification in one transaction.
+// It returns false without creating the notification when the same reminder
+// was already claimed, so concurrent schedulers never double-send.
+func (r *reminderRepository) Claim(ctx context.Context, reminder *models.TaskReminder, notification *models.Notification) (bool, error) {
+	claimed := false
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(reminder)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Create(notification).Error; err != nil {
+			return err
+		}
+
+		claimed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return claimed, nil
+}
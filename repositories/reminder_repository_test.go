@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestReminderRepository_FindTasksWithDeadlineBetween(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &reminderRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "testuser", "test@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+
+	now := time.Now()
+	soon := now.Add(time.Hour)
+	later := now.Add(48 * time.Hour)
+
+	require.NoError(t, db.Create(&models.Task{ColumnID: column.ID, Title: "Soon", Deadline: &soon}).Error)
+	require.NoError(t, db.Create(&models.Task{ColumnID: column.ID, Title: "Later", Deadline: &later}).Error)
+	require.NoError(t, db.Create(&models.Task{ColumnID: column.ID, Title: "No deadline"}).Error)
+
+	tasks, err := repo.FindTasksWithDeadlineBetween(ctx, now, now.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "Soon", tasks[0].Title)
+	assert.NotNil(t, tasks[0].Column)
+	assert.NotNil(t, tasks[0].Column.Board)
+}
+
+func TestReminderRepository_Claim(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &reminderRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "testuser", "test@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+
+	deadline := time.Now().Add(time.Hour).Truncate(time.Second)
+	task := &models.Task{ColumnID: column.ID, Title: "Task", Deadline: &deadline}
+	require.NoError(t, db.Create(task).Error)
+
+	newClaim := func() (*models.TaskReminder, *models.Notification) {
+		return &models.TaskReminder{
+			TaskID:      task.ID,
+			UserID:      user.ID,
+			Kind:        models.ReminderKindUpcoming,
+			LeadMinutes: 60,
+			Deadline:    deadline,
+		}, &models.Notification{
+			UserID:  user.ID,
+			Type:    models.NotificationTypeDeadlineReminder,
+			TaskID:  &task.ID,
+			Message: "Task is due soon",
+		}
+	}
+
+	reminder, notification := newClaim()
+	claimed, err := repo.Claim(ctx, reminder, notification)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	reminder, notification = newClaim()
+	claimed, err = repo.Claim(ctx, reminder, notification)
+	require.NoError(t, err)
+	assert.False(t, claimed, "the same reminder must not be claimed twice")
+
+	var count int64
+	db.Model(&models.Notification{}).Where("user_id = ?", user.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
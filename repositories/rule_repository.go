@@ -0,0 +1,179 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type RuleRepository interface {
+	Create(ctx context.Context, rule *models.Rule) error
+	FindByID(ctx context.Context, id string) (*models.Rule, error)
+	FindByBoardID(ctx context.Context, boardID string) ([]*models.Rule, error)
+	FindEnabledByBoardID(ctx context.Context, boardID string) ([]*models.Rule, error)
+	FindEnabledByTrigger(ctx context.Context, trigger string) ([]*models.Rule, error)
+	Update(ctx context.Context, rule *models.Rule) error
+	Delete(ctx context.Context, id string) error
+	CreateExecution(ctx context.Context, execution *models.RuleExecution) error
+	FindExecutions(ctx context.Context, ruleID string, limit int) ([]*models.RuleExecution, error)
+	HasExecutionSince(ctx context.Context, ruleID, taskID string, since time.Time) (bool, error)
+	FindDueTasks(ctx context.Context, boardID string, from, to time.Time) ([]*models.Task, error)
+	FindTaskCreatorID(ctx context.Context, taskID string) (string, error)
+	FindOrCreateLabel(ctx context.Context, name string) (*models.Label, error)
+}
+
+type ruleRepository struct {
+	db *gorm.DB
+}
+
+func NewRuleRepository() RuleRepository {
+	return &ruleRepository{
+		db: config.DB,
+	}
+}
+
+func (r *ruleRepository) Create(ctx context.Context, rule *models.Rule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+func (r *ruleRepository) FindByID(ctx context.Context, id string) (*models.Rule, error) {
+	var rule models.Rule
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *ruleRepository) FindByBoardID(ctx context.Context, boardID string) ([]*models.Rule, error) {
+	var rules []*models.Rule
+	err := r.db.WithContext(ctx).
+		Where("board_id = ?", boardID).
+		Order("created_at ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// FindEnabledByBoardID returns the board's enabled rules in the order they
+// were created, which is the order they run in
+func (r *ruleRepository) FindEnabledByBoardID(ctx context.Context, boardID string) ([]*models.Rule, error) {
+	var rules []*models.Rule
+	err := r.db.WithContext(ctx).
+		Where("board_id = ? AND enabled = ?", boardID, true).
+		Order("created_at ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// FindEnabledByTrigger returns enabled rules on live boards with the given
+// trigger type
+func (r *ruleRepository) FindEnabledByTrigger(ctx context.Context, trigger string) ([]*models.Rule, error) {
+	var rules []*models.Rule
+	err := r.db.WithContext(ctx).
+		Joins("JOIN boards ON boards.id = rules.board_id AND boards.deleted_at IS NULL").
+		Where("rules.enabled = ?", true).
+		Order("rules.created_at ASC").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// The trigger is stored as JSON, so it is matched here rather than in SQL
+	matching := rules[:0]
+	for _, rule := range rules {
+		if rule.Trigger.Type == trigger {
+			matching = append(matching, rule)
+		}
+	}
+	return matching, nil
+}
+
+func (r *ruleRepository) Update(ctx context.Context, rule *models.Rule) error {
+	return r.db.WithContext(ctx).Save(rule).Error
+}
+
+func (r *ruleRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Rule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("rule with id %s not found", id)
+	}
+	return nil
+}
+
+func (r *ruleRepository) CreateExecution(ctx context.Context, execution *models.RuleExecution) error {
+	return r.db.WithContext(ctx).Create(execution).Error
+}
+
+// FindExecutions returns the rule's most recent executions, newest first
+func (r *ruleRepository) FindExecutions(ctx context.Context, ruleID string, limit int) ([]*models.RuleExecution, error) {
+	var executions []*models.RuleExecution
+	err := r.db.WithContext(ctx).
+		Where("rule_id = ?", ruleID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&executions).Error
+	if err != nil {
+		return nil, err
+	}
+	return executions, nil
+}
+
+// HasExecutionSince reports whether the rule already ran for the task at or
+// after since
+func (r *ruleRepository) HasExecutionSince(ctx context.Context, ruleID, taskID string, since time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.RuleExecution{}).
+		Where("rule_id = ? AND task_id = ? AND created_at >= ?", ruleID, taskID, since).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindDueTasks returns the board's unarchived tasks whose deadline falls in
+// (from, to]
+func (r *ruleRepository) FindDueTasks(ctx context.Context, boardID string, from, to time.Time) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.WithContext(ctx).
+		Scopes(onLiveBoard).
+		Where("columns.board_id = ? AND tasks.archived_at IS NULL", boardID).
+		Where("tasks.deadline > ? AND tasks.deadline <= ?", from, to).
+		Order("tasks.deadline ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindTaskCreatorID returns the user who created the task, from its history
+func (r *ruleRepository) FindTaskCreatorID(ctx context.Context, taskID string) (string, error) {
+	var activity models.TaskActivity
+	err := r.db.WithContext(ctx).
+		Where("task_id = ? AND action = ?", taskID, models.ActivityCreated).
+		Order("created_at ASC").
+		First(&activity).Error
+	if err != nil {
+		return "", err
+	}
+	return activity.UserID, nil
+}
+
+func (r *ruleRepository) FindOrCreateLabel(ctx context.Context, name string) (*models.Label, error) {
+	return findOrCreateLabel(r.db.WithContext(ctx), name, "")
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestRuleRepository_DueTasksAndExecutions(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &ruleRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "rules", "rules@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+
+	due := &models.Rule{BoardID: board.ID, Name: "due", Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskDue}, Actions: []models.RuleAction{{Type: models.RuleActionComment, Text: "late"}}, Enabled: true}
+	moved := &models.Rule{BoardID: board.ID, Name: "moved", Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskMoved}, Actions: []models.RuleAction{{Type: models.RuleActionComment, Text: "moved"}}, Enabled: true}
+	require.NoError(t, repo.Create(ctx, due))
+	require.NoError(t, repo.Create(ctx, moved))
+
+	rules, err := repo.FindEnabledByTrigger(ctx, models.RuleTriggerTaskDue)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, due.ID, rules[0].ID)
+	assert.Equal(t, "late", rules[0].Actions[0].Text)
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	newTask := func(title string, deadline time.Time) *models.Task {
+		task := &models.Task{ColumnID: column.ID, Title: title, Deadline: &deadline}
+		require.NoError(t, db.Create(task).Error)
+		return task
+	}
+	overdue := newTask("overdue", now.Add(-time.Hour))
+	newTask("upcoming", now.Add(time.Hour))
+	newTask("long overdue", now.AddDate(0, 0, -3))
+
+	tasks, err := repo.FindDueTasks(ctx, board.ID, now.Add(-24*time.Hour), now)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, overdue.ID, tasks[0].ID)
+
+	ran, err := repo.HasExecutionSince(ctx, due.ID, overdue.ID, *overdue.Deadline)
+	require.NoError(t, err)
+	assert.False(t, ran)
+
+	require.NoError(t, repo.CreateExecution(ctx, &models.RuleExecution{RuleID: due.ID, TaskID: overdue.ID, Trigger: models.RuleTriggerTaskDue, Status: models.RuleExecutionSucceeded, Actions: 1}))
+	ran, err = repo.HasExecutionSince(ctx, due.ID, overdue.ID, *overdue.Deadline)
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	executions, err := repo.FindExecutions(ctx, due.ID, 10)
+	require.NoError(t, err)
+	assert.Len(t, executions, 1)
+
+	require.NoError(t, db.Create(&models.TaskActivity{TaskID: overdue.ID, UserID: user.ID, Action: models.ActivityCreated}).Error)
+	creatorID, err := repo.FindTaskCreatorID(ctx, overdue.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, creatorID)
+}
@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type SprintRepository interface {
+	Create(ctx context.Context, sprint *models.Sprint) error
+	FindByID(ctx context.Context, id string) (*models.Sprint, error)
+	FindByBoardID(ctx context.Context, boardID string) ([]*models.Sprint, error)
+	FindActiveByBoardID(ctx context.Context, boardID string) (*models.Sprint, error)
+	FindTasks(ctx context.Context, sprintID string) ([]*models.Task, error)
+	SetTaskSprint(ctx context.Context, taskIDs []string, sprintID *string) error
+	Update(ctx context.Context, sprint *models.Sprint) error
+	Start(ctx context.Context, sprint *models.Sprint) error
+	Complete(ctx context.Context, sprint *models.Sprint, nextSprintID *string) ([]*models.Task, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// Errors returned by Start and Complete when another request changed a
+// sprint first
+var (
+	ErrSprintNotPlanned     = errors.New("sprint is not planned")
+	ErrSprintNotActive      = errors.New("sprint is not active")
+	ErrSprintCompleted      = errors.New("sprint is completed")
+	ErrBoardHasActiveSprint = errors.New("board already has an active sprint")
+)
+
+type sprintRepository struct {
+	db *gorm.DB
+}
+
+func NewSprintRepository() SprintRepository {
+	return &sprintRepository{
+		db: config.DB,
+	}
+}
+
+func (r *sprintRepository) Create(ctx context.Context, sprint *models.Sprint) error {
+	return r.db.WithContext(ctx).Create(sprint).Error
+}
+
+func (r *sprintRepository) FindByID(ctx context.Context, id string) (*models.Sprint, error) {
+	var sprint models.Sprint
+	err := r.db.WithContext(ctx).
+		Preload("Board").
+		Where("id = ?", id).
+		First(&sprint).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sprint, nil
+}
+
+func (r *sprintRepository) FindByBoardID(ctx context.Context, boardID string) ([]*models.Sprint, error) {
+	var sprints []*models.Sprint
+	err := r.db.WithContext(ctx).
+		Where("board_id = ?", boardID).
+		Order("start_date ASC").
+		Find(&sprints).Error
+	if err != nil {
+		return nil, err
+	}
+	return sprints, nil
+}
+
+func (r *sprintRepository) FindActiveByBoardID(ctx context.Context, boardID string) (*models.Sprint, error) {
+	var sprint models.Sprint
+	err := r.db.WithContext(ctx).
+		Where("board_id = ? AND status = ?", boardID, models.SprintStatusActive).
+		First(&sprint).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sprint, nil
+}
+
+// FindTasks returns the tasks currently planned into the sprint with their columns
+func (r *sprintRepository) FindTasks(ctx context.Context, sprintID string) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.WithContext(ctx).
+		Preload("Column").
+		Where("sprint_id = ?", sprintID).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// SetTaskSprint moves tasks into a sprint, or back to the backlog when sprintID is nil
+func (r *sprintRepository) SetTaskSprint(ctx context.Context, taskIDs []string, sprintID *string) error {
+	if len(taskIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Model(&models.Task{}).
+		Where("id IN ?", taskIDs).
+		Update("sprint_id", sprintID).Error
+}
+
+func (r *sprintRepository) Update(ctx context.Context, sprint *models.Sprint) error {
+	return r.db.WithContext(ctx).Omit("Board").Save(sprint).Error
+}
+
+// Start makes the sprint active as of sprint.StartedAt. The status change
+// only applies while the sprint is still planned, so of two concurrent starts
+// one fails with ErrSprintNotPlanned; ErrBoardHasActiveSprint means another
+// sprint of the board became active first.
+func (r *sprintRepository) Start(ctx context.Context, sprint *models.Sprint) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.Sprint{}).
+		Where("id = ? AND status = ?", sprint.ID, models.SprintStatusPlanned).
+		Updates(map[string]interface{}{"status": models.SprintStatusActive, "started_at": sprint.StartedAt})
+	if result.Error != nil {
+		if isUniqueViolation(r.db, result.Error) {
+			return ErrBoardHasActiveSprint
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrSprintNotPlanned
+	}
+	return nil
+}
+
+// Complete marks the sprint completed at sprint.CompletedAt and moves its
+// unfinished tasks to nextSprintID, or to the backlog when it is nil, in one
+// transaction. The status change only applies while the sprint is still
+// active, so of two concurrent completions one fails with ErrSprintNotActive;
+// ErrSprintCompleted means the next sprint was completed in the meantime. The
+// tasks that rolled over are returned with their previous sprint.
+func (r *sprintRepository) Complete(ctx context.Context, sprint *models.Sprint, nextSprintID *string) ([]*models.Task, error) {
+	var unfinished []*models.Task
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Sprint{}).
+			Where("id = ? AND status = ?", sprint.ID, models.SprintStatusActive).
+			Updates(map[string]interface{}{"status": models.SprintStatusCompleted, "completed_at": sprint.CompletedAt})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSprintNotActive
+		}
+
+		// Touching the next sprint locks it until the rollover commits
+		if nextSprintID != nil {
+			result := tx.Model(&models.Sprint{}).
+				Where("id = ? AND status <> ?", *nextSprintID, models.SprintStatusCompleted).
+				Update("updated_at", sprint.CompletedAt)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return ErrSprintCompleted
+			}
+		}
+
+		var tasks []*models.Task
+		if err := tx.Preload("Column").Where("sprint_id = ?", sprint.ID).Find(&tasks).Error; err != nil {
+			return err
+		}
+
+		taskIDs := make([]string, 0, len(tasks))
+		for _, task := range tasks {
+			if task.Column == nil || !task.Column.IsDone() {
+				unfinished = append(unfinished, task)
+				taskIDs = append(taskIDs, task.ID)
+			}
+		}
+		if len(taskIDs) == 0 {
+			return nil
+		}
+
+		return tx.Model(&models.Task{}).Where("id IN ?", taskIDs).Update("sprint_id", nextSprintID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return unfinished, nil
+}
+
+// Delete removes the sprint and returns its tasks to the backlog
+func (r *sprintRepository) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Task{}).Where("sprint_id = ?", id).Update("sprint_id", nil).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&models.Sprint{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("sprint with id %s not found", id)
+		}
+		return nil
+	})
+}
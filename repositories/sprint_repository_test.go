@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"kanban-backend/models"
+)
+
+func createTestSprint(db *gorm.DB, boardID, status string) *models.Sprint {
+	sprint := &models.Sprint{
+		BoardID:   boardID,
+		Name:      "Sprint",
+		StartDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC),
+		Status:    status,
+	}
+	db.Create(sprint)
+	return sprint
+}
+
+func TestSprintRepository_FindActiveByBoardID(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &sprintRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	createTestSprint(db, board.ID, models.SprintStatusPlanned)
+
+	_, err := repo.FindActiveByBoardID(ctx, board.ID)
+	assert.Error(t, err)
+
+	active := createTestSprint(db, board.ID, models.SprintStatusActive)
+	found, err := repo.FindActiveByBoardID(ctx, board.ID)
+	require.NoError(t, err)
+	assert.Equal(t, active.ID, found.ID)
+
+	sprints, err := repo.FindByBoardID(ctx, board.ID)
+	require.NoError(t, err)
+	assert.Len(t, sprints, 2)
+}
+
+func TestSprintRepository_Start(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	require.NoError(t, db.Exec("CREATE UNIQUE INDEX idx_sprints_one_active ON sprints(board_id) WHERE status = 'active' AND deleted_at IS NULL").Error)
+	repo := &sprintRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	first := createTestSprint(db, board.ID, models.SprintStatusPlanned)
+	second := createTestSprint(db, board.ID, models.SprintStatusPlanned)
+
+	now := time.Now().UTC()
+	first.StartedAt = &now
+	require.NoError(t, repo.Start(ctx, first))
+	assert.ErrorIs(t, repo.Start(ctx, first), ErrSprintNotPlanned)
+
+	second.StartedAt = &now
+	assert.ErrorIs(t, repo.Start(ctx, second), ErrBoardHasActiveSprint)
+
+	var planned, active models.Sprint
+	require.NoError(t, db.First(&planned, "id = ?", second.ID).Error)
+	assert.Equal(t, models.SprintStatusPlanned, planned.Status)
+	require.NoError(t, db.First(&active, "id = ?", first.ID).Error)
+	assert.Equal(t, models.SprintStatusActive, active.Status)
+	assert.NotNil(t, active.StartedAt)
+}
+
+func TestSprintRepository_TaskMembership(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &sprintRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+	sprint := createTestSprint(db, board.ID, models.SprintStatusPlanned)
+
+	first := &models.Task{ColumnID: column.ID, Title: "First"}
+	second := &models.Task{ColumnID: column.ID, Title: "Second"}
+	require.NoError(t, db.Create(first).Error)
+	require.NoError(t, db.Create(second).Error)
+
+	require.NoError(t, repo.SetTaskSprint(ctx, []string{first.ID, second.ID}, &sprint.ID))
+
+	tasks, err := repo.FindTasks(ctx, sprint.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 2)
+	assert.NotNil(t, tasks[0].Column)
+
+	require.NoError(t, repo.Delete(ctx, sprint.ID))
+
+	var reloaded models.Task
+	require.NoError(t, db.First(&reloaded, "id = ?", first.ID).Error)
+	assert.Nil(t, reloaded.SprintID)
+
+	assert.Error(t, repo.Delete(ctx, sprint.ID))
+
+	var deleted models.Sprint
+	require.NoError(t, db.Unscoped().First(&deleted, "id = ?", sprint.ID).Error)
+	assert.True(t, deleted.DeletedAt.Valid, "sprints are soft deleted like the rest of the board")
+}
+
+func TestSprintRepository_Complete(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &sprintRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	doing := createTestColumn(db, board.ID)
+	done := createTestColumn(db, board.ID)
+	require.NoError(t, db.Model(done).Update("stage", models.ColumnStageDone).Error)
+
+	current := createTestSprint(db, board.ID, models.SprintStatusActive)
+	next := createTestSprint(db, board.ID, models.SprintStatusPlanned)
+	finished := &models.Task{ColumnID: done.ID, Title: "Finished", SprintID: &current.ID}
+	open := &models.Task{ColumnID: doing.ID, Title: "Open", SprintID: &current.ID}
+	require.NoError(t, db.Create(finished).Error)
+	require.NoError(t, db.Create(open).Error)
+
+	completedAt := time.Now().UTC()
+	completed := *current
+	completed.CompletedAt = &completedAt
+
+	unfinished, err := repo.Complete(ctx, &completed, &next.ID)
+	require.NoError(t, err)
+	require.Len(t, unfinished, 1)
+	assert.Equal(t, open.ID, unfinished[0].ID)
+	assert.Equal(t, current.ID, *unfinished[0].SprintID, "rolled over tasks keep their previous sprint")
+
+	var reloaded models.Sprint
+	require.NoError(t, db.First(&reloaded, "id = ?", current.ID).Error)
+	assert.Equal(t, models.SprintStatusCompleted, reloaded.Status)
+	assert.NotNil(t, reloaded.CompletedAt)
+
+	var moved models.Task
+	require.NoError(t, db.First(&moved, "id = ?", open.ID).Error)
+	assert.Equal(t, next.ID, *moved.SprintID)
+	var kept models.Task
+	require.NoError(t, db.First(&kept, "id = ?", finished.ID).Error)
+	assert.Equal(t, current.ID, *kept.SprintID)
+
+	// A second completion, e.g. a concurrent request, changes nothing
+	_, err = repo.Complete(ctx, &completed, nil)
+	assert.ErrorIs(t, err, ErrSprintNotActive)
+	var unchanged models.Task
+	require.NoError(t, db.First(&unchanged, "id = ?", open.ID).Error)
+	assert.Equal(t, next.ID, *unchanged.SprintID)
+
+	// Rolling over into a sprint completed in the meantime undoes the completion
+	other := createTestSprint(db, board.ID, models.SprintStatusActive)
+	otherCompleted := *other
+	otherCompleted.CompletedAt = &completedAt
+	_, err = repo.Complete(ctx, &otherCompleted, &current.ID)
+	assert.ErrorIs(t, err, ErrSprintCompleted)
+	var stillActive models.Sprint
+	require.NoError(t, db.First(&stillActive, "id = ?", other.ID).Error)
+	assert.Equal(t, models.SprintStatusActive, stillActive.Status)
+}
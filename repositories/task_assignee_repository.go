@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskAssigneeRepository interface {
+	Add(ctx context.Context, taskID, userID string) error
+	Remove(ctx context.Context, taskID, userID string) error
+	FindByTaskID(ctx context.Context, taskID string) ([]*models.TaskAssignee, error)
+}
+
+type taskAssigneeRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskAssigneeRepository() TaskAssigneeRepository {
+	return &taskAssigneeRepository{
+		db: config.DB,
+	}
+}
+
+func (r *taskAssigneeRepository) Add(ctx context.Context, taskID, userID string) error {
+	assignee := &models.TaskAssignee{
+		TaskID: taskID,
+		UserID: userID,
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(assignee).Error
+}
+
+func (r *taskAssigneeRepository) Remove(ctx context.Context, taskID, userID string) error {
+	result := r.db.WithContext(ctx).Where("task_id = ? AND user_id = ?", taskID, userID).Delete(&models.TaskAssignee{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user %s is not assigned to task %s", userID, taskID)
+	}
+	return nil
+}
+
+func (r *taskAssigneeRepository) FindByTaskID(ctx context.Context, taskID string) ([]*models.TaskAssignee, error) {
+	var assignees []*models.TaskAssignee
+	err := r.db.WithContext(ctx).
+		Where("task_id = ?", taskID).
+		Order("created_at ASC").
+		Find(&assignees).Error
+	if err != nil {
+		return nil, err
+	}
+	return assignees, nil
+}
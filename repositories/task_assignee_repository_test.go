@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestTaskAssigneeRepository_AddAndRemove(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &taskAssigneeRepository{db: db}
+	ctx := context.Background()
+
+	owner := createTestUser(db, "owner", "owner@example.com")
+	assignee := createTestUser(db, "assignee", "assignee@example.com")
+	board := createTestBoard(db, owner.ID)
+	column := createTestColumn(db, board.ID)
+
+	task := &models.Task{ColumnID: column.ID, Title: "Task"}
+	require.NoError(t, db.Create(task).Error)
+
+	require.NoError(t, repo.Add(ctx, task.ID, assignee.ID))
+	require.NoError(t, repo.Add(ctx, task.ID, assignee.ID), "adding twice should be a no-op")
+
+	assignees, err := repo.FindByTaskID(ctx, task.ID)
+	require.NoError(t, err)
+	require.Len(t, assignees, 1)
+	assert.Equal(t, assignee.ID, assignees[0].UserID)
+
+	require.NoError(t, repo.Remove(ctx, task.ID, assignee.ID))
+	assert.Error(t, repo.Remove(ctx, task.ID, assignee.ID))
+
+	assignees, err = repo.FindByTaskID(ctx, task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, assignees)
+}
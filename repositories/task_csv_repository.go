@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskCSVRepository interface {
+	FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error)
+	CreateTasks(ctx context.Context, tasks []*models.Task) error
+}
+
+type taskCSVRepository struct {
+	db *gorm.DB
+}
+
+func NewTaskCSVRepository() TaskCSVRepository {
+	return &taskCSVRepository{
+		db: config.DB,
+	}
+}
+
+// FindBoardTasks returns the board's unarchived tasks in board order with
+// their column, labels and assignees
+func (r *taskCSVRepository) FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.WithContext(ctx).
+		Preload("Column").
+		Preload("Labels").
+		Preload("Assignees").
+		Joins("JOIN columns ON columns.id = tasks.column_id").
+		Where("columns.board_id = ? AND columns.deleted_at IS NULL AND tasks.archived_at IS NULL", boardID).
+		Order("columns.order_num ASC, tasks.created_at ASC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// CreateTasks creates all tasks in one transaction. Labels are matched by name
+// and created when missing, so only their Name and Color are read. Assignees
+// are linked by user ID.
+func (r *taskCSVRepository) CreateTasks(ctx context.Context, tasks []*models.Task) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		labels := make(map[string]*models.Label)
+		for _, task := range tasks {
+			if err := tx.Omit(clause.Associations).Create(task).Error; err != nil {
+				return err
+			}
+
+			// Append fills task.Labels with the stored labels again
+			wantedLabels := task.Labels
+			task.Labels = nil
+			for _, wanted := range wantedLabels {
+				label, ok := labels[wanted.Name]
+				if !ok {
+					var err error
+					if label, err = findOrCreateLabel(tx, wanted.Name, wanted.Color); err != nil {
+						return err
+					}
+					labels[wanted.Name] = label
+				}
+				if err := tx.Model(task).Association("Labels").Append(label); err != nil {
+					return err
+				}
+			}
+
+			for i := range task.Assignees {
+				task.Assignees[i].TaskID = task.ID
+				if err := tx.Create(&task.Assignees[i]).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
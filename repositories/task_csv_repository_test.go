@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestTaskCSVRepository_CreateTasks(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &taskCSVRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	assignee := createTestUser(db, "dev", "dev@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+	existing := &models.Label{Name: "Bug", Color: "#ff0000"}
+	require.NoError(t, db.Create(existing).Error)
+
+	tasks := []*models.Task{
+		{
+			ColumnID:  column.ID,
+			Title:     "Fix login",
+			Labels:    []models.Label{{Name: "Bug"}, {Name: "Urgent"}},
+			Assignees: []models.TaskAssignee{{UserID: assignee.ID}},
+		},
+		{ColumnID: column.ID, Title: "Write docs", Labels: []models.Label{{Name: "Urgent"}}},
+	}
+	require.NoError(t, repo.CreateTasks(ctx, tasks))
+
+	var labelCount int64
+	db.Model(&models.Label{}).Count(&labelCount)
+	assert.Equal(t, int64(2), labelCount, "labels should be matched by name")
+
+	now := time.Now()
+	archived := &models.Task{ColumnID: column.ID, Title: "Old", ArchivedAt: &now}
+	require.NoError(t, db.Create(archived).Error)
+
+	found, err := repo.FindBoardTasks(ctx, board.ID)
+	require.NoError(t, err)
+	require.Len(t, found, 2, "archived tasks are not exported")
+
+	byTitle := map[string]*models.Task{found[0].Title: found[0], found[1].Title: found[1]}
+	fix := byTitle["Fix login"]
+	require.NotNil(t, fix)
+	require.Len(t, fix.Labels, 2)
+	assert.Contains(t, []string{fix.Labels[0].ID, fix.Labels[1].ID}, existing.ID)
+	require.Len(t, fix.Assignees, 1)
+	assert.Equal(t, assignee.ID, fix.Assignees[0].UserID)
+}
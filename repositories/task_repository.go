@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"kanban-backend/config"
 	"kanban-backend/models"
@@ -10,11 +12,16 @@ import (
 	"gorm.io/gorm"
 )
 
+// ErrAmbiguousTaskKey is returned when a task key matches more than one of the
+// user's tasks
+var ErrAmbiguousTaskKey = errors.New("task key matches more than one task")
+
 type TaskRepository interface {
 	Create(ctx context.Context, task *models.Task) error
 	FindByID(ctx context.Context, id string) (*models.Task, error)
+	FindByKey(ctx context.Context, userID, key string) (*models.Task, error)
 	FindByColumnID(ctx context.Context, columnID string) ([]*models.Task, error)
-	FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, page, limit int) ([]*models.Task, int, error)
+	FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, archived bool, page, limit int) ([]*models.Task, int, error)
 	Search(ctx context.Context, boardID string, keyword string, page, limit int) ([]*models.Task, int, error)
 	Update(ctx context.Context, task *models.Task) error
 	Delete(ctx context.Context, id string) error
@@ -35,14 +42,24 @@ func (r *taskRepository) Create(ctx context.Context, task *models.Task) error {
 	return r.db.WithContext(ctx).Create(task).Error
 }
 
+// onLiveBoard limits task queries to tasks whose column and board have not
+// been deleted, so a board in the trash takes its tasks with it
+func onLiveBoard(db *gorm.DB) *gorm.DB {
+	return db.
+		Joins("JOIN columns ON columns.id = tasks.column_id AND columns.deleted_at IS NULL").
+		Joins("JOIN boards ON boards.id = columns.board_id AND boards.deleted_at IS NULL")
+}
+
 func (r *taskRepository) FindByID(ctx context.Context, id string) (*models.Task, error) {
 	var task models.Task
 	err := r.db.WithContext(ctx).
+		Scopes(onLiveBoard).
 		Preload("Comments").
 		Preload("Labels").
 		Preload("Attachments").
+		Preload("Assignees").
 		Preload("Column.Board").
-		Where("id = ?", id).
+		Where("tasks.id = ?", id).
 		First(&task).Error
 	if err != nil {
 		return nil, err
@@ -50,14 +67,44 @@ func (r *taskRepository) FindByID(ctx context.Context, id string) (*models.Task,
 	return &task, nil
 }
 
+// FindByKey finds a task by its key among the boards the user owns. Prefixes
+// are unique per owner and numbers per board, so a key names at most one task;
+// if more than one matches anyway, ErrAmbiguousTaskKey is returned rather than
+// guessing.
+func (r *taskRepository) FindByKey(ctx context.Context, userID, key string) (*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.WithContext(ctx).
+		Scopes(onLiveBoard).
+		Preload("Comments").
+		Preload("Labels").
+		Preload("Attachments").
+		Preload("Assignees").
+		Preload("Column.Board").
+		Where("tasks.key = ? AND boards.user_id = ?", key, userID).
+		Limit(2).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	switch len(tasks) {
+	case 0:
+		return nil, gorm.ErrRecordNotFound
+	case 1:
+		return tasks[0], nil
+	}
+	return nil, ErrAmbiguousTaskKey
+}
+
 func (r *taskRepository) FindByColumnID(ctx context.Context, columnID string) ([]*models.Task, error) {
 	var tasks []*models.Task
 	err := r.db.WithContext(ctx).
+		Scopes(onLiveBoard).
 		Preload("Comments").
 		Preload("Labels").
 		Preload("Attachments").
+		Preload("Assignees").
 		Preload("Column.Board").
-		Where("column_id = ?", columnID).
+		Where("tasks.column_id = ? AND tasks.archived_at IS NULL", columnID).
 		Find(&tasks).Error
 	if err != nil {
 		return nil, err
@@ -91,14 +138,22 @@ func (r *taskRepository) SoftDelete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *taskRepository) FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, page, limit int) ([]*models.Task, int, error) {
+// FindByColumnIDWithFilters lists the column's tasks; archived selects archived
+// tasks instead of the active ones
+func (r *taskRepository) FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, archived bool, page, limit int) ([]*models.Task, int, error) {
 	var tasks []*models.Task
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&models.Task{}).Where("column_id = ?", columnID)
+	query := r.db.WithContext(ctx).Model(&models.Task{}).Scopes(onLiveBoard).Where("tasks.column_id = ?", columnID)
+
+	if archived {
+		query = query.Where("tasks.archived_at IS NOT NULL")
+	} else {
+		query = query.Where("tasks.archived_at IS NULL")
+	}
 
 	if title != "" {
-		query = query.Where("title ILIKE ?", "%"+title+"%")
+		query = query.Where("tasks.title ILIKE ?", "%"+title+"%")
 	}
 
 	query.Count(&total)
@@ -107,6 +162,7 @@ func (r *taskRepository) FindByColumnIDWithFilters(ctx context.Context, columnID
 	err := query.Preload("Comments").
 		Preload("Labels").
 		Preload("Attachments").
+		Preload("Assignees").
 		Preload("Column.Board").
 		Offset(offset).
 		Limit(limit).
@@ -120,10 +176,9 @@ func (r *taskRepository) Search(ctx context.Context, boardID string, keyword str
 	var total int64
 
 	query := r.db.WithContext(ctx).
-		Joins("JOIN columns ON columns.id = tasks.column_id").
-		Joins("JOIN boards ON boards.id = columns.board_id").
-		Where("boards.id = ?", boardID).
-		Where("tasks.title ILIKE ? OR tasks.description ILIKE ?", "%"+keyword+"%", "%"+keyword+"%")
+		Scopes(onLiveBoard).
+		Where("boards.id = ? AND tasks.archived_at IS NULL", boardID).
+		Where("tasks.title ILIKE ? OR tasks.description ILIKE ? OR tasks.key = ?", "%"+keyword+"%", "%"+keyword+"%", strings.ToUpper(strings.TrimSpace(keyword)))
 
 	query.Model(&models.Task{}).Count(&total)
 
@@ -131,6 +186,7 @@ func (r *taskRepository) Search(ctx context.Context, boardID string, keyword str
 	err := query.Preload("Comments").
 		Preload("Labels").
 		Preload("Attachments").
+		Preload("Assignees").
 		Preload("Column.Board").
 		Offset(offset).
 		Limit(limit).
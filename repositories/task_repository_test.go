@@ -390,3 +390,98 @@ func TestTaskRepository_Context(t *testing.T) {
 	_, err = repo.FindByID(ctx, testTask.ID)
 	assert.Error(t, err)
 }
+
+func TestTaskRepository_FindByColumnIDWithFilters_Archived(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &taskRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "testuser", "test@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+
+	archivedAt := time.Now().UTC()
+	active := &models.Task{ColumnID: column.ID, Title: "Active"}
+	archived := &models.Task{ColumnID: column.ID, Title: "Archived", ArchivedAt: &archivedAt}
+	require.NoError(t, repo.Create(ctx, active))
+	require.NoError(t, repo.Create(ctx, archived))
+
+	tasks, total, err := repo.FindByColumnIDWithFilters(ctx, column.ID, "", false, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, active.ID, tasks[0].ID)
+
+	tasks, total, err = repo.FindByColumnIDWithFilters(ctx, column.ID, "", true, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, archived.ID, tasks[0].ID)
+
+	found, err := repo.FindByID(ctx, archived.ID)
+	require.NoError(t, err, "archived tasks stay reachable by id")
+	assert.NotNil(t, found.ArchivedAt)
+}
+
+func TestTaskRepository_TaskKeys(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &taskRepository{db: db}
+	boardRepo := &boardRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "testuser", "test@example.com")
+	board := &models.Board{Title: "Ops team", UserID: user.ID, Color: "#000000"}
+	require.NoError(t, boardRepo.Create(ctx, board))
+	assert.Equal(t, "OPS", board.TaskKeyPrefix)
+	todo := createTestColumn(db, board.ID)
+	done := createTestColumn(db, board.ID)
+
+	// A stale copy of the board must not rewind the counter when saved
+	stale, err := boardRepo.FindByID(ctx, board.ID)
+	require.NoError(t, err)
+
+	first := &models.Task{ColumnID: todo.ID, Title: "First"}
+	second := &models.Task{ColumnID: done.ID, Title: "Second"}
+	require.NoError(t, repo.Create(ctx, first))
+	require.NoError(t, repo.Create(ctx, second))
+	assert.Equal(t, 1, first.Number)
+	assert.Equal(t, "OPS-1", first.Key)
+	assert.Equal(t, "OPS-2", second.Key, "numbers run across the board's columns")
+
+	stale.Title = "Operations"
+	require.NoError(t, boardRepo.Update(ctx, stale))
+
+	third := &models.Task{ColumnID: todo.ID, Title: "Third"}
+	require.NoError(t, repo.Create(ctx, third))
+	assert.Equal(t, "OPS-3", third.Key)
+
+	// Deleted tasks keep their number
+	require.NoError(t, repo.SoftDelete(ctx, third.ID))
+	fourth := &models.Task{ColumnID: todo.ID, Title: "Fourth"}
+	require.NoError(t, repo.Create(ctx, fourth))
+	assert.Equal(t, "OPS-4", fourth.Key)
+
+	found, err := repo.FindByKey(ctx, user.ID, "OPS-2")
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, found.ID)
+
+	other := createTestUser(db, "other", "other@example.com")
+	_, err = repo.FindByKey(ctx, other.ID, "OPS-2")
+	assert.Error(t, err, "keys only resolve on the user's own boards")
+
+	require.NoError(t, boardRepo.UpdateTaskKeyPrefix(ctx, board.ID, "INFRA"))
+	found, err = repo.FindByKey(ctx, user.ID, "INFRA-2")
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, found.ID)
+	_, err = repo.FindByKey(ctx, user.ID, "OPS-2")
+	assert.Error(t, err)
+
+	var trashed models.Task
+	require.NoError(t, db.Unscoped().First(&trashed, "id = ?", third.ID).Error)
+	assert.Equal(t, "INFRA-3", trashed.Key, "trashed tasks are re-keyed too")
+
+	// A key matching two tasks is reported rather than resolved to either
+	require.NoError(t, db.Model(fourth).Update("key", "INFRA-2").Error)
+	_, err = repo.FindByKey(ctx, user.ID, "INFRA-2")
+	assert.ErrorIs(t, err, ErrAmbiguousTaskKey)
+}
@@ -15,7 +15,7 @@ func setupRepositoryTestDB(t *testing.T) *gorm.DB {
 		t.Fatal(err)
 	}
 
-	err = db.AutoMigrate(&models.User{}, &models.Board{}, &models.Member{}, &models.Column{}, &models.Task{}, &models.RefreshToken{}, &models.Comment{}, &models.Label{}, &models.Attachment{})
+	err = db.AutoMigrate(&models.User{}, &models.Board{}, &models.Member{}, &models.Column{}, &models.Task{}, &models.RefreshToken{}, &models.Comment{}, &models.Label{}, &models.Attachment{}, &models.TaskAssignee{}, &models.TaskReminder{}, &models.Notification{}, &models.Watch{}, &models.TaskActivity{}, &models.Sprint{}, &models.CustomField{}, &models.BoardTemplate{}, &models.ImportJob{}, &models.CalendarToken{}, &models.Webhook{}, &models.WebhookDelivery{}, &models.Rule{}, &models.RuleExecution{}, &models.GitIntegration{}, &models.BoardInbox{}, &models.NotificationPreference{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,240 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+// TrashRepository finds and restores soft-deleted boards, tasks, comments and
+// attachments, and purges them for good once they have been in the trash long
+// enough
+type TrashRepository interface {
+	FindBoards(ctx context.Context, userID string) ([]*models.Board, error)
+	FindTasks(ctx context.Context, userID string) ([]*models.Task, error)
+	FindComments(ctx context.Context, userID string) ([]*models.Comment, error)
+	FindAttachments(ctx context.Context, userID string) ([]*models.Attachment, error)
+	FindBoard(ctx context.Context, id string) (*models.Board, error)
+	FindTask(ctx context.Context, id string) (*models.Task, error)
+	FindComment(ctx context.Context, id string) (*models.Comment, error)
+	FindAttachment(ctx context.Context, id string) (*models.Attachment, error)
+	Restore(ctx context.Context, model interface{}, id string) error
+	Purge(ctx context.Context, before time.Time) (int64, []string, error)
+}
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository() TrashRepository {
+	return &trashRepository{
+		db: config.DB,
+	}
+}
+
+// FindBoards returns the user's deleted boards, most recently deleted first
+func (r *trashRepository) FindBoards(ctx context.Context, userID string) ([]*models.Board, error) {
+	var boards []*models.Board
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID).
+		Order("deleted_at DESC").
+		Find(&boards).Error
+	if err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
+// FindTasks returns deleted tasks on the user's boards. Tasks on a deleted
+// board are left out; they come back with the board.
+func (r *trashRepository) FindTasks(ctx context.Context, userID string) ([]*models.Task, error) {
+	var tasks []*models.Task
+	err := r.db.WithContext(ctx).Unscoped().
+		Scopes(onLiveBoard).
+		Where("boards.user_id = ? AND tasks.deleted_at IS NOT NULL", userID).
+		Order("tasks.deleted_at DESC").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// FindComments returns deleted comments on live tasks of the user's boards
+func (r *trashRepository) FindComments(ctx context.Context, userID string) ([]*models.Comment, error) {
+	var comments []*models.Comment
+	err := r.db.WithContext(ctx).Unscoped().
+		Joins("JOIN tasks ON tasks.id = comments.task_id AND tasks.deleted_at IS NULL").
+		Scopes(onLiveBoard).
+		Where("boards.user_id = ? AND comments.deleted_at IS NOT NULL", userID).
+		Order("comments.deleted_at DESC").
+		Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// FindAttachments returns deleted attachments on live tasks of the user's boards
+func (r *trashRepository) FindAttachments(ctx context.Context, userID string) ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+	err := r.db.WithContext(ctx).Unscoped().
+		Joins("JOIN tasks ON tasks.id = attachments.task_id AND tasks.deleted_at IS NULL").
+		Scopes(onLiveBoard).
+		Where("boards.user_id = ? AND attachments.deleted_at IS NOT NULL", userID).
+		Order("attachments.deleted_at DESC").
+		Find(&attachments).Error
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *trashRepository) FindBoard(ctx context.Context, id string) (*models.Board, error) {
+	var board models.Board
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ?", id).
+		First(&board).Error
+	if err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// FindTask returns the task whether or not it is deleted, with its column and
+// board loaded even when those are deleted
+func (r *trashRepository) FindTask(ctx context.Context, id string) (*models.Task, error) {
+	unscoped := func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}
+
+	var task models.Task
+	err := r.db.WithContext(ctx).Unscoped().
+		Preload("Column", unscoped).
+		Preload("Column.Board", unscoped).
+		Where("id = ?", id).
+		First(&task).Error
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+func (r *trashRepository) FindComment(ctx context.Context, id string) (*models.Comment, error) {
+	var comment models.Comment
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ?", id).
+		First(&comment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *trashRepository) FindAttachment(ctx context.Context, id string) (*models.Attachment, error) {
+	var attachment models.Attachment
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ?", id).
+		First(&attachment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// Restore clears deleted_at on the row of model's table with the given id
+func (r *trashRepository) Restore(ctx context.Context, model interface{}, id string) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Model(model).
+		Where("id = ?", id).
+		Update("deleted_at", nil).Error
+}
+
+// Purge permanently deletes everything that was moved to the trash before the
+// given time and returns how many rows were removed. Rows belonging to a purged
+// board or task are removed by the foreign key cascades. It also returns the
+// file URLs of purged attachments that no remaining attachment uses; duplicated
+// and imported boards share files, so a URL still referenced elsewhere is kept.
+func (r *trashRepository) Purge(ctx context.Context, before time.Time) (int64, []string, error) {
+	var purged int64
+	var orphaned []string
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if orphaned, err = r.orphanedFiles(tx, before); err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{&models.Comment{}, &models.Attachment{}, &models.Task{}, &models.Board{}} {
+			result := tx.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(model)
+			if result.Error != nil {
+				return result.Error
+			}
+			purged += result.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return purged, orphaned, nil
+}
+
+// orphanedFiles returns the file URLs of the attachments a purge up to before
+// removes, directly or with their task or board, that no other attachment row,
+// trashed or not, refers to
+func (r *trashRepository) orphanedFiles(tx *gorm.DB, before time.Time) ([]string, error) {
+	expired := func(table string) *gorm.DB {
+		return tx.Unscoped().Table(table).Select("id").Where("deleted_at IS NOT NULL AND deleted_at < ?", before)
+	}
+	purgedTasks := tx.Unscoped().Model(&models.Task{}).Select("id").
+		Where("(deleted_at IS NOT NULL AND deleted_at < ?) OR column_id IN (?)", before,
+			tx.Unscoped().Model(&models.Column{}).Select("id").Where("board_id IN (?)", expired("boards")))
+
+	var purged []*models.Attachment
+	err := tx.Unscoped().
+		Select("id", "file_url").
+		Where("id IN (?) OR task_id IN (?)", expired("attachments"), purgedTasks).
+		Find(&purged).Error
+	if err != nil || len(purged) == 0 {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(purged))
+	urls := make(map[string]bool, len(purged))
+	for _, attachment := range purged {
+		ids = append(ids, attachment.ID)
+		urls[attachment.FileURL] = true
+	}
+
+	candidates := make([]string, 0, len(urls))
+	for url := range urls {
+		candidates = append(candidates, url)
+	}
+
+	var shared []string
+	err = tx.Unscoped().
+		Model(&models.Attachment{}).
+		Where("file_url IN ? AND id NOT IN ?", candidates, ids).
+		Distinct().
+		Pluck("file_url", &shared).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, url := range shared {
+		delete(urls, url)
+	}
+
+	orphaned := make([]string, 0, len(urls))
+	for _, url := range candidates {
+		if urls[url] {
+			orphaned = append(orphaned, url)
+		}
+	}
+	return orphaned, nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestTrashRepository_FindAndRestore(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &trashRepository{db: db}
+	taskRepo := &taskRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+
+	kept := &models.Task{ColumnID: column.ID, Title: "Kept"}
+	trashed := &models.Task{ColumnID: column.ID, Title: "Trashed"}
+	require.NoError(t, db.Create(kept).Error)
+	require.NoError(t, db.Create(trashed).Error)
+	comment := &models.Comment{TaskID: kept.ID, UserID: user.ID, Content: "Oops"}
+	require.NoError(t, db.Create(comment).Error)
+
+	require.NoError(t, taskRepo.SoftDelete(ctx, trashed.ID))
+	require.NoError(t, db.Delete(comment).Error)
+
+	tasks, err := repo.FindTasks(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, trashed.ID, tasks[0].ID)
+	assert.True(t, tasks[0].DeletedAt.Valid)
+
+	comments, err := repo.FindComments(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+
+	others, err := repo.FindTasks(ctx, createTestUser(db, "other", "other@example.com").ID)
+	require.NoError(t, err)
+	assert.Empty(t, others)
+
+	require.NoError(t, repo.Restore(ctx, &models.Task{}, trashed.ID))
+	restored, err := taskRepo.FindByID(ctx, trashed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Trashed", restored.Title)
+
+	// Deleting the board hides its tasks without trashing them one by one
+	require.NoError(t, (&boardRepository{db: db}).SoftDelete(ctx, board.ID))
+	_, err = taskRepo.FindByID(ctx, kept.ID)
+	assert.Error(t, err)
+
+	boards, err := repo.FindBoards(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, boards, 1)
+	tasks, err = repo.FindTasks(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, tasks)
+
+	task, err := repo.FindTask(ctx, kept.ID)
+	require.NoError(t, err)
+	require.NotNil(t, task.Column)
+	require.NotNil(t, task.Column.Board)
+	assert.True(t, task.Column.Board.DeletedAt.Valid)
+
+	require.NoError(t, repo.Restore(ctx, &models.Board{}, board.ID))
+	_, err = taskRepo.FindByID(ctx, kept.ID)
+	assert.NoError(t, err)
+}
+
+func TestTrashRepository_Purge(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &trashRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+
+	old := &models.Task{ColumnID: column.ID, Title: "Old"}
+	recent := &models.Task{ColumnID: column.ID, Title: "Recent"}
+	live := &models.Task{ColumnID: column.ID, Title: "Live"}
+	for _, task := range []*models.Task{old, recent, live} {
+		require.NoError(t, db.Create(task).Error)
+	}
+
+	now := time.Now()
+	require.NoError(t, db.Unscoped().Model(old).Update("deleted_at", now.AddDate(0, 0, -40)).Error)
+	require.NoError(t, db.Unscoped().Model(recent).Update("deleted_at", now.AddDate(0, 0, -5)).Error)
+
+	purged, _, err := repo.Purge(ctx, now.AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	var remaining int64
+	db.Unscoped().Model(&models.Task{}).Count(&remaining)
+	assert.Equal(t, int64(2), remaining)
+
+	_, err = repo.FindTask(ctx, old.ID)
+	assert.Error(t, err)
+}
+
+func TestTrashRepository_Purge_OrphanedFiles(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &trashRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "owner", "owner@example.com")
+	board := createTestBoard(db, user.ID)
+	column := createTestColumn(db, board.ID)
+	copyBoard := createTestBoard(db, user.ID)
+	copyColumn := createTestColumn(db, copyBoard.ID)
+
+	live := &models.Task{ColumnID: column.ID, Title: "Live"}
+	old := &models.Task{ColumnID: column.ID, Title: "Old"}
+	copied := &models.Task{ColumnID: copyColumn.ID, Title: "Copied"}
+	for _, task := range []*models.Task{live, old, copied} {
+		require.NoError(t, db.Create(task).Error)
+	}
+
+	attach := func(taskID, url string) *models.Attachment {
+		attachment := &models.Attachment{TaskID: taskID, FileName: "file", FileURL: url}
+		require.NoError(t, db.Create(attachment).Error)
+		return attachment
+	}
+	trashed := attach(live.ID, "https://files/trashed.pdf")
+	attach(old.ID, "https://files/on-old-task.pdf")
+	attach(old.ID, "https://files/shared.pdf")
+	attach(copied.ID, "https://files/shared.pdf")
+	attach(copied.ID, "https://files/on-old-board.pdf")
+	attach(live.ID, "https://files/live.pdf")
+
+	now := time.Now()
+	purgedAt := now.AddDate(0, 0, -40)
+	require.NoError(t, db.Unscoped().Model(trashed).Update("deleted_at", purgedAt).Error)
+	require.NoError(t, db.Unscoped().Model(old).Update("deleted_at", purgedAt).Error)
+	require.NoError(t, db.Unscoped().Model(copyBoard).Update("deleted_at", purgedAt).Error)
+
+	// The shared file stays while the copy on the other board is kept
+	keep := attach(live.ID, "https://files/shared.pdf")
+
+	_, orphaned, err := repo.Purge(ctx, now.AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"https://files/trashed.pdf",
+		"https://files/on-old-task.pdf",
+		"https://files/on-old-board.pdf",
+	}, orphaned)
+
+	var kept models.Attachment
+	require.NoError(t, db.First(&kept, "id = ?", keep.ID).Error)
+}
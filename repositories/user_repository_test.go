@@ -127,6 +127,27 @@ func TestUserRepository_FindByEmail(t *testing.T) {
 	}
 }
 
+func TestUserRepository_FindByEmailIgnoreCase(t *testing.T) {
+	db := setupUserRepositoryTestDB(t)
+	repo := &userRepository{db: db}
+
+	ctx := context.Background()
+
+	testUser := &models.User{
+		Username: "testuser",
+		Email:    "Test@Example.com",
+		Password: "password123",
+	}
+	require.NoError(t, repo.Create(ctx, testUser))
+
+	user, err := repo.FindByEmailIgnoreCase(ctx, "test@example.COM")
+	require.NoError(t, err)
+	assert.Equal(t, testUser.ID, user.ID)
+
+	_, err = repo.FindByEmail(ctx, "test@example.com")
+	assert.Error(t, err, "FindByEmail stays exact")
+}
+
 func TestUserRepository_FindByID(t *testing.T) {
 	db := setupUserRepositoryTestDB(t)
 	repo := &userRepository{db: db}
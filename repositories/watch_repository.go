@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WatchRepository interface {
+	Add(ctx context.Context, userID, targetType, targetID string) error
+	Remove(ctx context.Context, userID, targetType, targetID string) error
+	FindByUserID(ctx context.Context, userID string) ([]*models.Watch, error)
+	FindWatcherIDs(ctx context.Context, taskID, boardID string) ([]string, error)
+}
+
+type watchRepository struct {
+	db *gorm.DB
+}
+
+func NewWatchRepository() WatchRepository {
+	return &watchRepository{
+		db: config.DB,
+	}
+}
+
+func (r *watchRepository) Add(ctx context.Context, userID, targetType, targetID string) error {
+	watch := &models.Watch{
+		UserID:     userID,
+		TargetType: targetType,
+		TargetID:   targetID,
+	}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(watch).Error
+}
+
+func (r *watchRepository) Remove(ctx context.Context, userID, targetType, targetID string) error {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND target_type = ? AND target_id = ?", userID, targetType, targetID).
+		Delete(&models.Watch{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user %s is not watching %s %s", userID, targetType, targetID)
+	}
+	return nil
+}
+
+func (r *watchRepository) FindByUserID(ctx context.Context, userID string) ([]*models.Watch, error) {
+	var watches []*models.Watch
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&watches).Error
+	if err != nil {
+		return nil, err
+	}
+	return watches, nil
+}
+
+// FindWatcherIDs returns the distinct users watching the task or its board
+func (r *watchRepository) FindWatcherIDs(ctx context.Context, taskID, boardID string) ([]string, error) {
+	var userIDs []string
+	err := r.db.WithContext(ctx).
+		Model(&models.Watch{}).
+		Distinct("user_id").
+		Where("(target_type = ? AND target_id = ?) OR (target_type = ? AND target_id = ?)",
+			models.WatchTargetTask, taskID, models.WatchTargetBoard, boardID).
+		Order("user_id").
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
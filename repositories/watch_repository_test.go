@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestWatchRepository_AddAndRemove(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &watchRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "watcher", "watcher@example.com")
+
+	require.NoError(t, repo.Add(ctx, user.ID, models.WatchTargetTask, "task-1"))
+	require.NoError(t, repo.Add(ctx, user.ID, models.WatchTargetTask, "task-1"), "watching twice should be a no-op")
+	require.NoError(t, repo.Add(ctx, user.ID, models.WatchTargetBoard, "board-1"))
+
+	watches, err := repo.FindByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Len(t, watches, 2)
+
+	require.NoError(t, repo.Remove(ctx, user.ID, models.WatchTargetTask, "task-1"))
+	assert.Error(t, repo.Remove(ctx, user.ID, models.WatchTargetTask, "task-1"))
+
+	watches, err = repo.FindByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, watches, 1)
+	assert.Equal(t, models.WatchTargetBoard, watches[0].TargetType)
+}
+
+func TestWatchRepository_FindWatcherIDs(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &watchRepository{db: db}
+	ctx := context.Background()
+
+	taskWatcher := createTestUser(db, "task", "task@example.com")
+	boardWatcher := createTestUser(db, "board", "board@example.com")
+	both := createTestUser(db, "both", "both@example.com")
+	other := createTestUser(db, "other", "other@example.com")
+
+	require.NoError(t, repo.Add(ctx, taskWatcher.ID, models.WatchTargetTask, "task-1"))
+	require.NoError(t, repo.Add(ctx, boardWatcher.ID, models.WatchTargetBoard, "board-1"))
+	require.NoError(t, repo.Add(ctx, both.ID, models.WatchTargetTask, "task-1"))
+	require.NoError(t, repo.Add(ctx, both.ID, models.WatchTargetBoard, "board-1"))
+	require.NoError(t, repo.Add(ctx, other.ID, models.WatchTargetTask, "task-2"))
+
+	watcherIDs, err := repo.FindWatcherIDs(ctx, "task-1", "board-1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{taskWatcher.ID, boardWatcher.ID, both.ID}, watcherIDs)
+}
@@ -0,0 +1,183 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kanban-backend/config"
+	"kanban-backend/models"
+
+	"gorm.io/gorm"
+)
+
+type WebhookRepository interface {
+	Create(ctx context.Context, webhook *models.Webhook) error
+	FindByID(ctx context.Context, id string) (*models.Webhook, error)
+	FindByBoardID(ctx context.Context, boardID string) ([]*models.Webhook, error)
+	FindActiveByBoardID(ctx context.Context, boardID string) ([]*models.Webhook, error)
+	Update(ctx context.Context, webhook *models.Webhook) error
+	Delete(ctx context.Context, id string) error
+	RecordSuccess(ctx context.Context, id string) error
+	RecordFailure(ctx context.Context, id string, disableAfter int, now time.Time) (bool, error)
+	CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	FindDeliveries(ctx context.Context, webhookID string, limit int) ([]*models.WebhookDelivery, error)
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error)
+	ClaimDelivery(ctx context.Context, delivery *models.WebhookDelivery, leaseUntil time.Time) (bool, error)
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository() WebhookRepository {
+	return &webhookRepository{
+		db: config.DB,
+	}
+}
+
+func (r *webhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	return r.db.WithContext(ctx).Create(webhook).Error
+}
+
+func (r *webhookRepository) FindByID(ctx context.Context, id string) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := r.db.WithContext(ctx).
+		Where("id = ?", id).
+		First(&webhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *webhookRepository) FindByBoardID(ctx context.Context, boardID string) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	err := r.db.WithContext(ctx).
+		Where("board_id = ?", boardID).
+		Order("created_at ASC").
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) FindActiveByBoardID(ctx context.Context, boardID string) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	err := r.db.WithContext(ctx).
+		Where("board_id = ? AND active = ?", boardID, true).
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *webhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	return r.db.WithContext(ctx).Save(webhook).Error
+}
+
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Webhook{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("webhook with id %s not found", id)
+	}
+	return nil
+}
+
+// RecordSuccess resets the webhook's run of failed attempts
+func (r *webhookRepository) RecordSuccess(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.Webhook{}).
+		Where("id = ? AND consecutive_failures > 0", id).
+		Update("consecutive_failures", 0).Error
+}
+
+// RecordFailure counts a failed attempt and disables the webhook once
+// disableAfter attempts in a row have failed. It reports whether the webhook
+// was disabled by this failure.
+func (r *webhookRepository) RecordFailure(ctx context.Context, id string, disableAfter int, now time.Time) (bool, error) {
+	disabled := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Webhook{}).
+			Where("id = ?", id).
+			Update("consecutive_failures", gorm.Expr("consecutive_failures + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.Webhook{}).
+			Where("id = ? AND active = ? AND consecutive_failures >= ?", id, true, disableAfter).
+			Updates(map[string]interface{}{"active": false, "disabled_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		disabled = result.RowsAffected > 0
+		return nil
+	})
+	return disabled, err
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&deliveries).Error
+}
+
+// FindDeliveries returns the webhook's most recent deliveries, newest first
+func (r *webhookRepository) FindDeliveries(ctx context.Context, webhookID string, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("webhook_id = ?", webhookID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// FindDueDeliveries returns pending deliveries whose next attempt is due, with
+// their webhook, oldest first
+func (r *webhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Preload("Webhook").
+		Where("status = ? AND next_attempt_at <= ?", models.DeliveryStatusPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// ClaimDelivery pushes a due delivery's next attempt out to leaseUntil so that
+// no other worker picks it up while it is being sent. It reports false when
+// another worker claimed it first.
+func (r *webhookRepository) ClaimDelivery(ctx context.Context, delivery *models.WebhookDelivery, leaseUntil time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", delivery.ID, models.DeliveryStatusPending, delivery.NextAttemptAt).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	delivery.NextAttemptAt = &leaseUntil
+	return true, nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Omit("Webhook").Save(delivery).Error
+}
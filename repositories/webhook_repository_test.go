@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"kanban-backend/models"
+)
+
+func TestWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &webhookRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "hooks", "hooks@example.com")
+	board := createTestBoard(db, user.ID)
+	webhook := &models.Webhook{BoardID: board.ID, URL: "https://example.com/hook", Secret: "0123456789abcdef", EventTypes: []string{"task.created"}, Active: true}
+	require.NoError(t, repo.Create(ctx, webhook))
+
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	due := now.Add(-time.Minute)
+	later := now.Add(time.Hour)
+	payload := []byte(`{"event":"task.created"}`)
+	require.NoError(t, repo.CreateDeliveries(ctx, []*models.WebhookDelivery{
+		{WebhookID: webhook.ID, EventType: "task.created", Payload: payload, Status: models.DeliveryStatusPending, NextAttemptAt: &due},
+		{WebhookID: webhook.ID, EventType: "task.created", Payload: payload, Status: models.DeliveryStatusPending, NextAttemptAt: &later},
+	}))
+
+	deliveries, err := repo.FindDueDeliveries(ctx, now, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	require.NotNil(t, deliveries[0].Webhook)
+	assert.Equal(t, webhook.URL, deliveries[0].Webhook.URL)
+	assert.JSONEq(t, string(payload), string(deliveries[0].Payload))
+
+	stale := *deliveries[0]
+	claimed, err := repo.ClaimDelivery(ctx, deliveries[0], now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = repo.ClaimDelivery(ctx, &stale, now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed, "a delivery must only be claimed once")
+
+	deliveries, err = repo.FindDueDeliveries(ctx, now, 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
+
+func TestWebhookRepository_RecordFailureDisables(t *testing.T) {
+	db := setupRepositoryTestDB(t)
+	repo := &webhookRepository{db: db}
+	ctx := context.Background()
+
+	user := createTestUser(db, "hooks", "hooks@example.com")
+	board := createTestBoard(db, user.ID)
+	webhook := &models.Webhook{BoardID: board.ID, URL: "https://example.com/hook", Secret: "0123456789abcdef", EventTypes: []string{"task.created"}, Active: true}
+	require.NoError(t, repo.Create(ctx, webhook))
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	disabled, err := repo.RecordFailure(ctx, webhook.ID, 2, now)
+	require.NoError(t, err)
+	assert.False(t, disabled)
+
+	require.NoError(t, repo.RecordSuccess(ctx, webhook.ID))
+	disabled, err = repo.RecordFailure(ctx, webhook.ID, 2, now)
+	require.NoError(t, err)
+	assert.False(t, disabled, "a success resets the failure count")
+
+	disabled, err = repo.RecordFailure(ctx, webhook.ID, 2, now)
+	require.NoError(t, err)
+	assert.True(t, disabled)
+
+	found, err := repo.FindByID(ctx, webhook.ID)
+	require.NoError(t, err)
+	assert.False(t, found.Active)
+	assert.NotNil(t, found.DisabledAt)
+	assert.Equal(t, 2, found.ConsecutiveFailures)
+
+	active, err := repo.FindActiveByBoardID(ctx, board.ID)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+}
@@ -1,7 +1,9 @@
 package routes
 
 import (
+	"kanban-backend/config"
 	"kanban-backend/controllers"
+	"kanban-backend/metrics"
 	"kanban-backend/middleware"
 	"kanban-backend/services"
 
@@ -9,63 +11,190 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cors"
 )
 
-func Setup(app *fiber.App, authService services.AuthService, authController *controllers.AuthController, boardController *controllers.BoardController, taskController *controllers.TaskController, commentController *controllers.CommentController, labelController *controllers.LabelController, attachmentController *controllers.AttachmentController) {
+// Controllers holds the controllers whose handlers Setup registers
+type Controllers struct {
+	Auth              *controllers.AuthController
+	Board             *controllers.BoardController
+	Task              *controllers.TaskController
+	Comment           *controllers.CommentController
+	Label             *controllers.LabelController
+	Attachment        *controllers.AttachmentController
+	Assignee          *controllers.AssigneeController
+	User              *controllers.UserController
+	Watch             *controllers.WatchController
+	Activity          *controllers.ActivityController
+	Analytics         *controllers.AnalyticsController
+	Sprint            *controllers.SprintController
+	Template          *controllers.BoardTemplateController
+	Trash             *controllers.TrashController
+	Export            *controllers.BoardExportController
+	Import            *controllers.ImportController
+	TaskCSV           *controllers.TaskCSVController
+	Calendar          *controllers.CalendarController
+	Webhook           *controllers.WebhookController
+	Rule              *controllers.RuleController
+	Git               *controllers.GitIntegrationController
+	EmailInbox        *controllers.EmailInboxController
+	NotificationEmail *controllers.NotificationEmailController
+	Health            *controllers.HealthController
+}
+
+// Setup registers the middleware and every route of the API on app
+func Setup(app *fiber.App, authService services.AuthService, ctrl Controllers, serverConfig config.ServerConfig) {
+	app.Use(middleware.Metrics())
+	app.Use(middleware.Tracing())
 	app.Use(middleware.Logger())
-	app.Use(cors.New(middleware.CORSConfig()))
+	app.Use(cors.New(middleware.CORSConfig(serverConfig.CORSAllowedOrigins)))
 
-	app.Get("/health", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{"status": "ok"})
-	})
+	// Probes: /health is kept as an alias of /livez for existing monitors
+	app.Get("/livez", ctrl.Health.Livez)
+	app.Get("/readyz", ctrl.Health.Readyz)
+	app.Get("/health", ctrl.Health.Livez)
+
+	app.Get("/metrics", middleware.MetricsAuth(serverConfig.MetricsToken), metrics.Handler())
 
 	auth := app.Group("/api/v1/auth")
-	auth.Post("/register", authController.Register)
-	auth.Post("/login", authController.Login)
-	auth.Get("/me", middleware.AuthMiddleware(authService), authController.Me)
-	auth.Post("/logout", middleware.AuthMiddleware(authService), authController.Logout)
+	auth.Post("/register", ctrl.Auth.Register)
+	auth.Post("/login", ctrl.Auth.Login)
+	auth.Get("/me", middleware.AuthMiddleware(authService), ctrl.Auth.Me)
+	auth.Post("/logout", middleware.AuthMiddleware(authService), ctrl.Auth.Logout)
+
+	me := app.Group("/api/v1/me")
+	me.Use(middleware.AuthMiddleware(authService))
+	me.Get("/settings", ctrl.User.GetSettings)
+	me.Put("/settings", ctrl.User.UpdateSettings)
+	me.Get("/notification-preferences", ctrl.NotificationEmail.GetPreferences)
+	me.Put("/notification-preferences", ctrl.NotificationEmail.UpdatePreferences)
+	me.Get("/watching", ctrl.Watch.FindWatching)
+	me.Post("/calendar-tokens", ctrl.Calendar.CreateToken)
+	me.Get("/calendar-tokens", ctrl.Calendar.FindTokens)
+	me.Delete("/calendar-tokens/:id", ctrl.Calendar.RevokeToken)
+
+	// Calendar feeds authenticate with the secret token in the path
+	app.Get("/calendar/:token.ics", ctrl.Calendar.Feed)
+
+	// Git webhooks authenticate with the integration's signature or token
+	app.Post("/hooks/git/:id", ctrl.Git.Webhook)
+
+	// The mail relay authenticates with the inbound email secret
+	app.Post("/hooks/email", ctrl.EmailInbox.Receive)
 
 	boards := app.Group("/api/v1/boards")
 	boards.Use(middleware.AuthMiddleware(authService))
-	boards.Post("/", boardController.Create)
-	boards.Get("/:id", boardController.FindByID)
-	boards.Get("/", boardController.FindAll)
-	boards.Get("/search", boardController.Search)
-	boards.Put("/:id", boardController.Update)
-	boards.Delete("/:id", boardController.Delete)
+	boards.Post("/", ctrl.Board.Create)
+	boards.Get("/:id", ctrl.Board.FindByID)
+	boards.Get("/", ctrl.Board.FindAll)
+	boards.Get("/search", ctrl.Board.Search)
+	boards.Post("/import", ctrl.Export.Import)
+	boards.Put("/:id", ctrl.Board.Update)
+	boards.Delete("/:id", ctrl.Board.Delete)
+	boards.Post("/:id/duplicate", ctrl.Board.Duplicate)
+	boards.Get("/:id/export", ctrl.Export.Export)
+	boards.Get("/:id/tasks.csv", ctrl.TaskCSV.Export)
+	boards.Post("/:id/tasks/import", ctrl.TaskCSV.Import)
+	boards.Post("/:id/archive", ctrl.Board.Archive)
+	boards.Delete("/:id/archive", ctrl.Board.Unarchive)
+	boards.Post("/:id/watch", ctrl.Watch.WatchBoard)
+	boards.Delete("/:id/watch", ctrl.Watch.UnwatchBoard)
+	boards.Get("/:id/analytics", ctrl.Analytics.BoardAnalytics)
+	boards.Get("/:id/cfd", ctrl.Analytics.CumulativeFlow)
+	boards.Put("/:id/columns/:column_id/stage", ctrl.Analytics.SetColumnStage)
+	boards.Post("/:id/sprints", ctrl.Sprint.Create)
+	boards.Get("/:id/sprints", ctrl.Sprint.FindByBoardID)
+	boards.Post("/:id/templates", ctrl.Template.SaveFromBoard)
+	boards.Post("/:id/webhooks", ctrl.Webhook.Create)
+	boards.Get("/:id/webhooks", ctrl.Webhook.FindByBoardID)
+	boards.Post("/:id/rules", ctrl.Rule.Create)
+	boards.Get("/:id/rules", ctrl.Rule.FindByBoardID)
+	boards.Put("/:id/git-integration", ctrl.Git.Configure)
+	boards.Get("/:id/git-integration", ctrl.Git.Find)
+	boards.Delete("/:id/git-integration", ctrl.Git.Delete)
+	boards.Put("/:id/inbox", ctrl.EmailInbox.Configure)
+	boards.Get("/:id/inbox", ctrl.EmailInbox.Find)
+	boards.Delete("/:id/inbox", ctrl.EmailInbox.Delete)
+
+	webhooks := app.Group("/api/v1/webhooks")
+	webhooks.Use(middleware.AuthMiddleware(authService))
+	webhooks.Put("/:id", ctrl.Webhook.Update)
+	webhooks.Delete("/:id", ctrl.Webhook.Delete)
+	webhooks.Get("/:id/deliveries", ctrl.Webhook.FindDeliveries)
+
+	rules := app.Group("/api/v1/rules")
+	rules.Use(middleware.AuthMiddleware(authService))
+	rules.Put("/:id", ctrl.Rule.Update)
+	rules.Delete("/:id", ctrl.Rule.Delete)
+	rules.Get("/:id/executions", ctrl.Rule.FindExecutions)
+
+	templates := app.Group("/api/v1/board-templates")
+	templates.Use(middleware.AuthMiddleware(authService))
+	templates.Get("/", ctrl.Template.FindAll)
+	templates.Get("/:id", ctrl.Template.FindByID)
+	templates.Delete("/:id", ctrl.Template.Delete)
+
+	imports := app.Group("/api/v1/imports")
+	imports.Use(middleware.AuthMiddleware(authService))
+	imports.Post("/trello", ctrl.Import.ImportTrello)
+	imports.Get("/:id", ctrl.Import.FindJob)
 
 	tasks := app.Group("/api/v1/tasks")
 	tasks.Use(middleware.AuthMiddleware(authService))
-	tasks.Post("/", taskController.Create)
-	tasks.Get("/:id", taskController.FindByID)
-	tasks.Get("/column/:columnId", taskController.FindByColumnID)
-	tasks.Get("/search", taskController.Search)
-	tasks.Put("/:id", taskController.Update)
-	tasks.Delete("/:id", taskController.Delete)
-	tasks.Put("/:id/move", taskController.Move)
-	tasks.Post("/:id/labels/:label_id", labelController.AddToTask)
-	tasks.Delete("/:id/labels/:label_id", labelController.RemoveFromTask)
+	tasks.Post("/", ctrl.Task.Create)
+	tasks.Get("/key/:key", ctrl.Task.FindByKey)
+	tasks.Get("/:id", ctrl.Task.FindByID)
+	tasks.Get("/column/:columnId", ctrl.Task.FindByColumnID)
+	tasks.Get("/search", ctrl.Task.Search)
+	tasks.Put("/:id", ctrl.Task.Update)
+	tasks.Delete("/:id", ctrl.Task.Delete)
+	tasks.Put("/:id/move", ctrl.Task.Move)
+	tasks.Post("/:id/archive", ctrl.Task.Archive)
+	tasks.Delete("/:id/archive", ctrl.Task.Unarchive)
+	tasks.Post("/:id/labels/:label_id", ctrl.Label.AddToTask)
+	tasks.Delete("/:id/labels/:label_id", ctrl.Label.RemoveFromTask)
+	tasks.Get("/:id/assignees", ctrl.Assignee.FindByTaskID)
+	tasks.Post("/:id/assignees/:user_id", ctrl.Assignee.AddToTask)
+	tasks.Delete("/:id/assignees/:user_id", ctrl.Assignee.RemoveFromTask)
+	tasks.Post("/:id/watch", ctrl.Watch.WatchTask)
+	tasks.Delete("/:id/watch", ctrl.Watch.UnwatchTask)
+	tasks.Get("/:id/activity", ctrl.Activity.FindByTaskID)
+
+	sprints := app.Group("/api/v1/sprints")
+	sprints.Use(middleware.AuthMiddleware(authService))
+	sprints.Get("/:id", ctrl.Sprint.FindByID)
+	sprints.Put("/:id", ctrl.Sprint.Update)
+	sprints.Delete("/:id", ctrl.Sprint.Delete)
+	sprints.Post("/:id/start", ctrl.Sprint.Start)
+	sprints.Post("/:id/complete", ctrl.Sprint.Complete)
+	sprints.Get("/:id/burndown", ctrl.Sprint.Burndown)
+	sprints.Post("/:id/tasks/:task_id", ctrl.Sprint.AddTask)
+	sprints.Delete("/:id/tasks/:task_id", ctrl.Sprint.RemoveTask)
+
+	trash := app.Group("/api/v1/trash")
+	trash.Use(middleware.AuthMiddleware(authService))
+	trash.Get("/", ctrl.Trash.FindAll)
+	trash.Post("/:type/:id/restore", ctrl.Trash.Restore)
 
 	comments := app.Group("/api/v1/comments")
 	comments.Use(middleware.AuthMiddleware(authService))
-	comments.Post("/", commentController.Create)
-	comments.Get("/:id", commentController.FindByID)
-	comments.Get("/task/:task_id", commentController.FindByTaskID)
-	comments.Put("/:id", commentController.Update)
-	comments.Delete("/:id", commentController.Delete)
+	comments.Post("/", ctrl.Comment.Create)
+	comments.Get("/:id", ctrl.Comment.FindByID)
+	comments.Get("/task/:task_id", ctrl.Comment.FindByTaskID)
+	comments.Put("/:id", ctrl.Comment.Update)
+	comments.Delete("/:id", ctrl.Comment.Delete)
 
 	labels := app.Group("/api/v1/labels")
 	labels.Use(middleware.AuthMiddleware(authService))
-	labels.Post("/", labelController.Create)
-	labels.Get("/", labelController.FindAll)
-	labels.Get("/search", labelController.Search)
-	labels.Get("/:id", labelController.FindByID)
-	labels.Put("/:id", labelController.Update)
-	labels.Delete("/:id", labelController.Delete)
+	labels.Post("/", ctrl.Label.Create)
+	labels.Get("/", ctrl.Label.FindAll)
+	labels.Get("/search", ctrl.Label.Search)
+	labels.Get("/:id", ctrl.Label.FindByID)
+	labels.Put("/:id", ctrl.Label.Update)
+	labels.Delete("/:id", ctrl.Label.Delete)
 
 	attachments := app.Group("/api/v1/attachments")
 	attachments.Use(middleware.AuthMiddleware(authService))
-	attachments.Post("/", attachmentController.Create)
-	attachments.Get("/:id", attachmentController.FindByID)
-	attachments.Get("/task/:task_id", attachmentController.FindByTaskID)
-	attachments.Put("/:id", attachmentController.Update)
-	attachments.Delete("/:id", attachmentController.Delete)
+	attachments.Post("/", ctrl.Attachment.Create)
+	attachments.Get("/:id", ctrl.Attachment.FindByID)
+	attachments.Get("/task/:task_id", ctrl.Attachment.FindByTaskID)
+	attachments.Put("/:id", ctrl.Attachment.Update)
+	attachments.Delete("/:id", ctrl.Attachment.Delete)
 }
@@ -2,17 +2,25 @@ package routes
 
 import (
 	"context"
+	"io"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"kanban-backend/config"
 	"kanban-backend/controllers"
+	"kanban-backend/events"
 	"kanban-backend/models"
+	"kanban-backend/services"
 	"kanban-backend/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 type MockAuthService struct{}
@@ -36,6 +44,10 @@ func (m *MockAuthService) ValidateToken(tokenString string) (string, error) {
 	return "", utils.NewUnauthorized("invalid or expired token")
 }
 
+func (m *MockAuthService) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	return &models.User{ID: userID, Username: "testuser", Email: "test@example.com"}, nil
+}
+
 func (m *MockAuthService) HashPassword(password string) (string, error) {
 	return "hashed-password", nil
 }
@@ -46,8 +58,8 @@ func (m *MockAuthService) VerifyPassword(hashedPassword, password string) error
 
 type MockBoardService struct{}
 
-func (m *MockBoardService) Create(ctx context.Context, userID, title, color string) (*models.Board, error) {
-	return &models.Board{ID: "board-1", Title: title, Color: color, UserID: userID}, nil
+func (m *MockBoardService) Create(ctx context.Context, userID, title, color, taskKeyPrefix, templateID string) (*models.Board, error) {
+	return &models.Board{ID: "board-1", Title: title, Color: color, TaskKeyPrefix: taskKeyPrefix, UserID: userID}, nil
 }
 
 func (m *MockBoardService) FindByID(ctx context.Context, boardID, userID string) (*models.Board, error) {
@@ -63,7 +75,7 @@ func (m *MockBoardService) FindByUserID(ctx context.Context, userID string) ([]*
 	}, nil
 }
 
-func (m *MockBoardService) Update(ctx context.Context, boardID, userID, title, color string) (*models.Board, error) {
+func (m *MockBoardService) Update(ctx context.Context, boardID, userID, title, color, taskKeyPrefix string) (*models.Board, error) {
 	if boardID == "board-1" {
 		return &models.Board{ID: boardID, Title: title, Color: color, UserID: userID}, nil
 	}
@@ -77,7 +89,21 @@ func (m *MockBoardService) Delete(ctx context.Context, boardID, userID string) e
 	return utils.NewNotFound("board not found")
 }
 
-func (m *MockBoardService) FindByUserIDWithFilters(ctx context.Context, userID, title string, offset, limit int) ([]*models.Board, int, error) {
+func (m *MockBoardService) Archive(ctx context.Context, boardID, userID string, archived bool) (*models.Board, error) {
+	if boardID == "board-1" {
+		return &models.Board{ID: boardID, UserID: userID}, nil
+	}
+	return nil, utils.NewNotFound("board not found")
+}
+
+func (m *MockBoardService) Duplicate(ctx context.Context, boardID, userID, title string, options models.BoardCopyOptions) (*models.Board, error) {
+	if boardID != "board-1" {
+		return nil, utils.NewNotFound("board not found")
+	}
+	return &models.Board{ID: "board-2", Title: title, UserID: userID}, nil
+}
+
+func (m *MockBoardService) FindByUserIDWithFilters(ctx context.Context, userID, title string, archived bool, offset, limit int) ([]*models.Board, int, error) {
 	boards := []*models.Board{
 		{ID: "board-1", Title: "Test Board", UserID: userID},
 		{ID: "board-2", Title: "Another Board", UserID: userID},
@@ -94,7 +120,7 @@ func (m *MockBoardService) Search(ctx context.Context, userID, keyword string, o
 
 type MockTaskService struct{}
 
-func (m *MockTaskService) Create(ctx context.Context, userID, columnID, title, description string, deadline *time.Time) (*models.Task, error) {
+func (m *MockTaskService) Create(ctx context.Context, userID, columnID, title, description string, deadline *time.Time, deadlineAllDay bool) (*models.Task, error) {
 	return &models.Task{ID: "task-1", ColumnID: columnID, Title: title, Description: description}, nil
 }
 
@@ -105,6 +131,13 @@ func (m *MockTaskService) FindByID(ctx context.Context, taskID, userID string) (
 	return nil, utils.NewNotFound("task not found")
 }
 
+func (m *MockTaskService) FindByKey(ctx context.Context, key, userID string) (*models.Task, error) {
+	if key == "OPS-1" {
+		return &models.Task{ID: "task-1", Number: 1, Key: key, Title: "Test Task"}, nil
+	}
+	return nil, utils.NewNotFound("task not found")
+}
+
 func (m *MockTaskService) FindByColumnID(ctx context.Context, columnID, userID string) ([]*models.Task, error) {
 	if columnID == "column-1" {
 		return []*models.Task{
@@ -114,7 +147,7 @@ func (m *MockTaskService) FindByColumnID(ctx context.Context, columnID, userID s
 	return nil, utils.NewNotFound("column not found")
 }
 
-func (m *MockTaskService) Update(ctx context.Context, taskID, userID, title, description string, deadline *time.Time) (*models.Task, error) {
+func (m *MockTaskService) Update(ctx context.Context, taskID, userID, title, description string, deadline *time.Time, deadlineAllDay bool, storyPoints *int) (*models.Task, error) {
 	if taskID == "task-1" {
 		return &models.Task{ID: taskID, Title: title, Description: description}, nil
 	}
@@ -128,6 +161,13 @@ func (m *MockTaskService) Delete(ctx context.Context, taskID, userID string) err
 	return utils.NewNotFound("task not found")
 }
 
+func (m *MockTaskService) Archive(ctx context.Context, taskID, userID string, archived bool) (*models.Task, error) {
+	if taskID == "task-1" {
+		return &models.Task{ID: taskID}, nil
+	}
+	return nil, utils.NewNotFound("task not found")
+}
+
 func (m *MockTaskService) Move(ctx context.Context, taskID, columnID, userID string) error {
 	if taskID == "task-1" {
 		return nil
@@ -135,7 +175,7 @@ func (m *MockTaskService) Move(ctx context.Context, taskID, columnID, userID str
 	return utils.NewNotFound("task not found")
 }
 
-func (m *MockTaskService) FindByColumnIDWithFilters(ctx context.Context, columnID, title, status string, offset, limit int) ([]*models.Task, int, error) {
+func (m *MockTaskService) FindByColumnIDWithFilters(ctx context.Context, columnID, userID, title string, archived bool, offset, limit int) ([]*models.Task, int, error) {
 	tasks := []*models.Task{
 		{ID: "task-1", ColumnID: columnID, Title: "Test Task"},
 		{ID: "task-2", ColumnID: columnID, Title: "Another Task"},
@@ -301,7 +341,345 @@ func (m *MockAttachmentService) Delete(ctx context.Context, id, userID string) e
 	return utils.NewNotFound("attachment not found")
 }
 
+type MockAssigneeService struct{}
+
+func (m *MockAssigneeService) AddToTask(ctx context.Context, taskID, assigneeID, userID string) error {
+	if taskID == "task-1" {
+		return nil
+	}
+	return utils.NewNotFound("task not found")
+}
+
+func (m *MockAssigneeService) RemoveFromTask(ctx context.Context, taskID, assigneeID, userID string) error {
+	if taskID == "task-1" {
+		return nil
+	}
+	return utils.NewNotFound("task not found")
+}
+
+func (m *MockAssigneeService) FindByTaskID(ctx context.Context, taskID, userID string) ([]*models.TaskAssignee, error) {
+	return []*models.TaskAssignee{{TaskID: taskID, UserID: "user-2"}}, nil
+}
+
+type MockUserService struct{}
+
+func (m *MockUserService) GetSettings(ctx context.Context, userID string) (*models.User, error) {
+	return &models.User{ID: userID, ReminderLeadTimes: models.DefaultReminderLeadTimes}, nil
+}
+
+func (m *MockUserService) UpdateSettings(ctx context.Context, userID, reminderLeadTimes, timezone string) (*models.User, error) {
+	return &models.User{ID: userID, ReminderLeadTimes: reminderLeadTimes, Timezone: timezone}, nil
+}
+
+type MockWatchService struct{}
+
+func (m *MockWatchService) WatchTask(ctx context.Context, taskID, userID string) error {
+	return nil
+}
+
+func (m *MockWatchService) UnwatchTask(ctx context.Context, taskID, userID string) error {
+	return nil
+}
+
+func (m *MockWatchService) WatchBoard(ctx context.Context, boardID, userID string) error {
+	return nil
+}
+
+func (m *MockWatchService) UnwatchBoard(ctx context.Context, boardID, userID string) error {
+	return nil
+}
+
+func (m *MockWatchService) FindByUserID(ctx context.Context, userID string) ([]*models.Watch, error) {
+	return []*models.Watch{{UserID: userID, TargetType: models.WatchTargetTask, TargetID: "task-1"}}, nil
+}
+
+func (m *MockWatchService) HandleEvent(ctx context.Context, event events.Event) {}
+
+type MockActivityService struct{}
+
+func (m *MockActivityService) FindByTaskIDWithPagination(ctx context.Context, taskID, userID string, page, limit int) ([]*models.TaskActivity, int, error) {
+	return []*models.TaskActivity{{ID: "act-1", TaskID: taskID, UserID: userID, Action: models.ActivityCreated}}, 1, nil
+}
+
+func (m *MockActivityService) HandleEvent(ctx context.Context, event events.Event) {}
+
+type MockAnalyticsService struct{}
+
+func (m *MockAnalyticsService) BoardAnalytics(ctx context.Context, boardID, userID string, from, to time.Time) (*services.BoardAnalytics, error) {
+	return &services.BoardAnalytics{BoardID: boardID, From: from, To: to}, nil
+}
+
+func (m *MockAnalyticsService) CumulativeFlow(ctx context.Context, boardID, userID string, from, to time.Time, interval string) (*services.CumulativeFlow, error) {
+	return &services.CumulativeFlow{BoardID: boardID, From: from, To: to, Interval: interval}, nil
+}
+
+func (m *MockAnalyticsService) SetColumnStage(ctx context.Context, boardID, columnID, userID, stage string) (*models.Column, error) {
+	return &models.Column{ID: columnID, BoardID: boardID, Stage: stage}, nil
+}
+
+type MockSprintService struct{}
+
+func (m *MockSprintService) Create(ctx context.Context, boardID, userID, name, goal string, startDate, endDate time.Time) (*models.Sprint, error) {
+	return &models.Sprint{ID: "sprint-1", BoardID: boardID, Name: name, StartDate: startDate, EndDate: endDate}, nil
+}
+
+func (m *MockSprintService) FindByID(ctx context.Context, sprintID, userID string) (*models.Sprint, error) {
+	return &models.Sprint{ID: sprintID}, nil
+}
+
+func (m *MockSprintService) FindByBoardID(ctx context.Context, boardID, userID string) ([]*models.Sprint, error) {
+	return []*models.Sprint{}, nil
+}
+
+func (m *MockSprintService) Update(ctx context.Context, sprintID, userID, name, goal string, startDate, endDate *time.Time) (*models.Sprint, error) {
+	return &models.Sprint{ID: sprintID, Name: name}, nil
+}
+
+func (m *MockSprintService) Delete(ctx context.Context, sprintID, userID string) error {
+	return nil
+}
+
+func (m *MockSprintService) AddTask(ctx context.Context, sprintID, taskID, userID string) error {
+	return nil
+}
+
+func (m *MockSprintService) RemoveTask(ctx context.Context, sprintID, taskID, userID string) error {
+	return nil
+}
+
+func (m *MockSprintService) Start(ctx context.Context, sprintID, userID string) (*models.Sprint, error) {
+	return &models.Sprint{ID: sprintID, Status: models.SprintStatusActive}, nil
+}
+
+func (m *MockSprintService) Complete(ctx context.Context, sprintID, userID, nextSprintID string) (*services.SprintCompletion, error) {
+	return &services.SprintCompletion{Sprint: &models.Sprint{ID: sprintID, Status: models.SprintStatusCompleted}}, nil
+}
+
+func (m *MockSprintService) Burndown(ctx context.Context, sprintID, userID string) (*services.Burndown, error) {
+	return &services.Burndown{SprintID: sprintID}, nil
+}
+
+type MockBoardTemplateService struct{}
+
+func (m *MockBoardTemplateService) FindAll(ctx context.Context, userID string) ([]*models.BoardTemplate, error) {
+	return []*models.BoardTemplate{{ID: "scrum", Name: "Scrum", BuiltIn: true}}, nil
+}
+
+func (m *MockBoardTemplateService) FindByID(ctx context.Context, templateID, userID string) (*models.BoardTemplate, error) {
+	return &models.BoardTemplate{ID: templateID}, nil
+}
+
+func (m *MockBoardTemplateService) SaveFromBoard(ctx context.Context, boardID, userID, name, description string, includeTasks bool) (*models.BoardTemplate, error) {
+	return &models.BoardTemplate{ID: "template-1", UserID: userID, Name: name}, nil
+}
+
+func (m *MockBoardTemplateService) Delete(ctx context.Context, templateID, userID string) error {
+	return nil
+}
+
+type MockTrashService struct{}
+
+func (m *MockTrashService) FindAll(ctx context.Context, userID string) ([]services.TrashItem, error) {
+	return []services.TrashItem{{Type: services.TrashItemTask, ID: "task-1", Title: "Test Task"}}, nil
+}
+
+func (m *MockTrashService) Restore(ctx context.Context, itemType, id, userID string) error {
+	if id == "task-1" {
+		return nil
+	}
+	return utils.NewNotFound("task not found in trash")
+}
+
+func (m *MockTrashService) Purge(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+type MockBoardExportService struct{}
+
+func (m *MockBoardExportService) Export(ctx context.Context, boardID, userID string) (*models.BoardExport, error) {
+	return &models.BoardExport{Version: models.BoardExportVersion, Board: models.ExportedBoard{Title: "Test Board"}}, nil
+}
+
+func (m *MockBoardExportService) Import(ctx context.Context, userID string, export *models.BoardExport) (*models.Board, error) {
+	return &models.Board{ID: "board-2", UserID: userID, Title: export.Board.Title}, nil
+}
+
+type MockImportService struct{}
+
+func (m *MockImportService) StartTrelloImport(ctx context.Context, userID string, data []byte) (*models.ImportJob, error) {
+	return &models.ImportJob{ID: "job-1", UserID: userID, Source: models.ImportSourceTrello, Status: models.ImportStatusPending}, nil
+}
+
+func (m *MockImportService) FindJob(ctx context.Context, jobID, userID string) (*models.ImportJob, error) {
+	if jobID == "job-1" {
+		return &models.ImportJob{ID: jobID, UserID: userID, Status: models.ImportStatusRunning}, nil
+	}
+	return nil, utils.NewNotFound("import job not found")
+}
+
+func (m *MockImportService) Wait() {}
+
+type MockTaskCSVService struct{}
+
+func (m *MockTaskCSVService) Export(ctx context.Context, boardID, userID string, w io.Writer) error {
+	_, err := io.WriteString(w, "id,title\ntask-1,Test Task\n")
+	return err
+}
+
+func (m *MockTaskCSVService) Import(ctx context.Context, boardID, userID string, r io.Reader, mapping map[string]string, dryRun bool) (*services.CSVImportResult, error) {
+	return &services.CSVImportResult{DryRun: dryRun, Rows: 1, Imported: 1, Errors: []services.CSVRowError{}}, nil
+}
+
+type MockCalendarService struct{}
+
+func (m *MockCalendarService) CreateToken(ctx context.Context, userID, name string) (*services.CreatedCalendarToken, error) {
+	return &services.CreatedCalendarToken{CalendarToken: &models.CalendarToken{ID: "token-1", UserID: userID, Name: name}, Token: "secret"}, nil
+}
+
+func (m *MockCalendarService) FindTokens(ctx context.Context, userID string) ([]*models.CalendarToken, error) {
+	return []*models.CalendarToken{}, nil
+}
+
+func (m *MockCalendarService) RevokeToken(ctx context.Context, tokenID, userID string) error {
+	return nil
+}
+
+func (m *MockCalendarService) Feed(ctx context.Context, token, boardID string, now time.Time) (string, error) {
+	if token != "secret" {
+		return "", utils.NewNotFound("calendar not found")
+	}
+	return "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", nil
+}
+
+type MockWebhookService struct{}
+
+func (m *MockWebhookService) Create(ctx context.Context, boardID, userID, targetURL, secret string, eventTypes []string) (*services.CreatedWebhook, error) {
+	return &services.CreatedWebhook{Webhook: &models.Webhook{ID: "webhook-1", BoardID: boardID, URL: targetURL, EventTypes: eventTypes, Active: true}, Secret: "secret"}, nil
+}
+
+func (m *MockWebhookService) FindByBoardID(ctx context.Context, boardID, userID string) ([]*models.Webhook, error) {
+	return []*models.Webhook{}, nil
+}
+
+func (m *MockWebhookService) Update(ctx context.Context, webhookID, userID, targetURL string, eventTypes []string, active *bool) (*models.Webhook, error) {
+	return &models.Webhook{ID: webhookID, URL: targetURL}, nil
+}
+
+func (m *MockWebhookService) Delete(ctx context.Context, webhookID, userID string) error {
+	return nil
+}
+
+func (m *MockWebhookService) FindDeliveries(ctx context.Context, webhookID, userID string) ([]*models.WebhookDelivery, error) {
+	return []*models.WebhookDelivery{}, nil
+}
+
+func (m *MockWebhookService) HandleEvent(ctx context.Context, event events.Event) {}
+
+func (m *MockWebhookService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	return 0, nil
+}
+
+type MockRuleService struct{}
+
+func (m *MockRuleService) Create(ctx context.Context, boardID, userID string, input services.RuleInput) (*models.Rule, error) {
+	return &models.Rule{ID: "rule-1", BoardID: boardID, Name: input.Name, Enabled: true}, nil
+}
+
+func (m *MockRuleService) FindByBoardID(ctx context.Context, boardID, userID string) ([]*models.Rule, error) {
+	return []*models.Rule{}, nil
+}
+
+func (m *MockRuleService) Update(ctx context.Context, ruleID, userID string, input services.RuleInput) (*models.Rule, error) {
+	return &models.Rule{ID: ruleID, Name: input.Name}, nil
+}
+
+func (m *MockRuleService) Delete(ctx context.Context, ruleID, userID string) error {
+	return nil
+}
+
+func (m *MockRuleService) FindExecutions(ctx context.Context, ruleID, userID string) ([]*models.RuleExecution, error) {
+	return []*models.RuleExecution{}, nil
+}
+
+func (m *MockRuleService) HandleEvent(ctx context.Context, event events.Event) {}
+
+func (m *MockRuleService) RunDue(ctx context.Context, now time.Time) (int, error) {
+	return 0, nil
+}
+
+type MockGitIntegrationService struct{}
+
+func (m *MockGitIntegrationService) Configure(ctx context.Context, boardID, userID string, openedColumnID, mergedColumnID *string, rotateSecret bool) (*services.ConfiguredGitIntegration, error) {
+	return &services.ConfiguredGitIntegration{GitIntegration: &models.GitIntegration{ID: "git-1", BoardID: boardID}, Secret: "secret"}, nil
+}
+
+func (m *MockGitIntegrationService) Find(ctx context.Context, boardID, userID string) (*models.GitIntegration, error) {
+	return &models.GitIntegration{ID: "git-1", BoardID: boardID}, nil
+}
+
+func (m *MockGitIntegrationService) Delete(ctx context.Context, boardID, userID string) error {
+	return nil
+}
+
+func (m *MockGitIntegrationService) HandleWebhook(ctx context.Context, integrationID string, headers services.GitWebhookHeaders, body []byte) (*services.GitWebhookResult, error) {
+	if headers.GitLabToken != "secret" {
+		return nil, utils.NewUnauthorized("invalid webhook token")
+	}
+	return &services.GitWebhookResult{Provider: services.GitProviderGitLab, Event: headers.GitLabEvent}, nil
+}
+
+type MockEmailInboxService struct{}
+
+func (m *MockEmailInboxService) Configure(ctx context.Context, boardID, userID string, columnID *string, rotateAddress bool) (*models.BoardInbox, error) {
+	return &models.BoardInbox{ID: "inbox-1", BoardID: boardID, LocalPart: "ops-abc", Address: "ops-abc@in.example.com"}, nil
+}
+
+func (m *MockEmailInboxService) Find(ctx context.Context, boardID, userID string) (*models.BoardInbox, error) {
+	return &models.BoardInbox{ID: "inbox-1", BoardID: boardID, LocalPart: "ops-abc", Address: "ops-abc@in.example.com"}, nil
+}
+
+func (m *MockEmailInboxService) Delete(ctx context.Context, boardID, userID string) error {
+	return nil
+}
+
+func (m *MockEmailInboxService) ReplyAddress(taskID, userID string) string {
+	return ""
+}
+
+func (m *MockEmailInboxService) Receive(ctx context.Context, secret string, envelopeRecipients []string, raw []byte) (*services.InboundEmailResult, error) {
+	if secret != "secret" {
+		return nil, utils.NewUnauthorized("invalid inbound email secret")
+	}
+	return &services.InboundEmailResult{TaskID: "task-1"}, nil
+}
+
+type MockNotificationEmailService struct{}
+
+func (m *MockNotificationEmailService) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	return []models.NotificationPreference{{UserID: userID, Type: models.NotificationTypeTaskComment, Delivery: models.EmailDeliveryInstant}}, nil
+}
+
+func (m *MockNotificationEmailService) UpdatePreferences(ctx context.Context, userID string, deliveries map[string]string) ([]models.NotificationPreference, error) {
+	return []models.NotificationPreference{{UserID: userID, Type: models.NotificationTypeTaskComment, Delivery: deliveries[models.NotificationTypeTaskComment]}}, nil
+}
+
+func (m *MockNotificationEmailService) SendDue(ctx context.Context, now time.Time) (int, error) {
+	return 0, nil
+}
+
+type MockHealthService struct{}
+
+func (m *MockHealthService) Ready(ctx context.Context) *services.ReadinessReport {
+	return &services.ReadinessReport{Status: services.HealthStatusOK, Checks: map[string]string{"database": services.HealthStatusOK}}
+}
+
+func (m *MockHealthService) Drain() {}
+
 func setupApp() *fiber.App {
+	return setupAppWithConfig(config.Defaults().Server)
+}
+
+func setupAppWithConfig(serverConfig config.ServerConfig) *fiber.App {
 	app := fiber.New()
 
 	mockAuthService := &MockAuthService{}
@@ -310,15 +688,51 @@ func setupApp() *fiber.App {
 	mockCommentService := &MockCommentService{}
 	mockLabelService := &MockLabelService{}
 	mockAttachmentService := &MockAttachmentService{}
-
-	authController := controllers.NewAuthController(mockAuthService)
-	boardController := controllers.NewBoardController(mockBoardService)
-	taskController := controllers.NewTaskController(mockTaskService)
-	commentController := controllers.NewCommentController(mockCommentService)
-	labelController := controllers.NewLabelController(mockLabelService)
-	attachmentController := controllers.NewAttachmentController(mockAttachmentService)
-
-	Setup(app, mockAuthService, authController, boardController, taskController, commentController, labelController, attachmentController)
+	mockAssigneeService := &MockAssigneeService{}
+	mockUserService := &MockUserService{}
+	mockWatchService := &MockWatchService{}
+	mockActivityService := &MockActivityService{}
+	mockAnalyticsService := &MockAnalyticsService{}
+	mockSprintService := &MockSprintService{}
+	mockTemplateService := &MockBoardTemplateService{}
+	mockTrashService := &MockTrashService{}
+	mockExportService := &MockBoardExportService{}
+	mockImportService := &MockImportService{}
+	mockCSVService := &MockTaskCSVService{}
+	mockCalendarService := &MockCalendarService{}
+	mockWebhookService := &MockWebhookService{}
+	mockRuleService := &MockRuleService{}
+	mockGitService := &MockGitIntegrationService{}
+	mockInboxService := &MockEmailInboxService{}
+	mockNotificationEmailService := &MockNotificationEmailService{}
+	mockHealthService := &MockHealthService{}
+
+	Setup(app, mockAuthService, Controllers{
+		Auth:              controllers.NewAuthController(mockAuthService),
+		Board:             controllers.NewBoardController(mockBoardService),
+		Task:              controllers.NewTaskController(mockTaskService),
+		Comment:           controllers.NewCommentController(mockCommentService),
+		Label:             controllers.NewLabelController(mockLabelService),
+		Attachment:        controllers.NewAttachmentController(mockAttachmentService),
+		Assignee:          controllers.NewAssigneeController(mockAssigneeService),
+		User:              controllers.NewUserController(mockUserService),
+		Watch:             controllers.NewWatchController(mockWatchService),
+		Activity:          controllers.NewActivityController(mockActivityService),
+		Analytics:         controllers.NewAnalyticsController(mockAnalyticsService),
+		Sprint:            controllers.NewSprintController(mockSprintService),
+		Template:          controllers.NewBoardTemplateController(mockTemplateService),
+		Trash:             controllers.NewTrashController(mockTrashService),
+		Export:            controllers.NewBoardExportController(mockExportService),
+		Import:            controllers.NewImportController(mockImportService),
+		TaskCSV:           controllers.NewTaskCSVController(mockCSVService),
+		Calendar:          controllers.NewCalendarController(mockCalendarService),
+		Webhook:           controllers.NewWebhookController(mockWebhookService),
+		Rule:              controllers.NewRuleController(mockRuleService),
+		Git:               controllers.NewGitIntegrationController(mockGitService),
+		EmailInbox:        controllers.NewEmailInboxController(mockInboxService),
+		NotificationEmail: controllers.NewNotificationEmailController(mockNotificationEmailService),
+		Health:            controllers.NewHealthController(mockHealthService),
+	}, serverConfig)
 
 	return app
 }
@@ -333,6 +747,78 @@ func TestHealthCheck(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
+func TestProbes(t *testing.T) {
+	app := setupApp()
+
+	for _, path := range []string{"/livez", "/readyz"} {
+		resp, err := app.Test(httptest.NewRequest("GET", path, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode, path)
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), `"status":"ok"`, path)
+	}
+}
+
+func TestMetrics_RecordsRouteTemplates(t *testing.T) {
+	app := setupApp()
+
+	for _, path := range []string{"/api/v1/boards/board123", "/wp-login.php"} {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer mock-jwt-token")
+		_, err := app.Test(req)
+		assert.NoError(t, err)
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `kanban_http_requests_total{method="GET",route="/api/v1/boards/:id"`)
+	assert.Contains(t, string(body), `kanban_http_requests_total{method="GET",route="unmatched",status="404"}`)
+	assert.NotContains(t, string(body), "board123")
+	assert.NotContains(t, string(body), "wp-login")
+}
+
+func TestMetrics_RequiresConfiguredToken(t *testing.T) {
+	serverConfig := config.Defaults().Server
+	serverConfig.MetricsToken = "scrape-secret"
+	app := setupAppWithConfig(serverConfig)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer scrape-secret")
+	resp, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestTracing_ContinuesIncomingTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(previous)
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/boards/board123", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, err := app.Test(req)
+	assert.NoError(t, err)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "GET /api/v1/boards/:id", spans[0].Name())
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].Parent().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	}
+}
+
 func TestAuthRegister(t *testing.T) {
 	app := setupApp()
 
@@ -474,6 +960,20 @@ func TestTaskFindByID_WithValidToken(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
+func TestTaskFindByKey_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/tasks/key/OPS-1", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"key":"OPS-1"`)
+}
+
 func TestTaskFindByColumnID_WithValidToken(t *testing.T) {
 	app := setupApp()
 
@@ -522,6 +1022,384 @@ func TestTaskMove_WithValidToken(t *testing.T) {
 	assert.Equal(t, 200, resp.StatusCode)
 }
 
+func TestTaskAssign_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("POST", "/api/v1/tasks/task-1/assignees/user-2", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestSettingsUpdate_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	payload := `{"reminder_lead_times":"120,30"}`
+	req := httptest.NewRequest("PUT", "/api/v1/me/settings", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestSettingsGet_WithoutToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/me/settings", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestTaskWatch_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("POST", "/api/v1/tasks/task-1/watch", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestWatching_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/me/watching", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestTaskActivity_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/tasks/task-1/activity?page=1&limit=10", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestBoardAnalytics_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/boards/board-1/analytics?from=2026-01-01&to=2026-01-31", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestCumulativeFlow_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/boards/board-1/cfd?from=2026-01-01&to=2026-01-31&interval=week", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestSprintBurndown_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/sprints/sprint-1/burndown", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestSprintBurndown_WithoutToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/sprints/sprint-1/burndown", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestBoardTemplates_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/board-templates", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestTrash_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/trash", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("POST", "/api/v1/trash/task/task-1/restore", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestBoardExport_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/boards/board-1/export", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "board-board-1.json")
+
+	req = httptest.NewRequest("POST", "/api/v1/boards/import", strings.NewReader(`{"version":1,"board":{"title":"Imported"},"columns":[]}`))
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestImports_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("POST", "/api/v1/imports/trello", strings.NewReader(`{"name":"Roadmap","lists":[]}`))
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/api/v1/imports/job-1", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/api/v1/imports/job-2", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestTaskCSV_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/api/v1/boards/board-1/tasks.csv", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+
+	req = httptest.NewRequest("POST", "/api/v1/boards/board-1/tasks/import?dry_run=true", strings.NewReader("title\nTest Task\n"))
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	req.Header.Set("Content-Type", "text/csv")
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+}
+
+func TestCalendarFeed_WithoutBearerToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("GET", "/calendar/secret.ics", nil)
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/calendar")
+
+	req = httptest.NewRequest("GET", "/calendar/revoked.ics", nil)
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestCalendarTokens_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("POST", "/api/v1/me/calendar-tokens", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("POST", "/api/v1/me/calendar-tokens", nil)
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestWebhooks_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("POST", "/api/v1/boards/board-1/webhooks", strings.NewReader(`{"url":"https://example.com/hook"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/api/v1/webhooks/webhook-1/deliveries", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/api/v1/webhooks/webhook-1/deliveries", nil)
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestRules_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("POST", "/api/v1/boards/board-1/rules", strings.NewReader(`{"name":"Unblock done","trigger":{"type":"task_moved"},"actions":[{"type":"remove_label","label":"blocked"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/api/v1/rules/rule-1/executions", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/api/v1/rules/rule-1/executions", nil)
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestGitWebhook_WithoutBearerToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("POST", "/hooks/git/git-1", strings.NewReader(`{}`))
+	req.Header.Set("X-Gitlab-Event", "Push Hook")
+	req.Header.Set("X-Gitlab-Token", "secret")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("POST", "/hooks/git/git-1", strings.NewReader(`{}`))
+	req.Header.Set("X-Gitlab-Event", "Push Hook")
+	req.Header.Set("X-Gitlab-Token", "wrong")
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestGitIntegration_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("PUT", "/api/v1/boards/board-1/git-integration", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/api/v1/boards/board-1/git-integration", nil)
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestInboundEmail_WithoutBearerToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("POST", "/hooks/email", strings.NewReader("Subject: Hi\r\n\r\nBody"))
+	req.Header.Set("X-Inbound-Email-Secret", "secret")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("POST", "/hooks/email?token=wrong", strings.NewReader("Subject: Hi\r\n\r\nBody"))
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestEmailInbox_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("PUT", "/api/v1/boards/board-1/inbox", nil)
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/api/v1/boards/board-1/inbox", nil)
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
+func TestNotificationPreferences_WithValidToken(t *testing.T) {
+	app := setupApp()
+
+	req := httptest.NewRequest("PUT", "/api/v1/me/notification-preferences", strings.NewReader(`{"preferences":{"task_comment":"daily"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer mock-jwt-token")
+	resp, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	req = httptest.NewRequest("GET", "/api/v1/me/notification-preferences", nil)
+	resp, err = app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 401, resp.StatusCode)
+}
+
 func TestInvalidRoute(t *testing.T) {
 	app := setupApp()
 
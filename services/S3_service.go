@@ -1,30 +1,38 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"mime/multipart"
-	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"kanban-backend/config"
+	"kanban-backend/tracing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
-type S3Service struct{}
+type S3Service struct {
+	bucket string
+	region string
+}
 
-func NewS3Service() *S3Service {
-	return &S3Service{}
+func NewS3Service(bucket, region string) *S3Service {
+	return &S3Service{
+		bucket: bucket,
+		region: region,
+	}
 }
 
 // UploadFile uploads a file to S3 and returns the public URL
 func (s *S3Service) UploadFile(file multipart.File, header *multipart.FileHeader, folder string) (string, error) {
-	bucket := os.Getenv("AWS_S3_BUCKET")
-	region := os.Getenv("AWS_REGION")
+	bucket := s.bucket
+	region := s.region
 
 	// Generate unique filename
 	ext := filepath.Ext(header.Filename)
@@ -48,9 +56,34 @@ func (s *S3Service) UploadFile(file multipart.File, header *multipart.FileHeader
 	return url, nil
 }
 
+// Upload stores content under folder and returns the public URL. It is the
+// AttachmentUploader used for files that do not arrive as multipart uploads.
+func (s *S3Service) Upload(ctx context.Context, folder, fileName, contentType string, content []byte) (string, error) {
+	ctx, span := tracing.Start(ctx, "S3Service.Upload")
+	defer span.End()
+
+	bucket := s.bucket
+	region := s.region
+
+	key := fmt.Sprintf("%s/%d%s", folder, time.Now().UnixNano(), filepath.Ext(fileName))
+
+	uploader := manager.NewUploader(config.S3Client)
+	_, err := uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(content),
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, region, key), nil
+}
+
 // DeleteFile deletes a file from S3
 func (s *S3Service) DeleteFile(key string) error {
-	bucket := os.Getenv("AWS_S3_BUCKET")
+	bucket := s.bucket
 
 	_, err := config.S3Client.DeleteObject(context.TODO(), &s3.DeleteObjectInput{
 		Bucket: aws.String(bucket),
@@ -62,9 +95,21 @@ func (s *S3Service) DeleteFile(key string) error {
 	return nil
 }
 
+// KeyForURL returns the object key of a public URL made by UploadFile or
+// Upload. URLs that do not point into the bucket, such as links kept from an
+// import, report false.
+func (s *S3Service) KeyForURL(url string) (string, bool) {
+	prefix := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/", s.bucket, s.region)
+	key, ok := strings.CutPrefix(url, prefix)
+	if !ok || key == "" {
+		return "", false
+	}
+	return key, true
+}
+
 // GetSignedURL generates a temporary signed URL (expires in 1 hour)
 func (s *S3Service) GetSignedURL(key string) (string, error) {
-	bucket := os.Getenv("AWS_S3_BUCKET")
+	bucket := s.bucket
 
 	presignClient := s3.NewPresignClient(config.S3Client)
 
@@ -80,3 +125,11 @@ func (s *S3Service) GetSignedURL(key string) (string, error) {
 	}
 	return result.URL, nil
 }
+
+// Ping checks that the bucket exists and the credentials can reach it
+func (s *S3Service) Ping(ctx context.Context) error {
+	_, err := config.S3Client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(s.bucket),
+	})
+	return err
+}
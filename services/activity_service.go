@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+type ActivityService interface {
+	FindByTaskIDWithPagination(ctx context.Context, taskID, userID string, page, limit int) ([]*models.TaskActivity, int, error)
+	HandleEvent(ctx context.Context, event events.Event)
+}
+
+type activityService struct {
+	activityRepo repositories.ActivityRepository
+	taskRepo     repositories.TaskRepository
+}
+
+func NewActivityService(activityRepo repositories.ActivityRepository, taskRepo repositories.TaskRepository) ActivityService {
+	return &activityService{
+		activityRepo: activityRepo,
+		taskRepo:     taskRepo,
+	}
+}
+
+func (s *activityService) FindByTaskIDWithPagination(ctx context.Context, taskID, userID string, page, limit int) ([]*models.TaskActivity, int, error) {
+	ctx, span := tracing.Start(ctx, "ActivityService.FindByTaskIDWithPagination")
+	defer span.End()
+
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, 0, utils.NewNotFound("task not found")
+	}
+
+	if task.Column == nil {
+		return nil, 0, utils.NewNotFound("column not found for task")
+	}
+
+	if task.Column.Board.UserID != userID {
+		return nil, 0, utils.NewUnauthorized("you do not have access to this task")
+	}
+
+	return s.activityRepo.FindByTaskIDWithPagination(ctx, taskID, page, limit)
+}
+
+// HandleEvent records the history entries for a task event
+func (s *activityService) HandleEvent(ctx context.Context, event events.Event) {
+	ctx, span := tracing.Start(ctx, "ActivityService.HandleEvent")
+	defer span.End()
+
+	activities := activitiesFor(event)
+	if len(activities) == 0 {
+		return
+	}
+
+	if event.TaskID == "" {
+		if err := s.activityRepo.CreateForBoardTasks(ctx, event.BoardID, activities); err != nil {
+			slog.ErrorContext(ctx, "failed to record activity", "board_id", event.BoardID, "error", err)
+		}
+		return
+	}
+
+	if err := s.activityRepo.CreateBatch(ctx, activities); err != nil {
+		slog.ErrorContext(ctx, "failed to record activity", "task_id", event.TaskID, "error", err)
+	}
+}
+
+// activitiesFor maps an event to activity entries. Events that do not change
+// the task itself, such as comments and deletions, produce none. Board events
+// produce entries without a task, which are recorded on each of its tasks.
+func activitiesFor(event events.Event) []*models.TaskActivity {
+	entry := func(action, field, oldValue, newValue string) *models.TaskActivity {
+		return &models.TaskActivity{
+			TaskID:    event.TaskID,
+			UserID:    event.ActorID,
+			Action:    action,
+			Field:     field,
+			OldValue:  optionalString(oldValue),
+			NewValue:  optionalString(newValue),
+			CreatedAt: event.OccurredAt,
+		}
+	}
+
+	switch event.Type {
+	case events.TaskCreated:
+		return []*models.TaskActivity{entry(models.ActivityCreated, "", "", "")}
+	case events.TaskUpdated, events.TaskMoved:
+		action := models.ActivityUpdated
+		if event.Type == events.TaskMoved {
+			action = models.ActivityMoved
+		}
+		activities := make([]*models.TaskActivity, 0, len(event.Changes))
+		for _, change := range event.Changes {
+			activities = append(activities, entry(action, change.Field, change.OldValue, change.NewValue))
+		}
+		return activities
+	case events.TaskArchived:
+		return []*models.TaskActivity{entry(models.ActivityArchived, "", "", "")}
+	case events.TaskUnarchived:
+		return []*models.TaskActivity{entry(models.ActivityUnarchived, "", "", "")}
+	case events.TaskRestored:
+		return []*models.TaskActivity{entry(models.ActivityRestored, "", "", "")}
+	case events.BoardArchived:
+		return []*models.TaskActivity{entry(models.ActivityArchived, "board", "", "")}
+	case events.BoardUnarchived:
+		return []*models.TaskActivity{entry(models.ActivityUnarchived, "board", "", "")}
+	case events.BoardRestored:
+		return []*models.TaskActivity{entry(models.ActivityRestored, "board", "", "")}
+	case events.LabelAdded:
+		return []*models.TaskActivity{entry(models.ActivityLabelAdded, "label", "", event.Data["label_name"])}
+	case events.LabelRemoved:
+		return []*models.TaskActivity{entry(models.ActivityLabelRemoved, "label", event.Data["label_name"], "")}
+	case events.AttachmentCreated:
+		return []*models.TaskActivity{entry(models.ActivityAttachmentAdded, "attachment", "", event.Data["file_name"])}
+	case events.AttachmentDeleted:
+		return []*models.TaskActivity{entry(models.ActivityAttachmentRemoved, "attachment", event.Data["file_name"], "")}
+	case events.AssigneeAdded:
+		return []*models.TaskActivity{entry(models.ActivityAssigneeAdded, "assignee", "", event.Data["user_id"])}
+	case events.AssigneeRemoved:
+		return []*models.TaskActivity{entry(models.ActivityAssigneeRemoved, "assignee", event.Data["user_id"], "")}
+	default:
+		return nil
+	}
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+type mockActivityRepository struct {
+	activities []*models.TaskActivity
+	boardTasks map[string][]string
+}
+
+func (m *mockActivityRepository) CreateBatch(ctx context.Context, activities []*models.TaskActivity) error {
+	m.activities = append(m.activities, activities...)
+	return nil
+}
+
+func (m *mockActivityRepository) CreateForBoardTasks(ctx context.Context, boardID string, activities []*models.TaskActivity) error {
+	for _, taskID := range m.boardTasks[boardID] {
+		for _, activity := range activities {
+			entry := *activity
+			entry.TaskID = taskID
+			m.activities = append(m.activities, &entry)
+		}
+	}
+	return nil
+}
+
+func (m *mockActivityRepository) FindByTaskIDWithPagination(ctx context.Context, taskID string, page, limit int) ([]*models.TaskActivity, int, error) {
+	var matching []*models.TaskActivity
+	for _, activity := range m.activities {
+		if activity.TaskID == taskID {
+			matching = append(matching, activity)
+		}
+	}
+	return matching, len(matching), nil
+}
+
+func TestActivityService_HandleEventRecordsChanges(t *testing.T) {
+	activityRepo := &mockActivityRepository{}
+	service := NewActivityService(activityRepo, newMockTaskRepository())
+	task := setupTestTask("col-1")
+	occurredAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	updated := events.ForTask(events.TaskUpdated, "user123", task)
+	updated.OccurredAt = occurredAt
+	updated.Changes = []events.Change{
+		{Field: "title", OldValue: "Old", NewValue: "New"},
+		{Field: "deadline", OldValue: "", NewValue: "2026-03-05"},
+	}
+	service.HandleEvent(context.Background(), updated)
+
+	if len(activityRepo.activities) != 2 {
+		t.Fatalf("expected 2 activities, got %d", len(activityRepo.activities))
+	}
+
+	title := activityRepo.activities[0]
+	if title.Action != models.ActivityUpdated || title.Field != "title" || *title.OldValue != "Old" || *title.NewValue != "New" {
+		t.Errorf("unexpected title activity: %+v", title)
+	}
+	if !title.CreatedAt.Equal(occurredAt) || title.UserID != "user123" {
+		t.Errorf("activity should carry the actor and event time, got %+v", title)
+	}
+
+	deadline := activityRepo.activities[1]
+	if deadline.OldValue != nil {
+		t.Errorf("expected no old deadline, got %q", *deadline.OldValue)
+	}
+}
+
+func TestActivityService_HandleEventRecordsBoardChangesOnTasks(t *testing.T) {
+	activityRepo := &mockActivityRepository{boardTasks: map[string][]string{"board123": {"task-1", "task-2"}}}
+	service := NewActivityService(activityRepo, newMockTaskRepository())
+
+	service.HandleEvent(context.Background(), events.ForBoard(events.BoardArchived, "user123", &models.Board{ID: "board123", Title: "Ops"}))
+
+	if len(activityRepo.activities) != 2 {
+		t.Fatalf("expected an activity on each of the board's 2 tasks, got %d", len(activityRepo.activities))
+	}
+	for i, taskID := range []string{"task-1", "task-2"} {
+		activity := activityRepo.activities[i]
+		if activity.TaskID != taskID || activity.Action != models.ActivityArchived || activity.Field != "board" || activity.UserID != "user123" {
+			t.Errorf("unexpected activity %+v", activity)
+		}
+	}
+}
+
+func TestActivitiesFor(t *testing.T) {
+	task := setupTestTask("col-1")
+
+	moved := events.ForTask(events.TaskMoved, "user123", task)
+	moved.Changes = []events.Change{{Field: "column_id", OldValue: "col-1", NewValue: "col-2"}}
+
+	labelAdded := events.ForTask(events.LabelAdded, "user123", task)
+	labelAdded.Data["label_name"] = "bug"
+
+	assigneeRemoved := events.ForTask(events.AssigneeRemoved, "user123", task)
+	assigneeRemoved.Data["user_id"] = "bob"
+
+	tests := []struct {
+		name       string
+		event      events.Event
+		wantAction string
+		wantField  string
+	}{
+		{name: "created", event: events.ForTask(events.TaskCreated, "user123", task), wantAction: models.ActivityCreated},
+		{name: "moved", event: moved, wantAction: models.ActivityMoved, wantField: "column_id"},
+		{name: "label added", event: labelAdded, wantAction: models.ActivityLabelAdded, wantField: "label"},
+		{name: "assignee removed", event: assigneeRemoved, wantAction: models.ActivityAssigneeRemoved, wantField: "assignee"},
+		{name: "archived", event: events.ForTask(events.TaskArchived, "user123", task), wantAction: models.ActivityArchived},
+		{name: "unarchived", event: events.ForTask(events.TaskUnarchived, "user123", task), wantAction: models.ActivityUnarchived},
+		{name: "restored", event: events.ForTask(events.TaskRestored, "user123", task), wantAction: models.ActivityRestored},
+		{name: "board archived", event: events.ForBoard(events.BoardArchived, "user123", &models.Board{ID: "board123"}), wantAction: models.ActivityArchived, wantField: "board"},
+		{name: "board restored", event: events.ForBoard(events.BoardRestored, "user123", &models.Board{ID: "board123"}), wantAction: models.ActivityRestored, wantField: "board"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activities := activitiesFor(tt.event)
+			if len(activities) != 1 {
+				t.Fatalf("expected 1 activity, got %d", len(activities))
+			}
+			if activities[0].Action != tt.wantAction || activities[0].Field != tt.wantField {
+				t.Errorf("got action %s field %s, want %s %s", activities[0].Action, activities[0].Field, tt.wantAction, tt.wantField)
+			}
+		})
+	}
+
+	if activities := activitiesFor(events.ForTask(events.CommentCreated, "user123", task)); len(activities) != 0 {
+		t.Errorf("comments should not be recorded as activity, got %d", len(activities))
+	}
+}
+
+func TestActivityService_FindByTaskIDUnauthorized(t *testing.T) {
+	taskRepo := newMockTaskRepository()
+	task := setupTestTask("col-1")
+	taskRepo.tasks[task.ID] = task
+	service := NewActivityService(&mockActivityRepository{}, taskRepo)
+
+	_, _, err := service.FindByTaskIDWithPagination(context.Background(), task.ID, "someone-else", 1, 10)
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if !errors.As(err, &unauthorizedErr) {
+		t.Errorf("FindByTaskIDWithPagination() error = %v, want unauthorized", err)
+	}
+}
@@ -0,0 +1,567 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+// DefaultAnalyticsRange is the reporting window used when no start date is given
+const DefaultAnalyticsRange = 30 * 24 * time.Hour
+
+// Cumulative flow intervals
+const (
+	CFDIntervalDay  = "day"
+	CFDIntervalWeek = "week"
+
+	// MaxCFDPoints caps the number of intervals returned by a single request
+	MaxCFDPoints = 366
+)
+
+// DurationStats summarizes a set of durations in hours
+type DurationStats struct {
+	Count        int     `json:"count"`
+	AverageHours float64 `json:"average_hours"`
+	P50Hours     float64 `json:"p50_hours"`
+	P85Hours     float64 `json:"p85_hours"`
+	P95Hours     float64 `json:"p95_hours"`
+}
+
+// WeeklyThroughput is the number of tasks finished in the week starting on WeekStart (Monday, UTC)
+type WeeklyThroughput struct {
+	WeekStart time.Time `json:"week_start"`
+	Completed int       `json:"completed"`
+}
+
+// ColumnAge is the open work sitting in a column and how long it has been in flight
+type ColumnAge struct {
+	ColumnID        string  `json:"column_id"`
+	ColumnTitle     string  `json:"column_title"`
+	Stage           string  `json:"stage"`
+	OpenTasks       int     `json:"open_tasks"`
+	AverageAgeHours float64 `json:"average_age_hours"`
+}
+
+// BoardAnalytics holds flow metrics for a board over a date range
+type BoardAnalytics struct {
+	BoardID    string             `json:"board_id"`
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	Completed  int                `json:"completed"`
+	LeadTime   DurationStats      `json:"lead_time"`
+	CycleTime  DurationStats      `json:"cycle_time"`
+	Throughput []WeeklyThroughput `json:"throughput"`
+	OpenWork   []ColumnAge        `json:"open_work"`
+}
+
+// CFDColumn describes a column that appears in cumulative flow data. Columns
+// deleted since are kept so that history referencing them still adds up.
+type CFDColumn struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Order   int    `json:"order"`
+	Stage   string `json:"stage,omitempty"`
+	Deleted bool   `json:"deleted"`
+}
+
+// CFDPoint is the number of tasks in each column at the end of an interval.
+// Closed intervals ended in the past and will not change.
+type CFDPoint struct {
+	Start  time.Time      `json:"start"`
+	End    time.Time      `json:"end"`
+	Closed bool           `json:"closed"`
+	Counts map[string]int `json:"counts"`
+}
+
+// CumulativeFlow holds cumulative flow diagram data for a board
+type CumulativeFlow struct {
+	BoardID  string      `json:"board_id"`
+	From     time.Time   `json:"from"`
+	To       time.Time   `json:"to"`
+	Interval string      `json:"interval"`
+	Columns  []CFDColumn `json:"columns"`
+	Points   []CFDPoint  `json:"points"`
+}
+
+// Closed reports whether every interval has ended, so the data can be cached
+func (f *CumulativeFlow) Closed() bool {
+	for _, point := range f.Points {
+		if !point.Closed {
+			return false
+		}
+	}
+	return true
+}
+
+type AnalyticsService interface {
+	BoardAnalytics(ctx context.Context, boardID, userID string, from, to time.Time) (*BoardAnalytics, error)
+	CumulativeFlow(ctx context.Context, boardID, userID string, from, to time.Time, interval string) (*CumulativeFlow, error)
+	SetColumnStage(ctx context.Context, boardID, columnID, userID, stage string) (*models.Column, error)
+}
+
+type analyticsService struct {
+	analyticsRepo repositories.AnalyticsRepository
+	boardRepo     repositories.BoardRepository
+	columnRepo    repositories.ColumnRepository
+}
+
+func NewAnalyticsService(analyticsRepo repositories.AnalyticsRepository, boardRepo repositories.BoardRepository, columnRepo repositories.ColumnRepository) AnalyticsService {
+	return &analyticsService{
+		analyticsRepo: analyticsRepo,
+		boardRepo:     boardRepo,
+		columnRepo:    columnRepo,
+	}
+}
+
+// BoardAnalytics computes lead time (created to done), cycle time (first
+// started to done) and weekly throughput for tasks finished between from and
+// to, plus the age of work that is still open. Which columns count as started
+// and done is taken from each column's stage.
+func (s *analyticsService) BoardAnalytics(ctx context.Context, boardID, userID string, from, to time.Time) (*BoardAnalytics, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.BoardAnalytics")
+	defer span.End()
+
+	if to.Before(from) {
+		return nil, utils.NewValidation("from must be before to")
+	}
+
+	if err := s.checkBoardAccess(ctx, boardID, userID); err != nil {
+		return nil, err
+	}
+
+	columns, err := s.columnRepo.FindByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.analyticsRepo.FindBoardTasks(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions, err := s.analyticsRepo.FindColumnTransitions(ctx, boardID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeBoardAnalytics(boardID, columns, tasks, transitions, from.UTC(), to.UTC()), nil
+}
+
+// CumulativeFlow reconstructs how many tasks sat in each column at the end of
+// every interval between from and to, replaying the recorded column moves
+func (s *analyticsService) CumulativeFlow(ctx context.Context, boardID, userID string, from, to time.Time, interval string) (*CumulativeFlow, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.CumulativeFlow")
+	defer span.End()
+
+	if interval != CFDIntervalDay && interval != CFDIntervalWeek {
+		return nil, utils.NewValidation("interval must be day or week")
+	}
+
+	if to.Before(from) {
+		return nil, utils.NewValidation("from must be before to")
+	}
+
+	if countIntervals(from.UTC(), to.UTC(), interval) > MaxCFDPoints {
+		return nil, utils.NewValidation(fmt.Sprintf("range is too large: at most %d intervals are allowed", MaxCFDPoints))
+	}
+
+	if err := s.checkBoardAccess(ctx, boardID, userID); err != nil {
+		return nil, err
+	}
+
+	columns, err := s.analyticsRepo.FindBoardColumns(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.analyticsRepo.FindBoardTasks(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions, err := s.analyticsRepo.FindColumnTransitions(ctx, boardID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeCumulativeFlow(boardID, columns, tasks, transitions, from.UTC(), to.UTC(), interval, time.Now().UTC()), nil
+}
+
+func (s *analyticsService) SetColumnStage(ctx context.Context, boardID, columnID, userID, stage string) (*models.Column, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsService.SetColumnStage")
+	defer span.End()
+
+	if !models.IsValidColumnStage(stage) {
+		return nil, utils.NewValidation("stage must be one of todo, in_progress, done")
+	}
+
+	if err := s.checkBoardAccess(ctx, boardID, userID); err != nil {
+		return nil, err
+	}
+
+	column, err := s.columnRepo.FindByID(ctx, columnID)
+	if err != nil || column.BoardID != boardID {
+		return nil, utils.NewNotFound("column not found")
+	}
+
+	// Drop loaded relations so saving the column does not re-save them
+	column.Tasks = nil
+	column.Board = nil
+	column.Stage = stage
+
+	if err := s.columnRepo.Update(ctx, column); err != nil {
+		return nil, err
+	}
+
+	return column, nil
+}
+
+func (s *analyticsService) checkBoardAccess(ctx context.Context, boardID, userID string) error {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return utils.NewNotFound("board not found")
+	}
+
+	if board.UserID != userID {
+		return utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	return nil
+}
+
+// taskFlow is when a task started and finished, derived from its column moves
+type taskFlow struct {
+	startedAt *time.Time
+	doneAt    *time.Time
+}
+
+func computeBoardAnalytics(boardID string, columns []*models.Column, tasks []*models.Task, transitions []*models.TaskActivity, from, to time.Time) *BoardAnalytics {
+	columnsByID := make(map[string]*models.Column, len(columns))
+	for _, column := range columns {
+		columnsByID[column.ID] = column
+	}
+
+	transitionsByTask := make(map[string][]*models.TaskActivity)
+	for _, transition := range transitions {
+		transitionsByTask[transition.TaskID] = append(transitionsByTask[transition.TaskID], transition)
+	}
+
+	var leadTimes, cycleTimes []time.Duration
+	completedByWeek := make(map[time.Time]int)
+	openAges := make(map[string][]time.Duration)
+
+	for _, task := range tasks {
+		if task.CreatedAt.After(to) {
+			continue
+		}
+
+		flow := resolveTaskFlow(task, transitionsByTask[task.ID], columnsByID)
+
+		if flow.doneAt == nil {
+			started := task.CreatedAt
+			if flow.startedAt != nil {
+				started = *flow.startedAt
+			}
+			openAges[task.ColumnID] = append(openAges[task.ColumnID], to.Sub(started))
+			continue
+		}
+
+		if flow.doneAt.Before(from) || flow.doneAt.After(to) {
+			continue
+		}
+
+		leadTimes = append(leadTimes, flow.doneAt.Sub(task.CreatedAt))
+		if flow.startedAt != nil {
+			cycleTimes = append(cycleTimes, flow.doneAt.Sub(*flow.startedAt))
+		}
+		completedByWeek[weekStart(*flow.doneAt)]++
+	}
+
+	analytics := &BoardAnalytics{
+		BoardID:   boardID,
+		From:      from,
+		To:        to,
+		Completed: len(leadTimes),
+		LeadTime:  summarizeDurations(leadTimes),
+		CycleTime: summarizeDurations(cycleTimes),
+	}
+
+	for week := weekStart(from); !week.After(to); week = week.AddDate(0, 0, 7) {
+		analytics.Throughput = append(analytics.Throughput, WeeklyThroughput{
+			WeekStart: week,
+			Completed: completedByWeek[week],
+		})
+	}
+
+	ordered := make([]*models.Column, len(columns))
+	copy(ordered, columns)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].OrderNum < ordered[j].OrderNum
+	})
+
+	for _, column := range ordered {
+		if column.IsDone() {
+			continue
+		}
+		ages := openAges[column.ID]
+		analytics.OpenWork = append(analytics.OpenWork, ColumnAge{
+			ColumnID:        column.ID,
+			ColumnTitle:     column.Title,
+			Stage:           column.Stage,
+			OpenTasks:       len(ages),
+			AverageAgeHours: averageHours(ages),
+		})
+	}
+
+	return analytics
+}
+
+// resolveTaskFlow finds when a task was first started and, if it currently
+// sits in a done column, when it last entered one. Tasks created directly in a
+// started or done column count from their creation time.
+func resolveTaskFlow(task *models.Task, transitions []*models.TaskActivity, columnsByID map[string]*models.Column) taskFlow {
+	var flow taskFlow
+
+	initialColumnID := task.ColumnID
+	if len(transitions) > 0 && transitions[0].OldValue != nil {
+		initialColumnID = *transitions[0].OldValue
+	}
+
+	createdAt := task.CreatedAt
+	if isStartedStage(columnsByID[initialColumnID]) {
+		flow.startedAt = &createdAt
+	}
+
+	var lastDone *time.Time
+	if isDoneStage(columnsByID[initialColumnID]) {
+		lastDone = &createdAt
+	}
+
+	for _, transition := range transitions {
+		if transition.NewValue == nil {
+			continue
+		}
+		movedAt := transition.CreatedAt
+		target := columnsByID[*transition.NewValue]
+
+		if flow.startedAt == nil && isStartedStage(target) {
+			flow.startedAt = &movedAt
+		}
+
+		wasDone := transition.OldValue != nil && isDoneStage(columnsByID[*transition.OldValue])
+		if isDoneStage(target) && !wasDone {
+			lastDone = &movedAt
+		}
+	}
+
+	if isDoneStage(columnsByID[task.ColumnID]) {
+		flow.doneAt = lastDone
+		if flow.doneAt == nil {
+			flow.doneAt = &createdAt
+		}
+	}
+
+	return flow
+}
+
+// isStartedStage treats every column past the todo stage as started. Columns
+// that no longer exist are assumed to have been in progress.
+func isStartedStage(column *models.Column) bool {
+	return column == nil || column.Stage != models.ColumnStageTodo
+}
+
+func isDoneStage(column *models.Column) bool {
+	return column != nil && column.IsDone()
+}
+
+func computeCumulativeFlow(boardID string, columns []*models.Column, tasks []*models.Task, transitions []*models.TaskActivity, from, to time.Time, interval string, now time.Time) *CumulativeFlow {
+	flow := &CumulativeFlow{
+		BoardID:  boardID,
+		From:     from,
+		To:       to,
+		Interval: interval,
+	}
+
+	known := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		known[column.ID] = true
+		flow.Columns = append(flow.Columns, CFDColumn{
+			ID:      column.ID,
+			Title:   column.Title,
+			Order:   column.OrderNum,
+			Stage:   column.Stage,
+			Deleted: column.DeletedAt.Valid,
+		})
+	}
+
+	// Current columns keep their board order, soft-deleted ones follow
+	sort.SliceStable(flow.Columns, func(i, j int) bool {
+		if flow.Columns[i].Deleted != flow.Columns[j].Deleted {
+			return !flow.Columns[i].Deleted
+		}
+		return flow.Columns[i].Order < flow.Columns[j].Order
+	})
+
+	transitionsByTask := make(map[string][]*models.TaskActivity)
+	for _, transition := range transitions {
+		transitionsByTask[transition.TaskID] = append(transitionsByTask[transition.TaskID], transition)
+	}
+
+	// Columns removed from the database entirely only survive in the history
+	addUnknown := func(columnID string) {
+		if columnID == "" || known[columnID] {
+			return
+		}
+		known[columnID] = true
+		flow.Columns = append(flow.Columns, CFDColumn{ID: columnID, Title: "Deleted column", Deleted: true})
+	}
+	for _, task := range tasks {
+		addUnknown(task.ColumnID)
+	}
+	for _, transition := range transitions {
+		if transition.OldValue != nil {
+			addUnknown(*transition.OldValue)
+		}
+		if transition.NewValue != nil {
+			addUnknown(*transition.NewValue)
+		}
+	}
+
+	for start := intervalStart(from, interval); !start.After(to); start = nextInterval(start, interval) {
+		end := nextInterval(start, interval)
+		snapshot := end
+		if snapshot.After(to) {
+			snapshot = to
+		}
+		if snapshot.After(now) {
+			snapshot = now
+		}
+
+		point := CFDPoint{
+			Start:  start,
+			End:    end,
+			Closed: !end.After(now),
+			Counts: make(map[string]int, len(flow.Columns)),
+		}
+		for _, column := range flow.Columns {
+			point.Counts[column.ID] = 0
+		}
+
+		for _, task := range tasks {
+			if columnID, ok := columnAt(task, transitionsByTask[task.ID], snapshot); ok {
+				point.Counts[columnID]++
+			}
+		}
+
+		flow.Points = append(flow.Points, point)
+	}
+
+	return flow
+}
+
+// columnAt returns the column a task was in just before the given time, or
+// false when the task did not exist yet
+func columnAt(task *models.Task, transitions []*models.TaskActivity, at time.Time) (string, bool) {
+	if !task.CreatedAt.Before(at) {
+		return "", false
+	}
+
+	columnID := task.ColumnID
+	if len(transitions) > 0 && transitions[0].OldValue != nil {
+		columnID = *transitions[0].OldValue
+	}
+
+	for _, transition := range transitions {
+		if !transition.CreatedAt.Before(at) {
+			break
+		}
+		if transition.NewValue != nil {
+			columnID = *transition.NewValue
+		}
+	}
+
+	return columnID, true
+}
+
+func intervalStart(t time.Time, interval string) time.Time {
+	if interval == CFDIntervalWeek {
+		return weekStart(t)
+	}
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func nextInterval(start time.Time, interval string) time.Time {
+	if interval == CFDIntervalWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+func countIntervals(from, to time.Time, interval string) int {
+	count := 0
+	for start := intervalStart(from, interval); !start.After(to); start = nextInterval(start, interval) {
+		count++
+		if count > MaxCFDPoints {
+			break
+		}
+	}
+	return count
+}
+
+// weekStart returns midnight UTC on the Monday of t's week
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	year, month, day := t.AddDate(0, 0, -offset).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func summarizeDurations(durations []time.Duration) DurationStats {
+	if len(durations) == 0 {
+		return DurationStats{}
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	return DurationStats{
+		Count:        len(sorted),
+		AverageHours: averageHours(sorted),
+		P50Hours:     toHours(percentile(sorted, 50)),
+		P85Hours:     toHours(percentile(sorted, 85)),
+		P95Hours:     toHours(percentile(sorted, 95)),
+	}
+}
+
+// percentile uses the nearest-rank method on an ascending slice
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func averageHours(durations []time.Duration) float64 {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return toHours(total / time.Duration(len(durations)))
+}
+
+func toHours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
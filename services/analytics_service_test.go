@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+
+	"gorm.io/gorm"
+)
+
+type mockAnalyticsRepository struct {
+	columns     []*models.Column
+	tasks       []*models.Task
+	transitions []*models.TaskActivity
+}
+
+func (m *mockAnalyticsRepository) FindBoardColumns(ctx context.Context, boardID string) ([]*models.Column, error) {
+	return m.columns, nil
+}
+
+func (m *mockAnalyticsRepository) FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error) {
+	return m.tasks, nil
+}
+
+func (m *mockAnalyticsRepository) FindFieldChanges(ctx context.Context, boardID string, fields []string, until time.Time) ([]*models.TaskActivity, error) {
+	var changes []*models.TaskActivity
+	for _, change := range m.transitions {
+		for _, field := range fields {
+			if change.Field == field && !change.CreatedAt.After(until) {
+				changes = append(changes, change)
+			}
+		}
+	}
+	return changes, nil
+}
+
+func (m *mockAnalyticsRepository) FindColumnTransitions(ctx context.Context, boardID string, until time.Time) ([]*models.TaskActivity, error) {
+	var transitions []*models.TaskActivity
+	for _, transition := range m.transitions {
+		if !transition.CreatedAt.After(until) {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions, nil
+}
+
+// analyticsFixture builds a board with todo, in progress and done columns and
+// tasks whose history exercises lead time, cycle time and open work
+func analyticsFixture() ([]*models.Column, *mockAnalyticsRepository) {
+	columns := []*models.Column{
+		{ID: "done", BoardID: "board123", Title: "Shipped", OrderNum: 3, Stage: models.ColumnStageDone},
+		{ID: "todo", BoardID: "board123", Title: "Backlog", OrderNum: 1, Stage: models.ColumnStageTodo},
+		{ID: "doing", BoardID: "board123", Title: "Doing", OrderNum: 2, Stage: models.ColumnStageInProgress},
+	}
+
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+	}
+	move := func(taskID, from, to string, when time.Time) *models.TaskActivity {
+		return &models.TaskActivity{TaskID: taskID, Action: models.ActivityMoved, Field: "column_id", OldValue: &from, NewValue: &to, CreatedAt: when}
+	}
+
+	repo := &mockAnalyticsRepository{
+		tasks: []*models.Task{
+			{ID: "a", ColumnID: "done", CreatedAt: at(2, 9)},
+			{ID: "b", ColumnID: "done", CreatedAt: at(3, 9)},
+			{ID: "c", ColumnID: "done", CreatedAt: at(4, 9)},
+			{ID: "d", ColumnID: "doing", CreatedAt: at(5, 9)},
+			{ID: "e", ColumnID: "todo", CreatedAt: at(9, 9)},
+			{ID: "old", ColumnID: "done", CreatedAt: time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)},
+		},
+		transitions: []*models.TaskActivity{
+			move("old", "todo", "done", time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC)),
+			move("a", "todo", "doing", at(3, 9)),
+			move("b", "todo", "done", at(3, 19)),
+			move("c", "doing", "done", at(4, 14)),
+			move("a", "doing", "done", at(5, 9)),
+			move("d", "todo", "doing", at(6, 9)),
+		},
+	}
+
+	return columns, repo
+}
+
+func TestComputeBoardAnalytics(t *testing.T) {
+	columns, repo := analyticsFixture()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	analytics := computeBoardAnalytics("board123", columns, repo.tasks, repo.transitions, from, to)
+
+	if analytics.Completed != 3 {
+		t.Fatalf("Completed = %d, want 3", analytics.Completed)
+	}
+
+	wantLead := DurationStats{Count: 3, AverageHours: 29, P50Hours: 10, P85Hours: 72, P95Hours: 72}
+	if analytics.LeadTime != wantLead {
+		t.Errorf("LeadTime = %+v, want %+v", analytics.LeadTime, wantLead)
+	}
+
+	wantCycle := DurationStats{Count: 3, AverageHours: 17.67, P50Hours: 5, P85Hours: 48, P95Hours: 48}
+	if analytics.CycleTime != wantCycle {
+		t.Errorf("CycleTime = %+v, want %+v", analytics.CycleTime, wantCycle)
+	}
+
+	wantWeeks := []int{0, 3, 0}
+	if len(analytics.Throughput) != len(wantWeeks) {
+		t.Fatalf("Throughput has %d weeks, want %d", len(analytics.Throughput), len(wantWeeks))
+	}
+	for i, week := range analytics.Throughput {
+		if week.Completed != wantWeeks[i] {
+			t.Errorf("week %s completed = %d, want %d", week.WeekStart.Format(utils.DateLayout), week.Completed, wantWeeks[i])
+		}
+		if week.WeekStart.Weekday() != time.Monday {
+			t.Errorf("week %s does not start on Monday", week.WeekStart)
+		}
+	}
+
+	if len(analytics.OpenWork) != 2 {
+		t.Fatalf("OpenWork has %d columns, want 2", len(analytics.OpenWork))
+	}
+	if analytics.OpenWork[0].ColumnID != "todo" || analytics.OpenWork[0].AverageAgeHours != 24 {
+		t.Errorf("unexpected todo open work: %+v", analytics.OpenWork[0])
+	}
+	if analytics.OpenWork[1].ColumnID != "doing" || analytics.OpenWork[1].AverageAgeHours != 96 {
+		t.Errorf("unexpected doing open work: %+v", analytics.OpenWork[1])
+	}
+}
+
+func TestResolveTaskFlow_ReopenedTaskUsesLastCompletion(t *testing.T) {
+	columns, _ := analyticsFixture()
+	columnsByID := make(map[string]*models.Column)
+	for _, column := range columns {
+		columnsByID[column.ID] = column
+	}
+
+	created := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	move := func(from, to string, hours int) *models.TaskActivity {
+		return &models.TaskActivity{OldValue: &from, NewValue: &to, CreatedAt: created.Add(time.Duration(hours) * time.Hour)}
+	}
+
+	task := &models.Task{ID: "r", ColumnID: "done", CreatedAt: created}
+	flow := resolveTaskFlow(task, []*models.TaskActivity{
+		move("todo", "doing", 1),
+		move("doing", "done", 2),
+		move("done", "doing", 3),
+		move("doing", "done", 5),
+	}, columnsByID)
+
+	if flow.startedAt == nil || !flow.startedAt.Equal(created.Add(time.Hour)) {
+		t.Errorf("startedAt = %v, want first move into progress", flow.startedAt)
+	}
+	if flow.doneAt == nil || !flow.doneAt.Equal(created.Add(5*time.Hour)) {
+		t.Errorf("doneAt = %v, want last move into done", flow.doneAt)
+	}
+}
+
+func TestAnalyticsService_BoardAnalyticsAccess(t *testing.T) {
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards["board123"] = &models.Board{ID: "board123", UserID: "user123"}
+	service := NewAnalyticsService(&mockAnalyticsRepository{}, boardRepo, newMockColumnRepository())
+	ctx := context.Background()
+	now := time.Now()
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.BoardAnalytics(ctx, "board123", "someone-else", now.Add(-time.Hour), now); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("BoardAnalytics() error = %v, want unauthorized", err)
+	}
+
+	var validationErr utils.ErrValidation
+	if _, err := service.BoardAnalytics(ctx, "board123", "user123", now, now.Add(-time.Hour)); !errors.As(err, &validationErr) {
+		t.Errorf("BoardAnalytics() error = %v, want validation error", err)
+	}
+
+	if _, err := service.BoardAnalytics(ctx, "board123", "user123", now.Add(-time.Hour), now); err != nil {
+		t.Errorf("BoardAnalytics() unexpected error = %v", err)
+	}
+}
+
+func TestComputeCumulativeFlow(t *testing.T) {
+	columns, repo := analyticsFixture()
+	// The "doing" column was deleted after the range, and "review" no longer exists at all
+	columns[2].DeletedAt = gorm.DeletedAt{Time: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC), Valid: true}
+	from := "review"
+	to := "done"
+	repo.tasks = append(repo.tasks, &models.Task{ID: "f", ColumnID: "done", CreatedAt: time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)})
+	repo.transitions = append(repo.transitions, &models.TaskActivity{TaskID: "f", Action: models.ActivityMoved, Field: "column_id", OldValue: &from, NewValue: &to, CreatedAt: time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)})
+
+	start := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 5, 23, 59, 59, 0, time.UTC)
+	now := time.Date(2026, 3, 5, 18, 0, 0, 0, time.UTC)
+
+	flow := computeCumulativeFlow("board123", columns, repo.tasks, repo.transitions, start, end, CFDIntervalDay, now)
+
+	wantColumns := []string{"todo", "done", "doing", "review"}
+	if len(flow.Columns) != len(wantColumns) {
+		t.Fatalf("Columns = %+v, want %v", flow.Columns, wantColumns)
+	}
+	for i, id := range wantColumns {
+		if flow.Columns[i].ID != id {
+			t.Fatalf("column %d = %s, want %s", i, flow.Columns[i].ID, id)
+		}
+	}
+	if !flow.Columns[2].Deleted || flow.Columns[3].Title != "Deleted column" {
+		t.Errorf("expected deleted columns to be flagged: %+v", flow.Columns[2:])
+	}
+
+	// End of day counts; the last day is still open and sampled at now
+	want := []map[string]int{
+		{"todo": 0, "doing": 1, "done": 2, "review": 1},
+		{"todo": 0, "doing": 1, "done": 3, "review": 1},
+		{"todo": 1, "doing": 0, "done": 5, "review": 0},
+	}
+	if len(flow.Points) != len(want) {
+		t.Fatalf("Points has %d intervals, want %d", len(flow.Points), len(want))
+	}
+	for i, point := range flow.Points {
+		for columnID, count := range want[i] {
+			if point.Counts[columnID] != count {
+				t.Errorf("%s %s = %d, want %d", point.Start.Format(utils.DateLayout), columnID, point.Counts[columnID], count)
+			}
+		}
+	}
+
+	if !flow.Points[0].Closed || !flow.Points[1].Closed || flow.Points[2].Closed {
+		t.Errorf("unexpected closed flags: %v %v %v", flow.Points[0].Closed, flow.Points[1].Closed, flow.Points[2].Closed)
+	}
+	if flow.Closed() {
+		t.Error("flow with an open interval should not be closed")
+	}
+}
+
+func TestAnalyticsService_CumulativeFlowValidation(t *testing.T) {
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards["board123"] = &models.Board{ID: "board123", UserID: "user123"}
+	service := NewAnalyticsService(&mockAnalyticsRepository{}, boardRepo, newMockColumnRepository())
+	ctx := context.Background()
+	now := time.Now()
+
+	var validationErr utils.ErrValidation
+	if _, err := service.CumulativeFlow(ctx, "board123", "user123", now.Add(-time.Hour), now, "month"); !errors.As(err, &validationErr) {
+		t.Errorf("CumulativeFlow() error = %v, want validation error for interval", err)
+	}
+	if _, err := service.CumulativeFlow(ctx, "board123", "user123", now.AddDate(-2, 0, 0), now, CFDIntervalDay); !errors.As(err, &validationErr) {
+		t.Errorf("CumulativeFlow() error = %v, want validation error for range", err)
+	}
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.CumulativeFlow(ctx, "board123", "someone-else", now.Add(-time.Hour), now, CFDIntervalDay); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("CumulativeFlow() error = %v, want unauthorized", err)
+	}
+
+	if _, err := service.CumulativeFlow(ctx, "board123", "user123", now.AddDate(-2, 0, 0), now, CFDIntervalWeek); err != nil {
+		t.Errorf("CumulativeFlow() unexpected error = %v", err)
+	}
+}
+
+func TestAnalyticsService_SetColumnStage(t *testing.T) {
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards["board123"] = &models.Board{ID: "board123", UserID: "user123"}
+	columnRepo := newMockColumnRepository()
+	columnRepo.columns["col-1"] = &models.Column{ID: "col-1", BoardID: "board123", Title: "QA", Stage: models.ColumnStageInProgress}
+	service := NewAnalyticsService(&mockAnalyticsRepository{}, boardRepo, columnRepo)
+	ctx := context.Background()
+
+	column, err := service.SetColumnStage(ctx, "board123", "col-1", "user123", models.ColumnStageDone)
+	if err != nil {
+		t.Fatalf("SetColumnStage() unexpected error = %v", err)
+	}
+	if column.Stage != models.ColumnStageDone || columnRepo.columns["col-1"].Stage != models.ColumnStageDone {
+		t.Errorf("expected stage to be saved as done, got %s", column.Stage)
+	}
+
+	var validationErr utils.ErrValidation
+	if _, err := service.SetColumnStage(ctx, "board123", "col-1", "user123", "archived"); !errors.As(err, &validationErr) {
+		t.Errorf("SetColumnStage() error = %v, want validation error", err)
+	}
+
+	var notFoundErr utils.ErrNotFound
+	if _, err := service.SetColumnStage(ctx, "other-board", "col-1", "user123", models.ColumnStageDone); !errors.As(err, &notFoundErr) {
+		t.Errorf("SetColumnStage() error = %v, want not found", err)
+	}
+}
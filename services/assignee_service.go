@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+type AssigneeService interface {
+	AddToTask(ctx context.Context, taskID, assigneeID, userID string) error
+	RemoveFromTask(ctx context.Context, taskID, assigneeID, userID string) error
+	FindByTaskID(ctx context.Context, taskID, userID string) ([]*models.TaskAssignee, error)
+}
+
+type assigneeService struct {
+	assigneeRepo repositories.TaskAssigneeRepository
+	taskRepo     repositories.TaskRepository
+	userRepo     repositories.UserRepository
+}
+
+func NewAssigneeService(assigneeRepo repositories.TaskAssigneeRepository, taskRepo repositories.TaskRepository, userRepo repositories.UserRepository) AssigneeService {
+	return &assigneeService{
+		assigneeRepo: assigneeRepo,
+		taskRepo:     taskRepo,
+		userRepo:     userRepo,
+	}
+}
+
+func (s *assigneeService) AddToTask(ctx context.Context, taskID, assigneeID, userID string) error {
+	ctx, span := tracing.Start(ctx, "AssigneeService.AddToTask")
+	defer span.End()
+
+	task, err := s.checkTaskAccess(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.userRepo.FindByID(ctx, assigneeID); err != nil {
+		return utils.NewNotFound("user not found")
+	}
+
+	if err := s.assigneeRepo.Add(ctx, taskID, assigneeID); err != nil {
+		return err
+	}
+
+	event := events.ForTask(events.AssigneeAdded, userID, task)
+	event.Data["user_id"] = assigneeID
+	events.Publish(ctx, event)
+
+	return nil
+}
+
+func (s *assigneeService) RemoveFromTask(ctx context.Context, taskID, assigneeID, userID string) error {
+	ctx, span := tracing.Start(ctx, "AssigneeService.RemoveFromTask")
+	defer span.End()
+
+	task, err := s.checkTaskAccess(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.assigneeRepo.Remove(ctx, taskID, assigneeID); err != nil {
+		return utils.NewNotFound("user is not assigned to this task")
+	}
+
+	event := events.ForTask(events.AssigneeRemoved, userID, task)
+	event.Data["user_id"] = assigneeID
+	events.Publish(ctx, event)
+
+	return nil
+}
+
+func (s *assigneeService) FindByTaskID(ctx context.Context, taskID, userID string) ([]*models.TaskAssignee, error) {
+	ctx, span := tracing.Start(ctx, "AssigneeService.FindByTaskID")
+	defer span.End()
+
+	if _, err := s.checkTaskAccess(ctx, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.assigneeRepo.FindByTaskID(ctx, taskID)
+}
+
+func (s *assigneeService) checkTaskAccess(ctx context.Context, taskID, userID string) (*models.Task, error) {
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return nil, utils.NewNotFound("task not found")
+	}
+
+	if task.Column == nil {
+		return nil, utils.NewNotFound("column not found for task")
+	}
+
+	if task.Column.Board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this task")
+	}
+
+	return task, nil
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+type mockTaskAssigneeRepository struct {
+	assignees map[string][]string
+}
+
+func newMockTaskAssigneeRepository() *mockTaskAssigneeRepository {
+	return &mockTaskAssigneeRepository{
+		assignees: make(map[string][]string),
+	}
+}
+
+func (m *mockTaskAssigneeRepository) Add(ctx context.Context, taskID, userID string) error {
+	for _, id := range m.assignees[taskID] {
+		if id == userID {
+			return nil
+		}
+	}
+	m.assignees[taskID] = append(m.assignees[taskID], userID)
+	return nil
+}
+
+func (m *mockTaskAssigneeRepository) Remove(ctx context.Context, taskID, userID string) error {
+	ids := m.assignees[taskID]
+	for i, id := range ids {
+		if id == userID {
+			m.assignees[taskID] = append(ids[:i], ids[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("not assigned")
+}
+
+func (m *mockTaskAssigneeRepository) FindByTaskID(ctx context.Context, taskID string) ([]*models.TaskAssignee, error) {
+	var assignees []*models.TaskAssignee
+	for _, id := range m.assignees[taskID] {
+		assignees = append(assignees, &models.TaskAssignee{TaskID: taskID, UserID: id})
+	}
+	return assignees, nil
+}
+
+func setupAssigneeService() (AssigneeService, *mockTaskAssigneeRepository, *models.Task) {
+	taskRepo := newMockTaskRepository()
+	task := setupTestTask("col-1")
+	taskRepo.tasks[task.ID] = task
+
+	userRepo := newMockUserRepository()
+	userRepo.users["bob@example.com"] = &models.User{ID: "bob", Email: "bob@example.com"}
+
+	assigneeRepo := newMockTaskAssigneeRepository()
+	return NewAssigneeService(assigneeRepo, taskRepo, userRepo), assigneeRepo, task
+}
+
+func TestAssigneeService_AddToTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		assigneeID string
+		userID     string
+		wantErr    error
+	}{
+		{name: "owner assigns existing user", assigneeID: "bob", userID: "user123"},
+		{name: "unknown assignee", assigneeID: "ghost", userID: "user123", wantErr: utils.ErrNotFound{}},
+		{name: "non-owner cannot assign", assigneeID: "bob", userID: "intruder", wantErr: utils.ErrUnauthorized{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, assigneeRepo, task := setupAssigneeService()
+
+			err := service.AddToTask(context.Background(), task.ID, tt.assigneeID, tt.userID)
+
+			switch tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("AddToTask() unexpected error = %v", err)
+				}
+				if len(assigneeRepo.assignees[task.ID]) != 1 {
+					t.Errorf("expected 1 assignee, got %d", len(assigneeRepo.assignees[task.ID]))
+				}
+			case utils.ErrNotFound:
+				var notFoundErr utils.ErrNotFound
+				if !errors.As(err, &notFoundErr) {
+					t.Errorf("AddToTask() error = %v, want not found", err)
+				}
+			case utils.ErrUnauthorized:
+				var unauthorizedErr utils.ErrUnauthorized
+				if !errors.As(err, &unauthorizedErr) {
+					t.Errorf("AddToTask() error = %v, want unauthorized", err)
+				}
+			}
+		})
+	}
+}
+
+func TestAssigneeService_RemoveFromTask(t *testing.T) {
+	service, assigneeRepo, task := setupAssigneeService()
+	ctx := context.Background()
+
+	if err := service.AddToTask(ctx, task.ID, "bob", "user123"); err != nil {
+		t.Fatalf("AddToTask() unexpected error = %v", err)
+	}
+
+	if err := service.RemoveFromTask(ctx, task.ID, "bob", "user123"); err != nil {
+		t.Fatalf("RemoveFromTask() unexpected error = %v", err)
+	}
+
+	if len(assigneeRepo.assignees[task.ID]) != 0 {
+		t.Errorf("expected no assignees after removal, got %d", len(assigneeRepo.assignees[task.ID]))
+	}
+
+	err := service.RemoveFromTask(ctx, task.ID, "bob", "user123")
+	var notFoundErr utils.ErrNotFound
+	if !errors.As(err, &notFoundErr) {
+		t.Errorf("RemoveFromTask() for unassigned user error = %v, want not found", err)
+	}
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"kanban-backend/events"
 	"kanban-backend/models"
 	"kanban-backend/repositories"
+	"kanban-backend/tracing"
 	"kanban-backend/utils"
 )
 
@@ -29,6 +31,9 @@ func NewAttachmentService(attachmentRepo repositories.AttachmentRepository, task
 }
 
 func (s *attachmentService) Create(ctx context.Context, taskID, userID, fileName, fileURL string, fileSize int64) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Create")
+	defer span.End()
+
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
 		return nil, utils.NewNotFound("task not found")
@@ -54,10 +59,18 @@ func (s *attachmentService) Create(ctx context.Context, taskID, userID, fileName
 		return nil, err
 	}
 
+	event := events.ForTask(events.AttachmentCreated, userID, task)
+	event.Data["attachment_id"] = attachment.ID
+	event.Data["file_name"] = attachment.FileName
+	events.Publish(ctx, event)
+
 	return attachment, nil
 }
 
 func (s *attachmentService) FindByID(ctx context.Context, id, userID string) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.FindByID")
+	defer span.End()
+
 	attachment, err := s.attachmentRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, utils.NewNotFound("attachment not found")
@@ -67,7 +80,7 @@ func (s *attachmentService) FindByID(ctx context.Context, id, userID string) (*m
 		return nil, utils.NewNotFound("task not found for attachment")
 	}
 
-	if attachment.Task.Column == nil {
+	if attachment.Task.Column == nil || attachment.Task.Column.Board == nil {
 		return nil, utils.NewNotFound("column not found for task")
 	}
 
@@ -79,6 +92,9 @@ func (s *attachmentService) FindByID(ctx context.Context, id, userID string) (*m
 }
 
 func (s *attachmentService) FindByTaskID(ctx context.Context, taskID, userID string) ([]*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.FindByTaskID")
+	defer span.End()
+
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
 		return nil, utils.NewNotFound("task not found")
@@ -101,6 +117,9 @@ func (s *attachmentService) FindByTaskID(ctx context.Context, taskID, userID str
 }
 
 func (s *attachmentService) Update(ctx context.Context, id, userID, fileName, fileURL string, fileSize int64) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.Update")
+	defer span.End()
+
 	attachment, err := s.FindByID(ctx, id, userID)
 	if err != nil {
 		return nil, err
@@ -126,21 +145,34 @@ func (s *attachmentService) Update(ctx context.Context, id, userID, fileName, fi
 	return attachment, nil
 }
 
+// Delete moves the attachment to the trash; the stored file is kept until the
+// trash is purged, and after that as long as another attachment shares it
 func (s *attachmentService) Delete(ctx context.Context, id, userID string) error {
-	_, err := s.FindByID(ctx, id, userID)
+	ctx, span := tracing.Start(ctx, "AttachmentService.Delete")
+	defer span.End()
+
+	attachment, err := s.FindByID(ctx, id, userID)
 	if err != nil {
 		return err
 	}
 
-	err = s.attachmentRepo.Delete(ctx, id)
+	err = s.attachmentRepo.SoftDelete(ctx, id)
 	if err != nil {
 		return err
 	}
 
+	event := events.ForTask(events.AttachmentDeleted, userID, attachment.Task)
+	event.Data["attachment_id"] = attachment.ID
+	event.Data["file_name"] = attachment.FileName
+	events.Publish(ctx, event)
+
 	return nil
 }
 
 func (s *attachmentService) FindByTaskIDWithPagination(ctx context.Context, taskID, userID string, page, limit int) ([]*models.Attachment, int, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.FindByTaskIDWithPagination")
+	defer span.End()
+
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
 		return nil, 0, utils.NewNotFound("task not found")
@@ -119,6 +119,10 @@ func newMockTaskRepositoryForAttachment() *mockTaskRepositoryForAttachment {
 	}
 }
 
+func (m *mockTaskRepositoryForAttachment) FindByKey(ctx context.Context, userID, key string) (*models.Task, error) {
+	return nil, utils.NewNotFound("task not found")
+}
+
 func (m *mockTaskRepositoryForAttachment) FindByID(ctx context.Context, id string) (*models.Task, error) {
 	task, exists := m.tasks[id]
 	if !exists {
@@ -149,7 +153,7 @@ func (m *mockTaskRepositoryForAttachment) FindByColumnID(ctx context.Context, co
 	return nil, nil
 }
 
-func (m *mockTaskRepositoryForAttachment) FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, offset, limit int) ([]*models.Task, int, error) {
+func (m *mockTaskRepositoryForAttachment) FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, archived bool, offset, limit int) ([]*models.Task, int, error) {
 	return nil, 0, nil
 }
 
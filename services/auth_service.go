@@ -6,6 +6,7 @@ import (
 
 	"kanban-backend/models"
 	"kanban-backend/repositories"
+	"kanban-backend/tracing"
 	"kanban-backend/utils"
 )
 
@@ -20,16 +21,22 @@ type AuthService interface {
 }
 
 type authService struct {
-	userRepo repositories.UserRepository
+	userRepo    repositories.UserRepository
+	tokenExpiry time.Duration
 }
 
-func NewAuthService(userRepo repositories.UserRepository) AuthService {
+// NewAuthService issues access tokens that are valid for tokenExpiry
+func NewAuthService(userRepo repositories.UserRepository, tokenExpiry time.Duration) AuthService {
 	return &authService{
-		userRepo: userRepo,
+		userRepo:    userRepo,
+		tokenExpiry: tokenExpiry,
 	}
 }
 
 func (s *authService) Register(ctx context.Context, username, email, password string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Register")
+	defer span.End()
+
 	if len(password) < 8 {
 		return nil, utils.NewValidation("password must be at least 8 characters long")
 	}
@@ -54,6 +61,9 @@ func (s *authService) Register(ctx context.Context, username, email, password st
 }
 
 func (s *authService) Login(ctx context.Context, email, password string) (string, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.Login")
+	defer span.End()
+
 	user, err := s.userRepo.FindByEmail(ctx, email)
 	if err != nil {
 		return "", utils.NewUnauthorized("invalid email or password")
@@ -64,7 +74,7 @@ func (s *authService) Login(ctx context.Context, email, password string) (string
 		return "", utils.NewUnauthorized("invalid email or password")
 	}
 
-	token, err := utils.GenerateToken(user.ID, 24*time.Hour)
+	token, err := utils.GenerateToken(user.ID, s.tokenExpiry)
 	if err != nil {
 		return "", err
 	}
@@ -85,6 +95,9 @@ func (s *authService) ValidateToken(tokenString string) (string, error) {
 }
 
 func (s *authService) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "AuthService.GetUserByID")
+	defer span.End()
+
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, utils.NewNotFound("user not found")
@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"errors"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +12,11 @@ import (
 	"kanban-backend/utils"
 )
 
+func TestMain(m *testing.M) {
+	utils.SetJWTSecret("test-secret-key-for-testing")
+	os.Exit(m.Run())
+}
+
 type mockUserRepository struct {
 	users map[string]*models.User
 }
@@ -53,6 +60,15 @@ func (m *mockUserRepository) FindByEmail(ctx context.Context, email string) (*mo
 	return user, nil
 }
 
+func (m *mockUserRepository) FindByEmailIgnoreCase(ctx context.Context, email string) (*models.User, error) {
+	for _, user := range m.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
 func (m *mockUserRepository) FindByID(ctx context.Context, id string) (*models.User, error) {
 	for _, user := range m.users {
 		if user.ID == id {
@@ -86,7 +102,7 @@ func (m *mockUserRepository) SoftDelete(ctx context.Context, id string) error {
 
 func TestNewAuthService(t *testing.T) {
 	mockRepo := newMockUserRepository()
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, 24*time.Hour)
 
 	if service == nil {
 		t.Error("NewAuthService() should return non-nil service")
@@ -141,7 +157,7 @@ func TestAuthService_Register(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := newMockUserRepository()
-			service := NewAuthService(mockRepo)
+			service := NewAuthService(mockRepo, 24*time.Hour)
 
 			user, err := service.Register(context.Background(), tt.username, tt.email, tt.password)
 
@@ -192,7 +208,7 @@ func TestAuthService_Register(t *testing.T) {
 
 func TestAuthService_RegisterDuplicateEmail(t *testing.T) {
 	mockRepo := newMockUserRepository()
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, 24*time.Hour)
 
 	ctx := context.Background()
 	username := "testuser"
@@ -273,7 +289,7 @@ func TestAuthService_Login(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := newMockUserRepository()
-			service := NewAuthService(mockRepo)
+			service := NewAuthService(mockRepo, 24*time.Hour)
 			ctx := context.Background()
 
 			if tt.setupUser {
@@ -345,7 +361,7 @@ func TestAuthService_GenerateToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := newMockUserRepository()
-			service := NewAuthService(mockRepo)
+			service := NewAuthService(mockRepo, 24*time.Hour)
 
 			token, err := service.GenerateToken(tt.userID, tt.expiry)
 
@@ -410,7 +426,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := newMockUserRepository()
-			service := NewAuthService(mockRepo)
+			service := NewAuthService(mockRepo, 24*time.Hour)
 
 			userID, err := service.ValidateToken(tt.token)
 
@@ -473,7 +489,7 @@ func TestAuthService_HashPassword(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := newMockUserRepository()
-			service := NewAuthService(mockRepo)
+			service := NewAuthService(mockRepo, 24*time.Hour)
 
 			hashed, err := service.HashPassword(tt.password)
 
@@ -545,7 +561,7 @@ func TestAuthService_VerifyPassword(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := newMockUserRepository()
-			service := NewAuthService(mockRepo)
+			service := NewAuthService(mockRepo, 24*time.Hour)
 
 			err := service.VerifyPassword(tt.hashedPassword, tt.password)
 
@@ -565,7 +581,7 @@ func TestAuthService_VerifyPassword(t *testing.T) {
 
 func TestAuthService_Integration(t *testing.T) {
 	mockRepo := newMockUserRepository()
-	service := NewAuthService(mockRepo)
+	service := NewAuthService(mockRepo, 24*time.Hour)
 	ctx := context.Background()
 
 	username := "testuser"
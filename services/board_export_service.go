@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+type BoardExportService interface {
+	Export(ctx context.Context, boardID, userID string) (*models.BoardExport, error)
+	Import(ctx context.Context, userID string, export *models.BoardExport) (*models.Board, error)
+}
+
+type boardExportService struct {
+	exportRepo repositories.BoardExportRepository
+	boardRepo  repositories.BoardRepository
+	sprintRepo repositories.SprintRepository
+}
+
+func NewBoardExportService(exportRepo repositories.BoardExportRepository, boardRepo repositories.BoardRepository, sprintRepo repositories.SprintRepository) BoardExportService {
+	return &boardExportService{
+		exportRepo: exportRepo,
+		boardRepo:  boardRepo,
+		sprintRepo: sprintRepo,
+	}
+}
+
+// Export builds a self-contained snapshot of the board. Users are referenced
+// by email so the document can be imported on another server.
+func (s *boardExportService) Export(ctx context.Context, boardID, userID string) (*models.BoardExport, error) {
+	ctx, span := tracing.Start(ctx, "BoardExportService.Export")
+	defer span.End()
+
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+
+	if board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	tasks, err := s.exportRepo.FindBoardTasks(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	sprints, err := s.sprintRepo.FindByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var assigneeIDs []string
+	for _, task := range tasks {
+		for _, assignee := range task.Assignees {
+			assigneeIDs = append(assigneeIDs, assignee.UserID)
+		}
+	}
+	users, err := s.exportRepo.FindUsersByID(ctx, assigneeIDs)
+	if err != nil {
+		return nil, err
+	}
+	emails := make(map[string]string, len(users))
+	for _, user := range users {
+		emails[user.ID] = user.Email
+	}
+
+	return buildBoardExport(board, tasks, sprints, emails, time.Now().UTC()), nil
+}
+
+// Import recreates an exported board for the user. The user becomes the
+// author of every comment and the only possible assignee; see
+// BoardExportRepository.Import.
+func (s *boardExportService) Import(ctx context.Context, userID string, export *models.BoardExport) (*models.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardExportService.Import")
+	defer span.End()
+
+	if err := validateBoardExport(export); err != nil {
+		return nil, err
+	}
+
+	board := &models.Board{
+		Title:  export.Board.Title,
+		Color:  export.Board.Color,
+		UserID: userID,
+	}
+	if err := s.exportRepo.Import(ctx, board, export, nil); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+func buildBoardExport(board *models.Board, tasks []*models.Task, sprints []*models.Sprint, emails map[string]string, now time.Time) *models.BoardExport {
+	export := &models.BoardExport{
+		Version:    models.BoardExportVersion,
+		ExportedAt: now,
+		Board: models.ExportedBoard{
+			Title:     board.Title,
+			Color:     board.Color,
+			CreatedAt: board.CreatedAt,
+		},
+		Columns: make([]models.ExportedColumn, 0, len(board.Columns)),
+	}
+	if board.User != nil {
+		export.Board.OwnerEmail = board.User.Email
+	}
+
+	columns := make([]models.Column, len(board.Columns))
+	copy(columns, board.Columns)
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].OrderNum < columns[j].OrderNum
+	})
+	for _, column := range columns {
+		export.Columns = append(export.Columns, models.ExportedColumn{
+			ID:       column.ID,
+			Title:    column.Title,
+			Order:    column.OrderNum,
+			Stage:    column.Stage,
+			WipLimit: column.WipLimit,
+		})
+	}
+
+	for _, field := range board.CustomFields {
+		export.CustomFields = append(export.CustomFields, models.TemplateCustomField{
+			Name:    field.Name,
+			Type:    field.Type,
+			Options: field.Options,
+		})
+	}
+
+	for _, sprint := range sprints {
+		export.Sprints = append(export.Sprints, models.ExportedSprint{
+			ID:          sprint.ID,
+			Name:        sprint.Name,
+			Goal:        sprint.Goal,
+			StartDate:   sprint.StartDate,
+			EndDate:     sprint.EndDate,
+			Status:      sprint.Status,
+			StartedAt:   sprint.StartedAt,
+			CompletedAt: sprint.CompletedAt,
+		})
+	}
+
+	seenLabels := make(map[string]bool)
+	for _, task := range tasks {
+		exported := models.ExportedTask{
+			ID:             task.ID,
+			ColumnID:       task.ColumnID,
+			SprintID:       task.SprintID,
+			Title:          task.Title,
+			Description:    task.Description,
+			Deadline:       task.Deadline,
+			DeadlineAllDay: task.DeadlineAllDay,
+			StoryPoints:    task.StoryPoints,
+			ArchivedAt:     task.ArchivedAt,
+			CreatedAt:      task.CreatedAt,
+		}
+
+		for _, label := range task.Labels {
+			exported.Labels = append(exported.Labels, label.Name)
+			if !seenLabels[label.Name] {
+				seenLabels[label.Name] = true
+				export.Labels = append(export.Labels, models.TemplateLabel{Name: label.Name, Color: label.Color})
+			}
+		}
+
+		for _, assignee := range task.Assignees {
+			if email, ok := emails[assignee.UserID]; ok {
+				exported.Assignees = append(exported.Assignees, email)
+			}
+		}
+
+		for _, comment := range task.Comments {
+			exportedComment := models.ExportedComment{
+				Content:   comment.Content,
+				CreatedAt: comment.CreatedAt,
+			}
+			if comment.User != nil {
+				exportedComment.AuthorEmail = comment.User.Email
+			}
+			exported.Comments = append(exported.Comments, exportedComment)
+		}
+
+		for _, attachment := range task.Attachments {
+			exported.Attachments = append(exported.Attachments, models.ExportedAttachment{
+				FileName:  attachment.FileName,
+				FileURL:   attachment.FileURL,
+				FileSize:  attachment.FileSize,
+				CreatedAt: attachment.CreatedAt,
+			})
+		}
+
+		export.Tasks = append(export.Tasks, exported)
+	}
+
+	return export
+}
+
+// validateBoardExport checks an uploaded export before anything is created.
+// Columns without a stage default to in progress.
+func validateBoardExport(export *models.BoardExport) error {
+	if export.Version < 1 || export.Version > models.BoardExportVersion {
+		return utils.NewValidation(fmt.Sprintf("unsupported export version %d", export.Version))
+	}
+
+	if export.Board.Title == "" {
+		return utils.NewValidation("board title is required")
+	}
+
+	columns := make(map[string]bool, len(export.Columns))
+	for i := range export.Columns {
+		column := &export.Columns[i]
+		if column.ID == "" || columns[column.ID] {
+			return utils.NewValidation("column ids must be present and unique")
+		}
+		if column.Title == "" {
+			return utils.NewValidation(fmt.Sprintf("column %q has no title", column.ID))
+		}
+		if column.Stage == "" {
+			column.Stage = models.ColumnStageInProgress
+		}
+		if !models.IsValidColumnStage(column.Stage) {
+			return utils.NewValidation(fmt.Sprintf("column %q has an invalid stage", column.Title))
+		}
+		if column.WipLimit != nil && *column.WipLimit <= 0 {
+			return utils.NewValidation(fmt.Sprintf("column %q has an invalid WIP limit", column.Title))
+		}
+		columns[column.ID] = true
+	}
+
+	for _, field := range export.CustomFields {
+		if !models.IsValidCustomFieldType(field.Type) {
+			return utils.NewValidation(fmt.Sprintf("custom field %q has an invalid type", field.Name))
+		}
+	}
+
+	sprints := make(map[string]bool, len(export.Sprints))
+	for _, sprint := range export.Sprints {
+		if sprint.ID == "" || sprints[sprint.ID] {
+			return utils.NewValidation("sprint ids must be present and unique")
+		}
+		switch sprint.Status {
+		case models.SprintStatusPlanned, models.SprintStatusActive, models.SprintStatusCompleted:
+		default:
+			return utils.NewValidation(fmt.Sprintf("sprint %q has an invalid status", sprint.Name))
+		}
+		if sprint.EndDate.Before(sprint.StartDate) {
+			return utils.NewValidation(fmt.Sprintf("sprint %q ends before it starts", sprint.Name))
+		}
+		sprints[sprint.ID] = true
+	}
+
+	for _, task := range export.Tasks {
+		if task.Title == "" {
+			return utils.NewValidation("task title is required")
+		}
+		if !columns[task.ColumnID] {
+			return utils.NewValidation(fmt.Sprintf("task %q references unknown column %q", task.Title, task.ColumnID))
+		}
+		if task.SprintID != nil && !sprints[*task.SprintID] {
+			return utils.NewValidation(fmt.Sprintf("task %q references unknown sprint %q", task.Title, *task.SprintID))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+type mockBoardExportRepository struct {
+	tasks    []*models.Task
+	users    []*models.User
+	imported *models.BoardExport
+}
+
+func (m *mockBoardExportRepository) FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error) {
+	return m.tasks, nil
+}
+
+func (m *mockBoardExportRepository) FindUsersByID(ctx context.Context, ids []string) ([]*models.User, error) {
+	var users []*models.User
+	for _, user := range m.users {
+		for _, id := range ids {
+			if user.ID == id {
+				users = append(users, user)
+				break
+			}
+		}
+	}
+	return users, nil
+}
+
+func (m *mockBoardExportRepository) FindUsersByEmail(ctx context.Context, emails []string) ([]*models.User, error) {
+	var users []*models.User
+	for _, user := range m.users {
+		for _, email := range emails {
+			if user.Email == email {
+				users = append(users, user)
+				break
+			}
+		}
+	}
+	return users, nil
+}
+
+func (m *mockBoardExportRepository) FindUsersByUsername(ctx context.Context, usernames []string) ([]*models.User, error) {
+	var users []*models.User
+	for _, user := range m.users {
+		for _, username := range usernames {
+			if user.Username == username {
+				users = append(users, user)
+				break
+			}
+		}
+	}
+	return users, nil
+}
+
+func (m *mockBoardExportRepository) Import(ctx context.Context, board *models.Board, export *models.BoardExport, onTask func(imported int)) error {
+	board.ID = "imported-board"
+	m.imported = export
+	if onTask != nil {
+		for i := range export.Tasks {
+			onTask(i + 1)
+		}
+	}
+	return nil
+}
+
+func TestBoardExportService_Export(t *testing.T) {
+	board := &models.Board{
+		ID:     "board123",
+		UserID: "user123",
+		Title:  "Roadmap",
+		User:   &models.User{ID: "user123", Email: "owner@example.com"},
+		Columns: []models.Column{
+			{ID: "col-done", Title: "Done", OrderNum: 2, Stage: models.ColumnStageDone},
+			{ID: "col-todo", Title: "Todo", OrderNum: 1, Stage: models.ColumnStageTodo},
+		},
+	}
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards[board.ID] = board
+
+	exportRepo := &mockBoardExportRepository{
+		users: []*models.User{{ID: "user456", Email: "dev@example.com"}},
+		tasks: []*models.Task{
+			{
+				ID:        "task-1",
+				ColumnID:  "col-todo",
+				Title:     "Write spec",
+				Labels:    []models.Label{{Name: "Docs", Color: "#00ff00"}},
+				Assignees: []models.TaskAssignee{{TaskID: "task-1", UserID: "user456"}},
+				Comments:  []models.Comment{{Content: "Draft ready", User: &models.User{Email: "dev@example.com"}}},
+			},
+		},
+	}
+
+	service := NewBoardExportService(exportRepo, boardRepo, newMockSprintRepository())
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.Export(context.Background(), "board123", "someone-else"); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("Export() error = %v, want unauthorized", err)
+	}
+
+	export, err := service.Export(context.Background(), "board123", "user123")
+	if err != nil {
+		t.Fatalf("Export() unexpected error = %v", err)
+	}
+
+	if export.Version != models.BoardExportVersion || export.Board.OwnerEmail != "owner@example.com" {
+		t.Errorf("unexpected export header %+v", export.Board)
+	}
+	if len(export.Columns) != 2 || export.Columns[0].ID != "col-todo" {
+		t.Errorf("columns should be exported in board order, got %+v", export.Columns)
+	}
+	if len(export.Labels) != 1 || export.Labels[0].Name != "Docs" {
+		t.Errorf("labels = %+v, want Docs", export.Labels)
+	}
+	task := export.Tasks[0]
+	if len(task.Assignees) != 1 || task.Assignees[0] != "dev@example.com" {
+		t.Errorf("assignees = %v, want dev@example.com", task.Assignees)
+	}
+	if len(task.Comments) != 1 || task.Comments[0].AuthorEmail != "dev@example.com" {
+		t.Errorf("comments = %+v, want author dev@example.com", task.Comments)
+	}
+}
+
+func TestBoardExportService_Import(t *testing.T) {
+	valid := func() *models.BoardExport {
+		return &models.BoardExport{
+			Version: models.BoardExportVersion,
+			Board:   models.ExportedBoard{Title: "Roadmap"},
+			Columns: []models.ExportedColumn{{ID: "col-1", Title: "Todo"}},
+			Tasks: []models.ExportedTask{{
+				ID:        "task-1",
+				ColumnID:  "col-1",
+				Title:     "Write spec",
+				Assignees: []string{"dev@example.com", "gone@example.com"},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(export *models.BoardExport)
+	}{
+		{name: "future version", modify: func(export *models.BoardExport) { export.Version = models.BoardExportVersion + 1 }},
+		{name: "missing title", modify: func(export *models.BoardExport) { export.Board.Title = "" }},
+		{name: "duplicate column ids", modify: func(export *models.BoardExport) {
+			export.Columns = append(export.Columns, models.ExportedColumn{ID: "col-1", Title: "Again"})
+		}},
+		{name: "unknown column", modify: func(export *models.BoardExport) { export.Tasks[0].ColumnID = "col-9" }},
+		{name: "unknown sprint", modify: func(export *models.BoardExport) { export.Tasks[0].SprintID = stringPointer("sprint-9") }},
+		{name: "invalid sprint status", modify: func(export *models.BoardExport) {
+			export.Sprints = []models.ExportedSprint{{ID: "sprint-1", Name: "One", Status: "running", StartDate: time.Now(), EndDate: time.Now()}}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exportRepo := &mockBoardExportRepository{}
+			service := NewBoardExportService(exportRepo, newMockBoardRepository(), newMockSprintRepository())
+
+			export := valid()
+			tt.modify(export)
+
+			var validationErr utils.ErrValidation
+			if _, err := service.Import(context.Background(), "user123", export); !errors.As(err, &validationErr) {
+				t.Errorf("Import() error = %v, want validation error", err)
+			}
+			if exportRepo.imported != nil {
+				t.Error("nothing should be imported from an invalid export")
+			}
+		})
+	}
+
+	t.Run("imports for the user", func(t *testing.T) {
+		exportRepo := &mockBoardExportRepository{}
+		service := NewBoardExportService(exportRepo, newMockBoardRepository(), newMockSprintRepository())
+
+		board, err := service.Import(context.Background(), "user123", valid())
+		if err != nil {
+			t.Fatalf("Import() unexpected error = %v", err)
+		}
+		if board.UserID != "user123" || board.Title != "Roadmap" {
+			t.Errorf("unexpected board %+v", board)
+		}
+		if exportRepo.imported.Columns[0].Stage != models.ColumnStageInProgress {
+			t.Errorf("column without stage should default to in progress, got %q", exportRepo.imported.Columns[0].Stage)
+		}
+	})
+}
@@ -2,50 +2,90 @@ package services
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
+	"kanban-backend/events"
 	"kanban-backend/models"
 	"kanban-backend/repositories"
+	"kanban-backend/tracing"
 	"kanban-backend/utils"
 )
 
 type BoardService interface {
-	Create(ctx context.Context, userID, title, color string) (*models.Board, error)
+	Create(ctx context.Context, userID, title, color, taskKeyPrefix, templateID string) (*models.Board, error)
 	FindByID(ctx context.Context, boardID, userID string) (*models.Board, error)
 	FindByUserID(ctx context.Context, userID string) ([]*models.Board, error)
-	FindByUserIDWithFilters(ctx context.Context, userID string, title string, page, limit int) ([]*models.Board, int, error)
+	FindByUserIDWithFilters(ctx context.Context, userID string, title string, archived bool, page, limit int) ([]*models.Board, int, error)
 	Search(ctx context.Context, userID string, keyword string, page, limit int) ([]*models.Board, int, error)
-	Update(ctx context.Context, boardID, userID, title, color string) (*models.Board, error)
+	Update(ctx context.Context, boardID, userID, title, color, taskKeyPrefix string) (*models.Board, error)
 	Delete(ctx context.Context, boardID, userID string) error
+	Archive(ctx context.Context, boardID, userID string, archived bool) (*models.Board, error)
+	Duplicate(ctx context.Context, boardID, userID, title string, options models.BoardCopyOptions) (*models.Board, error)
 }
 
 type boardService struct {
-	boardRepo  repositories.BoardRepository
-	columnRepo repositories.ColumnRepository
+	boardRepo    repositories.BoardRepository
+	columnRepo   repositories.ColumnRepository
+	templateRepo repositories.BoardTemplateRepository
 }
 
-func NewBoardService(boardRepo repositories.BoardRepository, columnRepo repositories.ColumnRepository) BoardService {
+func NewBoardService(boardRepo repositories.BoardRepository, columnRepo repositories.ColumnRepository, templateRepo repositories.BoardTemplateRepository) BoardService {
 	return &boardService{
-		boardRepo:  boardRepo,
-		columnRepo: columnRepo,
+		boardRepo:    boardRepo,
+		columnRepo:   columnRepo,
+		templateRepo: templateRepo,
 	}
 }
 
-func (s *boardService) Create(ctx context.Context, userID, title, color string) (*models.Board, error) {
+// Create makes a board with the default three columns, or with the layout of
+// the given built-in or saved template. Without a task key prefix one is
+// derived from the title.
+func (s *boardService) Create(ctx context.Context, userID, title, color, taskKeyPrefix, templateID string) (*models.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.Create")
+	defer span.End()
+
 	board := &models.Board{
 		Title:  title,
 		UserID: userID,
 		Color:  color,
 	}
 
+	if taskKeyPrefix != "" {
+		prefix, err := s.checkTaskKeyPrefix(ctx, userID, "", taskKeyPrefix)
+		if err != nil {
+			return nil, err
+		}
+		board.TaskKeyPrefix = prefix
+	}
+
+	if templateID != "" {
+		template, err := findBoardTemplate(ctx, s.templateRepo, templateID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validateTemplateDefinition(template.Definition); err != nil {
+			return nil, err
+		}
+
+		if err := s.templateRepo.CreateBoard(ctx, board, template.Definition); err != nil {
+			return nil, taskKeyPrefixError(err)
+		}
+
+		return board, nil
+	}
+
 	err := s.boardRepo.Create(ctx, board)
 	if err != nil {
-		return nil, err
+		return nil, taskKeyPrefixError(err)
 	}
 
 	defaultColumns := []models.Column{
-		{Title: "To Do", OrderNum: 1, BoardID: board.ID},
-		{Title: "In Progress", OrderNum: 2, BoardID: board.ID},
-		{Title: "Done", OrderNum: 3, BoardID: board.ID},
+		{Title: "To Do", OrderNum: 1, BoardID: board.ID, Stage: models.ColumnStageTodo},
+		{Title: "In Progress", OrderNum: 2, BoardID: board.ID, Stage: models.ColumnStageInProgress},
+		{Title: "Done", OrderNum: 3, BoardID: board.ID, Stage: models.ColumnStageDone},
 	}
 
 	for _, col := range defaultColumns {
@@ -61,6 +101,9 @@ func (s *boardService) Create(ctx context.Context, userID, title, color string)
 }
 
 func (s *boardService) FindByID(ctx context.Context, boardID, userID string) (*models.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.FindByID")
+	defer span.End()
+
 	board, err := s.boardRepo.FindByID(ctx, boardID)
 	if err != nil {
 		return nil, utils.NewNotFound("board not found")
@@ -74,6 +117,9 @@ func (s *boardService) FindByID(ctx context.Context, boardID, userID string) (*m
 }
 
 func (s *boardService) FindByUserID(ctx context.Context, userID string) ([]*models.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.FindByUserID")
+	defer span.End()
+
 	boards, err := s.boardRepo.FindByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -82,12 +128,24 @@ func (s *boardService) FindByUserID(ctx context.Context, userID string) ([]*mode
 	return boards, nil
 }
 
-func (s *boardService) Update(ctx context.Context, boardID, userID, title, color string) (*models.Board, error) {
+// Update changes the board's title, color and task key prefix. A new prefix
+// re-keys every task on the board.
+func (s *boardService) Update(ctx context.Context, boardID, userID, title, color, taskKeyPrefix string) (*models.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.Update")
+	defer span.End()
+
 	board, err := s.FindByID(ctx, boardID, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	prefix := board.TaskKeyPrefix
+	if taskKeyPrefix != "" {
+		if prefix, err = s.checkTaskKeyPrefix(ctx, userID, board.ID, taskKeyPrefix); err != nil {
+			return nil, err
+		}
+	}
+
 	if title != "" {
 		board.Title = title
 	}
@@ -101,16 +159,57 @@ func (s *boardService) Update(ctx context.Context, boardID, userID, title, color
 		return nil, err
 	}
 
+	if prefix != board.TaskKeyPrefix {
+		if err := s.boardRepo.UpdateTaskKeyPrefix(ctx, board.ID, prefix); err != nil {
+			return nil, taskKeyPrefixError(err)
+		}
+		board.TaskKeyPrefix = prefix
+	}
+
 	return board, nil
 }
 
+// checkTaskKeyPrefix upper-cases a requested prefix and makes sure it is well
+// formed and not used by another of the user's boards
+func (s *boardService) checkTaskKeyPrefix(ctx context.Context, userID, boardID, prefix string) (string, error) {
+	prefix = strings.ToUpper(strings.TrimSpace(prefix))
+	if !models.ValidTaskKeyPrefix(prefix) {
+		return "", utils.NewValidation("task key prefix must be 2-10 letters or digits, starting with a letter")
+	}
+
+	taken, err := s.boardRepo.TaskKeyPrefixTaken(ctx, userID, prefix, boardID)
+	if err != nil {
+		return "", err
+	}
+	if taken {
+		return "", utils.NewValidation(taskKeyPrefixTakenMessage)
+	}
+
+	return prefix, nil
+}
+
+const taskKeyPrefixTakenMessage = "task key prefix is already used by another of your boards"
+
+// taskKeyPrefixError reports a prefix claimed by a concurrent request between
+// checkTaskKeyPrefix and the write the same way as one that was already taken
+func taskKeyPrefixError(err error) error {
+	if errors.Is(err, repositories.ErrTaskKeyPrefixTaken) {
+		return utils.NewValidation(taskKeyPrefixTakenMessage)
+	}
+	return err
+}
+
+// Delete moves the board to the trash along with everything on it
 func (s *boardService) Delete(ctx context.Context, boardID, userID string) error {
+	ctx, span := tracing.Start(ctx, "BoardService.Delete")
+	defer span.End()
+
 	_, err := s.FindByID(ctx, boardID, userID)
 	if err != nil {
 		return err
 	}
 
-	err = s.boardRepo.Delete(ctx, boardID)
+	err = s.boardRepo.SoftDelete(ctx, boardID)
 	if err != nil {
 		return err
 	}
@@ -118,8 +217,83 @@ func (s *boardService) Delete(ctx context.Context, boardID, userID string) error
 	return nil
 }
 
-func (s *boardService) FindByUserIDWithFilters(ctx context.Context, userID string, title string, page, limit int) ([]*models.Board, int, error) {
-	boards, total, err := s.boardRepo.FindByUserIDWithFilters(ctx, userID, title, page, limit)
+// Archive hides the board from board listings, or brings it back when
+// archived is false
+func (s *boardService) Archive(ctx context.Context, boardID, userID string, archived bool) (*models.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.Archive")
+	defer span.End()
+
+	board, err := s.FindByID(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if archived == (board.ArchivedAt != nil) {
+		return board, nil
+	}
+
+	board.ArchivedAt = nil
+	if archived {
+		now := time.Now().UTC()
+		board.ArchivedAt = &now
+	}
+
+	if err := s.boardRepo.Update(ctx, board); err != nil {
+		return nil, err
+	}
+
+	eventType := events.BoardUnarchived
+	if archived {
+		eventType = events.BoardArchived
+	}
+	events.Publish(ctx, events.ForBoard(eventType, userID, board))
+
+	return board, nil
+}
+
+// Duplicate copies a board into a new board owned by the user. Without a title
+// the copy is named after the source board. Columns and custom fields are
+// always copied; options select tasks, which bring their sprints along, and
+// with them labels, comments and attachments. Checklists, task dependencies
+// and comment threads are not copied: tasks have no checklists or
+// dependencies and comments are a flat list, so the copy has to be extended
+// if those are added. Copied attachments point at the same stored files as
+// the originals rather than duplicating them.
+func (s *boardService) Duplicate(ctx context.Context, boardID, userID, title string, options models.BoardCopyOptions) (*models.Board, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.Duplicate")
+	defer span.End()
+
+	source, err := s.FindByID(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !options.Tasks && (options.Comments || options.Labels || options.Attachments) {
+		return nil, utils.NewValidation("comments, labels and attachments can only be copied together with tasks")
+	}
+
+	if title == "" {
+		title = source.Title + " (copy)"
+	}
+
+	board := &models.Board{
+		Title:  title,
+		UserID: userID,
+		Color:  source.Color,
+	}
+
+	if err := s.boardRepo.Duplicate(ctx, source.ID, board, options); err != nil {
+		return nil, err
+	}
+
+	return board, nil
+}
+
+func (s *boardService) FindByUserIDWithFilters(ctx context.Context, userID string, title string, archived bool, page, limit int) ([]*models.Board, int, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.FindByUserIDWithFilters")
+	defer span.End()
+
+	boards, total, err := s.boardRepo.FindByUserIDWithFilters(ctx, userID, title, archived, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -128,6 +302,9 @@ func (s *boardService) FindByUserIDWithFilters(ctx context.Context, userID strin
 }
 
 func (s *boardService) Search(ctx context.Context, userID string, keyword string, page, limit int) ([]*models.Board, int, error) {
+	ctx, span := tracing.Start(ctx, "BoardService.Search")
+	defer span.End()
+
 	boards, total, err := s.boardRepo.Search(ctx, userID, keyword, page, limit)
 	if err != nil {
 		return nil, 0, err
@@ -80,7 +80,34 @@ func (m *mockBoardRepository) SoftDelete(ctx context.Context, id string) error {
 	return m.Delete(ctx, id)
 }
 
-func (m *mockBoardRepository) FindByUserIDWithFilters(ctx context.Context, userID string, title string, page, limit int) ([]*models.Board, int, error) {
+func (m *mockBoardRepository) Duplicate(ctx context.Context, sourceID string, board *models.Board, options models.BoardCopyOptions) error {
+	if _, exists := m.boards[sourceID]; !exists {
+		return errors.New("board not found")
+	}
+	board.ID = "copy-of-" + sourceID
+	m.boards[board.ID] = board
+	return nil
+}
+
+func (m *mockBoardRepository) TaskKeyPrefixTaken(ctx context.Context, userID, prefix, exceptBoardID string) (bool, error) {
+	for _, board := range m.boards {
+		if board.UserID == userID && board.TaskKeyPrefix == prefix && board.ID != exceptBoardID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockBoardRepository) UpdateTaskKeyPrefix(ctx context.Context, boardID, prefix string) error {
+	board, exists := m.boards[boardID]
+	if !exists {
+		return errors.New("board not found")
+	}
+	board.TaskKeyPrefix = prefix
+	return nil
+}
+
+func (m *mockBoardRepository) FindByUserIDWithFilters(ctx context.Context, userID string, title string, archived bool, page, limit int) ([]*models.Board, int, error) {
 	var boards []*models.Board
 	for _, board := range m.boards {
 		if board.UserID == userID && (title == "" || board.Title == title) {
@@ -178,7 +205,7 @@ func (m *mockColumnRepository) SoftDelete(ctx context.Context, id string) error
 func TestNewBoardService(t *testing.T) {
 	mockBoardRepo := newMockBoardRepository()
 	mockColumnRepo := newMockColumnRepository()
-	service := NewBoardService(mockBoardRepo, mockColumnRepo)
+	service := NewBoardService(mockBoardRepo, mockColumnRepo, newMockBoardTemplateRepository())
 
 	if service == nil {
 		t.Error("NewBoardService() should return non-nil service")
@@ -213,10 +240,10 @@ func TestBoardService_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockBoardRepo := newMockBoardRepository()
 			mockColumnRepo := newMockColumnRepository()
-			service := NewBoardService(mockBoardRepo, mockColumnRepo)
+			service := NewBoardService(mockBoardRepo, mockColumnRepo, newMockBoardTemplateRepository())
 			ctx := context.Background()
 
-			board, err := service.Create(ctx, tt.userID, tt.title, tt.color)
+			board, err := service.Create(ctx, tt.userID, tt.title, tt.color, "", "")
 
 			if tt.expectError {
 				if err == nil {
@@ -305,7 +332,7 @@ func TestBoardService_FindByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockBoardRepo := newMockBoardRepository()
 			mockColumnRepo := newMockColumnRepository()
-			service := NewBoardService(mockBoardRepo, mockColumnRepo)
+			service := NewBoardService(mockBoardRepo, mockColumnRepo, newMockBoardTemplateRepository())
 			ctx := context.Background()
 
 			if tt.setupBoard {
@@ -387,7 +414,7 @@ func TestBoardService_FindByUserID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockBoardRepo := newMockBoardRepository()
 			mockColumnRepo := newMockColumnRepository()
-			service := NewBoardService(mockBoardRepo, mockColumnRepo)
+			service := NewBoardService(mockBoardRepo, mockColumnRepo, newMockBoardTemplateRepository())
 			ctx := context.Background()
 
 			for i := 0; i < tt.setupBoards; i++ {
@@ -483,7 +510,7 @@ func TestBoardService_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockBoardRepo := newMockBoardRepository()
 			mockColumnRepo := newMockColumnRepository()
-			service := NewBoardService(mockBoardRepo, mockColumnRepo)
+			service := NewBoardService(mockBoardRepo, mockColumnRepo, newMockBoardTemplateRepository())
 			ctx := context.Background()
 
 			if tt.setupBoard {
@@ -499,7 +526,7 @@ func TestBoardService_Update(t *testing.T) {
 				}
 			}
 
-			board, err := service.Update(ctx, tt.boardID, tt.requestUserID, tt.title, tt.color)
+			board, err := service.Update(ctx, tt.boardID, tt.requestUserID, tt.title, tt.color, "")
 
 			if tt.expectError {
 				if err == nil {
@@ -584,7 +611,7 @@ func TestBoardService_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockBoardRepo := newMockBoardRepository()
 			mockColumnRepo := newMockColumnRepository()
-			service := NewBoardService(mockBoardRepo, mockColumnRepo)
+			service := NewBoardService(mockBoardRepo, mockColumnRepo, newMockBoardTemplateRepository())
 			ctx := context.Background()
 
 			if tt.setupBoard {
@@ -634,17 +661,106 @@ func TestBoardService_Delete(t *testing.T) {
 	}
 }
 
+func TestBoardService_Duplicate(t *testing.T) {
+	tests := []struct {
+		name          string
+		requestUserID string
+		title         string
+		options       models.BoardCopyOptions
+		wantTitle     string
+		errorType     string
+	}{
+		{name: "default title", requestUserID: "user123", wantTitle: "Roadmap (copy)"},
+		{name: "custom title with tasks", requestUserID: "user123", title: "Roadmap 2027", options: models.BoardCopyOptions{Tasks: true, Comments: true}, wantTitle: "Roadmap 2027"},
+		{name: "comments without tasks", requestUserID: "user123", options: models.BoardCopyOptions{Comments: true}, errorType: "validation"},
+		{name: "another user's board", requestUserID: "user456", errorType: "unauthorized"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockBoardRepo := newMockBoardRepository()
+			mockBoardRepo.boards["board123"] = &models.Board{ID: "board123", Title: "Roadmap", UserID: "user123", Color: "#123456"}
+			service := NewBoardService(mockBoardRepo, newMockColumnRepository(), newMockBoardTemplateRepository())
+
+			board, err := service.Duplicate(context.Background(), "board123", tt.requestUserID, tt.title, tt.options)
+			if tt.errorType == "validation" {
+				var validationErr utils.ErrValidation
+				if !errors.As(err, &validationErr) {
+					t.Errorf("Duplicate() should return ErrValidation, got %v", err)
+				}
+				return
+			}
+			if tt.errorType == "unauthorized" {
+				var unauthorizedErr utils.ErrUnauthorized
+				if !errors.As(err, &unauthorizedErr) {
+					t.Errorf("Duplicate() should return ErrUnauthorized, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Duplicate() unexpected error = %v", err)
+			}
+			if board.Title != tt.wantTitle || board.UserID != tt.requestUserID || board.Color != "#123456" {
+				t.Errorf("unexpected copy %+v", board)
+			}
+			if board.ID == "board123" {
+				t.Error("copy should get a new ID")
+			}
+		})
+	}
+}
+
+func TestBoardService_TaskKeyPrefix(t *testing.T) {
+	mockBoardRepo := newMockBoardRepository()
+	service := NewBoardService(mockBoardRepo, newMockColumnRepository(), newMockBoardTemplateRepository())
+	ctx := context.Background()
+
+	ops, err := service.Create(ctx, "user123", "Operations", "#000000", "ops", "")
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if ops.TaskKeyPrefix != "OPS" {
+		t.Errorf("TaskKeyPrefix = %q, want OPS", ops.TaskKeyPrefix)
+	}
+
+	var validationErr utils.ErrValidation
+	if _, err := service.Create(ctx, "user123", "Other", "#000000", "OPS", ""); !errors.As(err, &validationErr) {
+		t.Errorf("Create() with a taken prefix error = %v, want validation error", err)
+	}
+	if _, err := service.Create(ctx, "user456", "Theirs", "#000000", "OPS", ""); err != nil {
+		t.Errorf("prefixes only need to be unique per owner, got %v", err)
+	}
+	for _, prefix := range []string{"O", "1OPS", "OPS-1", "ABCDEFGHIJK"} {
+		if _, err := service.Create(ctx, "user123", "Bad", "#000000", prefix, ""); !errors.As(err, &validationErr) {
+			t.Errorf("Create() with prefix %q error = %v, want validation error", prefix, err)
+		}
+	}
+
+	updated, err := service.Update(ctx, ops.ID, "user123", "", "", "infra")
+	if err != nil {
+		t.Fatalf("Update() unexpected error = %v", err)
+	}
+	if updated.TaskKeyPrefix != "INFRA" || mockBoardRepo.boards[ops.ID].TaskKeyPrefix != "INFRA" {
+		t.Errorf("TaskKeyPrefix = %q, want INFRA", updated.TaskKeyPrefix)
+	}
+
+	// Keeping the board's own prefix is not a clash
+	if _, err := service.Update(ctx, ops.ID, "user123", "", "", "INFRA"); err != nil {
+		t.Errorf("Update() with the current prefix error = %v", err)
+	}
+}
+
 func TestBoardService_Integration(t *testing.T) {
 	mockBoardRepo := newMockBoardRepository()
 	mockColumnRepo := newMockColumnRepository()
-	service := NewBoardService(mockBoardRepo, mockColumnRepo)
+	service := NewBoardService(mockBoardRepo, mockColumnRepo, newMockBoardTemplateRepository())
 	ctx := context.Background()
 
 	userID := "user123"
 	title := "My Kanban Board"
 	color := "#FF5733"
 
-	board, err := service.Create(ctx, userID, title, color)
+	board, err := service.Create(ctx, userID, title, color, "", "")
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -663,7 +779,7 @@ func TestBoardService_Integration(t *testing.T) {
 	}
 
 	updatedTitle := "Updated Kanban Board"
-	updatedBoard, err := service.Update(ctx, board.ID, userID, updatedTitle, "")
+	updatedBoard, err := service.Update(ctx, board.ID, userID, updatedTitle, "", "")
 	if err != nil {
 		t.Fatalf("Update() failed: %v", err)
 	}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+type BoardTemplateService interface {
+	FindAll(ctx context.Context, userID string) ([]*models.BoardTemplate, error)
+	FindByID(ctx context.Context, templateID, userID string) (*models.BoardTemplate, error)
+	SaveFromBoard(ctx context.Context, boardID, userID, name, description string, includeTasks bool) (*models.BoardTemplate, error)
+	Delete(ctx context.Context, templateID, userID string) error
+}
+
+type boardTemplateService struct {
+	templateRepo repositories.BoardTemplateRepository
+	boardRepo    repositories.BoardRepository
+}
+
+func NewBoardTemplateService(templateRepo repositories.BoardTemplateRepository, boardRepo repositories.BoardRepository) BoardTemplateService {
+	return &boardTemplateService{
+		templateRepo: templateRepo,
+		boardRepo:    boardRepo,
+	}
+}
+
+// FindAll lists the built-in templates followed by the user's own
+func (s *boardTemplateService) FindAll(ctx context.Context, userID string) ([]*models.BoardTemplate, error) {
+	ctx, span := tracing.Start(ctx, "BoardTemplateService.FindAll")
+	defer span.End()
+
+	saved, err := s.templateRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*models.BoardTemplate, 0, len(builtinBoardTemplates)+len(saved))
+	templates = append(templates, builtinBoardTemplates...)
+	return append(templates, saved...), nil
+}
+
+func (s *boardTemplateService) FindByID(ctx context.Context, templateID, userID string) (*models.BoardTemplate, error) {
+	ctx, span := tracing.Start(ctx, "BoardTemplateService.FindByID")
+	defer span.End()
+
+	return findBoardTemplate(ctx, s.templateRepo, templateID, userID)
+}
+
+// SaveFromBoard captures a board's columns, WIP limits, custom fields and the
+// labels its tasks use as a new template. With includeTasks the current tasks
+// are kept as sample tasks.
+func (s *boardTemplateService) SaveFromBoard(ctx context.Context, boardID, userID, name, description string, includeTasks bool) (*models.BoardTemplate, error) {
+	ctx, span := tracing.Start(ctx, "BoardTemplateService.SaveFromBoard")
+	defer span.End()
+
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+
+	if board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	if len(board.Columns) == 0 {
+		return nil, utils.NewValidation("board has no columns to save")
+	}
+
+	tasks, err := s.templateRepo.FindBoardTasks(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	definition := definitionFromBoard(board, tasks, includeTasks)
+	if err := validateTemplateDefinition(definition); err != nil {
+		return nil, err
+	}
+
+	template := &models.BoardTemplate{
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		Definition:  definition,
+	}
+
+	if err := s.templateRepo.Create(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *boardTemplateService) Delete(ctx context.Context, templateID, userID string) error {
+	ctx, span := tracing.Start(ctx, "BoardTemplateService.Delete")
+	defer span.End()
+
+	if _, ok := findBuiltinBoardTemplate(templateID); ok {
+		return utils.NewValidation("built-in templates cannot be deleted")
+	}
+
+	if _, err := findBoardTemplate(ctx, s.templateRepo, templateID, userID); err != nil {
+		return err
+	}
+
+	return s.templateRepo.Delete(ctx, templateID)
+}
+
+// findBoardTemplate resolves a built-in template or one of the user's saved templates
+func findBoardTemplate(ctx context.Context, templateRepo repositories.BoardTemplateRepository, templateID, userID string) (*models.BoardTemplate, error) {
+	if template, ok := findBuiltinBoardTemplate(templateID); ok {
+		return template, nil
+	}
+
+	template, err := templateRepo.FindByID(ctx, templateID)
+	if err != nil {
+		return nil, utils.NewNotFound("board template not found")
+	}
+
+	if template.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this template")
+	}
+
+	return template, nil
+}
+
+func definitionFromBoard(board *models.Board, tasks []*models.Task, includeTasks bool) models.TemplateDefinition {
+	columns := make([]models.Column, len(board.Columns))
+	copy(columns, board.Columns)
+	sort.SliceStable(columns, func(i, j int) bool {
+		return columns[i].OrderNum < columns[j].OrderNum
+	})
+
+	var definition models.TemplateDefinition
+	for _, column := range columns {
+		stage := column.Stage
+		if stage == "" {
+			stage = models.ColumnStageInProgress
+			if column.IsDone() {
+				stage = models.ColumnStageDone
+			}
+		}
+		definition.Columns = append(definition.Columns, models.TemplateColumn{
+			Title:    column.Title,
+			Stage:    stage,
+			WipLimit: column.WipLimit,
+		})
+	}
+
+	for _, field := range board.CustomFields {
+		definition.CustomFields = append(definition.CustomFields, models.TemplateCustomField{
+			Name:    field.Name,
+			Type:    field.Type,
+			Options: field.Options,
+		})
+	}
+
+	seenLabels := make(map[string]bool)
+	for _, task := range tasks {
+		var labelNames []string
+		for _, label := range task.Labels {
+			labelNames = append(labelNames, label.Name)
+			if !seenLabels[label.Name] {
+				seenLabels[label.Name] = true
+				definition.Labels = append(definition.Labels, models.TemplateLabel{Name: label.Name, Color: label.Color})
+			}
+		}
+
+		if includeTasks && task.Column != nil {
+			definition.SampleTasks = append(definition.SampleTasks, models.TemplateTask{
+				Title:       task.Title,
+				Description: task.Description,
+				Column:      task.Column.Title,
+				Labels:      labelNames,
+			})
+		}
+	}
+
+	return definition
+}
+
+// validateTemplateDefinition checks a definition before boards are created from it
+func validateTemplateDefinition(definition models.TemplateDefinition) error {
+	if len(definition.Columns) == 0 {
+		return utils.NewValidation("template has no columns")
+	}
+
+	columns := make(map[string]bool, len(definition.Columns))
+	for _, column := range definition.Columns {
+		if column.Title == "" || columns[column.Title] {
+			return utils.NewValidation("template column titles must be present and unique")
+		}
+		if !models.IsValidColumnStage(column.Stage) {
+			return utils.NewValidation(fmt.Sprintf("template column %q has an invalid stage", column.Title))
+		}
+		if column.WipLimit != nil && *column.WipLimit <= 0 {
+			return utils.NewValidation(fmt.Sprintf("template column %q has an invalid WIP limit", column.Title))
+		}
+		columns[column.Title] = true
+	}
+
+	for _, field := range definition.CustomFields {
+		if !models.IsValidCustomFieldType(field.Type) {
+			return utils.NewValidation(fmt.Sprintf("custom field %q has an invalid type", field.Name))
+		}
+	}
+
+	for _, task := range definition.SampleTasks {
+		if !columns[task.Column] {
+			return utils.NewValidation(fmt.Sprintf("sample task %q references unknown column %q", task.Title, task.Column))
+		}
+	}
+
+	return nil
+}
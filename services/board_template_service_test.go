@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+type mockBoardTemplateRepository struct {
+	templates    map[string]*models.BoardTemplate
+	boardTasks   []*models.Task
+	createdBoard *models.Board
+	createdWith  *models.TemplateDefinition
+}
+
+func newMockBoardTemplateRepository() *mockBoardTemplateRepository {
+	return &mockBoardTemplateRepository{
+		templates: make(map[string]*models.BoardTemplate),
+	}
+}
+
+func (m *mockBoardTemplateRepository) Create(ctx context.Context, template *models.BoardTemplate) error {
+	if template.ID == "" {
+		template.ID = "template-" + template.Name
+	}
+	m.templates[template.ID] = template
+	return nil
+}
+
+func (m *mockBoardTemplateRepository) FindByID(ctx context.Context, id string) (*models.BoardTemplate, error) {
+	template, exists := m.templates[id]
+	if !exists {
+		return nil, errors.New("template not found")
+	}
+	return template, nil
+}
+
+func (m *mockBoardTemplateRepository) FindByUserID(ctx context.Context, userID string) ([]*models.BoardTemplate, error) {
+	var templates []*models.BoardTemplate
+	for _, template := range m.templates {
+		if template.UserID == userID {
+			templates = append(templates, template)
+		}
+	}
+	return templates, nil
+}
+
+func (m *mockBoardTemplateRepository) FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error) {
+	return m.boardTasks, nil
+}
+
+func (m *mockBoardTemplateRepository) CreateBoard(ctx context.Context, board *models.Board, definition models.TemplateDefinition) error {
+	board.ID = "board-from-template"
+	for i, column := range definition.Columns {
+		board.Columns = append(board.Columns, models.Column{BoardID: board.ID, Title: column.Title, OrderNum: i + 1, Stage: column.Stage, WipLimit: column.WipLimit})
+	}
+	m.createdBoard = board
+	m.createdWith = &definition
+	return nil
+}
+
+func (m *mockBoardTemplateRepository) Delete(ctx context.Context, id string) error {
+	delete(m.templates, id)
+	return nil
+}
+
+func TestBuiltinBoardTemplatesAreValid(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, template := range builtinBoardTemplates {
+		if seen[template.ID] {
+			t.Errorf("duplicate built-in template id %q", template.ID)
+		}
+		seen[template.ID] = true
+
+		if err := validateTemplateDefinition(template.Definition); err != nil {
+			t.Errorf("built-in template %q is invalid: %v", template.ID, err)
+		}
+	}
+
+	for _, id := range []string{"scrum", "bug-triage", "content-pipeline"} {
+		if !seen[id] {
+			t.Errorf("missing built-in template %q", id)
+		}
+	}
+}
+
+func TestBoardService_CreateFromTemplate(t *testing.T) {
+	templateRepo := newMockBoardTemplateRepository()
+	templateRepo.templates["mine"] = &models.BoardTemplate{
+		ID:         "mine",
+		UserID:     "user123",
+		Definition: models.TemplateDefinition{Columns: []models.TemplateColumn{{Title: "Only", Stage: models.ColumnStageTodo}}},
+	}
+	templateRepo.templates["broken"] = &models.BoardTemplate{ID: "broken", UserID: "user123"}
+	columnRepo := newMockColumnRepository()
+	service := NewBoardService(newMockBoardRepository(), columnRepo, templateRepo)
+	ctx := context.Background()
+
+	board, err := service.Create(ctx, "user123", "Sprint board", "#000000", "", "scrum")
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if len(board.Columns) != 5 || board.Columns[2].WipLimit == nil || *board.Columns[2].WipLimit != 5 {
+		t.Errorf("expected scrum columns with WIP limits, got %+v", board.Columns)
+	}
+	if len(templateRepo.createdWith.SampleTasks) == 0 || len(templateRepo.createdWith.CustomFields) == 0 {
+		t.Error("expected sample tasks and custom fields to be passed to the repository")
+	}
+	if len(columnRepo.columns) != 0 {
+		t.Error("default columns should not be created for templated boards")
+	}
+
+	if _, err := service.Create(ctx, "user123", "Mine", "#000000", "", "mine"); err != nil {
+		t.Errorf("Create() from saved template unexpected error = %v", err)
+	}
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.Create(ctx, "someone-else", "Mine", "#000000", "", "mine"); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("Create() error = %v, want unauthorized", err)
+	}
+
+	var notFoundErr utils.ErrNotFound
+	if _, err := service.Create(ctx, "user123", "Missing", "#000000", "", "missing"); !errors.As(err, &notFoundErr) {
+		t.Errorf("Create() error = %v, want not found", err)
+	}
+
+	var validationErr utils.ErrValidation
+	if _, err := service.Create(ctx, "user123", "Broken", "#000000", "", "broken"); !errors.As(err, &validationErr) {
+		t.Errorf("Create() error = %v, want validation error", err)
+	}
+}
+
+func TestBoardTemplateService_SaveFromBoard(t *testing.T) {
+	limit := 2
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards["board123"] = &models.Board{
+		ID:     "board123",
+		UserID: "user123",
+		Columns: []models.Column{
+			{ID: "col-done", Title: "Done", OrderNum: 3, Stage: models.ColumnStageDone},
+			{ID: "col-todo", Title: "To Do", OrderNum: 1, Stage: models.ColumnStageTodo},
+			{ID: "col-doing", Title: "Doing", OrderNum: 2, Stage: models.ColumnStageInProgress, WipLimit: &limit},
+		},
+		CustomFields: []models.CustomField{{Name: "Priority", Type: models.CustomFieldSelect, Options: []string{"Low", "High"}}},
+	}
+
+	bug := models.Label{Name: "Bug", Color: "#ff0000"}
+	templateRepo := newMockBoardTemplateRepository()
+	templateRepo.boardTasks = []*models.Task{
+		{Title: "Fix login", Column: &models.Column{Title: "Doing"}, Labels: []models.Label{bug}},
+		{Title: "Fix logout", Column: &models.Column{Title: "To Do"}, Labels: []models.Label{bug}},
+	}
+	service := NewBoardTemplateService(templateRepo, boardRepo)
+	ctx := context.Background()
+
+	template, err := service.SaveFromBoard(ctx, "board123", "user123", "Team layout", "", true)
+	if err != nil {
+		t.Fatalf("SaveFromBoard() unexpected error = %v", err)
+	}
+
+	definition := template.Definition
+	if len(definition.Columns) != 3 || definition.Columns[0].Title != "To Do" || definition.Columns[1].WipLimit == nil {
+		t.Errorf("unexpected columns %+v", definition.Columns)
+	}
+	if len(definition.Labels) != 1 || definition.Labels[0].Name != "Bug" {
+		t.Errorf("expected the Bug label once, got %+v", definition.Labels)
+	}
+	if len(definition.CustomFields) != 1 || len(definition.SampleTasks) != 2 {
+		t.Errorf("unexpected custom fields %+v or sample tasks %+v", definition.CustomFields, definition.SampleTasks)
+	}
+
+	withoutTasks, err := service.SaveFromBoard(ctx, "board123", "user123", "Layout only", "", false)
+	if err != nil {
+		t.Fatalf("SaveFromBoard() unexpected error = %v", err)
+	}
+	if len(withoutTasks.Definition.SampleTasks) != 0 {
+		t.Errorf("expected no sample tasks, got %+v", withoutTasks.Definition.SampleTasks)
+	}
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.SaveFromBoard(ctx, "board123", "someone-else", "Stolen", "", false); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("SaveFromBoard() error = %v, want unauthorized", err)
+	}
+
+	templates, err := service.FindAll(ctx, "user123")
+	if err != nil {
+		t.Fatalf("FindAll() unexpected error = %v", err)
+	}
+	if len(templates) != len(builtinBoardTemplates)+2 || !templates[0].BuiltIn {
+		t.Errorf("expected built-in templates first, got %d templates", len(templates))
+	}
+}
+
+func TestBoardTemplateService_DeleteBuiltIn(t *testing.T) {
+	service := NewBoardTemplateService(newMockBoardTemplateRepository(), newMockBoardRepository())
+
+	var validationErr utils.ErrValidation
+	if err := service.Delete(context.Background(), "scrum", "user123"); !errors.As(err, &validationErr) {
+		t.Errorf("Delete() error = %v, want validation error", err)
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import "kanban-backend/models"
+
+// builtinBoardTemplates are available to every user. Their IDs are fixed slugs
+// so they never collide with the UUIDs of saved templates.
+var builtinBoardTemplates = []*models.BoardTemplate{
+	{
+		ID:          "scrum",
+		Name:        "Scrum",
+		Description: "Backlog, sprint work, review and done with story point and priority fields",
+		BuiltIn:     true,
+		Definition: models.TemplateDefinition{
+			Columns: []models.TemplateColumn{
+				{Title: "Backlog", Stage: models.ColumnStageTodo},
+				{Title: "Sprint Backlog", Stage: models.ColumnStageTodo},
+				{Title: "In Progress", Stage: models.ColumnStageInProgress, WipLimit: wipLimit(5)},
+				{Title: "Review", Stage: models.ColumnStageInProgress, WipLimit: wipLimit(3)},
+				{Title: "Done", Stage: models.ColumnStageDone},
+			},
+			Labels: []models.TemplateLabel{
+				{Name: "Story", Color: "#2E86DE"},
+				{Name: "Bug", Color: "#E74C3C"},
+				{Name: "Chore", Color: "#95A5A6"},
+			},
+			CustomFields: []models.TemplateCustomField{
+				{Name: "Priority", Type: models.CustomFieldSelect, Options: []string{"Low", "Medium", "High"}},
+				{Name: "Acceptance criteria", Type: models.CustomFieldText},
+			},
+			SampleTasks: []models.TemplateTask{
+				{Title: "Write the first user story", Description: "As a user, I want ... so that ...", Column: "Backlog", Labels: []string{"Story"}},
+				{Title: "Plan the first sprint", Column: "Sprint Backlog", Labels: []string{"Chore"}},
+			},
+		},
+	},
+	{
+		ID:          "bug-triage",
+		Name:        "Bug triage",
+		Description: "Incoming reports are triaged, confirmed, fixed and verified",
+		BuiltIn:     true,
+		Definition: models.TemplateDefinition{
+			Columns: []models.TemplateColumn{
+				{Title: "Reported", Stage: models.ColumnStageTodo},
+				{Title: "Triaged", Stage: models.ColumnStageTodo},
+				{Title: "Fixing", Stage: models.ColumnStageInProgress, WipLimit: wipLimit(4)},
+				{Title: "Verifying", Stage: models.ColumnStageInProgress, WipLimit: wipLimit(4)},
+				{Title: "Closed", Stage: models.ColumnStageDone},
+			},
+			Labels: []models.TemplateLabel{
+				{Name: "Critical", Color: "#C0392B"},
+				{Name: "Regression", Color: "#E67E22"},
+				{Name: "Cannot Reproduce", Color: "#7F8C8D"},
+			},
+			CustomFields: []models.TemplateCustomField{
+				{Name: "Severity", Type: models.CustomFieldSelect, Options: []string{"S1", "S2", "S3", "S4"}},
+				{Name: "Affected version", Type: models.CustomFieldText},
+				{Name: "Reported on", Type: models.CustomFieldDate},
+			},
+			SampleTasks: []models.TemplateTask{
+				{Title: "Example: login fails with expired session", Description: "Steps to reproduce, expected and actual behaviour", Column: "Reported", Labels: []string{"Regression"}},
+			},
+		},
+	},
+	{
+		ID:          "content-pipeline",
+		Name:        "Content pipeline",
+		Description: "Ideas move through drafting, editing and scheduling to publication",
+		BuiltIn:     true,
+		Definition: models.TemplateDefinition{
+			Columns: []models.TemplateColumn{
+				{Title: "Ideas", Stage: models.ColumnStageTodo},
+				{Title: "Drafting", Stage: models.ColumnStageInProgress, WipLimit: wipLimit(3)},
+				{Title: "Editing", Stage: models.ColumnStageInProgress, WipLimit: wipLimit(2)},
+				{Title: "Scheduled", Stage: models.ColumnStageInProgress},
+				{Title: "Published", Stage: models.ColumnStageDone},
+			},
+			Labels: []models.TemplateLabel{
+				{Name: "Blog", Color: "#16A085"},
+				{Name: "Newsletter", Color: "#8E44AD"},
+				{Name: "Social", Color: "#F39C12"},
+			},
+			CustomFields: []models.TemplateCustomField{
+				{Name: "Channel", Type: models.CustomFieldSelect, Options: []string{"Blog", "Newsletter", "Social"}},
+				{Name: "Word count", Type: models.CustomFieldNumber},
+				{Name: "Publish date", Type: models.CustomFieldDate},
+			},
+			SampleTasks: []models.TemplateTask{
+				{Title: "Brainstorm next month's topics", Column: "Ideas", Labels: []string{"Blog"}},
+			},
+		},
+	},
+}
+
+func findBuiltinBoardTemplate(id string) (*models.BoardTemplate, bool) {
+	for _, template := range builtinBoardTemplates {
+		if template.ID == id {
+			return template, true
+		}
+	}
+	return nil, false
+}
+
+func wipLimit(limit int) *int {
+	return &limit
+}
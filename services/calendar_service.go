@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+// calendarFeedHistory is how far back deadlines stay in the feed
+const calendarFeedHistory = 90 * 24 * time.Hour
+
+// CreatedCalendarToken carries the secret of a new calendar token. The secret
+// cannot be retrieved again later.
+type CreatedCalendarToken struct {
+	*models.CalendarToken
+	Token string `json:"token"`
+}
+
+type CalendarService interface {
+	CreateToken(ctx context.Context, userID, name string) (*CreatedCalendarToken, error)
+	FindTokens(ctx context.Context, userID string) ([]*models.CalendarToken, error)
+	RevokeToken(ctx context.Context, tokenID, userID string) error
+	Feed(ctx context.Context, token, boardID string, now time.Time) (string, error)
+}
+
+type calendarService struct {
+	calendarRepo repositories.CalendarRepository
+}
+
+func NewCalendarService(calendarRepo repositories.CalendarRepository) CalendarService {
+	return &calendarService{
+		calendarRepo: calendarRepo,
+	}
+}
+
+func (s *calendarService) CreateToken(ctx context.Context, userID, name string) (*CreatedCalendarToken, error) {
+	ctx, span := tracing.Start(ctx, "CalendarService.CreateToken")
+	defer span.End()
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(secret)
+
+	calendarToken := &models.CalendarToken{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: hashCalendarToken(token),
+	}
+	if err := s.calendarRepo.CreateToken(ctx, calendarToken); err != nil {
+		return nil, err
+	}
+
+	return &CreatedCalendarToken{CalendarToken: calendarToken, Token: token}, nil
+}
+
+func (s *calendarService) FindTokens(ctx context.Context, userID string) ([]*models.CalendarToken, error) {
+	ctx, span := tracing.Start(ctx, "CalendarService.FindTokens")
+	defer span.End()
+
+	return s.calendarRepo.FindTokensByUserID(ctx, userID)
+}
+
+func (s *calendarService) RevokeToken(ctx context.Context, tokenID, userID string) error {
+	ctx, span := tracing.Start(ctx, "CalendarService.RevokeToken")
+	defer span.End()
+
+	if err := s.calendarRepo.DeleteToken(ctx, tokenID, userID); err != nil {
+		return utils.NewNotFound("calendar token not found")
+	}
+	return nil
+}
+
+// Feed renders the iCalendar feed for the token's owner: deadlines of tasks
+// they are assigned to or watch, directly or through a watched board
+func (s *calendarService) Feed(ctx context.Context, token, boardID string, now time.Time) (string, error) {
+	ctx, span := tracing.Start(ctx, "CalendarService.Feed")
+	defer span.End()
+
+	calendarToken, err := s.calendarRepo.FindTokenByHash(ctx, hashCalendarToken(token))
+	if err != nil {
+		return "", utils.NewNotFound("calendar not found")
+	}
+
+	if err := s.calendarRepo.TouchToken(ctx, calendarToken.ID, now); err != nil {
+		slog.ErrorContext(ctx, "failed to record calendar token use", "calendar_token_id", calendarToken.ID, "error", err)
+	}
+
+	tasks, err := s.calendarRepo.FindDeadlineTasks(ctx, calendarToken.UserID, boardID, now.Add(-calendarFeedHistory))
+	if err != nil {
+		return "", err
+	}
+
+	return renderICalendar(tasks, now), nil
+}
+
+func hashCalendarToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// renderICalendar writes tasks as RFC 5545 events. Each event's UID is derived
+// from the task ID so calendar clients update events instead of duplicating
+// them. Date-only deadlines become all-day events.
+func renderICalendar(tasks []*models.Task, now time.Time) string {
+	const timestampLayout = "20060102T150405Z"
+
+	var b strings.Builder
+	line := func(content string) {
+		b.WriteString(foldICalendarLine(content))
+		b.WriteString("\r\n")
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Kanban//Task Deadlines//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:Task deadlines")
+
+	for _, task := range tasks {
+		if task.Deadline == nil {
+			continue
+		}
+
+		stamp := task.UpdatedAt
+		if stamp.IsZero() {
+			stamp = now
+		}
+
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:task-%s@kanban", task.ID))
+		line("DTSTAMP:" + stamp.UTC().Format(timestampLayout))
+		line("LAST-MODIFIED:" + stamp.UTC().Format(timestampLayout))
+		if task.DeadlineAllDay {
+			day := task.Deadline.UTC()
+			line("DTSTART;VALUE=DATE:" + day.Format("20060102"))
+			line("DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format("20060102"))
+		} else {
+			line("DTSTART:" + task.Deadline.UTC().Format(timestampLayout))
+		}
+		line("SUMMARY:" + escapeICalendarText(task.Title))
+
+		var description []string
+		if task.Column != nil {
+			location := task.Column.Title
+			if task.Column.Board != nil {
+				location = task.Column.Board.Title + " / " + location
+			}
+			description = append(description, location)
+		}
+		if task.Description != "" {
+			description = append(description, task.Description)
+		}
+		if len(description) > 0 {
+			line("DESCRIPTION:" + escapeICalendarText(strings.Join(description, "\n\n")))
+		}
+		line("END:VEVENT")
+	}
+
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+// escapeICalendarText escapes a TEXT value as required by RFC 5545 3.3.11
+func escapeICalendarText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// foldICalendarLine splits content lines longer than 75 octets, never inside
+// a UTF-8 sequence, continuing each with a leading space
+func foldICalendarLine(content string) string {
+	const limit = 75
+
+	var b strings.Builder
+	width := 0
+	for _, r := range content {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
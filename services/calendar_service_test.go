@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+type mockCalendarRepository struct {
+	tokens  map[string]*models.CalendarToken
+	tasks   []*models.Task
+	since   time.Time
+	boardID string
+}
+
+func newMockCalendarRepository() *mockCalendarRepository {
+	return &mockCalendarRepository{tokens: make(map[string]*models.CalendarToken)}
+}
+
+func (m *mockCalendarRepository) CreateToken(ctx context.Context, token *models.CalendarToken) error {
+	token.ID = "token-1"
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *mockCalendarRepository) FindTokenByHash(ctx context.Context, tokenHash string) (*models.CalendarToken, error) {
+	token, exists := m.tokens[tokenHash]
+	if !exists {
+		return nil, errors.New("calendar token not found")
+	}
+	return token, nil
+}
+
+func (m *mockCalendarRepository) FindTokensByUserID(ctx context.Context, userID string) ([]*models.CalendarToken, error) {
+	var tokens []*models.CalendarToken
+	for _, token := range m.tokens {
+		if token.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (m *mockCalendarRepository) DeleteToken(ctx context.Context, id, userID string) error {
+	for hash, token := range m.tokens {
+		if token.ID == id && token.UserID == userID {
+			delete(m.tokens, hash)
+			return nil
+		}
+	}
+	return errors.New("calendar token not found")
+}
+
+func (m *mockCalendarRepository) TouchToken(ctx context.Context, id string, usedAt time.Time) error {
+	for _, token := range m.tokens {
+		if token.ID == id {
+			token.LastUsedAt = &usedAt
+		}
+	}
+	return nil
+}
+
+func (m *mockCalendarRepository) FindDeadlineTasks(ctx context.Context, userID, boardID string, since time.Time) ([]*models.Task, error) {
+	m.since = since
+	m.boardID = boardID
+	return m.tasks, nil
+}
+
+func TestCalendarService_Feed(t *testing.T) {
+	repo := newMockCalendarRepository()
+	service := NewCalendarService(repo)
+	ctx := context.Background()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	created, err := service.CreateToken(ctx, "user123", "Phone")
+	if err != nil {
+		t.Fatalf("CreateToken() unexpected error = %v", err)
+	}
+	if created.Token == "" || created.TokenHash == created.Token {
+		t.Fatalf("expected only a hash of the token to be stored, got %+v", created)
+	}
+
+	allDay := time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC)
+	timed := time.Date(2026, 5, 4, 15, 30, 0, 0, time.UTC)
+	board := &models.Board{Title: "Launch"}
+	repo.tasks = []*models.Task{
+		{ID: "task-1", Title: "Ship, finally; really", Deadline: &allDay, DeadlineAllDay: true, UpdatedAt: now, Column: &models.Column{Title: "Doing", Board: board}},
+		{ID: "task-2", Title: "Demo", Description: "Line one\nLine two " + strings.Repeat("x", 100), Deadline: &timed, UpdatedAt: now},
+	}
+
+	feed, err := service.Feed(ctx, created.Token, "board123", now)
+	if err != nil {
+		t.Fatalf("Feed() unexpected error = %v", err)
+	}
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:task-task-1@kanban\r\n",
+		"DTSTART;VALUE=DATE:20260503\r\n",
+		"DTEND;VALUE=DATE:20260504\r\n",
+		`SUMMARY:Ship\, finally\; really` + "\r\n",
+		"DESCRIPTION:Launch / Doing\r\n",
+		"UID:task-task-2@kanban\r\n",
+		"DTSTART:20260504T153000Z\r\n",
+		`DESCRIPTION:Line one\nLine two`,
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(feed, want) {
+			t.Errorf("feed is missing %q:\n%s", want, feed)
+		}
+	}
+	for _, line := range strings.Split(feed, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+
+	if repo.boardID != "board123" || !repo.since.Equal(now.Add(-calendarFeedHistory)) {
+		t.Errorf("unexpected feed query board=%q since=%v", repo.boardID, repo.since)
+	}
+	if tokens, _ := service.FindTokens(ctx, "user123"); len(tokens) != 1 || tokens[0].LastUsedAt == nil {
+		t.Errorf("expected the token's last use to be recorded, got %+v", tokens)
+	}
+
+	if err := service.RevokeToken(ctx, created.ID, "user123"); err != nil {
+		t.Fatalf("RevokeToken() unexpected error = %v", err)
+	}
+	var notFoundErr utils.ErrNotFound
+	if _, err := service.Feed(ctx, created.Token, "", now); !errors.As(err, &notFoundErr) {
+		t.Errorf("Feed() with a revoked token error = %v, want not found", err)
+	}
+}
@@ -2,8 +2,10 @@ package services
 
 import (
 	"context"
+	"kanban-backend/events"
 	"kanban-backend/models"
 	"kanban-backend/repositories"
+	"kanban-backend/tracing"
 	"kanban-backend/utils"
 )
 
@@ -29,6 +31,9 @@ func NewCommentService(commentRepo repositories.CommentRepository, taskRepo repo
 }
 
 func (s *commentService) Create(ctx context.Context, taskID, userID, content string) (*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.Create")
+	defer span.End()
+
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
 		return nil, utils.NewNotFound("task not found")
@@ -53,10 +58,17 @@ func (s *commentService) Create(ctx context.Context, taskID, userID, content str
 		return nil, err
 	}
 
+	event := events.ForTask(events.CommentCreated, userID, task)
+	event.Data["comment_id"] = comment.ID
+	events.Publish(ctx, event)
+
 	return comment, nil
 }
 
 func (s *commentService) FindByID(ctx context.Context, id, userID string) (*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.FindByID")
+	defer span.End()
+
 	comment, err := s.commentRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, utils.NewNotFound("comment not found")
@@ -70,6 +82,9 @@ func (s *commentService) FindByID(ctx context.Context, id, userID string) (*mode
 }
 
 func (s *commentService) FindByTaskID(ctx context.Context, taskID, userID string) ([]*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.FindByTaskID")
+	defer span.End()
+
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
 		return nil, utils.NewNotFound("task not found")
@@ -92,6 +107,9 @@ func (s *commentService) FindByTaskID(ctx context.Context, taskID, userID string
 }
 
 func (s *commentService) Update(ctx context.Context, id, userID, content string) (*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.Update")
+	defer span.End()
+
 	comment, err := s.FindByID(ctx, id, userID)
 	if err != nil {
 		return nil, err
@@ -107,13 +125,17 @@ func (s *commentService) Update(ctx context.Context, id, userID, content string)
 	return comment, nil
 }
 
+// Delete moves the comment to the trash
 func (s *commentService) Delete(ctx context.Context, id, userID string) error {
+	ctx, span := tracing.Start(ctx, "CommentService.Delete")
+	defer span.End()
+
 	_, err := s.FindByID(ctx, id, userID)
 	if err != nil {
 		return err
 	}
 
-	err = s.commentRepo.Delete(ctx, id)
+	err = s.commentRepo.SoftDelete(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -122,6 +144,9 @@ func (s *commentService) Delete(ctx context.Context, id, userID string) error {
 }
 
 func (s *commentService) FindByTaskIDWithPagination(ctx context.Context, taskID, userID string, page, limit int) ([]*models.Comment, int, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.FindByTaskIDWithPagination")
+	defer span.End()
+
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
 		return nil, 0, utils.NewNotFound("task not found")
@@ -88,6 +88,10 @@ func newMockTaskRepositoryForComment() *mockTaskRepositoryForComment {
 	}
 }
 
+func (m *mockTaskRepositoryForComment) FindByKey(ctx context.Context, userID, key string) (*models.Task, error) {
+	return nil, utils.NewNotFound("task not found")
+}
+
 func (m *mockTaskRepositoryForComment) FindByID(ctx context.Context, id string) (*models.Task, error) {
 	task, exists := m.tasks[id]
 	if !exists {
@@ -122,7 +126,7 @@ func (m *mockTaskRepositoryForComment) SoftDelete(ctx context.Context, id string
 	return nil
 }
 
-func (m *mockTaskRepositoryForComment) FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, offset, limit int) ([]*models.Task, int, error) {
+func (m *mockTaskRepositoryForComment) FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, archived bool, offset, limit int) ([]*models.Task, int, error) {
 	return nil, 0, nil
 }
 
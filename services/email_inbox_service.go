@@ -0,0 +1,383 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxInboundAttachmentSize skips larger attachments instead of storing them
+	maxInboundAttachmentSize = 10 << 20
+	// replyAddressPrefix starts the local part of reply addresses, followed by
+	// the base32 task ID and signature. That keeps the local part within the 64
+	// characters mail servers accept.
+	replyAddressPrefix = "reply+"
+	// replySignatureSize is how many bytes of the HMAC a reply address carries
+	replySignatureSize = 12
+	replyTaskIDSize    = len(uuid.UUID{})
+)
+
+// replyTokenEncoding encodes reply tokens; base32 survives the lower-casing
+// of addresses by relays
+var replyTokenEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// AttachmentUploader stores attachment content and returns its URL
+type AttachmentUploader interface {
+	Upload(ctx context.Context, folder, fileName, contentType string, content []byte) (string, error)
+}
+
+// InboundEmailResult reports what a received email became
+type InboundEmailResult struct {
+	TaskID             string `json:"task_id"`
+	CommentID          string `json:"comment_id,omitempty"`
+	Attachments        int    `json:"attachments"`
+	SkippedAttachments int    `json:"skipped_attachments"`
+}
+
+type EmailInboxService interface {
+	Configure(ctx context.Context, boardID, userID string, columnID *string, rotateAddress bool) (*models.BoardInbox, error)
+	Find(ctx context.Context, boardID, userID string) (*models.BoardInbox, error)
+	Delete(ctx context.Context, boardID, userID string) error
+	ReplyAddress(taskID, userID string) string
+	Receive(ctx context.Context, secret string, envelopeRecipients []string, raw []byte) (*InboundEmailResult, error)
+}
+
+type emailInboxService struct {
+	inboxRepo      repositories.BoardInboxRepository
+	boardRepo      repositories.BoardRepository
+	taskRepo       repositories.TaskRepository
+	commentRepo    repositories.CommentRepository
+	attachmentRepo repositories.AttachmentRepository
+	userRepo       repositories.UserRepository
+	taskService    TaskService
+	uploader       AttachmentUploader
+	domain         string
+	secret         string
+}
+
+// NewEmailInboxService receives mail for addresses at domain. The secret
+// authenticates the relay posting messages and signs reply addresses;
+// without a domain and secret inbound email is turned off.
+func NewEmailInboxService(inboxRepo repositories.BoardInboxRepository, boardRepo repositories.BoardRepository, taskRepo repositories.TaskRepository, commentRepo repositories.CommentRepository, attachmentRepo repositories.AttachmentRepository, userRepo repositories.UserRepository, taskService TaskService, uploader AttachmentUploader, domain, secret string) EmailInboxService {
+	return &emailInboxService{
+		inboxRepo:      inboxRepo,
+		boardRepo:      boardRepo,
+		taskRepo:       taskRepo,
+		commentRepo:    commentRepo,
+		attachmentRepo: attachmentRepo,
+		userRepo:       userRepo,
+		taskService:    taskService,
+		uploader:       uploader,
+		domain:         strings.ToLower(domain),
+		secret:         secret,
+	}
+}
+
+// Configure gives the board an inbound address, or changes the column new
+// tasks go to. A nil column keeps the current setting and an empty one falls
+// back to the board's first column. Rotating replaces the address.
+func (s *emailInboxService) Configure(ctx context.Context, boardID, userID string, columnID *string, rotateAddress bool) (*models.BoardInbox, error) {
+	ctx, span := tracing.Start(ctx, "EmailInboxService.Configure")
+	defer span.End()
+
+	if !s.enabled() {
+		return nil, utils.NewValidation("inbound email is not enabled on this server")
+	}
+
+	board, err := s.findBoard(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	inbox, err := s.inboxRepo.FindByBoardID(ctx, boardID)
+	isNew := err != nil
+	if isNew {
+		inbox = &models.BoardInbox{BoardID: boardID}
+	}
+
+	if inbox.ColumnID, err = boardColumnSetting(board, inbox.ColumnID, columnID); err != nil {
+		return nil, err
+	}
+
+	if isNew || rotateAddress {
+		if inbox.LocalPart, err = generateInboxLocalPart(board.TaskKeyPrefix); err != nil {
+			return nil, err
+		}
+	}
+
+	if isNew {
+		err = s.inboxRepo.Create(ctx, inbox)
+	} else {
+		err = s.inboxRepo.Update(ctx, inbox)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withAddress(inbox), nil
+}
+
+func (s *emailInboxService) Find(ctx context.Context, boardID, userID string) (*models.BoardInbox, error) {
+	ctx, span := tracing.Start(ctx, "EmailInboxService.Find")
+	defer span.End()
+
+	if _, err := s.findBoard(ctx, boardID, userID); err != nil {
+		return nil, err
+	}
+
+	inbox, err := s.inboxRepo.FindByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board inbox not found")
+	}
+	return s.withAddress(inbox), nil
+}
+
+func (s *emailInboxService) Delete(ctx context.Context, boardID, userID string) error {
+	ctx, span := tracing.Start(ctx, "EmailInboxService.Delete")
+	defer span.End()
+
+	inbox, err := s.Find(ctx, boardID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.inboxRepo.Delete(ctx, inbox.ID)
+}
+
+// ReplyAddress is the Reply-To address for email about a task sent to the
+// user; replies to it become the user's comments on the task. The address
+// names only the task and is signed for the user, who is recognised by the
+// sender address of the reply. It is empty when inbound email is off.
+func (s *emailInboxService) ReplyAddress(taskID, userID string) string {
+	id, err := uuid.Parse(taskID)
+	if !s.enabled() || err != nil {
+		return ""
+	}
+	token := append(id[:], s.replySignature(taskID, userID)...)
+	return replyAddressPrefix + strings.ToLower(replyTokenEncoding.EncodeToString(token)) + "@" + s.domain
+}
+
+// Receive handles a raw RFC 5322 message posted by the mail relay. Mail to a
+// board inbox creates a task; mail to a reply address adds a comment.
+// Envelope recipients are tried before the message's own headers.
+func (s *emailInboxService) Receive(ctx context.Context, secret string, envelopeRecipients []string, raw []byte) (*InboundEmailResult, error) {
+	ctx, span := tracing.Start(ctx, "EmailInboxService.Receive")
+	defer span.End()
+
+	if !s.enabled() || subtle.ConstantTimeCompare([]byte(secret), []byte(s.secret)) != 1 {
+		return nil, utils.NewUnauthorized("invalid inbound email secret")
+	}
+
+	email, err := parseInboundEmail(raw)
+	if err != nil {
+		return nil, utils.NewValidation("invalid email message")
+	}
+
+	recipients := make([]string, 0, len(envelopeRecipients)+len(email.recipients))
+	for _, recipient := range envelopeRecipients {
+		recipients = append(recipients, strings.ToLower(strings.TrimSpace(recipient)))
+	}
+	recipients = append(recipients, email.recipients...)
+
+	for _, recipient := range recipients {
+		at := strings.LastIndex(recipient, "@")
+		if at < 0 || recipient[at+1:] != s.domain {
+			continue
+		}
+
+		localPart := recipient[:at]
+		if strings.HasPrefix(localPart, replyAddressPrefix) {
+			return s.receiveReply(ctx, strings.TrimPrefix(localPart, replyAddressPrefix), email)
+		}
+
+		inbox, err := s.inboxRepo.FindByLocalPart(ctx, localPart)
+		if err == nil {
+			return s.receiveTask(ctx, inbox, email)
+		}
+	}
+
+	return nil, utils.NewNotFound("no inbox for the recipient")
+}
+
+// receiveTask creates a task from the email on behalf of the board owner
+func (s *emailInboxService) receiveTask(ctx context.Context, inbox *models.BoardInbox, email *inboundEmail) (*InboundEmailResult, error) {
+	board, err := s.boardRepo.FindByID(ctx, inbox.BoardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+
+	columnID := ""
+	if inbox.ColumnID != nil {
+		columnID = *inbox.ColumnID
+	} else if len(board.Columns) > 0 {
+		columns := append([]models.Column{}, board.Columns...)
+		sort.SliceStable(columns, func(i, j int) bool { return columns[i].OrderNum < columns[j].OrderNum })
+		columnID = columns[0].ID
+	}
+	if columnID == "" {
+		return nil, utils.NewValidation("board has no column for new tasks")
+	}
+
+	task, err := s.taskService.Create(ctx, board.UserID, columnID, emailTaskTitle(email.subject), email.text, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &InboundEmailResult{TaskID: task.ID}
+	s.attach(ctx, task, board.ID, board.UserID, email.attachments, result)
+
+	if err := s.inboxRepo.TouchReceived(ctx, inbox.ID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// receiveReply adds the reply as a comment by the user the reply address was
+// made for. The sender must be that user, and since reply addresses never
+// expire, the user must still be the board owner, a board member or an
+// assignee of the task.
+func (s *emailInboxService) receiveReply(ctx context.Context, token string, email *inboundEmail) (*InboundEmailResult, error) {
+	raw, err := replyTokenEncoding.DecodeString(strings.ToUpper(token))
+	if err != nil || len(raw) != replyTaskIDSize+replySignatureSize {
+		return nil, utils.NewUnauthorized("invalid reply address")
+	}
+	taskID := uuid.UUID(raw[:replyTaskIDSize]).String()
+
+	user, err := s.userRepo.FindByEmailIgnoreCase(ctx, email.from)
+	if err != nil || !hmac.Equal(raw[replyTaskIDSize:], s.replySignature(taskID, user.ID)) {
+		return nil, utils.NewUnauthorized("sender does not match the reply address")
+	}
+	userID := user.ID
+
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil || task.Column == nil {
+		return nil, utils.NewNotFound("task not found")
+	}
+
+	board, err := s.boardRepo.FindByID(ctx, task.Column.BoardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+	if !isBoardMember(board, userID) && !isTaskAssignee(task, userID) {
+		return nil, utils.NewUnauthorized("you no longer have access to this task")
+	}
+
+	content := stripQuotedReply(email.text)
+	if content == "" && len(email.attachments) == 0 {
+		return nil, utils.NewValidation("reply is empty")
+	}
+
+	result := &InboundEmailResult{TaskID: task.ID}
+	if content != "" {
+		comment := &models.Comment{TaskID: task.ID, UserID: userID, Content: content}
+		if err := s.commentRepo.Create(ctx, comment); err != nil {
+			return nil, err
+		}
+		result.CommentID = comment.ID
+
+		event := events.ForTask(events.CommentCreated, userID, task)
+		event.Data["comment_id"] = comment.ID
+		events.Publish(ctx, event)
+	}
+
+	s.attach(ctx, task, task.Column.BoardID, userID, email.attachments, result)
+
+	return result, nil
+}
+
+// attach stores the email's attachments on the task. Attachments that are
+// too large or fail to upload are counted as skipped so the email itself is
+// not rejected and redelivered.
+func (s *emailInboxService) attach(ctx context.Context, task *models.Task, boardID, actorID string, attachments []inboundAttachment, result *InboundEmailResult) {
+	for _, file := range attachments {
+		if len(file.content) > maxInboundAttachmentSize {
+			result.SkippedAttachments++
+			continue
+		}
+
+		url, err := s.uploader.Upload(ctx, "attachments/"+task.ID, file.fileName, file.contentType, file.content)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to store emailed attachment", "task_id", task.ID, "file_name", file.fileName, "error", err)
+			result.SkippedAttachments++
+			continue
+		}
+
+		attachment := &models.Attachment{
+			TaskID:   task.ID,
+			FileName: file.fileName,
+			FileURL:  url,
+			FileSize: int64(len(file.content)),
+		}
+		if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+			slog.WarnContext(ctx, "failed to save emailed attachment", "task_id", task.ID, "file_name", file.fileName, "error", err)
+			result.SkippedAttachments++
+			continue
+		}
+		result.Attachments++
+
+		event := events.ForTask(events.AttachmentCreated, actorID, task)
+		event.BoardID = boardID
+		event.Data["attachment_id"] = attachment.ID
+		event.Data["file_name"] = attachment.FileName
+		events.Publish(ctx, event)
+	}
+}
+
+func (s *emailInboxService) findBoard(ctx context.Context, boardID, userID string) (*models.Board, error) {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+
+	if board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	return board, nil
+}
+
+func (s *emailInboxService) enabled() bool {
+	return s.domain != "" && s.secret != ""
+}
+
+func (s *emailInboxService) withAddress(inbox *models.BoardInbox) *models.BoardInbox {
+	inbox.Address = inbox.LocalPart + "@" + s.domain
+	return inbox
+}
+
+func (s *emailInboxService) replySignature(taskID, userID string) []byte {
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write([]byte("reply:" + taskID + "." + userID))
+	return mac.Sum(nil)[:replySignatureSize]
+}
+
+// generateInboxLocalPart makes an unguessable local part that still hints at
+// the board, e.g. ops-k2x7q9m4tb
+func generateInboxLocalPart(prefix string) (string, error) {
+	random := make([]byte, 10)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	suffix := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(random))[:12]
+	if prefix == "" {
+		return "board-" + suffix, nil
+	}
+	return strings.ToLower(prefix) + "-" + suffix, nil
+}
@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+const (
+	emailTestDomain = "in.example.com"
+	emailTestSecret = "relay-secret"
+)
+
+type mockBoardInboxRepository struct {
+	inboxes map[string]*models.BoardInbox
+}
+
+func (m *mockBoardInboxRepository) Create(ctx context.Context, inbox *models.BoardInbox) error {
+	if inbox.ID == "" {
+		inbox.ID = "inbox-1"
+	}
+	m.inboxes[inbox.ID] = inbox
+	return nil
+}
+
+func (m *mockBoardInboxRepository) FindByBoardID(ctx context.Context, boardID string) (*models.BoardInbox, error) {
+	for _, inbox := range m.inboxes {
+		if inbox.BoardID == boardID {
+			return inbox, nil
+		}
+	}
+	return nil, errors.New("board inbox not found")
+}
+
+func (m *mockBoardInboxRepository) FindByLocalPart(ctx context.Context, localPart string) (*models.BoardInbox, error) {
+	for _, inbox := range m.inboxes {
+		if inbox.LocalPart == localPart {
+			return inbox, nil
+		}
+	}
+	return nil, errors.New("board inbox not found")
+}
+
+func (m *mockBoardInboxRepository) Update(ctx context.Context, inbox *models.BoardInbox) error {
+	m.inboxes[inbox.ID] = inbox
+	return nil
+}
+
+func (m *mockBoardInboxRepository) Delete(ctx context.Context, id string) error {
+	delete(m.inboxes, id)
+	return nil
+}
+
+func (m *mockBoardInboxRepository) TouchReceived(ctx context.Context, id string, receivedAt time.Time) error {
+	m.inboxes[id].LastReceivedAt = &receivedAt
+	return nil
+}
+
+type emailTestTaskService struct {
+	TaskService
+	taskRepo *mockTaskRepository
+}
+
+func (s *emailTestTaskService) Create(ctx context.Context, userID, columnID, title, description string, deadline *time.Time, deadlineAllDay bool) (*models.Task, error) {
+	task := &models.Task{ColumnID: columnID, Title: title, Description: description}
+	if err := s.taskRepo.Create(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+type mockAttachmentUploader struct {
+	uploads []string
+	fail    bool
+}
+
+func (m *mockAttachmentUploader) Upload(ctx context.Context, folder, fileName, contentType string, content []byte) (string, error) {
+	if m.fail {
+		return "", errors.New("storage unavailable")
+	}
+	m.uploads = append(m.uploads, folder+"/"+fileName)
+	return "https://files.example.com/" + folder + "/" + fileName, nil
+}
+
+type emailInboxTestEnv struct {
+	service        EmailInboxService
+	board          *models.Board
+	inboxRepo      *mockBoardInboxRepository
+	taskRepo       *mockTaskRepository
+	commentRepo    *mockCommentRepository
+	attachmentRepo *mockAttachmentRepository
+	uploader       *mockAttachmentUploader
+	inbox          *models.BoardInbox
+}
+
+func setupEmailInboxService(t *testing.T) *emailInboxTestEnv {
+	board := &models.Board{ID: "board123", UserID: "user123", TaskKeyPrefix: "OPS", Columns: []models.Column{{ID: "doing", OrderNum: 1}, {ID: "todo", OrderNum: 0}}}
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards[board.ID] = board
+
+	userRepo := newMockUserRepository()
+	userRepo.users["sam@example.com"] = &models.User{ID: "user123", Email: "sam@example.com"}
+	userRepo.users["dana@example.com"] = &models.User{ID: "dana", Email: "dana@example.com"}
+
+	env := &emailInboxTestEnv{
+		board:          board,
+		inboxRepo:      &mockBoardInboxRepository{inboxes: map[string]*models.BoardInbox{}},
+		taskRepo:       newMockTaskRepository(),
+		commentRepo:    newMockCommentRepository(),
+		attachmentRepo: newMockAttachmentRepository(newMockTaskRepositoryForAttachment()),
+		uploader:       &mockAttachmentUploader{},
+	}
+	env.service = NewEmailInboxService(env.inboxRepo, boardRepo, env.taskRepo, env.commentRepo, env.attachmentRepo, userRepo, &emailTestTaskService{taskRepo: env.taskRepo}, env.uploader, emailTestDomain, emailTestSecret)
+
+	inbox, err := env.service.Configure(context.Background(), board.ID, "user123", nil, false)
+	if err != nil {
+		t.Fatalf("Configure() unexpected error = %v", err)
+	}
+	// Pin the address used by the fixtures
+	inbox.LocalPart = "ops-k2x7q9m4tbab"
+	env.inbox = inbox
+
+	return env
+}
+
+func readEmailFixture(t *testing.T, name string) []byte {
+	body, err := os.ReadFile(filepath.Join("testdata", "email", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestEmailInboxService_CreatesTaskWithAttachments(t *testing.T) {
+	env := setupEmailInboxService(t)
+
+	result, err := env.service.Receive(context.Background(), emailTestSecret, nil, readEmailFixture(t, "multipart_attachment.eml"))
+	if err != nil {
+		t.Fatalf("Receive() unexpected error = %v", err)
+	}
+
+	task := env.taskRepo.tasks[result.TaskID]
+	if task == nil {
+		t.Fatal("no task created")
+	}
+	if task.Title != "Printer on floor 3 is jammed" {
+		t.Errorf("title = %q", task.Title)
+	}
+	if task.Description != "The printer shows error E42 – see the photo." {
+		t.Errorf("description = %q, want the plain text body", task.Description)
+	}
+	if task.ColumnID != "todo" {
+		t.Errorf("task created in %s, want the board's first column", task.ColumnID)
+	}
+
+	if result.Attachments != 1 || result.SkippedAttachments != 0 || len(env.attachmentRepo.attachments) != 1 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	for _, attachment := range env.attachmentRepo.attachments {
+		if attachment.TaskID != task.ID || attachment.FileName != "printer.png" || attachment.FileSize != 70 {
+			t.Errorf("unexpected attachment %+v", attachment)
+		}
+	}
+	if env.inboxRepo.inboxes[env.inbox.ID].LastReceivedAt == nil {
+		t.Error("last received time not recorded")
+	}
+}
+
+func TestEmailInboxService_HTMLOnlyBody(t *testing.T) {
+	env := setupEmailInboxService(t)
+	column := "doing"
+	if _, err := env.service.Configure(context.Background(), "board123", "user123", &column, false); err != nil {
+		t.Fatalf("Configure() unexpected error = %v", err)
+	}
+
+	result, err := env.service.Receive(context.Background(), emailTestSecret, nil, readEmailFixture(t, "html_only.eml"))
+	if err != nil {
+		t.Fatalf("Receive() unexpected error = %v", err)
+	}
+
+	task := env.taskRepo.tasks[result.TaskID]
+	if task.Title != "Café menu update" {
+		t.Errorf("title = %q, want the decoded subject", task.Title)
+	}
+	if task.Description != "Please add \"flat white\".\nThanks!" {
+		t.Errorf("description = %q", task.Description)
+	}
+	if task.ColumnID != "doing" {
+		t.Errorf("task created in %s, want the inbox's column", task.ColumnID)
+	}
+}
+
+// emailTestTaskID is a task ID as reply addresses expect it, a UUID
+const emailTestTaskID = "8c1d5a2e-4f0b-4c47-9a53-2f6e1b7d9c30"
+
+func TestEmailInboxService_ReplyBecomesComment(t *testing.T) {
+	env := setupEmailInboxService(t)
+	ctx := context.Background()
+	env.taskRepo.tasks[emailTestTaskID] = &models.Task{ID: emailTestTaskID, ColumnID: "doing", Column: &models.Column{ID: "doing", BoardID: "board123"}}
+
+	address := env.service.ReplyAddress(emailTestTaskID, "user123")
+	localPart, domain, _ := strings.Cut(address, "@")
+	if domain != emailTestDomain {
+		t.Fatalf("ReplyAddress() = %q", address)
+	}
+	// RFC 5321 limits local parts to 64 octets
+	if len(localPart) > 64 {
+		t.Errorf("reply address local part has %d characters, want at most 64", len(localPart))
+	}
+
+	result, err := env.service.Receive(ctx, emailTestSecret, []string{address}, readEmailFixture(t, "reply.eml"))
+	if err != nil {
+		t.Fatalf("Receive() unexpected error = %v", err)
+	}
+
+	comment := env.commentRepo.comments[result.CommentID]
+	if comment == nil || comment.TaskID != emailTestTaskID || comment.UserID != "user123" {
+		t.Fatalf("unexpected comment %+v", comment)
+	}
+	if comment.Content != "Fixed it, the tray was misaligned." {
+		t.Errorf("content = %q, want the reply without the quoted message", comment.Content)
+	}
+
+	// Another task's reply address must not be forged from this one
+	forged := replyAddressPrefix + "a" + localPart[len(replyAddressPrefix)+1:] + "@" + domain
+	if forged == address {
+		forged = replyAddressPrefix + "b" + localPart[len(replyAddressPrefix)+1:] + "@" + domain
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := env.service.Receive(ctx, emailTestSecret, []string{forged}, readEmailFixture(t, "reply.eml")); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("forged reply address error = %v, want unauthorized", err)
+	}
+
+	// Only the user the address was made for may reply through it
+	spoofed := strings.Replace(string(readEmailFixture(t, "reply.eml")), "SAM@example.com", "mallory@example.com", 1)
+	if _, err := env.service.Receive(ctx, emailTestSecret, []string{address}, []byte(spoofed)); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("other sender error = %v, want unauthorized", err)
+	}
+}
+
+func TestEmailInboxService_ReplyRequiresTaskAccess(t *testing.T) {
+	env := setupEmailInboxService(t)
+	ctx := context.Background()
+	task := &models.Task{ID: emailTestTaskID, ColumnID: "doing", Column: &models.Column{ID: "doing", BoardID: "board123"}}
+	env.taskRepo.tasks[task.ID] = task
+
+	address := env.service.ReplyAddress(task.ID, "dana")
+	reply := []byte(strings.Replace(string(readEmailFixture(t, "reply.eml")), "SAM@example.com", "dana@example.com", 1))
+
+	env.board.Members = []models.Member{{BoardID: "board123", UserID: "dana"}}
+	if _, err := env.service.Receive(ctx, emailTestSecret, []string{address}, reply); err != nil {
+		t.Fatalf("reply from a board member error = %v", err)
+	}
+
+	// The address outlives the membership
+	env.board.Members = nil
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := env.service.Receive(ctx, emailTestSecret, []string{address}, reply); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("reply after removal from the board error = %v, want unauthorized", err)
+	}
+	if len(env.commentRepo.comments) != 1 {
+		t.Errorf("got %d comments, want only the one from while dana was a member", len(env.commentRepo.comments))
+	}
+
+	task.Assignees = []models.TaskAssignee{{TaskID: task.ID, UserID: "dana"}}
+	if _, err := env.service.Receive(ctx, emailTestSecret, []string{address}, reply); err != nil {
+		t.Errorf("reply from an assignee error = %v", err)
+	}
+}
+
+func TestEmailInboxService_Rejections(t *testing.T) {
+	env := setupEmailInboxService(t)
+	ctx := context.Background()
+	body := readEmailFixture(t, "multipart_attachment.eml")
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := env.service.Receive(ctx, "wrong", nil, body); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("bad secret error = %v, want unauthorized", err)
+	}
+
+	var notFoundErr utils.ErrNotFound
+	if _, err := env.service.Receive(ctx, emailTestSecret, []string{"nobody@" + emailTestDomain}, []byte("To: nobody@in.example.com\r\nSubject: Hi\r\n\r\nHello")); !errors.As(err, &notFoundErr) {
+		t.Errorf("unknown recipient error = %v, want not found", err)
+	}
+
+	env.uploader.fail = true
+	result, err := env.service.Receive(ctx, emailTestSecret, nil, body)
+	if err != nil {
+		t.Fatalf("failed uploads must not reject the email: %v", err)
+	}
+	if result.Attachments != 0 || result.SkippedAttachments != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	disabled := NewEmailInboxService(env.inboxRepo, newMockBoardRepository(), env.taskRepo, env.commentRepo, env.attachmentRepo, newMockUserRepository(), nil, env.uploader, "", "")
+	if _, err := disabled.Receive(ctx, "", nil, body); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("disabled inbound email error = %v, want unauthorized", err)
+	}
+	if address := disabled.ReplyAddress(emailTestTaskID, "user123"); address != "" {
+		t.Errorf("ReplyAddress() = %q while inbound email is disabled", address)
+	}
+}
+
+func TestEmailInboxService_ConfigureRotatesAddress(t *testing.T) {
+	env := setupEmailInboxService(t)
+	ctx := context.Background()
+
+	if !strings.HasPrefix(env.inbox.Address, "ops-") || !strings.HasSuffix(env.inbox.Address, "@"+emailTestDomain) {
+		t.Errorf("unexpected address %q", env.inbox.Address)
+	}
+
+	rotated, err := env.service.Configure(ctx, "board123", "user123", nil, true)
+	if err != nil {
+		t.Fatalf("Configure() unexpected error = %v", err)
+	}
+	if rotated.ID != env.inbox.ID || rotated.LocalPart == "ops-k2x7q9m4tbab" {
+		t.Errorf("rotating should keep the inbox and change its address, got %+v", rotated)
+	}
+
+	var validationErr utils.ErrValidation
+	elsewhere := "elsewhere"
+	if _, err := env.service.Configure(ctx, "board123", "user123", &elsewhere, false); !errors.As(err, &validationErr) {
+		t.Errorf("Configure() error = %v, want validation error", err)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := env.service.Configure(ctx, "board123", "someone-else", nil, false); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("Configure() error = %v, want unauthorized", err)
+	}
+}
+
+func TestStripQuotedReply(t *testing.T) {
+	tests := map[string]string{
+		"Done.\n\n> earlier":                                  "Done.",
+		"Done.\n-- \nSam":                                     "Done.",
+		"Done.\n\n-----Original Message-----\nFrom: x":        "Done.",
+		"Line one\nLine two":                                  "Line one\nLine two",
+		"On Tue, Oct 13, 2026, Kanban wrote:\n> quoted reply": "",
+	}
+
+	for input, want := range tests {
+		if got := stripQuotedReply(input); got != want {
+			t.Errorf("stripQuotedReply(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
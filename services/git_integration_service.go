@@ -0,0 +1,304 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/subtle"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+// GitWebhookHeaders are the request headers that identify and authenticate a
+// GitHub or GitLab webhook delivery
+type GitWebhookHeaders struct {
+	GitHubEvent     string
+	GitHubSignature string
+	GitLabEvent     string
+	GitLabToken     string
+}
+
+// GitWebhookResult summarises what a webhook delivery did
+type GitWebhookResult struct {
+	Provider   string   `json:"provider"`
+	Event      string   `json:"event"`
+	References []string `json:"references"`
+	Linked     int      `json:"linked"`
+	Moved      int      `json:"moved"`
+}
+
+// ConfiguredGitIntegration carries the webhook secret after it was generated
+// or rotated. The secret cannot be retrieved again later.
+type ConfiguredGitIntegration struct {
+	*models.GitIntegration
+	Secret string `json:"secret,omitempty"`
+}
+
+type GitIntegrationService interface {
+	Configure(ctx context.Context, boardID, userID string, openedColumnID, mergedColumnID *string, rotateSecret bool) (*ConfiguredGitIntegration, error)
+	Find(ctx context.Context, boardID, userID string) (*models.GitIntegration, error)
+	Delete(ctx context.Context, boardID, userID string) error
+	HandleWebhook(ctx context.Context, integrationID string, headers GitWebhookHeaders, body []byte) (*GitWebhookResult, error)
+}
+
+type gitIntegrationService struct {
+	integrationRepo repositories.GitIntegrationRepository
+	boardRepo       repositories.BoardRepository
+	activityRepo    repositories.ActivityRepository
+	taskService     TaskService
+}
+
+func NewGitIntegrationService(integrationRepo repositories.GitIntegrationRepository, boardRepo repositories.BoardRepository, activityRepo repositories.ActivityRepository, taskService TaskService) GitIntegrationService {
+	return &gitIntegrationService{
+		integrationRepo: integrationRepo,
+		boardRepo:       boardRepo,
+		activityRepo:    activityRepo,
+		taskService:     taskService,
+	}
+}
+
+// Configure creates the board's integration or updates its columns. A nil
+// column keeps the current setting and an empty one clears it. The secret is
+// generated on creation and replaced when rotateSecret is set.
+func (s *gitIntegrationService) Configure(ctx context.Context, boardID, userID string, openedColumnID, mergedColumnID *string, rotateSecret bool) (*ConfiguredGitIntegration, error) {
+	ctx, span := tracing.Start(ctx, "GitIntegrationService.Configure")
+	defer span.End()
+
+	board, err := s.findBoard(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	integration, err := s.integrationRepo.FindByBoardID(ctx, boardID)
+	isNew := err != nil
+	if isNew {
+		integration = &models.GitIntegration{BoardID: boardID}
+	}
+
+	if integration.OpenedColumnID, err = boardColumnSetting(board, integration.OpenedColumnID, openedColumnID); err != nil {
+		return nil, err
+	}
+	if integration.MergedColumnID, err = boardColumnSetting(board, integration.MergedColumnID, mergedColumnID); err != nil {
+		return nil, err
+	}
+
+	configured := &ConfiguredGitIntegration{GitIntegration: integration}
+	if isNew || rotateSecret {
+		if integration.Secret, err = generateWebhookSecret(); err != nil {
+			return nil, err
+		}
+		configured.Secret = integration.Secret
+	}
+
+	if isNew {
+		err = s.integrationRepo.Create(ctx, integration)
+	} else {
+		err = s.integrationRepo.Update(ctx, integration)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return configured, nil
+}
+
+func (s *gitIntegrationService) Find(ctx context.Context, boardID, userID string) (*models.GitIntegration, error) {
+	ctx, span := tracing.Start(ctx, "GitIntegrationService.Find")
+	defer span.End()
+
+	if _, err := s.findBoard(ctx, boardID, userID); err != nil {
+		return nil, err
+	}
+
+	integration, err := s.integrationRepo.FindByBoardID(ctx, boardID)
+	if err != nil {
+		return nil, utils.NewNotFound("git integration not found")
+	}
+	return integration, nil
+}
+
+func (s *gitIntegrationService) Delete(ctx context.Context, boardID, userID string) error {
+	ctx, span := tracing.Start(ctx, "GitIntegrationService.Delete")
+	defer span.End()
+
+	integration, err := s.Find(ctx, boardID, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.integrationRepo.Delete(ctx, integration.ID)
+}
+
+// HandleWebhook verifies a GitHub or GitLab delivery, links the commits and
+// pull requests it mentions to the referenced tasks on the board and moves
+// tasks whose pull request was opened or merged to the configured columns
+func (s *gitIntegrationService) HandleWebhook(ctx context.Context, integrationID string, headers GitWebhookHeaders, body []byte) (*GitWebhookResult, error) {
+	ctx, span := tracing.Start(ctx, "GitIntegrationService.HandleWebhook")
+	defer span.End()
+
+	integration, err := s.integrationRepo.FindByID(ctx, integrationID)
+	if err != nil {
+		return nil, utils.NewNotFound("git integration not found")
+	}
+
+	result := &GitWebhookResult{References: []string{}}
+	var changes []gitChange
+	switch {
+	case headers.GitHubEvent != "":
+		if !validGitHubSignature(integration.Secret, headers.GitHubSignature, body) {
+			return nil, utils.NewUnauthorized("invalid webhook signature")
+		}
+		result.Provider, result.Event = GitProviderGitHub, headers.GitHubEvent
+		changes, err = parseGitHubPayload(headers.GitHubEvent, body)
+	case headers.GitLabEvent != "":
+		if subtle.ConstantTimeCompare([]byte(headers.GitLabToken), []byte(integration.Secret)) != 1 {
+			return nil, utils.NewUnauthorized("invalid webhook token")
+		}
+		result.Provider, result.Event = GitProviderGitLab, headers.GitLabEvent
+		changes, err = parseGitLabPayload(headers.GitLabEvent, body)
+	default:
+		return nil, utils.NewValidation("unrecognised webhook: expected a GitHub or GitLab event header")
+	}
+	if err != nil {
+		return nil, utils.NewValidation("invalid webhook payload")
+	}
+
+	board, err := s.boardRepo.FindByID(ctx, integration.BoardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+
+	now := time.Now().UTC()
+	for _, change := range changes {
+		tasks, references, err := s.referencedTasks(ctx, board.ID, change.text)
+		if err != nil {
+			return nil, err
+		}
+		result.References = append(result.References, references...)
+
+		for _, task := range tasks {
+			linked, err := s.link(ctx, task, change, board.UserID, now)
+			if err != nil {
+				return nil, err
+			}
+			if linked {
+				result.Linked++
+			}
+
+			moved, err := s.move(ctx, task, change, integration, board.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if moved {
+				result.Moved++
+			}
+		}
+	}
+
+	if err := s.integrationRepo.TouchDelivery(ctx, integration.ID, now); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// referencedTasks resolves the task references in text to tasks on the board
+func (s *gitIntegrationService) referencedTasks(ctx context.Context, boardID, text string) ([]*models.Task, []string, error) {
+	ids, keys := gitTaskReferences(text)
+	references := append(append([]string{}, ids...), keys...)
+
+	tasks, err := s.integrationRepo.FindBoardTasks(ctx, boardID, ids, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks, references, nil
+}
+
+// link records the commit or pull request in the task's history, once
+func (s *gitIntegrationService) link(ctx context.Context, task *models.Task, change gitChange, actorID string, now time.Time) (bool, error) {
+	if change.url == "" {
+		return false, nil
+	}
+
+	action := models.ActivityCommitLinked
+	if change.kind == "pull_request" {
+		action = models.ActivityPullRequestLinked
+	}
+
+	exists, err := s.integrationRepo.HasActivity(ctx, task.ID, action, change.url)
+	if err != nil || exists {
+		return false, err
+	}
+
+	url := change.url
+	err = s.activityRepo.CreateBatch(ctx, []*models.TaskActivity{{
+		TaskID:    task.ID,
+		UserID:    actorID,
+		Action:    action,
+		Field:     change.kind,
+		NewValue:  &url,
+		CreatedAt: now,
+	}})
+	return err == nil, err
+}
+
+// move puts the task of an opened or merged pull request into the configured
+// column, on behalf of the board owner
+func (s *gitIntegrationService) move(ctx context.Context, task *models.Task, change gitChange, integration *models.GitIntegration, ownerID string) (bool, error) {
+	var columnID *string
+	switch change.state {
+	case gitPullRequestOpened:
+		columnID = integration.OpenedColumnID
+	case gitPullRequestMerged:
+		columnID = integration.MergedColumnID
+	}
+	if columnID == nil || task.ColumnID == *columnID || task.ArchivedAt != nil {
+		return false, nil
+	}
+
+	if err := s.taskService.Move(ctx, task.ID, *columnID, ownerID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *gitIntegrationService) findBoard(ctx context.Context, boardID, userID string) (*models.Board, error) {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+
+	if board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	return board, nil
+}
+
+// boardColumnSetting applies an optional column setting: nil keeps current,
+// an empty value clears it, anything else must be a column on the board
+func boardColumnSetting(board *models.Board, current, requested *string) (*string, error) {
+	if requested == nil {
+		return current, nil
+	}
+	if *requested == "" {
+		return nil, nil
+	}
+
+	for _, column := range board.Columns {
+		if column.ID == *requested {
+			columnID := column.ID
+			return &columnID, nil
+		}
+	}
+	return nil, utils.NewValidation("column is not on this board")
+}
+
+// validGitHubSignature checks the X-Hub-Signature-256 header against the body.
+// GitHub signs the same way outgoing webhooks do.
+func validGitHubSignature(secret, signature string, body []byte) bool {
+	return hmac.Equal([]byte(SignWebhookPayload(secret, body)), []byte(signature))
+}
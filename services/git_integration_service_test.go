@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+const gitFixtureTaskID = "9b2f4c1a-7d3e-4f8a-b6c5-2e1d0a9f8b7c"
+
+type mockGitIntegrationRepository struct {
+	integrations map[string]*models.GitIntegration
+	tasks        map[string]*models.Task
+	activities   *mockActivityRepository
+}
+
+func (m *mockGitIntegrationRepository) Create(ctx context.Context, integration *models.GitIntegration) error {
+	if integration.ID == "" {
+		integration.ID = "git-1"
+	}
+	m.integrations[integration.ID] = integration
+	return nil
+}
+
+func (m *mockGitIntegrationRepository) FindByID(ctx context.Context, id string) (*models.GitIntegration, error) {
+	integration, exists := m.integrations[id]
+	if !exists {
+		return nil, errors.New("git integration not found")
+	}
+	return integration, nil
+}
+
+func (m *mockGitIntegrationRepository) FindByBoardID(ctx context.Context, boardID string) (*models.GitIntegration, error) {
+	for _, integration := range m.integrations {
+		if integration.BoardID == boardID {
+			return integration, nil
+		}
+	}
+	return nil, errors.New("git integration not found")
+}
+
+func (m *mockGitIntegrationRepository) Update(ctx context.Context, integration *models.GitIntegration) error {
+	m.integrations[integration.ID] = integration
+	return nil
+}
+
+func (m *mockGitIntegrationRepository) Delete(ctx context.Context, id string) error {
+	delete(m.integrations, id)
+	return nil
+}
+
+func (m *mockGitIntegrationRepository) TouchDelivery(ctx context.Context, id string, deliveredAt time.Time) error {
+	m.integrations[id].LastDeliveryAt = &deliveredAt
+	return nil
+}
+
+func (m *mockGitIntegrationRepository) FindBoardTasks(ctx context.Context, boardID string, taskIDs, keys []string) ([]*models.Task, error) {
+	wanted := map[string]bool{}
+	for _, reference := range append(taskIDs, keys...) {
+		wanted[reference] = true
+	}
+
+	var tasks []*models.Task
+	for _, task := range m.tasks {
+		if wanted[task.ID] || wanted[task.Key] {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockGitIntegrationRepository) HasActivity(ctx context.Context, taskID, action, value string) (bool, error) {
+	for _, activity := range m.activities.activities {
+		if activity.TaskID == taskID && activity.Action == action && activity.NewValue != nil && *activity.NewValue == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type gitTestTaskService struct {
+	TaskService
+	tasks map[string]*models.Task
+}
+
+func (s *gitTestTaskService) Move(ctx context.Context, taskID, columnID, userID string) error {
+	s.tasks[taskID].ColumnID = columnID
+	return nil
+}
+
+func setupGitIntegrationService(t *testing.T) (GitIntegrationService, *mockGitIntegrationRepository, *models.GitIntegration) {
+	board := &models.Board{ID: "board123", UserID: "user123", Columns: []models.Column{{ID: "doing"}, {ID: "review"}, {ID: "done"}}}
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards[board.ID] = board
+
+	tasks := map[string]*models.Task{gitFixtureTaskID: {ID: gitFixtureTaskID, Key: "KAN-42", ColumnID: "doing"}}
+	gitRepo := &mockGitIntegrationRepository{
+		integrations: map[string]*models.GitIntegration{},
+		tasks:        tasks,
+		activities:   &mockActivityRepository{},
+	}
+	service := NewGitIntegrationService(gitRepo, boardRepo, gitRepo.activities, &gitTestTaskService{tasks: tasks})
+
+	configured, err := service.Configure(context.Background(), board.ID, "user123", stringPointer("review"), stringPointer("done"), false)
+	if err != nil {
+		t.Fatalf("Configure() unexpected error = %v", err)
+	}
+	if configured.Secret == "" {
+		t.Fatal("Configure() should return the generated secret")
+	}
+
+	return service, gitRepo, configured.GitIntegration
+}
+
+func readGitFixture(t *testing.T, name string) []byte {
+	body, err := os.ReadFile(filepath.Join("testdata", "git", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestGitIntegrationService_GitHubPushLinksCommits(t *testing.T) {
+	service, gitRepo, integration := setupGitIntegrationService(t)
+	ctx := context.Background()
+	body := readGitFixture(t, "github_push.json")
+	headers := GitWebhookHeaders{GitHubEvent: "push", GitHubSignature: SignWebhookPayload(integration.Secret, body)}
+
+	result, err := service.HandleWebhook(ctx, integration.ID, headers, body)
+	if err != nil {
+		t.Fatalf("HandleWebhook() unexpected error = %v", err)
+	}
+
+	if result.Provider != GitProviderGitHub || result.Linked != 1 || result.Moved != 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if want := []string{gitFixtureTaskID, "KAN-42"}; !reflect.DeepEqual(result.References, want) {
+		t.Errorf("references = %v, want %v", result.References, want)
+	}
+
+	activities := gitRepo.activities.activities
+	if len(activities) != 1 || activities[0].Action != models.ActivityCommitLinked || *activities[0].NewValue != "https://github.com/acme/kanban/commit/0d1a26e67d8f5eaf1f6ba5c57fc3c7d91ac0fd1c" {
+		t.Errorf("unexpected activities %+v", activities)
+	}
+	if activities[0].UserID != "user123" {
+		t.Errorf("commit linked by %s, want the board owner", activities[0].UserID)
+	}
+
+	// Redeliveries must not link the commit again
+	result, err = service.HandleWebhook(ctx, integration.ID, headers, body)
+	if err != nil {
+		t.Fatalf("HandleWebhook() unexpected error = %v", err)
+	}
+	if result.Linked != 0 || len(gitRepo.activities.activities) != 1 {
+		t.Errorf("redelivery linked again: %+v", result)
+	}
+	if gitRepo.integrations[integration.ID].LastDeliveryAt == nil {
+		t.Error("last delivery time not recorded")
+	}
+}
+
+func TestGitIntegrationService_PullRequestsMoveTasks(t *testing.T) {
+	tests := []struct {
+		name       string
+		headers    func(secret string, body []byte) GitWebhookHeaders
+		fixtures   []string
+		wantColumn []string
+	}{
+		{
+			name: "github",
+			headers: func(secret string, body []byte) GitWebhookHeaders {
+				return GitWebhookHeaders{GitHubEvent: "pull_request", GitHubSignature: SignWebhookPayload(secret, body)}
+			},
+			fixtures:   []string{"github_pull_request_opened.json", "github_pull_request_merged.json"},
+			wantColumn: []string{"review", "done"},
+		},
+		{
+			name: "gitlab",
+			headers: func(secret string, body []byte) GitWebhookHeaders {
+				return GitWebhookHeaders{GitLabEvent: "Merge Request Hook", GitLabToken: secret}
+			},
+			fixtures:   []string{"gitlab_merge_request.json"},
+			wantColumn: []string{"done"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, gitRepo, integration := setupGitIntegrationService(t)
+
+			for i, fixture := range tt.fixtures {
+				body := readGitFixture(t, fixture)
+				result, err := service.HandleWebhook(context.Background(), integration.ID, tt.headers(integration.Secret, body), body)
+				if err != nil {
+					t.Fatalf("HandleWebhook(%s) unexpected error = %v", fixture, err)
+				}
+				if result.Moved != 1 {
+					t.Errorf("%s moved %d tasks, want 1", fixture, result.Moved)
+				}
+				if got := gitRepo.tasks[gitFixtureTaskID].ColumnID; got != tt.wantColumn[i] {
+					t.Errorf("after %s task is in %s, want %s", fixture, got, tt.wantColumn[i])
+				}
+			}
+
+			// The pull request is linked once, however often its state changes
+			activities := gitRepo.activities.activities
+			if len(activities) != 1 || activities[0].Action != models.ActivityPullRequestLinked {
+				t.Errorf("unexpected activities %+v", activities)
+			}
+		})
+	}
+}
+
+func TestGitIntegrationService_GitLabPush(t *testing.T) {
+	service, gitRepo, integration := setupGitIntegrationService(t)
+	body := readGitFixture(t, "gitlab_push.json")
+
+	result, err := service.HandleWebhook(context.Background(), integration.ID, GitWebhookHeaders{GitLabEvent: "Push Hook", GitLabToken: integration.Secret}, body)
+	if err != nil {
+		t.Fatalf("HandleWebhook() unexpected error = %v", err)
+	}
+	if result.Linked != 1 || len(gitRepo.activities.activities) != 1 {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestGitIntegrationService_ResolvesTaskKeys(t *testing.T) {
+	service, gitRepo, integration := setupGitIntegrationService(t)
+	body := []byte(`{"commits":[{"id":"a1","message":"kan-42: tidy up","url":"https://github.com/acme/kanban/commit/a1"},{"id":"b2","message":"OPS-1 elsewhere","url":"https://github.com/acme/kanban/commit/b2"}]}`)
+	headers := GitWebhookHeaders{GitHubEvent: "push", GitHubSignature: SignWebhookPayload(integration.Secret, body)}
+
+	result, err := service.HandleWebhook(context.Background(), integration.ID, headers, body)
+	if err != nil {
+		t.Fatalf("HandleWebhook() unexpected error = %v", err)
+	}
+	if result.Linked != 1 {
+		t.Errorf("linked %d commits, want only the one naming KAN-42", result.Linked)
+	}
+	if activities := gitRepo.activities.activities; len(activities) != 1 || activities[0].TaskID != gitFixtureTaskID {
+		t.Errorf("unexpected activities %+v", activities)
+	}
+}
+
+func TestGitIntegrationService_RejectsUnverifiedDeliveries(t *testing.T) {
+	service, gitRepo, integration := setupGitIntegrationService(t)
+	ctx := context.Background()
+	body := readGitFixture(t, "github_push.json")
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.HandleWebhook(ctx, integration.ID, GitWebhookHeaders{GitHubEvent: "push", GitHubSignature: SignWebhookPayload("wrong secret", body)}, body); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("bad signature error = %v, want unauthorized", err)
+	}
+	if _, err := service.HandleWebhook(ctx, integration.ID, GitWebhookHeaders{GitHubEvent: "push"}, body); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("missing signature error = %v, want unauthorized", err)
+	}
+	if _, err := service.HandleWebhook(ctx, integration.ID, GitWebhookHeaders{GitLabEvent: "Push Hook", GitLabToken: "wrong"}, body); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("bad token error = %v, want unauthorized", err)
+	}
+
+	var validationErr utils.ErrValidation
+	if _, err := service.HandleWebhook(ctx, integration.ID, GitWebhookHeaders{}, body); !errors.As(err, &validationErr) {
+		t.Errorf("unknown provider error = %v, want validation error", err)
+	}
+	if len(gitRepo.activities.activities) != 0 {
+		t.Error("unverified deliveries must not link anything")
+	}
+}
+
+func TestGitIntegrationService_Configure(t *testing.T) {
+	service, _, integration := setupGitIntegrationService(t)
+	ctx := context.Background()
+
+	updated, err := service.Configure(ctx, "board123", "user123", stringPointer(""), nil, false)
+	if err != nil {
+		t.Fatalf("Configure() unexpected error = %v", err)
+	}
+	if updated.OpenedColumnID != nil || updated.MergedColumnID == nil || *updated.MergedColumnID != "done" || updated.Secret != "" {
+		t.Errorf("unexpected integration %+v", updated)
+	}
+
+	rotated, err := service.Configure(ctx, "board123", "user123", nil, nil, true)
+	if err != nil {
+		t.Fatalf("Configure() unexpected error = %v", err)
+	}
+	if rotated.ID != integration.ID || rotated.Secret == "" {
+		t.Errorf("rotating should keep the integration and return a new secret, got %+v", rotated)
+	}
+
+	var validationErr utils.ErrValidation
+	if _, err := service.Configure(ctx, "board123", "user123", stringPointer("elsewhere"), nil, false); !errors.As(err, &validationErr) {
+		t.Errorf("Configure() error = %v, want validation error", err)
+	}
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.Configure(ctx, "board123", "someone-else", nil, nil, false); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("Configure() error = %v, want unauthorized", err)
+	}
+}
+
+func TestGitTaskReferences(t *testing.T) {
+	ids, keys := gitTaskReferences("Merge fix/kan-42-login: refs 9B2F4C1A-7d3e-4f8a-b6c5-2e1d0a9f8b7c, OPS-7 and KAN-42 again")
+
+	if want := []string{gitFixtureTaskID}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	if want := []string{"KAN-42", "OPS-7"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// Git providers
+const (
+	GitProviderGitHub = "github"
+	GitProviderGitLab = "gitlab"
+)
+
+// Pull request states that can move tasks
+const (
+	gitPullRequestOpened = "opened"
+	gitPullRequestMerged = "merged"
+)
+
+var (
+	gitTaskIDPattern  = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	gitTaskKeyPattern = regexp.MustCompile(`(?i)\b[a-z][a-z0-9]{1,9}-[0-9]+\b`)
+)
+
+// gitChange is a commit or pull request from a webhook payload, reduced to
+// what is needed to link it to tasks. Text is searched for task references.
+type gitChange struct {
+	kind  string
+	url   string
+	text  string
+	state string
+}
+
+type gitHubPushPayload struct {
+	Commits []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		URL     string `json:"url"`
+	} `json:"commits"`
+}
+
+type gitHubPullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		HTMLURL string `json:"html_url"`
+		Title   string `json:"title"`
+		Body    string `json:"body"`
+		Merged  bool   `json:"merged"`
+		Head    struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+	} `json:"pull_request"`
+}
+
+type gitLabPushPayload struct {
+	Commits []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		URL     string `json:"url"`
+	} `json:"commits"`
+}
+
+type gitLabMergeRequestPayload struct {
+	ObjectAttributes struct {
+		Title        string `json:"title"`
+		Description  string `json:"description"`
+		URL          string `json:"url"`
+		Action       string `json:"action"`
+		SourceBranch string `json:"source_branch"`
+	} `json:"object_attributes"`
+}
+
+// parseGitHubPayload reads push and pull_request events; other events, such
+// as the ping sent when a webhook is added, yield no changes
+func parseGitHubPayload(event string, body []byte) ([]gitChange, error) {
+	switch event {
+	case "push":
+		var payload gitHubPushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, err
+		}
+		changes := make([]gitChange, 0, len(payload.Commits))
+		for _, commit := range payload.Commits {
+			changes = append(changes, gitChange{kind: "commit", url: commit.URL, text: commit.Message})
+		}
+		return changes, nil
+
+	case "pull_request":
+		var payload gitHubPullRequestPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, err
+		}
+		pr := payload.PullRequest
+		change := gitChange{
+			kind: "pull_request",
+			url:  pr.HTMLURL,
+			text: strings.Join([]string{pr.Title, pr.Body, pr.Head.Ref}, "\n"),
+		}
+		switch {
+		case payload.Action == "opened" || payload.Action == "reopened" || payload.Action == "ready_for_review":
+			change.state = gitPullRequestOpened
+		case payload.Action == "closed" && pr.Merged:
+			change.state = gitPullRequestMerged
+		}
+		return []gitChange{change}, nil
+	}
+
+	return nil, nil
+}
+
+// parseGitLabPayload reads push and merge request hooks
+func parseGitLabPayload(event string, body []byte) ([]gitChange, error) {
+	switch event {
+	case "Push Hook":
+		var payload gitLabPushPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, err
+		}
+		changes := make([]gitChange, 0, len(payload.Commits))
+		for _, commit := range payload.Commits {
+			changes = append(changes, gitChange{kind: "commit", url: commit.URL, text: commit.Message})
+		}
+		return changes, nil
+
+	case "Merge Request Hook":
+		var payload gitLabMergeRequestPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, err
+		}
+		mr := payload.ObjectAttributes
+		change := gitChange{
+			kind: "pull_request",
+			url:  mr.URL,
+			text: strings.Join([]string{mr.Title, mr.Description, mr.SourceBranch}, "\n"),
+		}
+		switch mr.Action {
+		case "open", "reopen":
+			change.state = gitPullRequestOpened
+		case "merge":
+			change.state = gitPullRequestMerged
+		}
+		return []gitChange{change}, nil
+	}
+
+	return nil, nil
+}
+
+// gitTaskReferences finds task IDs and task keys such as KAN-42 in text.
+// Keys are returned upper-cased; IDs lower-cased.
+func gitTaskReferences(text string) (ids []string, keys []string) {
+	seen := map[string]bool{}
+	for _, match := range gitTaskIDPattern.FindAllString(text, -1) {
+		id := strings.ToLower(match)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	// IDs are removed first so that their segments are not read as keys
+	remaining := gitTaskIDPattern.ReplaceAllString(text, " ")
+	for _, match := range gitTaskKeyPattern.FindAllString(remaining, -1) {
+		key := strings.ToUpper(match)
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return ids, keys
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"kanban-backend/repositories"
+)
+
+const (
+	// healthCheckTimeout bounds each readiness check so a hanging
+	// dependency cannot stall the probe
+	healthCheckTimeout = 2 * time.Second
+
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+	HealthStatusFailed      = "failed"
+	HealthStatusDraining    = "draining"
+)
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_.+\.up\.sql$`)
+
+// StoragePinger checks that file storage is reachable
+type StoragePinger interface {
+	Ping(ctx context.Context) error
+}
+
+// ReadinessReport is the outcome of every readiness check by name. Failures
+// are logged; the report only says which checks failed.
+type ReadinessReport struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Ready reports whether the instance should receive traffic
+func (r *ReadinessReport) Ready() bool {
+	return r.Status == HealthStatusOK
+}
+
+type HealthService interface {
+	Ready(ctx context.Context) *ReadinessReport
+	Drain()
+}
+
+type healthService struct {
+	healthRepo     repositories.HealthRepository
+	storage        StoragePinger
+	migrationsPath string
+	draining       atomic.Bool
+}
+
+// NewHealthService checks the database, the storage and that the database
+// is migrated to the newest migration found in migrationsPath
+func NewHealthService(healthRepo repositories.HealthRepository, storage StoragePinger, migrationsPath string) HealthService {
+	return &healthService{
+		healthRepo:     healthRepo,
+		storage:        storage,
+		migrationsPath: migrationsPath,
+	}
+}
+
+// Ready runs the readiness checks concurrently. Once Drain has been called
+// the instance reports itself unavailable so load balancers stop sending it
+// new requests while in-flight ones finish.
+func (s *healthService) Ready(ctx context.Context) *ReadinessReport {
+	checks := map[string]func(context.Context) error{
+		"database":   s.healthRepo.Ping,
+		"storage":    s.storage.Ping,
+		"migrations": s.checkMigrations,
+	}
+
+	report := &ReadinessReport{Status: HealthStatusOK, Checks: make(map[string]string, len(checks)+1)}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			err := check(checkCtx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				slog.WarnContext(ctx, "readiness check failed", "check", name, "error", err)
+				report.Checks[name] = HealthStatusFailed
+				report.Status = HealthStatusUnavailable
+				return
+			}
+			report.Checks[name] = HealthStatusOK
+		}()
+	}
+	wg.Wait()
+
+	if s.draining.Load() {
+		report.Status = HealthStatusDraining
+	}
+	return report
+}
+
+// Drain marks the instance as shutting down
+func (s *healthService) Drain() {
+	s.draining.Store(true)
+}
+
+// checkMigrations fails when the database is behind the migration files or
+// a migration was left half applied
+func (s *healthService) checkMigrations(ctx context.Context) error {
+	latest, err := latestMigrationVersion(s.migrationsPath)
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := s.healthRepo.MigrationVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+	if version < latest {
+		return fmt.Errorf("database is at migration %d, want %d", version, latest)
+	}
+	return nil
+}
+
+// latestMigrationVersion is the highest version among the .up.sql files
+func latestMigrationVersion(migrationsPath string) (uint, error) {
+	entries, err := os.ReadDir(migrationsPath)
+	if err != nil {
+		return 0, fmt.Errorf("read migrations: %w", err)
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err == nil && uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found in %s", migrationsPath)
+	}
+	return latest, nil
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockHealthRepository struct {
+	pingErr error
+	version uint
+	dirty   bool
+}
+
+func (m *mockHealthRepository) Ping(ctx context.Context) error {
+	return m.pingErr
+}
+
+func (m *mockHealthRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	return m.version, m.dirty, nil
+}
+
+type mockStoragePinger struct {
+	err error
+}
+
+func (m *mockStoragePinger) Ping(ctx context.Context) error {
+	return m.err
+}
+
+// writeMigrations creates empty migration files in a temporary directory
+func writeMigrations(t *testing.T, names ...string) string {
+	dir := t.TempDir()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestHealthService_Ready(t *testing.T) {
+	migrations := writeMigrations(t, "000001_init.up.sql", "000001_init.down.sql", "000012_webhooks.up.sql", "000012_webhooks.down.sql", "README.md")
+
+	tests := []struct {
+		name       string
+		repo       *mockHealthRepository
+		storageErr error
+		wantStatus string
+		wantChecks map[string]string
+	}{
+		{
+			name:       "all healthy",
+			repo:       &mockHealthRepository{version: 12},
+			wantStatus: HealthStatusOK,
+			wantChecks: map[string]string{"database": HealthStatusOK, "storage": HealthStatusOK, "migrations": HealthStatusOK},
+		},
+		{
+			name:       "database down",
+			repo:       &mockHealthRepository{pingErr: errors.New("connection refused"), version: 12},
+			wantStatus: HealthStatusUnavailable,
+			wantChecks: map[string]string{"database": HealthStatusFailed, "storage": HealthStatusOK, "migrations": HealthStatusOK},
+		},
+		{
+			name:       "storage unreachable",
+			repo:       &mockHealthRepository{version: 12},
+			storageErr: errors.New("no such bucket"),
+			wantStatus: HealthStatusUnavailable,
+			wantChecks: map[string]string{"database": HealthStatusOK, "storage": HealthStatusFailed, "migrations": HealthStatusOK},
+		},
+		{
+			name:       "migrations behind",
+			repo:       &mockHealthRepository{version: 1},
+			wantStatus: HealthStatusUnavailable,
+			wantChecks: map[string]string{"database": HealthStatusOK, "storage": HealthStatusOK, "migrations": HealthStatusFailed},
+		},
+		{
+			name:       "dirty migration",
+			repo:       &mockHealthRepository{version: 12, dirty: true},
+			wantStatus: HealthStatusUnavailable,
+			wantChecks: map[string]string{"database": HealthStatusOK, "storage": HealthStatusOK, "migrations": HealthStatusFailed},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewHealthService(tt.repo, &mockStoragePinger{err: tt.storageErr}, migrations)
+
+			report := service.Ready(context.Background())
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Equal(t, tt.wantChecks, report.Checks)
+			assert.Equal(t, tt.wantStatus == HealthStatusOK, report.Ready())
+		})
+	}
+}
+
+func TestHealthService_Drain(t *testing.T) {
+	migrations := writeMigrations(t, "000001_init.up.sql")
+	service := NewHealthService(&mockHealthRepository{version: 1}, &mockStoragePinger{}, migrations)
+
+	assert.True(t, service.Ready(context.Background()).Ready())
+
+	service.Drain()
+	report := service.Ready(context.Background())
+	assert.False(t, report.Ready())
+	assert.Equal(t, HealthStatusDraining, report.Status)
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	_, err := latestMigrationVersion(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	_, err = latestMigrationVersion(writeMigrations(t, "notes.txt"))
+	assert.Error(t, err)
+
+	latest, err := latestMigrationVersion(writeMigrations(t, "000003_a.up.sql", "000010_b.up.sql", "000011_c.down.sql"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(10), latest)
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"kanban-backend/logging"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+// importProgressInterval is how many tasks are imported between progress updates
+const importProgressInterval = 25
+
+type ImportService interface {
+	StartTrelloImport(ctx context.Context, userID string, data []byte) (*models.ImportJob, error)
+	FindJob(ctx context.Context, jobID, userID string) (*models.ImportJob, error)
+	Wait()
+}
+
+type importService struct {
+	jobRepo    repositories.ImportJobRepository
+	exportRepo repositories.BoardExportRepository
+	running    sync.WaitGroup
+}
+
+func NewImportService(jobRepo repositories.ImportJobRepository, exportRepo repositories.BoardExportRepository) ImportService {
+	return &importService{
+		jobRepo:    jobRepo,
+		exportRepo: exportRepo,
+	}
+}
+
+// StartTrelloImport parses a Trello board export and imports it in the
+// background. The returned job can be polled for progress.
+func (s *importService) StartTrelloImport(ctx context.Context, userID string, data []byte) (*models.ImportJob, error) {
+	ctx, span := tracing.Start(ctx, "ImportService.StartTrelloImport")
+	defer span.End()
+
+	var board trelloBoard
+	if err := json.Unmarshal(data, &board); err != nil {
+		return nil, utils.NewValidation("invalid Trello export: " + err.Error())
+	}
+
+	if len(board.Lists) == 0 {
+		return nil, utils.NewValidation("Trello export has no lists")
+	}
+
+	job := &models.ImportJob{
+		UserID: userID,
+		Source: models.ImportSourceTrello,
+		Status: models.ImportStatusPending,
+		Total:  len(board.Cards),
+	}
+	if err := s.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	// The request context ends with the response, so the import gets its own.
+	// It also works on its own copy of the job, which is returned to the caller.
+	importCtx := logging.Detach(ctx)
+	running := *job
+	s.running.Add(1)
+	go func() {
+		defer s.running.Done()
+		defer func() {
+			if r := recover(); r != nil {
+				slog.ErrorContext(importCtx, "import job panicked", "import_job_id", running.ID, "panic", r)
+				s.failImport(importCtx, &running, fmt.Errorf("import panicked: %v", r))
+			}
+		}()
+		s.runTrelloImport(importCtx, &running, &board)
+	}()
+
+	return job, nil
+}
+
+func (s *importService) FindJob(ctx context.Context, jobID, userID string) (*models.ImportJob, error) {
+	ctx, span := tracing.Start(ctx, "ImportService.FindJob")
+	defer span.End()
+
+	job, err := s.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, utils.NewNotFound("import job not found")
+	}
+
+	if job.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this import job")
+	}
+
+	return job, nil
+}
+
+// Wait blocks until every running import has finished
+func (s *importService) Wait() {
+	s.running.Wait()
+}
+
+func (s *importService) runTrelloImport(ctx context.Context, job *models.ImportJob, board *trelloBoard) {
+	job.Status = models.ImportStatusRunning
+	if err := s.jobRepo.Update(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "failed to start import job", "import_job_id", job.ID, "error", err)
+		return
+	}
+
+	members, err := s.matchTrelloMembers(ctx, board.Members)
+	if err != nil {
+		s.failImport(ctx, job, err)
+		return
+	}
+
+	export, skipped := convertTrelloBoard(board, members, time.Now().UTC())
+	job.Skipped = skipped
+	job.Total = len(export.Tasks)
+	if err := validateBoardExport(export); err != nil {
+		s.failImport(ctx, job, err)
+		return
+	}
+	if err := s.jobRepo.Update(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "failed to update import job", "import_job_id", job.ID, "error", err)
+	}
+
+	target := &models.Board{
+		Title:  export.Board.Title,
+		Color:  export.Board.Color,
+		UserID: job.UserID,
+	}
+	err = s.exportRepo.Import(ctx, target, export, func(imported int) {
+		if imported%importProgressInterval != 0 {
+			return
+		}
+		if err := s.jobRepo.UpdateProgress(ctx, job.ID, imported); err != nil {
+			slog.ErrorContext(ctx, "failed to update import job progress", "import_job_id", job.ID, "error", err)
+		}
+	})
+	if err != nil {
+		s.failImport(ctx, job, err)
+		return
+	}
+
+	finishedAt := time.Now().UTC()
+	job.Status = models.ImportStatusCompleted
+	job.Processed = job.Total
+	job.BoardID = &target.ID
+	job.FinishedAt = &finishedAt
+	if err := s.jobRepo.Update(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "failed to complete import job", "import_job_id", job.ID, "error", err)
+	}
+}
+
+// matchTrelloMembers finds the local user for each Trello member, by email
+// when the export includes one and by username otherwise
+func (s *importService) matchTrelloMembers(ctx context.Context, trelloMembers []trelloMember) (map[string]*models.User, error) {
+	var emails, usernames []string
+	for _, member := range trelloMembers {
+		if member.Email != "" {
+			emails = append(emails, member.Email)
+		} else if member.Username != "" {
+			usernames = append(usernames, member.Username)
+		}
+	}
+
+	byEmail, err := s.exportRepo.FindUsersByEmail(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+	byUsername, err := s.exportRepo.FindUsersByUsername(ctx, usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[string]*models.User, len(byEmail)+len(byUsername))
+	for _, user := range byEmail {
+		users["email:"+user.Email] = user
+	}
+	for _, user := range byUsername {
+		users["username:"+user.Username] = user
+	}
+
+	members := make(map[string]*models.User, len(trelloMembers))
+	for _, member := range trelloMembers {
+		key := "username:" + member.Username
+		if member.Email != "" {
+			key = "email:" + member.Email
+		}
+		if user, ok := users[key]; ok {
+			members[member.ID] = user
+		}
+	}
+	return members, nil
+}
+
+func (s *importService) failImport(ctx context.Context, job *models.ImportJob, cause error) {
+	finishedAt := time.Now().UTC()
+	job.Status = models.ImportStatusFailed
+	job.Error = cause.Error()
+	job.FinishedAt = &finishedAt
+	if err := s.jobRepo.Update(ctx, job); err != nil {
+		slog.ErrorContext(ctx, "failed to record import job failure", "import_job_id", job.ID, "error", err)
+	}
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+const trelloFixture = `{
+	"name": "Product",
+	"prefs": {"backgroundColor": "blue"},
+	"lists": [
+		{"id": "list-done", "name": "Done", "pos": 300},
+		{"id": "list-todo", "name": "To Do", "pos": 100},
+		{"id": "list-old", "name": "Old ideas", "closed": true, "pos": 200}
+	],
+	"labels": [
+		{"id": "label-bug", "name": "Bug", "color": "red_dark"},
+		{"id": "label-blank", "name": "", "color": "green"}
+	],
+	"members": [
+		{"id": "member-ann", "username": "ann", "fullName": "Ann Lee"},
+		{"id": "member-bob", "username": "bob", "fullName": "Bob Roe", "email": "bob@example.com"},
+		{"id": "member-eve", "username": "eve", "fullName": "Eve Moe"}
+	],
+	"cards": [
+		{"id": "5f1e0c800000000000000002", "name": "Ship release", "idList": "list-done", "pos": 1, "closed": true, "dateLastActivity": "2020-08-01T10:00:00.000Z"},
+		{"id": "5f1e0c800000000000000001", "name": "Fix login", "desc": "Users are logged out", "idList": "list-todo", "pos": 2,
+			"due": "2020-08-10T17:00:00.000Z", "idLabels": ["label-bug", "label-blank"], "idMembers": ["member-ann", "member-eve"],
+			"attachments": [{"name": "trace.txt", "url": "https://trello.com/trace.txt", "bytes": 42}, {"name": "broken", "url": ""}]},
+		{"id": "5f1e0c800000000000000003", "name": "Someday", "idList": "list-old", "pos": 1}
+	],
+	"checklists": [
+		{"id": "check-1", "idCard": "5f1e0c800000000000000001", "name": "Steps", "checkItems": [
+			{"name": "Reproduce", "state": "complete", "pos": 1},
+			{"name": "Patch", "state": "incomplete", "pos": 2}
+		]}
+	],
+	"actions": [
+		{"type": "commentCard", "date": "2020-08-02T09:00:00.000Z", "idMemberCreator": "member-eve", "memberCreator": {"fullName": "Eve Moe"}, "data": {"text": "Seen it too", "card": {"id": "5f1e0c800000000000000001"}}},
+		{"type": "commentCard", "date": "2020-08-01T09:00:00.000Z", "idMemberCreator": "member-bob", "memberCreator": {"fullName": "Bob Roe"}, "data": {"text": "Looking", "card": {"id": "5f1e0c800000000000000001"}}},
+		{"type": "updateCard", "date": "2020-08-01T08:00:00.000Z", "data": {"card": {"id": "5f1e0c800000000000000001"}}}
+	]
+}`
+
+type mockImportJobRepository struct {
+	jobs     map[string]*models.ImportJob
+	progress []int
+}
+
+func newMockImportJobRepository() *mockImportJobRepository {
+	return &mockImportJobRepository{jobs: make(map[string]*models.ImportJob)}
+}
+
+func (m *mockImportJobRepository) Create(ctx context.Context, job *models.ImportJob) error {
+	if job.ID == "" {
+		job.ID = "job-1"
+	}
+	copied := *job
+	m.jobs[job.ID] = &copied
+	return nil
+}
+
+func (m *mockImportJobRepository) FindByID(ctx context.Context, id string) (*models.ImportJob, error) {
+	job, exists := m.jobs[id]
+	if !exists {
+		return nil, errors.New("import job not found")
+	}
+	return job, nil
+}
+
+func (m *mockImportJobRepository) Update(ctx context.Context, job *models.ImportJob) error {
+	copied := *job
+	m.jobs[job.ID] = &copied
+	return nil
+}
+
+func (m *mockImportJobRepository) UpdateProgress(ctx context.Context, id string, processed int) error {
+	m.progress = append(m.progress, processed)
+	return nil
+}
+
+func TestImportService_TrelloImport(t *testing.T) {
+	jobRepo := newMockImportJobRepository()
+	exportRepo := &mockBoardExportRepository{users: []*models.User{
+		{ID: "user-ann", Username: "ann", Email: "ann@example.com"},
+		{ID: "user-bob", Username: "robert", Email: "bob@example.com"},
+	}}
+	service := NewImportService(jobRepo, exportRepo)
+
+	job, err := service.StartTrelloImport(context.Background(), "user123", []byte(trelloFixture))
+	if err != nil {
+		t.Fatalf("StartTrelloImport() unexpected error = %v", err)
+	}
+	service.Wait()
+
+	job, err = service.FindJob(context.Background(), job.ID, "user123")
+	if err != nil {
+		t.Fatalf("FindJob() unexpected error = %v", err)
+	}
+	if job.Status != models.ImportStatusCompleted || job.BoardID == nil || job.FinishedAt == nil {
+		t.Fatalf("expected completed job with a board, got %+v", job)
+	}
+	if job.Total != 2 || job.Processed != 2 {
+		t.Errorf("progress = %d/%d, want 2/2", job.Processed, job.Total)
+	}
+
+	skipped := make(map[string]string)
+	for _, item := range job.Skipped {
+		skipped[item.Type+":"+item.Name] = item.Reason
+	}
+	for _, key := range []string{"list:Old ideas", "card:Someday", "label:green", "member:Eve Moe", "attachment:broken"} {
+		if _, ok := skipped[key]; !ok {
+			t.Errorf("expected %s to be reported as skipped, got %v", key, job.Skipped)
+		}
+	}
+
+	export := exportRepo.imported
+	if export.Board.Title != "Product" || export.Board.Color != "#0079BF" {
+		t.Errorf("unexpected board %+v", export.Board)
+	}
+	if len(export.Columns) != 2 || export.Columns[0].Title != "To Do" || export.Columns[1].Stage != models.ColumnStageDone {
+		t.Errorf("unexpected columns %+v", export.Columns)
+	}
+	if len(export.Labels) != 1 || export.Labels[0].Color != "#EB5A46" {
+		t.Errorf("labels = %+v, want Bug in red", export.Labels)
+	}
+
+	task := export.Tasks[0]
+	if task.Title != "Fix login" || task.Deadline == nil || task.ArchivedAt != nil {
+		t.Errorf("unexpected first task %+v", task)
+	}
+	if !strings.Contains(task.Description, "### Steps") || !strings.Contains(task.Description, "- [x] Reproduce") || !strings.Contains(task.Description, "- [ ] Patch") {
+		t.Errorf("checklist missing from description %q", task.Description)
+	}
+	if len(task.Assignees) != 1 || task.Assignees[0] != "ann@example.com" {
+		t.Errorf("assignees = %v, want ann@example.com", task.Assignees)
+	}
+	if len(task.Comments) != 2 || task.Comments[0].AuthorEmail != "bob@example.com" {
+		t.Fatalf("comments should be oldest first with bob matched by email, got %+v", task.Comments)
+	}
+	if task.Comments[1].AuthorEmail != "" || !strings.HasPrefix(task.Comments[1].Content, "Eve Moe wrote on Trello") {
+		t.Errorf("unmatched author should be credited in the content, got %+v", task.Comments[1])
+	}
+	if len(task.Attachments) != 1 || task.Attachments[0].FileSize != 42 {
+		t.Errorf("attachments = %+v", task.Attachments)
+	}
+	if want := time.Unix(0x5f1e0c80, 0).UTC(); !task.CreatedAt.Equal(want) {
+		t.Errorf("CreatedAt = %v, want %v from the card id", task.CreatedAt, want)
+	}
+	if archived := export.Tasks[1]; archived.ArchivedAt == nil {
+		t.Errorf("closed card should be archived, got %+v", archived)
+	}
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.FindJob(context.Background(), job.ID, "someone-else"); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("FindJob() error = %v, want unauthorized", err)
+	}
+}
+
+func TestImportService_StartTrelloImportRejectsInvalidExports(t *testing.T) {
+	service := NewImportService(newMockImportJobRepository(), &mockBoardExportRepository{})
+
+	for _, data := range []string{`not json`, `{"name":"Empty","lists":[]}`} {
+		var validationErr utils.ErrValidation
+		if _, err := service.StartTrelloImport(context.Background(), "user123", []byte(data)); !errors.As(err, &validationErr) {
+			t.Errorf("StartTrelloImport(%q) error = %v, want validation error", data, err)
+		}
+	}
+}
+
+// panickingExportRepository fails an import the way a bug in it would
+type panickingExportRepository struct {
+	mockBoardExportRepository
+}
+
+func (m *panickingExportRepository) Import(ctx context.Context, board *models.Board, export *models.BoardExport, onTask func(imported int)) error {
+	panic("nil map")
+}
+
+func TestImportService_PanickedImportFails(t *testing.T) {
+	jobRepo := newMockImportJobRepository()
+	service := NewImportService(jobRepo, &panickingExportRepository{})
+
+	job, err := service.StartTrelloImport(context.Background(), "user123", []byte(trelloFixture))
+	if err != nil {
+		t.Fatalf("StartTrelloImport() unexpected error = %v", err)
+	}
+	service.Wait()
+
+	job, err = service.FindJob(context.Background(), job.ID, "user123")
+	if err != nil {
+		t.Fatalf("FindJob() unexpected error = %v", err)
+	}
+	if job.Status != models.ImportStatusFailed || job.FinishedAt == nil || job.Error != "import panicked: nil map" {
+		t.Errorf("expected the panicked job to be failed, got %+v", job)
+	}
+}
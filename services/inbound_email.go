@@ -0,0 +1,240 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxMIMEDepth bounds how deeply nested multipart bodies are followed
+const maxMIMEDepth = 10
+
+var (
+	htmlBlockPattern       = regexp.MustCompile(`(?is)<(script|style|head)[^>]*>.*?</(script|style|head)>`)
+	htmlBreakPattern       = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|tr|h[1-6])>`)
+	htmlTagPattern         = regexp.MustCompile(`<[^>]*>`)
+	blankLinesPattern      = regexp.MustCompile(`\n{3,}`)
+	replyPrefixPattern     = regexp.MustCompile(`(?i)^\s*(re|fwd?|aw|wg)\s*:\s*`)
+	quoteHeaderPattern     = regexp.MustCompile(`(?i)^on\s.+wrote:\s*$`)
+	originalMessagePattern = regexp.MustCompile(`(?i)^-{2,}\s*(original message|forwarded message)\s*-{2,}`)
+)
+
+// inboundEmail is what a received message contributes to a task or comment
+type inboundEmail struct {
+	from        string
+	recipients  []string
+	subject     string
+	text        string
+	attachments []inboundAttachment
+}
+
+type inboundAttachment struct {
+	fileName    string
+	contentType string
+	content     []byte
+}
+
+// emailBodies collects the first body of each text type found in a message
+type emailBodies struct {
+	markdown string
+	plain    string
+	html     string
+}
+
+// parseInboundEmail reads a raw RFC 5322 message. The body prefers a
+// text/markdown part, then text/plain, then text/html reduced to text. Parts
+// sent as attachments, or carrying a file name, become attachments.
+func parseInboundEmail(raw []byte) (*inboundEmail, error) {
+	message, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	email := &inboundEmail{subject: decodeMIMEHeader(message.Header.Get("Subject"))}
+	if from, err := mail.ParseAddress(message.Header.Get("From")); err == nil {
+		email.from = strings.ToLower(from.Address)
+	}
+	for _, field := range []string{"Delivered-To", "X-Original-To", "To", "Cc"} {
+		addresses, err := message.Header.AddressList(field)
+		if err != nil {
+			continue
+		}
+		for _, address := range addresses {
+			email.recipients = append(email.recipients, strings.ToLower(address.Address))
+		}
+	}
+
+	var bodies emailBodies
+	if err := readMIMEPart(textproto.MIMEHeader(message.Header), message.Body, email, &bodies, 0); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case bodies.markdown != "":
+		email.text = bodies.markdown
+	case bodies.plain != "":
+		email.text = bodies.plain
+	default:
+		email.text = htmlToText(bodies.html)
+	}
+	email.text = strings.TrimSpace(strings.ReplaceAll(email.text, "\r\n", "\n"))
+
+	return email, nil
+}
+
+func readMIMEPart(header textproto.MIMEHeader, body io.Reader, email *inboundEmail, bodies *emailBodies, depth int) error {
+	if depth > maxMIMEDepth {
+		return errors.New("message is nested too deeply")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType, params = "text/plain", map[string]string{}
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := readMIMEPart(part.Header, part, email, bodies, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return err
+	}
+
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+	fileName := dispositionParams["filename"]
+	if fileName == "" {
+		fileName = params["name"]
+	}
+	fileName = decodeMIMEHeader(fileName)
+
+	isBody := mediaType == "text/plain" || mediaType == "text/markdown" || mediaType == "text/x-markdown" || mediaType == "text/html"
+	if disposition == "attachment" || fileName != "" || !isBody {
+		// Unnamed inline parts, such as calendar invites, are left out
+		if fileName == "" {
+			if disposition != "attachment" {
+				return nil
+			}
+			fileName = "attachment"
+		}
+		email.attachments = append(email.attachments, inboundAttachment{fileName: fileName, contentType: mediaType, content: content})
+		return nil
+	}
+
+	text := decodeCharset(content, params["charset"])
+	switch mediaType {
+	case "text/markdown", "text/x-markdown":
+		if bodies.markdown == "" {
+			bodies.markdown = text
+		}
+	case "text/plain":
+		if bodies.plain == "" {
+			bodies.plain = text
+		}
+	case "text/html":
+		if bodies.html == "" {
+			bodies.html = text
+		}
+	}
+	return nil
+}
+
+func decodeTransferEncoding(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, body)
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	}
+	return body
+}
+
+// decodeCharset converts Latin-1 text to UTF-8; other charsets are kept as
+// they are when they are valid UTF-8
+func decodeCharset(content []byte, charset string) string {
+	switch strings.ToLower(charset) {
+	case "iso-8859-1", "latin1", "windows-1252":
+		runes := make([]rune, len(content))
+		for i, b := range content {
+			runes[i] = rune(b)
+		}
+		return string(runes)
+	}
+	if !utf8.Valid(content) {
+		return strings.ToValidUTF8(string(content), "�")
+	}
+	return string(content)
+}
+
+func decodeMIMEHeader(value string) string {
+	decoded, err := new(mime.WordDecoder).DecodeHeader(value)
+	if err != nil {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(decoded)
+}
+
+// htmlToText keeps the text of an HTML body with its line breaks
+func htmlToText(body string) string {
+	body = htmlBlockPattern.ReplaceAllString(body, "")
+	body = htmlBreakPattern.ReplaceAllString(body, "\n")
+	body = html.UnescapeString(htmlTagPattern.ReplaceAllString(body, ""))
+
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return blankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+}
+
+// emailTaskTitle turns a subject into a task title, without reply and forward
+// prefixes
+func emailTaskTitle(subject string) string {
+	for replyPrefixPattern.MatchString(subject) {
+		subject = replyPrefixPattern.ReplaceAllString(subject, "")
+	}
+	subject = strings.TrimSpace(subject)
+	if subject == "" {
+		return "(no subject)"
+	}
+	if runes := []rune(subject); len(runes) > 255 {
+		subject = string(runes[:255])
+	}
+	return subject
+}
+
+// stripQuotedReply keeps what the sender wrote above the quoted message and
+// their signature
+func stripQuotedReply(text string) string {
+	var kept []string
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, ">") || quoteHeaderPattern.MatchString(trimmed) || originalMessagePattern.MatchString(trimmed) || line == "-- " {
+			break
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
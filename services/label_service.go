@@ -5,8 +5,10 @@ import (
 	"errors"
 
 	"kanban-backend/config"
+	"kanban-backend/events"
 	"kanban-backend/models"
 	"kanban-backend/repositories"
+	"kanban-backend/tracing"
 	"kanban-backend/utils"
 
 	"gorm.io/gorm"
@@ -39,6 +41,9 @@ func NewLabelService(labelRepo repositories.LabelRepository, taskRepo repositori
 }
 
 func (s *labelService) Create(ctx context.Context, name, color string) (*models.Label, error) {
+	ctx, span := tracing.Start(ctx, "LabelService.Create")
+	defer span.End()
+
 	if name == "" {
 		return nil, utils.NewValidation("name is required")
 	}
@@ -57,6 +62,9 @@ func (s *labelService) Create(ctx context.Context, name, color string) (*models.
 }
 
 func (s *labelService) FindByID(ctx context.Context, id string) (*models.Label, error) {
+	ctx, span := tracing.Start(ctx, "LabelService.FindByID")
+	defer span.End()
+
 	label, err := s.labelRepo.FindByID(ctx, id)
 	if err != nil {
 		return nil, utils.NewNotFound("label not found")
@@ -66,6 +74,9 @@ func (s *labelService) FindByID(ctx context.Context, id string) (*models.Label,
 }
 
 func (s *labelService) FindAll(ctx context.Context) ([]*models.Label, error) {
+	ctx, span := tracing.Start(ctx, "LabelService.FindAll")
+	defer span.End()
+
 	labels, err := s.labelRepo.FindAll(ctx)
 	if err != nil {
 		return nil, err
@@ -75,6 +86,9 @@ func (s *labelService) FindAll(ctx context.Context) ([]*models.Label, error) {
 }
 
 func (s *labelService) Update(ctx context.Context, id, name, color string) (*models.Label, error) {
+	ctx, span := tracing.Start(ctx, "LabelService.Update")
+	defer span.End()
+
 	label, err := s.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -97,6 +111,9 @@ func (s *labelService) Update(ctx context.Context, id, name, color string) (*mod
 }
 
 func (s *labelService) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "LabelService.Delete")
+	defer span.End()
+
 	_, err := s.FindByID(ctx, id)
 	if err != nil {
 		return err
@@ -111,6 +128,9 @@ func (s *labelService) Delete(ctx context.Context, id string) error {
 }
 
 func (s *labelService) AddToTask(ctx context.Context, taskID, labelID, userID string) error {
+	ctx, span := tracing.Start(ctx, "LabelService.AddToTask")
+	defer span.End()
+
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
 		return utils.NewNotFound("task not found")
@@ -134,10 +154,18 @@ func (s *labelService) AddToTask(ctx context.Context, taskID, labelID, userID st
 		return errors.New("failed to add label to task")
 	}
 
+	event := events.ForTask(events.LabelAdded, userID, task)
+	event.Data["label_id"] = label.ID
+	event.Data["label_name"] = label.Name
+	events.Publish(ctx, event)
+
 	return nil
 }
 
 func (s *labelService) RemoveFromTask(ctx context.Context, taskID, labelID, userID string) error {
+	ctx, span := tracing.Start(ctx, "LabelService.RemoveFromTask")
+	defer span.End()
+
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
 		return utils.NewNotFound("task not found")
@@ -156,15 +184,23 @@ func (s *labelService) RemoveFromTask(ctx context.Context, taskID, labelID, user
 		return err
 	}
 
-	_ = label
+	err = s.db.WithContext(ctx).Model(task).Association("Labels").Delete(label)
 	if err != nil {
 		return errors.New("failed to remove label from task")
 	}
 
+	event := events.ForTask(events.LabelRemoved, userID, task)
+	event.Data["label_id"] = label.ID
+	event.Data["label_name"] = label.Name
+	events.Publish(ctx, event)
+
 	return nil
 }
 
 func (s *labelService) FindAllWithPagination(ctx context.Context, page, limit int) ([]*models.Label, int, error) {
+	ctx, span := tracing.Start(ctx, "LabelService.FindAllWithPagination")
+	defer span.End()
+
 	labels, total, err := s.labelRepo.FindAllWithPagination(ctx, page, limit)
 	if err != nil {
 		return nil, 0, err
@@ -174,6 +210,9 @@ func (s *labelService) FindAllWithPagination(ctx context.Context, page, limit in
 }
 
 func (s *labelService) Search(ctx context.Context, keyword string, page, limit int) ([]*models.Label, int, error) {
+	ctx, span := tracing.Start(ctx, "LabelService.Search")
+	defer span.End()
+
 	labels, total, err := s.labelRepo.Search(ctx, keyword, page, limit)
 	if err != nil {
 		return nil, 0, err
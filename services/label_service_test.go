@@ -94,6 +94,10 @@ func newMockTaskRepositoryForLabel() *mockTaskRepositoryForLabel {
 	}
 }
 
+func (m *mockTaskRepositoryForLabel) FindByKey(ctx context.Context, userID, key string) (*models.Task, error) {
+	return nil, utils.NewNotFound("task not found")
+}
+
 func (m *mockTaskRepositoryForLabel) FindByID(ctx context.Context, id string) (*models.Task, error) {
 	task, exists := m.tasks[id]
 	if !exists {
@@ -128,7 +132,7 @@ func (m *mockTaskRepositoryForLabel) SoftDelete(ctx context.Context, id string)
 	return nil
 }
 
-func (m *mockTaskRepositoryForLabel) FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, offset, limit int) ([]*models.Task, int, error) {
+func (m *mockTaskRepositoryForLabel) FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, archived bool, offset, limit int) ([]*models.Task, int, error) {
 	return nil, 0, nil
 }
 
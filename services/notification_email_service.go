@@ -0,0 +1,405 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	htmltemplate "html/template"
+	"log/slog"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"kanban-backend/mailer"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+const (
+	// notificationEmailWindow is how far back unsent notifications are picked
+	// up. It covers a daily digest; older notifications are no longer mailed.
+	notificationEmailWindow = 48 * time.Hour
+	// notificationEmailBatch is how many users' notifications are loaded at
+	// a time
+	notificationEmailBatch = 100
+	// notificationEmailLease keeps notifications being emailed from being
+	// picked up by another worker. It covers a single send.
+	notificationEmailLease = 5 * time.Minute
+	// dailyDigestHour is the hour of the day, in the user's timezone, at which
+	// daily digests go out
+	dailyDigestHour = 8
+	digestTemplate  = "digest"
+)
+
+//go:embed templates/email
+var emailTemplateFiles embed.FS
+
+// emailTemplates holds the text and HTML template of each notification type
+// and of the digest, each parsed together with its layout
+var emailTemplates = loadEmailTemplates()
+
+type notificationEmailTemplates struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+func loadEmailTemplates() notificationEmailTemplates {
+	templates := notificationEmailTemplates{
+		text: map[string]*texttemplate.Template{},
+		html: map[string]*htmltemplate.Template{},
+	}
+	for _, name := range append([]string{digestTemplate}, models.NotificationTypes...) {
+		templates.text[name] = texttemplate.Must(texttemplate.ParseFS(emailTemplateFiles, "templates/email/layout.txt", "templates/email/"+name+".txt"))
+		templates.html[name] = htmltemplate.Must(htmltemplate.ParseFS(emailTemplateFiles, "templates/email/layout.html", "templates/email/"+name+".html"))
+	}
+	return templates
+}
+
+// notificationEmailItem is one notification as shown in an email
+type notificationEmailItem struct {
+	Message   string
+	TaskKey   string
+	TaskTitle string
+	TaskURL   string
+}
+
+type notificationEmailData struct {
+	Username string
+	Item     notificationEmailItem
+	Items    []notificationEmailItem
+	// ReplyTo is the task's reply address, when replies become comments
+	ReplyTo string
+}
+
+type NotificationEmailService interface {
+	GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error)
+	UpdatePreferences(ctx context.Context, userID string, deliveries map[string]string) ([]models.NotificationPreference, error)
+	SendDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type notificationEmailService struct {
+	emailRepo    repositories.NotificationEmailRepository
+	mailer       mailer.Mailer
+	inboxService EmailInboxService
+	appURL       string
+}
+
+// NewNotificationEmailService mails notifications through mailer. Replies to
+// instant emails about a task go to the task's reply address when inbound
+// email is enabled; appURL, when set, is used for links to tasks.
+func NewNotificationEmailService(emailRepo repositories.NotificationEmailRepository, mailer mailer.Mailer, inboxService EmailInboxService, appURL string) NotificationEmailService {
+	return &notificationEmailService{
+		emailRepo:    emailRepo,
+		mailer:       mailer,
+		inboxService: inboxService,
+		appURL:       strings.TrimRight(appURL, "/"),
+	}
+}
+
+// GetPreferences returns the user's email delivery for every notification
+// type, with the default for types they have not set
+func (s *notificationEmailService) GetPreferences(ctx context.Context, userID string) ([]models.NotificationPreference, error) {
+	ctx, span := tracing.Start(ctx, "NotificationEmailService.GetPreferences")
+	defer span.End()
+
+	saved, err := s.emailRepo.FindPreferences(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make(map[string]string, len(saved))
+	for _, preference := range saved {
+		deliveries[preference.Type] = preference.Delivery
+	}
+
+	preferences := make([]models.NotificationPreference, len(models.NotificationTypes))
+	for i, notificationType := range models.NotificationTypes {
+		delivery, ok := deliveries[notificationType]
+		if !ok {
+			delivery = models.DefaultEmailDelivery
+		}
+		preferences[i] = models.NotificationPreference{UserID: userID, Type: notificationType, Delivery: delivery}
+	}
+	return preferences, nil
+}
+
+// UpdatePreferences sets the delivery of the given notification types and
+// leaves the others as they are
+func (s *notificationEmailService) UpdatePreferences(ctx context.Context, userID string, deliveries map[string]string) ([]models.NotificationPreference, error) {
+	ctx, span := tracing.Start(ctx, "NotificationEmailService.UpdatePreferences")
+	defer span.End()
+
+	preferences := make([]*models.NotificationPreference, 0, len(deliveries))
+	for notificationType, delivery := range deliveries {
+		if !isNotificationType(notificationType) {
+			return nil, utils.NewValidation("unknown notification type: " + notificationType)
+		}
+		if !models.ValidEmailDelivery(delivery) {
+			return nil, utils.NewValidation("delivery must be one of instant, hourly, daily or off")
+		}
+		preferences = append(preferences, &models.NotificationPreference{UserID: userID, Type: notificationType, Delivery: delivery})
+	}
+
+	if err := s.emailRepo.SavePreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+
+	return s.GetPreferences(ctx, userID)
+}
+
+// SendDue emails unread notifications as their users' preferences ask:
+// instant ones straight away, hourly ones at the top of the next hour and
+// daily ones at the next morning in the user's timezone, batched into one
+// digest per user. Notifications read before their digest goes out are not
+// mailed. Each email's notifications are claimed just before it is sent, so
+// workers running side by side send each one once. It returns how many
+// emails were sent.
+func (s *notificationEmailService) SendDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "NotificationEmailService.SendDue")
+	defer span.End()
+
+	if s.mailer == nil {
+		return 0, nil
+	}
+
+	started := time.Now()
+	sent := 0
+	afterUserID := ""
+	for {
+		notifications, err := s.emailRepo.FindUnemailed(ctx, now.Add(-notificationEmailWindow), afterUserID, notificationEmailBatch)
+		if err != nil || len(notifications) == 0 {
+			return sent, err
+		}
+
+		batchSent, err := s.sendBatch(ctx, notifications, now, started)
+		sent += batchSent
+		if err != nil {
+			return sent, err
+		}
+		afterUserID = notifications[len(notifications)-1].UserID
+	}
+}
+
+// sendBatch emails the notifications of one batch of users. Claims are
+// timed from now plus the time since started, so that each one starts when
+// its email is sent rather than when the run began.
+func (s *notificationEmailService) sendBatch(ctx context.Context, notifications []*models.Notification, now, started time.Time) (int, error) {
+	at := func() time.Time { return now.Add(time.Since(started)) }
+
+	var userIDs, taskIDs []string
+	byUser := map[string][]*models.Notification{}
+	for _, notification := range notifications {
+		if _, seen := byUser[notification.UserID]; !seen {
+			userIDs = append(userIDs, notification.UserID)
+		}
+		byUser[notification.UserID] = append(byUser[notification.UserID], notification)
+		if notification.TaskID != nil {
+			taskIDs = append(taskIDs, *notification.TaskID)
+		}
+	}
+	saved, err := s.emailRepo.FindPreferences(ctx, userIDs)
+	if err != nil {
+		return 0, err
+	}
+	deliveries := map[string]string{}
+	for _, preference := range saved {
+		deliveries[preference.UserID+"/"+preference.Type] = preference.Delivery
+	}
+
+	found, err := s.emailRepo.FindTasks(ctx, taskIDs)
+	if err != nil {
+		return 0, err
+	}
+	tasks := make(map[string]*models.Task, len(found))
+	for _, task := range found {
+		tasks[task.ID] = task
+	}
+
+	sent := 0
+	for _, userID := range userIDs {
+		pending := byUser[userID]
+		user := pending[0].User
+		if user == nil || user.Email == "" {
+			continue
+		}
+
+		var skipped []string
+		var digest []*models.Notification
+		for _, notification := range pending {
+			delivery, ok := deliveries[userID+"/"+notification.Type]
+			if !ok {
+				delivery = models.DefaultEmailDelivery
+			}
+
+			switch delivery {
+			case models.EmailDeliveryOff:
+				skipped = append(skipped, notification.ID)
+			case models.EmailDeliveryHourly, models.EmailDeliveryDaily:
+				if !digestDueAt(notification.CreatedAt, delivery, user.Location()).After(now) {
+					digest = append(digest, notification)
+				}
+			default:
+				claimed, err := s.claim(ctx, []*models.Notification{notification}, at())
+				if err != nil {
+					return sent, err
+				}
+				if len(claimed) > 0 && s.send(ctx, user, notification.Type, s.instantEmail(user, notification, tasks), claimed, at()) {
+					sent++
+				}
+			}
+		}
+
+		if len(digest) > 0 {
+			claimed, err := s.claim(ctx, digest, at())
+			if err != nil {
+				return sent, err
+			}
+			if len(claimed) > 0 && s.send(ctx, user, digestTemplate, s.digestEmail(user, claimed, tasks), claimed, at()) {
+				sent++
+			}
+		}
+		if err := s.emailRepo.MarkEmailed(ctx, skipped, now); err != nil {
+			return sent, err
+		}
+	}
+
+	return sent, nil
+}
+
+// claim claims the notifications for emailing at the given time and returns
+// those no other worker is already sending
+func (s *notificationEmailService) claim(ctx context.Context, notifications []*models.Notification, at time.Time) ([]*models.Notification, error) {
+	ids := make([]string, len(notifications))
+	for i, notification := range notifications {
+		ids[i] = notification.ID
+	}
+	claimedIDs, err := s.emailRepo.ClaimEmail(ctx, ids, at, at.Add(notificationEmailLease))
+	if err != nil {
+		return nil, err
+	}
+
+	isClaimed := make(map[string]bool, len(claimedIDs))
+	for _, id := range claimedIDs {
+		isClaimed[id] = true
+	}
+	var claimed []*models.Notification
+	for _, notification := range notifications {
+		if isClaimed[notification.ID] {
+			claimed = append(claimed, notification)
+		}
+	}
+	return claimed, nil
+}
+
+// send renders and mails one email and marks its notifications as emailed.
+// Failures are logged and the notifications are picked up again once their
+// claim lapses.
+func (s *notificationEmailService) send(ctx context.Context, user *models.User, templateName string, data notificationEmailData, notifications []*models.Notification, now time.Time) bool {
+	message, err := renderNotificationEmail(templateName, data)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to render notification email", "template", templateName, "recipient_id", user.ID, "error", err)
+		return false
+	}
+	message.To = user.Email
+	message.ReplyTo = data.ReplyTo
+
+	if err := s.mailer.Send(ctx, message); err != nil {
+		slog.ErrorContext(ctx, "failed to email notifications", "recipient_id", user.ID, "error", err)
+		return false
+	}
+
+	ids := make([]string, len(notifications))
+	for i, notification := range notifications {
+		ids[i] = notification.ID
+	}
+	if err := s.emailRepo.MarkEmailed(ctx, ids, now); err != nil {
+		slog.ErrorContext(ctx, "failed to mark notifications emailed", "recipient_id", user.ID, "error", err)
+	}
+	return true
+}
+
+func (s *notificationEmailService) instantEmail(user *models.User, notification *models.Notification, tasks map[string]*models.Task) notificationEmailData {
+	data := notificationEmailData{Username: user.Username, Item: s.emailItem(notification, tasks)}
+	if notification.TaskID != nil && s.inboxService != nil {
+		data.ReplyTo = s.inboxService.ReplyAddress(*notification.TaskID, user.ID)
+	}
+	return data
+}
+
+func (s *notificationEmailService) digestEmail(user *models.User, notifications []*models.Notification, tasks map[string]*models.Task) notificationEmailData {
+	items := make([]notificationEmailItem, len(notifications))
+	for i, notification := range notifications {
+		items[i] = s.emailItem(notification, tasks)
+	}
+	return notificationEmailData{Username: user.Username, Items: items}
+}
+
+func (s *notificationEmailService) emailItem(notification *models.Notification, tasks map[string]*models.Task) notificationEmailItem {
+	item := notificationEmailItem{Message: notification.Message}
+	if notification.TaskID == nil {
+		return item
+	}
+	if task, ok := tasks[*notification.TaskID]; ok {
+		item.TaskKey = task.Key
+		item.TaskTitle = task.Title
+	}
+	if s.appURL != "" {
+		item.TaskURL = s.appURL + "/tasks/" + *notification.TaskID
+	}
+	return item
+}
+
+// renderNotificationEmail renders the subject and bodies of an email, falling
+// back to the general template for types without their own
+func renderNotificationEmail(templateName string, data notificationEmailData) (mailer.Message, error) {
+	text, ok := emailTemplates.text[templateName]
+	if !ok {
+		templateName = models.NotificationTypeGeneral
+		text = emailTemplates.text[templateName]
+	}
+	html := emailTemplates.html[templateName]
+
+	var subject, textBody, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return mailer.Message{}, err
+	}
+	if err := text.Execute(&textBody, data); err != nil {
+		return mailer.Message{}, err
+	}
+	if err := html.Execute(&htmlBody, data); err != nil {
+		return mailer.Message{}, err
+	}
+
+	return mailer.Message{
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    textBody.String(),
+		HTML:    htmlBody.String(),
+	}, nil
+}
+
+// digestDueAt is when a notification created at the given time is included
+// in a digest: the next full hour for hourly digests, or the next
+// dailyDigestHour in the user's timezone for daily ones
+func digestDueAt(createdAt time.Time, delivery string, loc *time.Location) time.Time {
+	if delivery == models.EmailDeliveryHourly {
+		return createdAt.Truncate(time.Hour).Add(time.Hour)
+	}
+
+	local := createdAt.In(loc)
+	due := time.Date(local.Year(), local.Month(), local.Day(), dailyDigestHour, 0, 0, 0, loc)
+	if !due.After(local) {
+		due = due.AddDate(0, 0, 1)
+	}
+	return due
+}
+
+func isNotificationType(notificationType string) bool {
+	for _, known := range models.NotificationTypes {
+		if known == notificationType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"kanban-backend/mailer"
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+type mockNotificationEmailRepository struct {
+	notifications []*models.Notification
+	tasks         []*models.Task
+	preferences   []*models.NotificationPreference
+	pages         int
+}
+
+func (m *mockNotificationEmailRepository) FindUnemailed(ctx context.Context, since time.Time, afterUserID string, users int) ([]*models.Notification, error) {
+	m.pages++
+	var pending []*models.Notification
+	for _, notification := range m.notifications {
+		if notification.EmailedAt == nil && notification.ReadAt == nil && !notification.CreatedAt.Before(since) && notification.UserID > afterUserID {
+			pending = append(pending, notification)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].UserID < pending[j].UserID })
+
+	for i, seen := 0, 0; i < len(pending); i++ {
+		if i == 0 || pending[i].UserID != pending[i-1].UserID {
+			if seen == users {
+				return pending[:i], nil
+			}
+			seen++
+		}
+	}
+	return pending, nil
+}
+
+func (m *mockNotificationEmailRepository) FindTasks(ctx context.Context, ids []string) ([]*models.Task, error) {
+	return m.tasks, nil
+}
+
+func (m *mockNotificationEmailRepository) ClaimEmail(ctx context.Context, ids []string, now, leaseUntil time.Time) ([]string, error) {
+	var claimed []string
+	for _, id := range ids {
+		for _, notification := range m.notifications {
+			if notification.ID != id || notification.EmailedAt != nil {
+				continue
+			}
+			if notification.EmailClaimedUntil != nil && notification.EmailClaimedUntil.After(now) {
+				continue
+			}
+			notification.EmailClaimedUntil = &leaseUntil
+			claimed = append(claimed, id)
+		}
+	}
+	return claimed, nil
+}
+
+func (m *mockNotificationEmailRepository) MarkEmailed(ctx context.Context, ids []string, emailedAt time.Time) error {
+	for _, id := range ids {
+		for _, notification := range m.notifications {
+			if notification.ID == id {
+				notification.EmailedAt = &emailedAt
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockNotificationEmailRepository) FindPreferences(ctx context.Context, userIDs []string) ([]*models.NotificationPreference, error) {
+	var preferences []*models.NotificationPreference
+	for _, preference := range m.preferences {
+		for _, userID := range userIDs {
+			if preference.UserID == userID {
+				preferences = append(preferences, preference)
+			}
+		}
+	}
+	return preferences, nil
+}
+
+func (m *mockNotificationEmailRepository) SavePreferences(ctx context.Context, preferences []*models.NotificationPreference) error {
+	for _, preference := range preferences {
+		replaced := false
+		for _, existing := range m.preferences {
+			if existing.UserID == preference.UserID && existing.Type == preference.Type {
+				existing.Delivery = preference.Delivery
+				replaced = true
+			}
+		}
+		if !replaced {
+			m.preferences = append(m.preferences, preference)
+		}
+	}
+	return nil
+}
+
+type mockMailer struct {
+	sent []mailer.Message
+	fail bool
+}
+
+func (m *mockMailer) Send(ctx context.Context, message mailer.Message) error {
+	if m.fail {
+		return errors.New("smtp unavailable")
+	}
+	m.sent = append(m.sent, message)
+	return nil
+}
+
+type fixedReplyAddresses struct {
+	EmailInboxService
+}
+
+func (fixedReplyAddresses) ReplyAddress(taskID, userID string) string {
+	return "reply+" + taskID + "@in.example.com"
+}
+
+func setupNotificationEmailService(notifications ...*models.Notification) (NotificationEmailService, *mockNotificationEmailRepository, *mockMailer) {
+	repo := &mockNotificationEmailRepository{
+		notifications: notifications,
+		tasks:         []*models.Task{{ID: "task123", Key: "OPS-7", Title: "Fix the printer"}},
+	}
+	mail := &mockMailer{}
+	return NewNotificationEmailService(repo, mail, fixedReplyAddresses{}, "https://kanban.example.com/"), repo, mail
+}
+
+func testNotification(id, notificationType string, createdAt time.Time) *models.Notification {
+	taskID := "task123"
+	return &models.Notification{
+		ID:        id,
+		UserID:    "user123",
+		Type:      notificationType,
+		TaskID:    &taskID,
+		Message:   "New comment on \"Fix the printer\"",
+		CreatedAt: createdAt,
+		User:      &models.User{ID: "user123", Username: "sam", Email: "sam@example.com", Timezone: "Europe/Berlin"},
+	}
+}
+
+func TestNotificationEmailService_SendsInstantEmails(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+	notification := testNotification("n1", models.NotificationTypeTaskComment, now.Add(-time.Minute))
+	service, _, mail := setupNotificationEmailService(notification)
+
+	sent, err := service.SendDue(context.Background(), now)
+	if err != nil {
+		t.Fatalf("SendDue() unexpected error = %v", err)
+	}
+	if sent != 1 || len(mail.sent) != 1 {
+		t.Fatalf("sent %d emails, want 1", len(mail.sent))
+	}
+
+	message := mail.sent[0]
+	if message.To != "sam@example.com" || message.Subject != "[OPS-7] New comment on Fix the printer" {
+		t.Errorf("unexpected message %+v", message)
+	}
+	if message.ReplyTo != "reply+task123@in.example.com" {
+		t.Errorf("Reply-To = %q, want the task's reply address", message.ReplyTo)
+	}
+	for _, body := range []string{message.Text, message.HTML} {
+		if !strings.Contains(body, "https://kanban.example.com/tasks/task123") || !strings.Contains(body, "Reply to this email") {
+			t.Errorf("body misses the task link or reply hint:\n%s", body)
+		}
+	}
+	if !strings.Contains(message.HTML, "New comment on &#34;Fix the printer&#34;") {
+		t.Errorf("HTML body should escape the message:\n%s", message.HTML)
+	}
+	if notification.EmailedAt == nil {
+		t.Error("notification not marked as emailed")
+	}
+
+	if sent, _ := service.SendDue(context.Background(), now); sent != 0 {
+		t.Errorf("notification emailed again")
+	}
+}
+
+func TestNotificationEmailService_Digests(t *testing.T) {
+	// 09:30 UTC is 11:30 in Berlin, so the daily digest is due at 06:00 UTC
+	created := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+	hourly := testNotification("hourly", models.NotificationTypeTaskMoved, created)
+	daily := testNotification("daily", models.NotificationTypeDeadlineChanged, created)
+	read := testNotification("read", models.NotificationTypeTaskMoved, created)
+	read.ReadAt = &created
+	muted := testNotification("muted", models.NotificationTypeRule, created)
+
+	service, repo, mail := setupNotificationEmailService(hourly, daily, read, muted)
+	repo.preferences = []*models.NotificationPreference{
+		{UserID: "user123", Type: models.NotificationTypeTaskMoved, Delivery: models.EmailDeliveryHourly},
+		{UserID: "user123", Type: models.NotificationTypeDeadlineChanged, Delivery: models.EmailDeliveryDaily},
+		{UserID: "user123", Type: models.NotificationTypeRule, Delivery: models.EmailDeliveryOff},
+	}
+	ctx := context.Background()
+
+	if sent, _ := service.SendDue(ctx, created.Add(20*time.Minute)); sent != 0 {
+		t.Fatalf("sent %d emails before any digest was due", sent)
+	}
+	if muted.EmailedAt == nil {
+		t.Error("notifications turned off should be marked as handled")
+	}
+
+	if sent, _ := service.SendDue(ctx, time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC)); sent != 1 {
+		t.Fatalf("sent %d emails at the top of the hour, want the hourly digest", sent)
+	}
+	if subject := mail.sent[0].Subject; subject != "Your Kanban digest: 1 new notification" {
+		t.Errorf("subject = %q", subject)
+	}
+	if daily.EmailedAt != nil || read.EmailedAt != nil {
+		t.Error("daily and read notifications must not be in the hourly digest")
+	}
+
+	if sent, _ := service.SendDue(ctx, time.Date(2026, 10, 13, 6, 0, 0, 0, time.UTC)); sent != 1 {
+		t.Fatalf("sent %d emails next morning, want the daily digest", sent)
+	}
+	if text := mail.sent[1].Text; !strings.Contains(text, "- [OPS-7] New comment") || mail.sent[1].ReplyTo != "" {
+		t.Errorf("unexpected daily digest %+v", mail.sent[1])
+	}
+}
+
+func TestNotificationEmailService_FailedSendsStayPending(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+	notification := testNotification("n1", models.NotificationTypeDeadlineReminder, now)
+	service, _, mail := setupNotificationEmailService(notification)
+	mail.fail = true
+	ctx := context.Background()
+
+	if sent, err := service.SendDue(ctx, now); err != nil || sent != 0 {
+		t.Fatalf("SendDue() = %d, %v", sent, err)
+	}
+	if notification.EmailedAt != nil {
+		t.Error("failed email marked as sent")
+	}
+
+	mail.fail = false
+	if sent, _ := service.SendDue(ctx, now.Add(time.Minute)); sent != 0 {
+		t.Errorf("sent %d emails while the failed send's claim holds", sent)
+	}
+	if sent, _ := service.SendDue(ctx, now.Add(notificationEmailLease+time.Second)); sent != 1 {
+		t.Errorf("sent %d emails once the claim lapsed, want 1", sent)
+	}
+}
+
+func TestNotificationEmailService_SkipsClaimedNotifications(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+	claimedUntil := now.Add(time.Minute)
+	claimed := testNotification("claimed", models.NotificationTypeTaskComment, now)
+	claimed.EmailClaimedUntil = &claimedUntil
+	free := testNotification("free", models.NotificationTypeTaskComment, now)
+	service, _, mail := setupNotificationEmailService(claimed, free)
+
+	if sent, err := service.SendDue(context.Background(), now); err != nil || sent != 1 {
+		t.Fatalf("SendDue() = %d, %v, want only the unclaimed notification sent", sent, err)
+	}
+	if claimed.EmailedAt != nil || free.EmailedAt == nil || len(mail.sent) != 1 {
+		t.Error("a notification another worker is sending was emailed again")
+	}
+}
+
+func TestNotificationEmailService_PagesByUser(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 30, 0, 0, time.UTC)
+	var notifications []*models.Notification
+	for i := range notificationEmailBatch + 20 {
+		for j := range 2 {
+			notification := testNotification(fmt.Sprintf("n%d-%d", i, j), models.NotificationTypeTaskMoved, now.Add(-time.Hour))
+			notification.UserID = fmt.Sprintf("user%03d", i)
+			notification.User = &models.User{ID: notification.UserID, Username: notification.UserID, Email: notification.UserID + "@example.com"}
+			notifications = append(notifications, notification)
+		}
+	}
+	service, repo, mail := setupNotificationEmailService(notifications...)
+	for i := range notificationEmailBatch + 20 {
+		repo.preferences = append(repo.preferences, &models.NotificationPreference{UserID: fmt.Sprintf("user%03d", i), Type: models.NotificationTypeTaskMoved, Delivery: models.EmailDeliveryHourly})
+	}
+
+	sent, err := service.SendDue(context.Background(), now)
+	if err != nil || sent != notificationEmailBatch+20 {
+		t.Fatalf("SendDue() = %d, %v, want one digest per user", sent, err)
+	}
+	if repo.pages != 3 {
+		t.Errorf("loaded %d pages, want a full one, the rest and an empty one", repo.pages)
+	}
+	for _, message := range mail.sent {
+		if message.Subject != "Your Kanban digest: 2 new notifications" {
+			t.Fatalf("digest split across pages: %q", message.Subject)
+		}
+	}
+}
+
+func TestNotificationEmailService_Preferences(t *testing.T) {
+	service, _, _ := setupNotificationEmailService()
+	ctx := context.Background()
+
+	preferences, err := service.UpdatePreferences(ctx, "user123", map[string]string{models.NotificationTypeTaskComment: models.EmailDeliveryDaily})
+	if err != nil {
+		t.Fatalf("UpdatePreferences() unexpected error = %v", err)
+	}
+	if len(preferences) != len(models.NotificationTypes) {
+		t.Fatalf("got %d preferences, want one per notification type", len(preferences))
+	}
+	for _, preference := range preferences {
+		want := models.DefaultEmailDelivery
+		if preference.Type == models.NotificationTypeTaskComment {
+			want = models.EmailDeliveryDaily
+		}
+		if preference.Delivery != want {
+			t.Errorf("%s delivery = %s, want %s", preference.Type, preference.Delivery, want)
+		}
+	}
+
+	var validationErr utils.ErrValidation
+	if _, err := service.UpdatePreferences(ctx, "user123", map[string]string{"unknown": models.EmailDeliveryOff}); !errors.As(err, &validationErr) {
+		t.Errorf("unknown type error = %v, want validation error", err)
+	}
+	if _, err := service.UpdatePreferences(ctx, "user123", map[string]string{models.NotificationTypeRule: "weekly"}); !errors.As(err, &validationErr) {
+		t.Errorf("unknown delivery error = %v, want validation error", err)
+	}
+}
+
+func TestDigestDueAt(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	tests := []struct {
+		name      string
+		createdAt time.Time
+		delivery  string
+		want      time.Time
+	}{
+		{"hourly", time.Date(2026, 10, 12, 9, 5, 0, 0, time.UTC), models.EmailDeliveryHourly, time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC)},
+		{"daily before digest hour", time.Date(2026, 10, 12, 7, 59, 0, 0, berlin), models.EmailDeliveryDaily, time.Date(2026, 10, 12, 8, 0, 0, 0, berlin)},
+		{"daily at digest hour", time.Date(2026, 10, 12, 8, 0, 0, 0, berlin), models.EmailDeliveryDaily, time.Date(2026, 10, 13, 8, 0, 0, 0, berlin)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := digestDueAt(tt.createdAt, tt.delivery, berlin); !got.Equal(tt.want) {
+				t.Errorf("digestDueAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
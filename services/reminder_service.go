@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+const (
+	// MaxReminderLeadTime caps how far ahead of a deadline a reminder can be configured
+	MaxReminderLeadTime = 7 * 24 * time.Hour
+
+	// overdueLookback limits overdue reminders to recently missed deadlines so
+	// that enabling the scheduler does not flood users about ancient tasks
+	overdueLookback = 7 * 24 * time.Hour
+
+	// allDayDeadlineSlack is how long after the stored midnight UTC a date-only
+	// deadline can fall due, at the end of the day in the westernmost timezone
+	allDayDeadlineSlack = 36 * time.Hour
+)
+
+type ReminderService interface {
+	SendDueReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+type reminderService struct {
+	reminderRepo repositories.ReminderRepository
+	userRepo     repositories.UserRepository
+	watchRepo    repositories.WatchRepository
+}
+
+func NewReminderService(reminderRepo repositories.ReminderRepository, userRepo repositories.UserRepository, watchRepo repositories.WatchRepository) ReminderService {
+	return &reminderService{
+		reminderRepo: reminderRepo,
+		userRepo:     userRepo,
+		watchRepo:    watchRepo,
+	}
+}
+
+// SendDueReminders notifies recipients of tasks that are approaching or past
+// their deadline and returns the number of reminders sent. Reminders that were
+// already sent are skipped, so it is safe to call repeatedly and concurrently.
+func (s *reminderService) SendDueReminders(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "ReminderService.SendDueReminders")
+	defer span.End()
+
+	tasks, err := s.reminderRepo.FindTasksWithDeadlineBetween(ctx, now.Add(-overdueLookback-allDayDeadlineSlack), now.Add(MaxReminderLeadTime))
+	if err != nil {
+		return 0, err
+	}
+
+	users := make(map[string]*models.User)
+	sent := 0
+
+	for _, task := range tasks {
+		if task.Deadline == nil || isDoneColumn(task.Column) {
+			continue
+		}
+
+		recipientIDs, err := s.reminderRecipients(ctx, task)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, recipientID := range recipientIDs {
+			user, ok := users[recipientID]
+			if !ok {
+				user, err = s.userRepo.FindByID(ctx, recipientID)
+				if err != nil {
+					continue
+				}
+				users[recipientID] = user
+			}
+
+			reminder, notification := buildReminder(task, user, now)
+			if reminder == nil {
+				continue
+			}
+
+			claimed, err := s.reminderRepo.Claim(ctx, reminder, notification)
+			if err != nil {
+				return sent, err
+			}
+			if claimed {
+				sent++
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+// reminderRecipients returns the users to remind about a task: its assignees,
+// or the board owner when nobody is assigned, and everyone watching the task
+// or its board
+func (s *reminderService) reminderRecipients(ctx context.Context, task *models.Task) ([]string, error) {
+	recipients := make([]string, 0, len(task.Assignees))
+	seen := make(map[string]bool, len(task.Assignees))
+	add := func(userID string) {
+		if !seen[userID] {
+			seen[userID] = true
+			recipients = append(recipients, userID)
+		}
+	}
+
+	for _, assignee := range task.Assignees {
+		add(assignee.UserID)
+	}
+	if len(recipients) == 0 && task.Column != nil && task.Column.Board != nil {
+		add(task.Column.Board.UserID)
+	}
+
+	var boardID string
+	if task.Column != nil {
+		boardID = task.Column.BoardID
+	}
+	watcherIDs, err := s.watchRepo.FindWatcherIDs(ctx, task.ID, boardID)
+	if err != nil {
+		return nil, err
+	}
+	for _, watcherID := range watcherIDs {
+		add(watcherID)
+	}
+
+	return recipients, nil
+}
+
+// buildReminder picks the reminder due for a user at the given time. Only the
+// tightest lead time that has been reached is used, so a task created close to
+// its deadline produces a single reminder instead of one per lead time.
+// Date-only deadlines fall due at the end of the day in the user's timezone.
+func buildReminder(task *models.Task, user *models.User, now time.Time) (*models.TaskReminder, *models.Notification) {
+	loc := user.Location()
+	deadline := *task.DeadlineIn(loc)
+	taskID := task.ID
+
+	if !now.Before(deadline) {
+		if now.Sub(deadline) > overdueLookback {
+			return nil, nil
+		}
+
+		return &models.TaskReminder{
+			TaskID:   task.ID,
+			UserID:   user.ID,
+			Kind:     models.ReminderKindOverdue,
+			Deadline: *task.Deadline,
+		}, &models.Notification{
+			UserID:  user.ID,
+			Type:    models.NotificationTypeDeadlineOverdue,
+			TaskID:  &taskID,
+			Message: fmt.Sprintf("Task %q is overdue (deadline was %s)", task.Title, formatDeadline(task, loc)),
+		}
+	}
+
+	remaining := deadline.Sub(now)
+	var lead time.Duration
+	for _, candidate := range user.ReminderLeadDurations() {
+		if candidate >= remaining {
+			lead = candidate
+		}
+	}
+
+	if lead == 0 {
+		return nil, nil
+	}
+
+	return &models.TaskReminder{
+		TaskID:      task.ID,
+		UserID:      user.ID,
+		Kind:        models.ReminderKindUpcoming,
+		LeadMinutes: int(lead / time.Minute),
+		Deadline:    *task.Deadline,
+	}, &models.Notification{
+		UserID:  user.ID,
+		Type:    models.NotificationTypeDeadlineReminder,
+		TaskID:  &taskID,
+		Message: fmt.Sprintf("Task %q is due within %s (deadline: %s)", task.Title, formatLeadTime(lead), formatDeadline(task, loc)),
+	}
+}
+
+// isDoneColumn reports whether tasks in the column are finished and no longer need reminders
+func isDoneColumn(column *models.Column) bool {
+	return column != nil && column.IsDone()
+}
+
+// formatDeadline renders a task deadline in the recipient's timezone
+func formatDeadline(task *models.Task, loc *time.Location) string {
+	if task.DeadlineAllDay {
+		return task.Deadline.UTC().Format(utils.DateLayout)
+	}
+	return task.Deadline.In(loc).Format("2006-01-02 15:04 MST")
+}
+
+func formatLeadTime(lead time.Duration) string {
+	switch {
+	case lead%(24*time.Hour) == 0:
+		return pluralize(int(lead/(24*time.Hour)), "day")
+	case lead%time.Hour == 0:
+		return pluralize(int(lead/time.Hour), "hour")
+	default:
+		return pluralize(int(lead/time.Minute), "minute")
+	}
+}
+
+func pluralize(count int, unit string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", count, unit)
+}
@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+)
+
+type mockReminderRepository struct {
+	tasks         []*models.Task
+	claimed       map[string]bool
+	notifications []*models.Notification
+}
+
+func newMockReminderRepository(tasks ...*models.Task) *mockReminderRepository {
+	return &mockReminderRepository{
+		tasks:   tasks,
+		claimed: make(map[string]bool),
+	}
+}
+
+func (m *mockReminderRepository) FindTasksWithDeadlineBetween(ctx context.Context, from, to time.Time) ([]*models.Task, error) {
+	var tasks []*models.Task
+	for _, task := range m.tasks {
+		if task.Deadline != nil && !task.Deadline.Before(from) && !task.Deadline.After(to) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockReminderRepository) Claim(ctx context.Context, reminder *models.TaskReminder, notification *models.Notification) (bool, error) {
+	key := fmt.Sprintf("%s|%s|%s|%d|%d", reminder.TaskID, reminder.UserID, reminder.Kind, reminder.LeadMinutes, reminder.Deadline.Unix())
+	if m.claimed[key] {
+		return false, nil
+	}
+	m.claimed[key] = true
+	m.notifications = append(m.notifications, notification)
+	return true, nil
+}
+
+func newReminderTestTask(id, columnTitle string, deadline time.Time, assigneeIDs ...string) *models.Task {
+	task := &models.Task{
+		ID:       id,
+		Title:    "Task " + id,
+		Deadline: &deadline,
+		Column: &models.Column{
+			ID:      "col-" + id,
+			BoardID: "board-1",
+			Title:   columnTitle,
+			Board:   &models.Board{ID: "board-1", UserID: "owner"},
+		},
+	}
+	for _, assigneeID := range assigneeIDs {
+		task.Assignees = append(task.Assignees, models.TaskAssignee{TaskID: id, UserID: assigneeID})
+	}
+	return task
+}
+
+func newReminderTestUsers(users ...*models.User) *mockUserRepository {
+	repo := newMockUserRepository()
+	for _, user := range users {
+		repo.users[user.Email] = user
+	}
+	return repo
+}
+
+func TestReminderService_SendDueReminders(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	owner := &models.User{ID: "owner", Email: "owner@example.com"}
+	alice := &models.User{ID: "alice", Email: "alice@example.com", ReminderLeadTimes: "120"}
+
+	tests := []struct {
+		name          string
+		task          *models.Task
+		wantSent      int
+		wantType      string
+		wantRecipient string
+	}{
+		{
+			name:          "upcoming deadline within default lead time notifies owner",
+			task:          newReminderTestTask("t1", "To Do", now.Add(30*time.Minute)),
+			wantSent:      1,
+			wantType:      models.NotificationTypeDeadlineReminder,
+			wantRecipient: "owner",
+		},
+		{
+			name:          "assignee lead time is respected",
+			task:          newReminderTestTask("t2", "To Do", now.Add(90*time.Minute), "alice"),
+			wantSent:      1,
+			wantType:      models.NotificationTypeDeadlineReminder,
+			wantRecipient: "alice",
+		},
+		{
+			name:     "deadline outside lead time is skipped",
+			task:     newReminderTestTask("t3", "To Do", now.Add(3*time.Hour), "alice"),
+			wantSent: 0,
+		},
+		{
+			name:          "past deadline sends overdue reminder",
+			task:          newReminderTestTask("t4", "In Progress", now.Add(-time.Hour)),
+			wantSent:      1,
+			wantType:      models.NotificationTypeDeadlineOverdue,
+			wantRecipient: "owner",
+		},
+		{
+			name:     "tasks in done column are skipped",
+			task:     newReminderTestTask("t5", "Done", now.Add(-time.Hour)),
+			wantSent: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminderRepo := newMockReminderRepository(tt.task)
+			service := NewReminderService(reminderRepo, newReminderTestUsers(owner, alice), newMockWatchRepository())
+
+			sent, err := service.SendDueReminders(context.Background(), now)
+			if err != nil {
+				t.Fatalf("SendDueReminders() unexpected error = %v", err)
+			}
+
+			if sent != tt.wantSent {
+				t.Fatalf("SendDueReminders() sent = %d, want %d", sent, tt.wantSent)
+			}
+
+			if tt.wantSent == 0 {
+				return
+			}
+
+			notification := reminderRepo.notifications[0]
+			if notification.Type != tt.wantType {
+				t.Errorf("notification type = %s, want %s", notification.Type, tt.wantType)
+			}
+			if notification.UserID != tt.wantRecipient {
+				t.Errorf("notification recipient = %s, want %s", notification.UserID, tt.wantRecipient)
+			}
+		})
+	}
+}
+
+func TestReminderService_RemindsWatchers(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	alice := &models.User{ID: "alice", Email: "alice@example.com"}
+	bob := &models.User{ID: "bob", Email: "bob@example.com"}
+	carol := &models.User{ID: "carol", Email: "carol@example.com"}
+	task := newReminderTestTask("t1", "To Do", now.Add(30*time.Minute), "alice")
+
+	watchRepo := newMockWatchRepository()
+	watchRepo.Add(context.Background(), "bob", models.WatchTargetTask, "t1")
+	watchRepo.Add(context.Background(), "alice", models.WatchTargetTask, "t1")
+	watchRepo.Add(context.Background(), "carol", models.WatchTargetBoard, "board-1")
+
+	reminderRepo := newMockReminderRepository(task)
+	service := NewReminderService(reminderRepo, newReminderTestUsers(alice, bob, carol), watchRepo)
+
+	sent, err := service.SendDueReminders(context.Background(), now)
+	if err != nil {
+		t.Fatalf("SendDueReminders() unexpected error = %v", err)
+	}
+	if sent != 3 {
+		t.Fatalf("SendDueReminders() sent = %d, want 3 (assignee and two watchers, once each)", sent)
+	}
+
+	recipients := make(map[string]bool)
+	for _, notification := range reminderRepo.notifications {
+		recipients[notification.UserID] = true
+	}
+	for _, userID := range []string{"alice", "bob", "carol"} {
+		if !recipients[userID] {
+			t.Errorf("%s was not reminded", userID)
+		}
+	}
+}
+
+func TestReminderService_SendDueRemindersIsIdempotent(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	owner := &models.User{ID: "owner", Email: "owner@example.com"}
+	task := newReminderTestTask("t1", "To Do", now.Add(30*time.Minute))
+
+	reminderRepo := newMockReminderRepository(task)
+	service := NewReminderService(reminderRepo, newReminderTestUsers(owner), newMockWatchRepository())
+
+	first, err := service.SendDueReminders(context.Background(), now)
+	if err != nil {
+		t.Fatalf("first run unexpected error = %v", err)
+	}
+
+	second, err := service.SendDueReminders(context.Background(), now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("second run unexpected error = %v", err)
+	}
+
+	if first != 1 || second != 0 {
+		t.Errorf("expected 1 then 0 reminders, got %d then %d", first, second)
+	}
+}
+
+func TestReminderService_SendsEachLeadTimeOnce(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	owner := &models.User{ID: "owner", Email: "owner@example.com", ReminderLeadTimes: "1440,60"}
+	task := newReminderTestTask("t1", "To Do", now.Add(20*time.Hour))
+
+	reminderRepo := newMockReminderRepository(task)
+	service := NewReminderService(reminderRepo, newReminderTestUsers(owner), newMockWatchRepository())
+
+	for _, offset := range []time.Duration{0, time.Hour, 19*time.Hour + 30*time.Minute, 19*time.Hour + 45*time.Minute} {
+		if _, err := service.SendDueReminders(context.Background(), now.Add(offset)); err != nil {
+			t.Fatalf("SendDueReminders() unexpected error = %v", err)
+		}
+	}
+
+	if len(reminderRepo.notifications) != 2 {
+		t.Fatalf("expected 2 reminders (24h and 1h), got %d", len(reminderRepo.notifications))
+	}
+}
+
+func TestReminderService_AllDayDeadlineUsesRecipientTimezone(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	deadline := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		timezone string
+		wantType string
+	}{
+		{name: "UTC user is reminded before the day ends", timezone: "UTC", wantType: models.NotificationTypeDeadlineReminder},
+		{name: "user east of UTC is already overdue", timezone: "Pacific/Kiritimati", wantType: models.NotificationTypeDeadlineOverdue},
+		{name: "user west of UTC is reminded", timezone: "America/Los_Angeles", wantType: models.NotificationTypeDeadlineReminder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := newReminderTestTask("t1", "To Do", deadline, "alice")
+			task.DeadlineAllDay = true
+			alice := &models.User{ID: "alice", Email: "alice@example.com", Timezone: tt.timezone}
+
+			reminderRepo := newMockReminderRepository(task)
+			service := NewReminderService(reminderRepo, newReminderTestUsers(alice), newMockWatchRepository())
+
+			sent, err := service.SendDueReminders(context.Background(), now)
+			if err != nil {
+				t.Fatalf("SendDueReminders() unexpected error = %v", err)
+			}
+			if sent != 1 {
+				t.Fatalf("SendDueReminders() sent = %d, want 1", sent)
+			}
+			if got := reminderRepo.notifications[0].Type; got != tt.wantType {
+				t.Errorf("notification type = %s, want %s", got, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestFormatLeadTime(t *testing.T) {
+	tests := []struct {
+		lead time.Duration
+		want string
+	}{
+		{24 * time.Hour, "1 day"},
+		{48 * time.Hour, "2 days"},
+		{time.Hour, "1 hour"},
+		{90 * time.Minute, "90 minutes"},
+	}
+
+	for _, tt := range tests {
+		if got := formatLeadTime(tt.lead); got != tt.want {
+			t.Errorf("formatLeadTime(%s) = %s, want %s", tt.lead, got, tt.want)
+		}
+	}
+}
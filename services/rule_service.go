@@ -0,0 +1,681 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+const (
+	// maxRuleChainDepth bounds how many rules may fire in a row off a single
+	// change, as each rule's actions can trigger further rules
+	maxRuleChainDepth = 5
+	// ruleDueLookback is how far back the due trigger catches up on deadlines
+	// that passed while the scheduler was not running
+	ruleDueLookback  = 24 * time.Hour
+	ruleExecutionLog = 50
+)
+
+// ruleEventTriggers maps the events that can fire rules to their trigger
+var ruleEventTriggers = map[string]string{
+	events.TaskCreated: models.RuleTriggerTaskCreated,
+	events.TaskMoved:   models.RuleTriggerTaskMoved,
+	events.LabelAdded:  models.RuleTriggerTaskLabeled,
+}
+
+// RuleInput describes a rule to create, or the new definition of an existing
+// one. A nil Enabled keeps the current state; new rules start enabled.
+type RuleInput struct {
+	Name       string                 `json:"name"`
+	Trigger    models.RuleTrigger     `json:"trigger"`
+	Conditions []models.RuleCondition `json:"conditions"`
+	Actions    []models.RuleAction    `json:"actions"`
+	Enabled    *bool                  `json:"enabled,omitempty"`
+}
+
+type RuleService interface {
+	Create(ctx context.Context, boardID, userID string, input RuleInput) (*models.Rule, error)
+	FindByBoardID(ctx context.Context, boardID, userID string) ([]*models.Rule, error)
+	Update(ctx context.Context, ruleID, userID string, input RuleInput) (*models.Rule, error)
+	Delete(ctx context.Context, ruleID, userID string) error
+	FindExecutions(ctx context.Context, ruleID, userID string) ([]*models.RuleExecution, error)
+	HandleEvent(ctx context.Context, event events.Event)
+	RunDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type ruleService struct {
+	ruleRepo         repositories.RuleRepository
+	boardRepo        repositories.BoardRepository
+	taskRepo         repositories.TaskRepository
+	notificationRepo repositories.NotificationRepository
+	taskService      TaskService
+	labelService     LabelService
+	assigneeService  AssigneeService
+	commentService   CommentService
+}
+
+func NewRuleService(ruleRepo repositories.RuleRepository, boardRepo repositories.BoardRepository, taskRepo repositories.TaskRepository, notificationRepo repositories.NotificationRepository, taskService TaskService, labelService LabelService, assigneeService AssigneeService, commentService CommentService) RuleService {
+	return &ruleService{
+		ruleRepo:         ruleRepo,
+		boardRepo:        boardRepo,
+		taskRepo:         taskRepo,
+		notificationRepo: notificationRepo,
+		taskService:      taskService,
+		labelService:     labelService,
+		assigneeService:  assigneeService,
+		commentService:   commentService,
+	}
+}
+
+func (s *ruleService) Create(ctx context.Context, boardID, userID string, input RuleInput) (*models.Rule, error) {
+	ctx, span := tracing.Start(ctx, "RuleService.Create")
+	defer span.End()
+
+	board, err := s.findBoard(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &models.Rule{BoardID: board.ID, Enabled: true}
+	if err := applyRuleInput(rule, input, board); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *ruleService) FindByBoardID(ctx context.Context, boardID, userID string) ([]*models.Rule, error) {
+	ctx, span := tracing.Start(ctx, "RuleService.FindByBoardID")
+	defer span.End()
+
+	if _, err := s.findBoard(ctx, boardID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.ruleRepo.FindByBoardID(ctx, boardID)
+}
+
+// Update replaces the rule's definition
+func (s *ruleService) Update(ctx context.Context, ruleID, userID string, input RuleInput) (*models.Rule, error) {
+	ctx, span := tracing.Start(ctx, "RuleService.Update")
+	defer span.End()
+
+	rule, board, err := s.findRule(ctx, ruleID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyRuleInput(rule, input, board); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.Update(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *ruleService) Delete(ctx context.Context, ruleID, userID string) error {
+	ctx, span := tracing.Start(ctx, "RuleService.Delete")
+	defer span.End()
+
+	if _, _, err := s.findRule(ctx, ruleID, userID); err != nil {
+		return err
+	}
+
+	return s.ruleRepo.Delete(ctx, ruleID)
+}
+
+// FindExecutions returns the rule's recent execution log, newest first
+func (s *ruleService) FindExecutions(ctx context.Context, ruleID, userID string) ([]*models.RuleExecution, error) {
+	ctx, span := tracing.Start(ctx, "RuleService.FindExecutions")
+	defer span.End()
+
+	if _, _, err := s.findRule(ctx, ruleID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.ruleRepo.FindExecutions(ctx, ruleID, ruleExecutionLog)
+}
+
+// HandleEvent runs the board's rules whose trigger matches the event
+func (s *ruleService) HandleEvent(ctx context.Context, event events.Event) {
+	ctx, span := tracing.Start(ctx, "RuleService.HandleEvent")
+	defer span.End()
+
+	trigger, ok := ruleEventTriggers[event.Type]
+	if !ok || event.BoardID == "" {
+		return
+	}
+
+	rules, err := s.ruleRepo.FindEnabledByBoardID(ctx, event.BoardID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load rules", "board_id", event.BoardID, "error", err)
+		return
+	}
+
+	for _, rule := range rules {
+		if ruleTriggerMatches(rule.Trigger, trigger, event) {
+			s.run(ctx, rule, event.TaskID, trigger, event.OccurredAt)
+		}
+	}
+}
+
+// RunDue fires task_due rules for tasks whose deadline has passed since the
+// rule last ran for them. It returns the number of rules that ran.
+func (s *ruleService) RunDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "RuleService.RunDue")
+	defer span.End()
+
+	rules, err := s.ruleRepo.FindEnabledByTrigger(ctx, models.RuleTriggerTaskDue)
+	if err != nil {
+		return 0, err
+	}
+
+	ran := 0
+	for _, rule := range rules {
+		tasks, err := s.ruleRepo.FindDueTasks(ctx, rule.BoardID, now.Add(-ruleDueLookback), now)
+		if err != nil {
+			return ran, err
+		}
+
+		for _, task := range tasks {
+			done, err := s.ruleRepo.HasExecutionSince(ctx, rule.ID, task.ID, *task.Deadline)
+			if err != nil {
+				return ran, err
+			}
+			if !done && s.run(ctx, rule, task.ID, models.RuleTriggerTaskDue, now) {
+				ran++
+			}
+		}
+	}
+
+	return ran, nil
+}
+
+// run checks the rule's conditions against the task's current state and
+// performs its actions, recording the outcome. It reports whether the rule
+// ran. The rules already running further up the chain travel in ctx, so a
+// rule never re-triggers itself and chains stay short.
+func (s *ruleService) run(ctx context.Context, rule *models.Rule, taskID, trigger string, now time.Time) bool {
+	execution := &models.RuleExecution{RuleID: rule.ID, TaskID: taskID, Trigger: trigger}
+
+	chain := ruleChain(ctx)
+	for _, running := range chain {
+		if running == rule.ID {
+			s.record(ctx, execution, models.RuleExecutionSkipped, "rule already ran earlier in this chain")
+			return false
+		}
+	}
+	if len(chain) >= maxRuleChainDepth {
+		s.record(ctx, execution, models.RuleExecutionSkipped, fmt.Sprintf("more than %d rules triggered in a row", maxRuleChainDepth))
+		return false
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil || task.Column == nil || task.Column.Board == nil {
+		return false
+	}
+	if !ruleConditionsHold(rule.Conditions, task) {
+		return false
+	}
+
+	ctx = withRuleChain(ctx, rule.ID)
+	ownerID := task.Column.Board.UserID
+	for _, action := range rule.Actions {
+		if err := s.perform(ctx, rule, action, task, ownerID, now); err != nil {
+			s.record(ctx, execution, models.RuleExecutionFailed, fmt.Sprintf("%s: %v", action.Type, err))
+			return true
+		}
+		execution.Actions++
+
+		// Later actions see the task as the earlier ones, and any rules they
+		// triggered, left it
+		if task, err = s.taskRepo.FindByID(ctx, taskID); err != nil {
+			break
+		}
+	}
+
+	s.record(ctx, execution, models.RuleExecutionSucceeded, "")
+	return true
+}
+
+// perform carries out one action on behalf of the board owner. Actions that
+// would not change anything are skipped.
+func (s *ruleService) perform(ctx context.Context, rule *models.Rule, action models.RuleAction, task *models.Task, ownerID string, now time.Time) error {
+	switch action.Type {
+	case models.RuleActionMove:
+		if task.ColumnID == action.ColumnID {
+			return nil
+		}
+		return s.taskService.Move(ctx, task.ID, action.ColumnID, ownerID)
+
+	case models.RuleActionAddLabel:
+		if taskHasLabel(task, action.Label) {
+			return nil
+		}
+		label, err := s.ruleRepo.FindOrCreateLabel(ctx, action.Label)
+		if err != nil {
+			return err
+		}
+		return s.labelService.AddToTask(ctx, task.ID, label.ID, ownerID)
+
+	case models.RuleActionRemoveLabel:
+		for _, label := range task.Labels {
+			if strings.EqualFold(label.Name, action.Label) {
+				return s.labelService.RemoveFromTask(ctx, task.ID, label.ID, ownerID)
+			}
+		}
+		return nil
+
+	case models.RuleActionAssign:
+		if taskHasAssignee(task, action.UserID) {
+			return nil
+		}
+		return s.assigneeService.AddToTask(ctx, task.ID, action.UserID, ownerID)
+
+	case models.RuleActionComment:
+		_, err := s.commentService.Create(ctx, task.ID, ownerID, action.Text)
+		return err
+
+	case models.RuleActionSetDeadline:
+		deadline := now.AddDate(0, 0, *action.Days)
+		_, err := s.taskService.Update(ctx, task.ID, ownerID, "", "", &deadline, true, nil)
+		return err
+
+	case models.RuleActionNotify:
+		return s.notify(ctx, rule, action, task, ownerID)
+	}
+
+	return fmt.Errorf("unknown action")
+}
+
+func (s *ruleService) notify(ctx context.Context, rule *models.Rule, action models.RuleAction, task *models.Task, ownerID string) error {
+	var recipients []string
+	switch action.Target {
+	case models.RuleNotifyCreator:
+		creatorID, err := s.ruleRepo.FindTaskCreatorID(ctx, task.ID)
+		if err != nil {
+			return errors.New("task creator is unknown")
+		}
+		recipients = append(recipients, creatorID)
+	case models.RuleNotifyAssignees:
+		for _, assignee := range task.Assignees {
+			recipients = append(recipients, assignee.UserID)
+		}
+	case models.RuleNotifyOwner:
+		recipients = append(recipients, ownerID)
+	}
+
+	message := action.Text
+	if message == "" {
+		message = fmt.Sprintf("Rule %q ran on %s", rule.Name, task.Title)
+	}
+
+	taskID := task.ID
+	notifications := make([]*models.Notification, 0, len(recipients))
+	for _, userID := range recipients {
+		notifications = append(notifications, &models.Notification{
+			UserID:  userID,
+			Type:    models.NotificationTypeRule,
+			TaskID:  &taskID,
+			Message: message,
+		})
+	}
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return s.notificationRepo.CreateBatch(ctx, notifications)
+}
+
+func (s *ruleService) record(ctx context.Context, execution *models.RuleExecution, status, message string) {
+	execution.Status = status
+	execution.Error = message
+	if err := s.ruleRepo.CreateExecution(ctx, execution); err != nil {
+		slog.ErrorContext(ctx, "failed to record rule execution", "rule_id", execution.RuleID, "error", err)
+	}
+}
+
+func (s *ruleService) findBoard(ctx context.Context, boardID, userID string) (*models.Board, error) {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+
+	if board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	return board, nil
+}
+
+func (s *ruleService) findRule(ctx context.Context, ruleID, userID string) (*models.Rule, *models.Board, error) {
+	rule, err := s.ruleRepo.FindByID(ctx, ruleID)
+	if err != nil {
+		return nil, nil, utils.NewNotFound("rule not found")
+	}
+
+	board, err := s.findBoard(ctx, rule.BoardID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return rule, board, nil
+}
+
+type ruleChainKey struct{}
+
+// withRuleChain marks ctx as running inside the given rule's actions
+func withRuleChain(ctx context.Context, ruleID string) context.Context {
+	chain := ruleChain(ctx)
+	extended := make([]string, len(chain), len(chain)+1)
+	copy(extended, chain)
+	return context.WithValue(ctx, ruleChainKey{}, append(extended, ruleID))
+}
+
+// ruleChain returns the rules whose actions led to the current change
+func ruleChain(ctx context.Context) []string {
+	chain, _ := ctx.Value(ruleChainKey{}).([]string)
+	return chain
+}
+
+func ruleTriggerMatches(ruleTrigger models.RuleTrigger, trigger string, event events.Event) bool {
+	if ruleTrigger.Type != trigger {
+		return false
+	}
+	if ruleTrigger.ColumnID != "" && (event.Task == nil || event.Task.ColumnID != ruleTrigger.ColumnID) {
+		return false
+	}
+	if ruleTrigger.Label != "" && !strings.EqualFold(event.Data["label_name"], ruleTrigger.Label) {
+		return false
+	}
+	return true
+}
+
+func ruleConditionsHold(conditions []models.RuleCondition, task *models.Task) bool {
+	for _, condition := range conditions {
+		if !ruleConditionHolds(condition, task) {
+			return false
+		}
+	}
+	return true
+}
+
+func ruleConditionHolds(condition models.RuleCondition, task *models.Task) bool {
+	var holds bool
+	switch condition.Type {
+	case models.RuleConditionLabel:
+		holds = taskHasLabel(task, condition.Value)
+	case models.RuleConditionColumn:
+		holds = task.ColumnID == condition.Value
+	case models.RuleConditionAssignee:
+		holds = taskHasAssignee(task, condition.Value)
+	case models.RuleConditionField:
+		return ruleFieldHolds(condition, task)
+	}
+
+	if condition.Operator == models.RuleOperatorIsNot {
+		return !holds
+	}
+	return holds
+}
+
+func ruleFieldHolds(condition models.RuleCondition, task *models.Task) bool {
+	value := ruleFieldValue(condition.Field, task)
+
+	switch condition.Operator {
+	case models.RuleOperatorEquals:
+		return strings.EqualFold(value, condition.Value)
+	case models.RuleOperatorNotEquals:
+		return !strings.EqualFold(value, condition.Value)
+	case models.RuleOperatorContains:
+		return strings.Contains(strings.ToLower(value), strings.ToLower(condition.Value))
+	case models.RuleOperatorEmpty:
+		return value == ""
+	case models.RuleOperatorNotEmpty:
+		return value != ""
+	case models.RuleOperatorGreater, models.RuleOperatorLess:
+		if value == "" {
+			return false
+		}
+		comparison := compareRuleFieldValues(condition.Field, value, condition.Value)
+		if condition.Operator == models.RuleOperatorGreater {
+			return comparison > 0
+		}
+		return comparison < 0
+	}
+	return false
+}
+
+// ruleFieldValue renders a task field the way conditions compare it
+func ruleFieldValue(field string, task *models.Task) string {
+	switch field {
+	case "title":
+		return task.Title
+	case "description":
+		return task.Description
+	case "story_points":
+		return storyPointsValue(task.StoryPoints)
+	case "deadline":
+		if task.Deadline == nil {
+			return ""
+		}
+		return task.Deadline.UTC().Format(time.RFC3339)
+	case "sprint_id":
+		if task.SprintID == nil {
+			return ""
+		}
+		return *task.SprintID
+	}
+	return ""
+}
+
+// compareRuleFieldValues orders story points numerically and deadlines in time
+func compareRuleFieldValues(field, value, against string) int {
+	if field == "deadline" {
+		deadline, _ := time.Parse(time.RFC3339, value)
+		other, _ := parseRuleTime(against)
+		return deadline.Compare(other)
+	}
+
+	points, _ := strconv.Atoi(value)
+	other, _ := strconv.Atoi(against)
+	switch {
+	case points > other:
+		return 1
+	case points < other:
+		return -1
+	}
+	return 0
+}
+
+// parseRuleTime accepts a timestamp or a calendar date
+func parseRuleTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse(utils.DateLayout, value)
+}
+
+func taskHasLabel(task *models.Task, name string) bool {
+	for _, label := range task.Labels {
+		if strings.EqualFold(label.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func taskHasAssignee(task *models.Task, userID string) bool {
+	for _, assignee := range task.Assignees {
+		if assignee.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// applyRuleInput validates the input against the board and copies it onto
+// the rule
+func applyRuleInput(rule *models.Rule, input RuleInput, board *models.Board) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return utils.NewValidation("rule name is required")
+	}
+
+	columns := make(map[string]bool, len(board.Columns))
+	for _, column := range board.Columns {
+		columns[column.ID] = true
+	}
+
+	trigger := input.Trigger
+	switch trigger.Type {
+	case models.RuleTriggerTaskCreated, models.RuleTriggerTaskMoved:
+		if trigger.Label != "" {
+			return utils.NewValidation("only task_labeled triggers can name a label")
+		}
+	case models.RuleTriggerTaskLabeled, models.RuleTriggerTaskDue:
+		if trigger.ColumnID != "" {
+			return utils.NewValidation("only task_created and task_moved triggers can name a column")
+		}
+		if trigger.Type == models.RuleTriggerTaskDue && trigger.Label != "" {
+			return utils.NewValidation("only task_labeled triggers can name a label")
+		}
+	default:
+		return utils.NewValidation(fmt.Sprintf("unknown trigger %q", trigger.Type))
+	}
+	if trigger.ColumnID != "" && !columns[trigger.ColumnID] {
+		return utils.NewValidation("trigger column is not on this board")
+	}
+
+	conditions := make([]models.RuleCondition, 0, len(input.Conditions))
+	for _, condition := range input.Conditions {
+		normalized, err := validateRuleCondition(condition, columns)
+		if err != nil {
+			return err
+		}
+		conditions = append(conditions, normalized)
+	}
+
+	if len(input.Actions) == 0 {
+		return utils.NewValidation("a rule needs at least one action")
+	}
+	for _, action := range input.Actions {
+		if err := validateRuleAction(action, columns); err != nil {
+			return err
+		}
+	}
+
+	rule.Name = name
+	rule.Trigger = trigger
+	rule.Conditions = conditions
+	rule.Actions = input.Actions
+	if input.Enabled != nil {
+		rule.Enabled = *input.Enabled
+	}
+	return nil
+}
+
+// validateRuleCondition checks a condition and fills in its default operator
+func validateRuleCondition(condition models.RuleCondition, columns map[string]bool) (models.RuleCondition, error) {
+	switch condition.Type {
+	case models.RuleConditionLabel, models.RuleConditionColumn, models.RuleConditionAssignee:
+		if condition.Operator == "" {
+			condition.Operator = models.RuleOperatorIs
+		}
+		if condition.Operator != models.RuleOperatorIs && condition.Operator != models.RuleOperatorIsNot {
+			return condition, utils.NewValidation(fmt.Sprintf("%s conditions support the is and is_not operators", condition.Type))
+		}
+		if condition.Value == "" {
+			return condition, utils.NewValidation(fmt.Sprintf("%s condition needs a value", condition.Type))
+		}
+		if condition.Type == models.RuleConditionColumn && !columns[condition.Value] {
+			return condition, utils.NewValidation("condition column is not on this board")
+		}
+
+	case models.RuleConditionField:
+		switch condition.Field {
+		case "title", "description", "story_points", "deadline", "sprint_id":
+		default:
+			return condition, utils.NewValidation(fmt.Sprintf("unknown field %q", condition.Field))
+		}
+
+		switch condition.Operator {
+		case models.RuleOperatorEmpty, models.RuleOperatorNotEmpty:
+		case models.RuleOperatorEquals, models.RuleOperatorNotEquals, models.RuleOperatorContains:
+			if condition.Value == "" {
+				return condition, utils.NewValidation(fmt.Sprintf("%s condition on %s needs a value", condition.Operator, condition.Field))
+			}
+		case models.RuleOperatorGreater, models.RuleOperatorLess:
+			var err error
+			switch condition.Field {
+			case "story_points":
+				_, err = strconv.Atoi(condition.Value)
+			case "deadline":
+				_, err = parseRuleTime(condition.Value)
+			default:
+				err = errors.New("not comparable")
+			}
+			if err != nil {
+				return condition, utils.NewValidation(fmt.Sprintf("%s condition on %s needs a comparable value", condition.Operator, condition.Field))
+			}
+		default:
+			return condition, utils.NewValidation(fmt.Sprintf("unknown operator %q", condition.Operator))
+		}
+
+	default:
+		return condition, utils.NewValidation(fmt.Sprintf("unknown condition type %q", condition.Type))
+	}
+
+	return condition, nil
+}
+
+func validateRuleAction(action models.RuleAction, columns map[string]bool) error {
+	switch action.Type {
+	case models.RuleActionMove:
+		if !columns[action.ColumnID] {
+			return utils.NewValidation("move action needs a column on this board")
+		}
+	case models.RuleActionAddLabel, models.RuleActionRemoveLabel:
+		if strings.TrimSpace(action.Label) == "" {
+			return utils.NewValidation(fmt.Sprintf("%s action needs a label", action.Type))
+		}
+	case models.RuleActionAssign:
+		if action.UserID == "" {
+			return utils.NewValidation("assign action needs a user_id")
+		}
+	case models.RuleActionComment:
+		if strings.TrimSpace(action.Text) == "" {
+			return utils.NewValidation("comment action needs text")
+		}
+	case models.RuleActionSetDeadline:
+		if action.Days == nil || *action.Days < 0 {
+			return utils.NewValidation("set_deadline action needs a number of days of zero or more")
+		}
+	case models.RuleActionNotify:
+		switch action.Target {
+		case models.RuleNotifyCreator, models.RuleNotifyAssignees, models.RuleNotifyOwner:
+		default:
+			return utils.NewValidation("notify action target must be creator, assignees or owner")
+		}
+	default:
+		return utils.NewValidation(fmt.Sprintf("unknown action %q", action.Type))
+	}
+	return nil
+}
@@ -0,0 +1,482 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+type mockRuleRepository struct {
+	rules      []*models.Rule
+	executions []*models.RuleExecution
+	dueTasks   []*models.Task
+	creators   map[string]string
+	now        time.Time
+}
+
+func (m *mockRuleRepository) Create(ctx context.Context, rule *models.Rule) error {
+	if rule.ID == "" {
+		rule.ID = fmt.Sprintf("rule-%d", len(m.rules)+1)
+	}
+	m.rules = append(m.rules, rule)
+	return nil
+}
+
+func (m *mockRuleRepository) FindByID(ctx context.Context, id string) (*models.Rule, error) {
+	for _, rule := range m.rules {
+		if rule.ID == id {
+			return rule, nil
+		}
+	}
+	return nil, errors.New("rule not found")
+}
+
+func (m *mockRuleRepository) FindByBoardID(ctx context.Context, boardID string) ([]*models.Rule, error) {
+	var rules []*models.Rule
+	for _, rule := range m.rules {
+		if rule.BoardID == boardID {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (m *mockRuleRepository) FindEnabledByBoardID(ctx context.Context, boardID string) ([]*models.Rule, error) {
+	var rules []*models.Rule
+	for _, rule := range m.rules {
+		if rule.BoardID == boardID && rule.Enabled {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (m *mockRuleRepository) FindEnabledByTrigger(ctx context.Context, trigger string) ([]*models.Rule, error) {
+	var rules []*models.Rule
+	for _, rule := range m.rules {
+		if rule.Enabled && rule.Trigger.Type == trigger {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+func (m *mockRuleRepository) Update(ctx context.Context, rule *models.Rule) error {
+	return nil
+}
+
+func (m *mockRuleRepository) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m *mockRuleRepository) CreateExecution(ctx context.Context, execution *models.RuleExecution) error {
+	execution.CreatedAt = m.now
+	m.executions = append(m.executions, execution)
+	return nil
+}
+
+func (m *mockRuleRepository) FindExecutions(ctx context.Context, ruleID string, limit int) ([]*models.RuleExecution, error) {
+	var executions []*models.RuleExecution
+	for _, execution := range m.executions {
+		if execution.RuleID == ruleID {
+			executions = append(executions, execution)
+		}
+	}
+	return executions, nil
+}
+
+func (m *mockRuleRepository) HasExecutionSince(ctx context.Context, ruleID, taskID string, since time.Time) (bool, error) {
+	for _, execution := range m.executions {
+		if execution.RuleID == ruleID && execution.TaskID == taskID && !execution.CreatedAt.Before(since) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockRuleRepository) FindDueTasks(ctx context.Context, boardID string, from, to time.Time) ([]*models.Task, error) {
+	var tasks []*models.Task
+	for _, task := range m.dueTasks {
+		if task.Deadline.After(from) && !task.Deadline.After(to) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockRuleRepository) FindTaskCreatorID(ctx context.Context, taskID string) (string, error) {
+	creatorID, exists := m.creators[taskID]
+	if !exists {
+		return "", errors.New("no creation activity")
+	}
+	return creatorID, nil
+}
+
+func (m *mockRuleRepository) FindOrCreateLabel(ctx context.Context, name string) (*models.Label, error) {
+	return &models.Label{ID: "label-" + name, Name: name}, nil
+}
+
+// ruleTestBoard applies rule actions to the mock tasks and publishes the
+// resulting events straight back into the rule engine, like the event bus
+type ruleTestBoard struct {
+	tasks    *mockTaskRepository
+	columns  map[string]*models.Column
+	rules    RuleService
+	comments []string
+}
+
+func (b *ruleTestBoard) publish(ctx context.Context, eventType string, task *models.Task, data map[string]string) {
+	event := events.ForTask(eventType, "user123", task)
+	for key, value := range data {
+		event.Data[key] = value
+	}
+	event.OccurredAt = time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	b.rules.HandleEvent(ctx, event)
+}
+
+type ruleTestTaskService struct {
+	TaskService
+	board *ruleTestBoard
+}
+
+func (s *ruleTestTaskService) Move(ctx context.Context, taskID, columnID, userID string) error {
+	task := s.board.tasks.tasks[taskID]
+	task.ColumnID = columnID
+	task.Column = s.board.columns[columnID]
+	s.board.publish(ctx, events.TaskMoved, task, nil)
+	return nil
+}
+
+func (s *ruleTestTaskService) Update(ctx context.Context, taskID, userID, title, description string, deadline *time.Time, deadlineAllDay bool, storyPoints *int) (*models.Task, error) {
+	task := s.board.tasks.tasks[taskID]
+	task.Deadline = normalizeDeadline(deadline, deadlineAllDay)
+	task.DeadlineAllDay = deadlineAllDay
+	return task, nil
+}
+
+type ruleTestLabelService struct {
+	LabelService
+	board *ruleTestBoard
+}
+
+func (s *ruleTestLabelService) AddToTask(ctx context.Context, taskID, labelID, userID string) error {
+	task := s.board.tasks.tasks[taskID]
+	label := models.Label{ID: labelID, Name: labelID[len("label-"):]}
+	task.Labels = append(task.Labels, label)
+	s.board.publish(ctx, events.LabelAdded, task, map[string]string{"label_id": label.ID, "label_name": label.Name})
+	return nil
+}
+
+func (s *ruleTestLabelService) RemoveFromTask(ctx context.Context, taskID, labelID, userID string) error {
+	task := s.board.tasks.tasks[taskID]
+	var labels []models.Label
+	for _, label := range task.Labels {
+		if label.ID != labelID {
+			labels = append(labels, label)
+		}
+	}
+	task.Labels = labels
+	return nil
+}
+
+type ruleTestAssigneeService struct {
+	AssigneeService
+}
+
+type ruleTestCommentService struct {
+	CommentService
+	board *ruleTestBoard
+}
+
+func (s *ruleTestCommentService) Create(ctx context.Context, taskID, userID, content string) (*models.Comment, error) {
+	s.board.comments = append(s.board.comments, content)
+	return &models.Comment{TaskID: taskID, UserID: userID, Content: content}, nil
+}
+
+func setupRuleService() (RuleService, *mockRuleRepository, *ruleTestBoard, *mockNotificationRepository) {
+	board := &models.Board{ID: "board123", UserID: "user123"}
+	columns := map[string]*models.Column{}
+	for _, id := range []string{"triage", "doing", "done"} {
+		column := &models.Column{ID: id, BoardID: board.ID, Title: id, Board: board}
+		columns[id] = column
+		board.Columns = append(board.Columns, *column)
+	}
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards[board.ID] = board
+
+	ruleRepo := &mockRuleRepository{creators: map[string]string{}, now: time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)}
+	notificationRepo := &mockNotificationRepository{}
+	testBoard := &ruleTestBoard{tasks: newMockTaskRepository(), columns: columns}
+
+	service := NewRuleService(ruleRepo, boardRepo, testBoard.tasks, notificationRepo,
+		&ruleTestTaskService{board: testBoard},
+		&ruleTestLabelService{board: testBoard},
+		&ruleTestAssigneeService{},
+		&ruleTestCommentService{board: testBoard})
+	testBoard.rules = service
+
+	return service, ruleRepo, testBoard, notificationRepo
+}
+
+func (b *ruleTestBoard) addTask(id, columnID string, labels ...string) *models.Task {
+	task := &models.Task{ID: id, ColumnID: columnID, Title: id, Column: b.columns[columnID]}
+	for _, name := range labels {
+		task.Labels = append(task.Labels, models.Label{ID: "label-" + name, Name: name})
+	}
+	b.tasks.tasks[id] = task
+	return task
+}
+
+func executionStatuses(executions []*models.RuleExecution) map[string]int {
+	statuses := map[string]int{}
+	for _, execution := range executions {
+		statuses[execution.Status]++
+	}
+	return statuses
+}
+
+func TestRuleService_MovedIntoDoneRemovesLabelAndNotifiesCreator(t *testing.T) {
+	service, ruleRepo, board, notificationRepo := setupRuleService()
+	ctx := context.Background()
+
+	rule, err := service.Create(ctx, "board123", "user123", RuleInput{
+		Name:    "Unblock finished work",
+		Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskMoved, ColumnID: "done"},
+		Actions: []models.RuleAction{
+			{Type: models.RuleActionRemoveLabel, Label: "blocked"},
+			{Type: models.RuleActionNotify, Target: models.RuleNotifyCreator, Text: "Your task is done"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	task := board.addTask("task-1", "doing", "blocked", "backend")
+	ruleRepo.creators[task.ID] = "alice"
+
+	taskService := &ruleTestTaskService{board: board}
+	if err := taskService.Move(ctx, task.ID, "triage", "user123"); err != nil {
+		t.Fatal(err)
+	}
+	if len(ruleRepo.executions) != 0 {
+		t.Fatalf("rule ran on a move into another column: %+v", ruleRepo.executions[0])
+	}
+
+	if err := taskService.Move(ctx, task.ID, "done", "user123"); err != nil {
+		t.Fatal(err)
+	}
+
+	if taskHasLabel(board.tasks.tasks[task.ID], "blocked") || !taskHasLabel(board.tasks.tasks[task.ID], "backend") {
+		t.Errorf("expected only the blocked label to be removed, got %+v", board.tasks.tasks[task.ID].Labels)
+	}
+	if len(notificationRepo.notifications) != 1 || notificationRepo.notifications[0].UserID != "alice" || notificationRepo.notifications[0].Type != models.NotificationTypeRule {
+		t.Errorf("expected one rule notification for the creator, got %+v", notificationRepo.notifications)
+	}
+
+	executions, err := service.FindExecutions(ctx, rule.ID, "user123")
+	if err != nil {
+		t.Fatalf("FindExecutions() unexpected error = %v", err)
+	}
+	if len(executions) != 1 || executions[0].Status != models.RuleExecutionSucceeded || executions[0].Actions != 2 || executions[0].Trigger != models.RuleTriggerTaskMoved {
+		t.Errorf("unexpected execution log %+v", executions)
+	}
+}
+
+func TestRuleService_CreatedWithLabelMovesToTriage(t *testing.T) {
+	service, ruleRepo, board, _ := setupRuleService()
+	ctx := context.Background()
+
+	if _, err := service.Create(ctx, "board123", "user123", RuleInput{
+		Name:       "Triage bugs",
+		Trigger:    models.RuleTrigger{Type: models.RuleTriggerTaskCreated},
+		Conditions: []models.RuleCondition{{Type: models.RuleConditionLabel, Value: "bug"}},
+		Actions: []models.RuleAction{
+			{Type: models.RuleActionMove, ColumnID: "triage"},
+			{Type: models.RuleActionComment, Text: "Moved to triage"},
+		},
+	}); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	feature := board.addTask("feature", "doing")
+	board.publish(ctx, events.TaskCreated, feature, nil)
+	bug := board.addTask("bug", "doing", "bug")
+	board.publish(ctx, events.TaskCreated, bug, nil)
+
+	if got := board.tasks.tasks["feature"].ColumnID; got != "doing" {
+		t.Errorf("task without the label moved to %s", got)
+	}
+	if got := board.tasks.tasks["bug"].ColumnID; got != "triage" {
+		t.Errorf("bug is in %s, want triage", got)
+	}
+	if len(board.comments) != 1 || len(ruleRepo.executions) != 1 {
+		t.Errorf("expected one run with one comment, got %d runs and comments %v", len(ruleRepo.executions), board.comments)
+	}
+}
+
+func TestRuleService_LoopProtection(t *testing.T) {
+	service, ruleRepo, board, _ := setupRuleService()
+	ctx := context.Background()
+
+	for _, columns := range [][2]string{{"triage", "doing"}, {"doing", "triage"}} {
+		if _, err := service.Create(ctx, "board123", "user123", RuleInput{
+			Name:    "bounce into " + columns[1],
+			Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskMoved, ColumnID: columns[0]},
+			Actions: []models.RuleAction{{Type: models.RuleActionMove, ColumnID: columns[1]}},
+		}); err != nil {
+			t.Fatalf("Create() unexpected error = %v", err)
+		}
+	}
+
+	task := board.addTask("task-1", "done")
+	taskService := &ruleTestTaskService{board: board}
+	if err := taskService.Move(ctx, task.ID, "triage", "user123"); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := executionStatuses(ruleRepo.executions)
+	if statuses[models.RuleExecutionSucceeded] != 2 || statuses[models.RuleExecutionSkipped] != 1 {
+		t.Errorf("expected both rules to run once and the loop to be cut, got %v", statuses)
+	}
+	if got := board.tasks.tasks[task.ID].ColumnID; got != "triage" {
+		t.Errorf("task ended up in %s, want triage", got)
+	}
+}
+
+func TestRuleService_ChainDepthIsLimited(t *testing.T) {
+	service, ruleRepo, board, _ := setupRuleService()
+	ctx := context.Background()
+
+	// Each rule adds the next label, which triggers the next rule
+	for i := 0; i <= maxRuleChainDepth; i++ {
+		if _, err := service.Create(ctx, "board123", "user123", RuleInput{
+			Name:    fmt.Sprintf("step %d", i),
+			Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskLabeled, Label: fmt.Sprintf("step-%d", i)},
+			Actions: []models.RuleAction{{Type: models.RuleActionAddLabel, Label: fmt.Sprintf("step-%d", i+1)}},
+		}); err != nil {
+			t.Fatalf("Create() unexpected error = %v", err)
+		}
+	}
+
+	task := board.addTask("task-1", "doing")
+	labels := &ruleTestLabelService{board: board}
+	if err := labels.AddToTask(ctx, task.ID, "label-step-0", "user123"); err != nil {
+		t.Fatal(err)
+	}
+
+	statuses := executionStatuses(ruleRepo.executions)
+	if statuses[models.RuleExecutionSucceeded] != maxRuleChainDepth || statuses[models.RuleExecutionSkipped] != 1 {
+		t.Errorf("expected %d runs and one skipped, got %v", maxRuleChainDepth, statuses)
+	}
+}
+
+func TestRuleService_RunDue(t *testing.T) {
+	service, ruleRepo, board, _ := setupRuleService()
+	ctx := context.Background()
+	now := ruleRepo.now
+
+	days := 2
+	if _, err := service.Create(ctx, "board123", "user123", RuleInput{
+		Name:    "Push overdue work back",
+		Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskDue},
+		Actions: []models.RuleAction{{Type: models.RuleActionSetDeadline, Days: &days}},
+	}); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	overdue := board.addTask("overdue", "doing")
+	deadline := now.Add(-time.Hour)
+	overdue.Deadline = &deadline
+	ruleRepo.dueTasks = []*models.Task{overdue}
+
+	ran, err := service.RunDue(ctx, now)
+	if err != nil {
+		t.Fatalf("RunDue() unexpected error = %v", err)
+	}
+	if ran != 1 {
+		t.Errorf("ran = %d, want 1", ran)
+	}
+	if want := time.Date(2026, 5, 3, 0, 0, 0, 0, time.UTC); !board.tasks.tasks["overdue"].Deadline.Equal(want) {
+		t.Errorf("deadline = %v, want %v", board.tasks.tasks["overdue"].Deadline, want)
+	}
+
+	ruleRepo.dueTasks = []*models.Task{{ID: "overdue", Deadline: &deadline}}
+	if ran, _ := service.RunDue(ctx, now.Add(time.Minute)); ran != 0 {
+		t.Errorf("rule ran again for the same deadline, ran = %d", ran)
+	}
+}
+
+func TestRuleService_CreateValidates(t *testing.T) {
+	service, _, _, _ := setupRuleService()
+	ctx := context.Background()
+	move := []models.RuleAction{{Type: models.RuleActionMove, ColumnID: "done"}}
+
+	tests := []struct {
+		name  string
+		input RuleInput
+	}{
+		{name: "missing name", input: RuleInput{Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskCreated}, Actions: move}},
+		{name: "unknown trigger", input: RuleInput{Name: "r", Trigger: models.RuleTrigger{Type: "task_exploded"}, Actions: move}},
+		{name: "no actions", input: RuleInput{Name: "r", Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskCreated}}},
+		{name: "column on another board", input: RuleInput{Name: "r", Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskCreated}, Actions: []models.RuleAction{{Type: models.RuleActionMove, ColumnID: "elsewhere"}}}},
+		{name: "label on a created trigger", input: RuleInput{Name: "r", Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskCreated, Label: "bug"}, Actions: move}},
+		{name: "unknown field", input: RuleInput{Name: "r", Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskCreated}, Conditions: []models.RuleCondition{{Type: models.RuleConditionField, Field: "colour", Operator: models.RuleOperatorEmpty}}, Actions: move}},
+		{name: "comparing titles", input: RuleInput{Name: "r", Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskCreated}, Conditions: []models.RuleCondition{{Type: models.RuleConditionField, Field: "title", Operator: models.RuleOperatorGreater, Value: "a"}}, Actions: move}},
+		{name: "notify nobody", input: RuleInput{Name: "r", Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskCreated}, Actions: []models.RuleAction{{Type: models.RuleActionNotify, Target: "everyone"}}}},
+		{name: "deadline without days", input: RuleInput{Name: "r", Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskDue}, Actions: []models.RuleAction{{Type: models.RuleActionSetDeadline}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr utils.ErrValidation
+			if _, err := service.Create(ctx, "board123", "user123", tt.input); !errors.As(err, &validationErr) {
+				t.Errorf("Create() error = %v, want validation error", err)
+			}
+		})
+	}
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.Create(ctx, "board123", "someone-else", RuleInput{Name: "r", Trigger: models.RuleTrigger{Type: models.RuleTriggerTaskCreated}, Actions: move}); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("Create() error = %v, want unauthorized", err)
+	}
+}
+
+func TestRuleConditionHolds(t *testing.T) {
+	deadline := time.Date(2026, 5, 10, 17, 0, 0, 0, time.UTC)
+	task := &models.Task{
+		ColumnID:    "doing",
+		Title:       "Fix login bug",
+		StoryPoints: intPointer(5),
+		Deadline:    &deadline,
+		Labels:      []models.Label{{Name: "Bug"}},
+		Assignees:   []models.TaskAssignee{{UserID: "bob"}},
+	}
+
+	tests := []struct {
+		condition models.RuleCondition
+		want      bool
+	}{
+		{models.RuleCondition{Type: models.RuleConditionLabel, Operator: models.RuleOperatorIs, Value: "bug"}, true},
+		{models.RuleCondition{Type: models.RuleConditionLabel, Operator: models.RuleOperatorIsNot, Value: "bug"}, false},
+		{models.RuleCondition{Type: models.RuleConditionColumn, Operator: models.RuleOperatorIs, Value: "done"}, false},
+		{models.RuleCondition{Type: models.RuleConditionAssignee, Operator: models.RuleOperatorIs, Value: "bob"}, true},
+		{models.RuleCondition{Type: models.RuleConditionField, Field: "title", Operator: models.RuleOperatorContains, Value: "LOGIN"}, true},
+		{models.RuleCondition{Type: models.RuleConditionField, Field: "description", Operator: models.RuleOperatorEmpty}, true},
+		{models.RuleCondition{Type: models.RuleConditionField, Field: "story_points", Operator: models.RuleOperatorGreater, Value: "3"}, true},
+		{models.RuleCondition{Type: models.RuleConditionField, Field: "story_points", Operator: models.RuleOperatorLess, Value: "5"}, false},
+		{models.RuleCondition{Type: models.RuleConditionField, Field: "deadline", Operator: models.RuleOperatorLess, Value: "2026-05-11"}, true},
+		{models.RuleCondition{Type: models.RuleConditionField, Field: "sprint_id", Operator: models.RuleOperatorNotEmpty}, false},
+	}
+
+	for _, tt := range tests {
+		if got := ruleConditionHolds(tt.condition, task); got != tt.want {
+			t.Errorf("ruleConditionHolds(%+v) = %v, want %v", tt.condition, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+// SprintCompletion reports what happened to a sprint's unfinished work
+type SprintCompletion struct {
+	Sprint       *models.Sprint `json:"sprint"`
+	RolledOver   int            `json:"rolled_over"`
+	NextSprintID *string        `json:"next_sprint_id,omitempty"`
+}
+
+// BurndownDay is the sprint's scope and remaining work at the end of a day
+type BurndownDay struct {
+	Date            time.Time `json:"date"`
+	TotalTasks      int       `json:"total_tasks"`
+	TotalPoints     int       `json:"total_points"`
+	RemainingTasks  int       `json:"remaining_tasks"`
+	RemainingPoints int       `json:"remaining_points"`
+}
+
+// Burndown holds one entry per sprint day that has started
+type Burndown struct {
+	SprintID  string        `json:"sprint_id"`
+	StartDate time.Time     `json:"start_date"`
+	EndDate   time.Time     `json:"end_date"`
+	Days      []BurndownDay `json:"days"`
+}
+
+// burndownFields are the task fields replayed to rebuild sprint history
+var burndownFields = []string{"column_id", "sprint_id", "story_points"}
+
+type SprintService interface {
+	Create(ctx context.Context, boardID, userID, name, goal string, startDate, endDate time.Time) (*models.Sprint, error)
+	FindByID(ctx context.Context, sprintID, userID string) (*models.Sprint, error)
+	FindByBoardID(ctx context.Context, boardID, userID string) ([]*models.Sprint, error)
+	Update(ctx context.Context, sprintID, userID, name, goal string, startDate, endDate *time.Time) (*models.Sprint, error)
+	Delete(ctx context.Context, sprintID, userID string) error
+	AddTask(ctx context.Context, sprintID, taskID, userID string) error
+	RemoveTask(ctx context.Context, sprintID, taskID, userID string) error
+	Start(ctx context.Context, sprintID, userID string) (*models.Sprint, error)
+	Complete(ctx context.Context, sprintID, userID, nextSprintID string) (*SprintCompletion, error)
+	Burndown(ctx context.Context, sprintID, userID string) (*Burndown, error)
+}
+
+type sprintService struct {
+	sprintRepo    repositories.SprintRepository
+	boardRepo     repositories.BoardRepository
+	taskRepo      repositories.TaskRepository
+	analyticsRepo repositories.AnalyticsRepository
+}
+
+func NewSprintService(sprintRepo repositories.SprintRepository, boardRepo repositories.BoardRepository, taskRepo repositories.TaskRepository, analyticsRepo repositories.AnalyticsRepository) SprintService {
+	return &sprintService{
+		sprintRepo:    sprintRepo,
+		boardRepo:     boardRepo,
+		taskRepo:      taskRepo,
+		analyticsRepo: analyticsRepo,
+	}
+}
+
+func (s *sprintService) Create(ctx context.Context, boardID, userID, name, goal string, startDate, endDate time.Time) (*models.Sprint, error) {
+	ctx, span := tracing.Start(ctx, "SprintService.Create")
+	defer span.End()
+
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+
+	if board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	startDate, endDate = sprintDate(startDate), sprintDate(endDate)
+	if endDate.Before(startDate) {
+		return nil, utils.NewValidation("end date must not be before start date")
+	}
+
+	sprint := &models.Sprint{
+		BoardID:   boardID,
+		Name:      name,
+		Goal:      goal,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Status:    models.SprintStatusPlanned,
+	}
+
+	if err := s.sprintRepo.Create(ctx, sprint); err != nil {
+		return nil, err
+	}
+
+	return sprint, nil
+}
+
+func (s *sprintService) FindByID(ctx context.Context, sprintID, userID string) (*models.Sprint, error) {
+	ctx, span := tracing.Start(ctx, "SprintService.FindByID")
+	defer span.End()
+
+	sprint, err := s.sprintRepo.FindByID(ctx, sprintID)
+	if err != nil {
+		return nil, utils.NewNotFound("sprint not found")
+	}
+
+	if sprint.Board == nil {
+		return nil, utils.NewNotFound("board not found for sprint")
+	}
+
+	if sprint.Board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this sprint")
+	}
+
+	return sprint, nil
+}
+
+func (s *sprintService) FindByBoardID(ctx context.Context, boardID, userID string) ([]*models.Sprint, error) {
+	ctx, span := tracing.Start(ctx, "SprintService.FindByBoardID")
+	defer span.End()
+
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+
+	if board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	return s.sprintRepo.FindByBoardID(ctx, boardID)
+}
+
+func (s *sprintService) Update(ctx context.Context, sprintID, userID, name, goal string, startDate, endDate *time.Time) (*models.Sprint, error) {
+	ctx, span := tracing.Start(ctx, "SprintService.Update")
+	defer span.End()
+
+	sprint, err := s.FindByID(ctx, sprintID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != "" {
+		sprint.Name = name
+	}
+
+	if goal != "" {
+		sprint.Goal = goal
+	}
+
+	if startDate != nil {
+		sprint.StartDate = sprintDate(*startDate)
+	}
+
+	if endDate != nil {
+		sprint.EndDate = sprintDate(*endDate)
+	}
+
+	if sprint.EndDate.Before(sprint.StartDate) {
+		return nil, utils.NewValidation("end date must not be before start date")
+	}
+
+	if err := s.sprintRepo.Update(ctx, sprint); err != nil {
+		return nil, err
+	}
+
+	return sprint, nil
+}
+
+func (s *sprintService) Delete(ctx context.Context, sprintID, userID string) error {
+	ctx, span := tracing.Start(ctx, "SprintService.Delete")
+	defer span.End()
+
+	if _, err := s.FindByID(ctx, sprintID, userID); err != nil {
+		return err
+	}
+
+	return s.sprintRepo.Delete(ctx, sprintID)
+}
+
+func (s *sprintService) AddTask(ctx context.Context, sprintID, taskID, userID string) error {
+	ctx, span := tracing.Start(ctx, "SprintService.AddTask")
+	defer span.End()
+
+	sprint, err := s.FindByID(ctx, sprintID, userID)
+	if err != nil {
+		return err
+	}
+
+	if sprint.Status == models.SprintStatusCompleted {
+		return utils.NewValidation("cannot add tasks to a completed sprint")
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return utils.NewNotFound("task not found")
+	}
+
+	if task.Column == nil || task.Column.BoardID != sprint.BoardID {
+		return utils.NewValidation("task does not belong to the sprint's board")
+	}
+
+	if task.SprintID != nil && *task.SprintID == sprint.ID {
+		return nil
+	}
+
+	return s.assignSprint(ctx, userID, []*models.Task{task}, &sprint.ID)
+}
+
+func (s *sprintService) RemoveTask(ctx context.Context, sprintID, taskID, userID string) error {
+	ctx, span := tracing.Start(ctx, "SprintService.RemoveTask")
+	defer span.End()
+
+	sprint, err := s.FindByID(ctx, sprintID, userID)
+	if err != nil {
+		return err
+	}
+
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil || task.SprintID == nil || *task.SprintID != sprint.ID {
+		return utils.NewNotFound("task is not in this sprint")
+	}
+
+	return s.assignSprint(ctx, userID, []*models.Task{task}, nil)
+}
+
+// Start makes a planned sprint the board's active sprint
+func (s *sprintService) Start(ctx context.Context, sprintID, userID string) (*models.Sprint, error) {
+	ctx, span := tracing.Start(ctx, "SprintService.Start")
+	defer span.End()
+
+	sprint, err := s.FindByID(ctx, sprintID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sprint.Status != models.SprintStatusPlanned {
+		return nil, utils.NewValidation("only planned sprints can be started")
+	}
+
+	if active, err := s.sprintRepo.FindActiveByBoardID(ctx, sprint.BoardID); err == nil && active.ID != sprint.ID {
+		return nil, utils.NewValidation("the board already has an active sprint")
+	}
+
+	now := time.Now().UTC()
+	started := *sprint
+	started.Status = models.SprintStatusActive
+	started.StartedAt = &now
+
+	err = s.sprintRepo.Start(ctx, &started)
+	if errors.Is(err, repositories.ErrSprintNotPlanned) {
+		return nil, utils.NewValidation("only planned sprints can be started")
+	}
+	if errors.Is(err, repositories.ErrBoardHasActiveSprint) {
+		return nil, utils.NewValidation("the board already has an active sprint")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &started, nil
+}
+
+// Complete closes an active sprint and rolls its unfinished tasks over to
+// nextSprintID, or back to the backlog when no next sprint is given
+func (s *sprintService) Complete(ctx context.Context, sprintID, userID, nextSprintID string) (*SprintCompletion, error) {
+	ctx, span := tracing.Start(ctx, "SprintService.Complete")
+	defer span.End()
+
+	sprint, err := s.FindByID(ctx, sprintID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sprint.Status != models.SprintStatusActive {
+		return nil, utils.NewValidation("only active sprints can be completed")
+	}
+
+	var next *string
+	if nextSprintID != "" {
+		nextSprint, err := s.sprintRepo.FindByID(ctx, nextSprintID)
+		if err != nil || nextSprint.BoardID != sprint.BoardID {
+			return nil, utils.NewNotFound("next sprint not found")
+		}
+		if nextSprint.ID == sprint.ID || nextSprint.Status == models.SprintStatusCompleted {
+			return nil, utils.NewValidation("unfinished tasks can only roll over to a sprint that is not completed")
+		}
+		next = &nextSprint.ID
+	}
+
+	// Close the sprint before rolling over so the burndown ends with the
+	// unfinished tasks still counted against it
+	now := time.Now().UTC()
+	completed := *sprint
+	completed.Status = models.SprintStatusCompleted
+	completed.CompletedAt = &now
+
+	unfinished, err := s.sprintRepo.Complete(ctx, &completed, next)
+	if errors.Is(err, repositories.ErrSprintNotActive) {
+		return nil, utils.NewValidation("only active sprints can be completed")
+	}
+	if errors.Is(err, repositories.ErrSprintCompleted) {
+		return nil, utils.NewValidation("unfinished tasks can only roll over to a sprint that is not completed")
+	}
+	if err != nil {
+		return nil, err
+	}
+	sprint = &completed
+
+	publishSprintChanges(ctx, userID, unfinished, next)
+
+	return &SprintCompletion{
+		Sprint:       sprint,
+		RolledOver:   len(unfinished),
+		NextSprintID: next,
+	}, nil
+}
+
+// Burndown rebuilds the sprint's scope and remaining work for each day from
+// the recorded column moves, sprint changes and estimate changes
+func (s *sprintService) Burndown(ctx context.Context, sprintID, userID string) (*Burndown, error) {
+	ctx, span := tracing.Start(ctx, "SprintService.Burndown")
+	defer span.End()
+
+	sprint, err := s.FindByID(ctx, sprintID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := s.analyticsRepo.FindBoardColumns(ctx, sprint.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.analyticsRepo.FindBoardTasks(ctx, sprint.BoardID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	changes, err := s.analyticsRepo.FindFieldChanges(ctx, sprint.BoardID, burndownFields, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return computeBurndown(sprint, columns, tasks, changes, now), nil
+}
+
+// assignSprint moves tasks into a sprint (or the backlog when sprintID is nil)
+// and publishes the change so it lands in each task's history
+func (s *sprintService) assignSprint(ctx context.Context, userID string, tasks []*models.Task, sprintID *string) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	taskIDs := make([]string, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+
+	if err := s.sprintRepo.SetTaskSprint(ctx, taskIDs, sprintID); err != nil {
+		return err
+	}
+
+	publishSprintChanges(ctx, userID, tasks, sprintID)
+	return nil
+}
+
+// publishSprintChanges records that tasks, still holding their previous
+// sprint, moved to sprintID
+func publishSprintChanges(ctx context.Context, userID string, tasks []*models.Task, sprintID *string) {
+	for _, task := range tasks {
+		previous := stringValue(task.SprintID)
+		task.SprintID = sprintID
+
+		event := events.ForTask(events.TaskUpdated, userID, task)
+		event.Changes = []events.Change{{Field: "sprint_id", OldValue: previous, NewValue: stringValue(sprintID)}}
+		events.Publish(ctx, event)
+	}
+}
+
+func computeBurndown(sprint *models.Sprint, columns []*models.Column, tasks []*models.Task, changes []*models.TaskActivity, now time.Time) *Burndown {
+	burndown := &Burndown{
+		SprintID:  sprint.ID,
+		StartDate: sprint.StartDate,
+		EndDate:   sprint.EndDate,
+		Days:      []BurndownDay{},
+	}
+
+	doneColumns := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		doneColumns[column.ID] = column.IsDone()
+	}
+
+	changesByTask := make(map[string]map[string][]*models.TaskActivity)
+	for _, change := range changes {
+		if changesByTask[change.TaskID] == nil {
+			changesByTask[change.TaskID] = make(map[string][]*models.TaskActivity)
+		}
+		changesByTask[change.TaskID][change.Field] = append(changesByTask[change.TaskID][change.Field], change)
+	}
+
+	// A completed sprint stops at completion, before its leftovers rolled over
+	cutoff := now
+	if sprint.CompletedAt != nil && sprint.CompletedAt.Before(cutoff) {
+		cutoff = *sprint.CompletedAt
+	}
+
+	for day := sprintDate(sprint.StartDate); !day.After(sprintDate(sprint.EndDate)) && day.Before(cutoff); day = day.AddDate(0, 0, 1) {
+		sample := day.AddDate(0, 0, 1)
+		if sample.After(cutoff) {
+			sample = cutoff
+		}
+
+		entry := BurndownDay{Date: day}
+		for _, task := range tasks {
+			if !task.CreatedAt.Before(sample) {
+				continue
+			}
+
+			history := changesByTask[task.ID]
+			if fieldValueAt(stringValue(task.SprintID), history["sprint_id"], sample) != sprint.ID {
+				continue
+			}
+
+			points, _ := strconv.Atoi(fieldValueAt(storyPointsValue(task.StoryPoints), history["story_points"], sample))
+			entry.TotalTasks++
+			entry.TotalPoints += points
+
+			if !doneColumns[fieldValueAt(task.ColumnID, history["column_id"], sample)] {
+				entry.RemainingTasks++
+				entry.RemainingPoints += points
+			}
+		}
+
+		burndown.Days = append(burndown.Days, entry)
+	}
+
+	return burndown
+}
+
+// fieldValueAt replays a task field's recorded changes, oldest first, to find
+// its value just before the given time. Without history the current value is
+// used; before the first change the field held that change's old value.
+func fieldValueAt(current string, changes []*models.TaskActivity, at time.Time) string {
+	if len(changes) == 0 {
+		return current
+	}
+
+	value := stringValue(changes[0].OldValue)
+	for _, change := range changes {
+		if !change.CreatedAt.Before(at) {
+			break
+		}
+		value = stringValue(change.NewValue)
+	}
+	return value
+}
+
+// sprintDate keeps only the calendar date, stored as midnight UTC
+func sprintDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/utils"
+)
+
+type mockSprintRepository struct {
+	sprints map[string]*models.Sprint
+	tasks   map[string]*models.Task
+}
+
+func newMockSprintRepository() *mockSprintRepository {
+	return &mockSprintRepository{
+		sprints: make(map[string]*models.Sprint),
+		tasks:   make(map[string]*models.Task),
+	}
+}
+
+func (m *mockSprintRepository) Create(ctx context.Context, sprint *models.Sprint) error {
+	if sprint.ID == "" {
+		sprint.ID = "sprint-" + sprint.Name
+	}
+	m.sprints[sprint.ID] = sprint
+	return nil
+}
+
+func (m *mockSprintRepository) FindByID(ctx context.Context, id string) (*models.Sprint, error) {
+	sprint, exists := m.sprints[id]
+	if !exists {
+		return nil, errors.New("sprint not found")
+	}
+	return sprint, nil
+}
+
+func (m *mockSprintRepository) FindByBoardID(ctx context.Context, boardID string) ([]*models.Sprint, error) {
+	var sprints []*models.Sprint
+	for _, sprint := range m.sprints {
+		if sprint.BoardID == boardID {
+			sprints = append(sprints, sprint)
+		}
+	}
+	return sprints, nil
+}
+
+func (m *mockSprintRepository) FindActiveByBoardID(ctx context.Context, boardID string) (*models.Sprint, error) {
+	for _, sprint := range m.sprints {
+		if sprint.BoardID == boardID && sprint.Status == models.SprintStatusActive {
+			return sprint, nil
+		}
+	}
+	return nil, errors.New("no active sprint")
+}
+
+func (m *mockSprintRepository) FindTasks(ctx context.Context, sprintID string) ([]*models.Task, error) {
+	var tasks []*models.Task
+	for _, task := range m.tasks {
+		if task.SprintID != nil && *task.SprintID == sprintID {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockSprintRepository) SetTaskSprint(ctx context.Context, taskIDs []string, sprintID *string) error {
+	for _, id := range taskIDs {
+		if task, exists := m.tasks[id]; exists {
+			task.SprintID = sprintID
+		}
+	}
+	return nil
+}
+
+func (m *mockSprintRepository) Update(ctx context.Context, sprint *models.Sprint) error {
+	m.sprints[sprint.ID] = sprint
+	return nil
+}
+
+func (m *mockSprintRepository) Start(ctx context.Context, sprint *models.Sprint) error {
+	if m.sprints[sprint.ID].Status != models.SprintStatusPlanned {
+		return repositories.ErrSprintNotPlanned
+	}
+	for _, other := range m.sprints {
+		if other.BoardID == sprint.BoardID && other.Status == models.SprintStatusActive {
+			return repositories.ErrBoardHasActiveSprint
+		}
+	}
+	m.sprints[sprint.ID] = sprint
+	return nil
+}
+
+func (m *mockSprintRepository) Complete(ctx context.Context, sprint *models.Sprint, nextSprintID *string) ([]*models.Task, error) {
+	if m.sprints[sprint.ID].Status != models.SprintStatusActive {
+		return nil, repositories.ErrSprintNotActive
+	}
+	m.sprints[sprint.ID] = sprint
+
+	var unfinished []*models.Task
+	for _, task := range m.tasks {
+		if task.SprintID != nil && *task.SprintID == sprint.ID && (task.Column == nil || !task.Column.IsDone()) {
+			unfinished = append(unfinished, &models.Task{ID: task.ID, SprintID: task.SprintID, Column: task.Column})
+			task.SprintID = nextSprintID
+		}
+	}
+	return unfinished, nil
+}
+
+func (m *mockSprintRepository) Delete(ctx context.Context, id string) error {
+	delete(m.sprints, id)
+	return nil
+}
+
+func setupSprintService() (SprintService, *mockSprintRepository) {
+	board := &models.Board{ID: "board123", UserID: "user123"}
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards[board.ID] = board
+
+	sprintRepo := newMockSprintRepository()
+	for _, sprint := range []*models.Sprint{
+		{ID: "current", BoardID: board.ID, Name: "Current", Status: models.SprintStatusActive, Board: board},
+		{ID: "next", BoardID: board.ID, Name: "Next", Status: models.SprintStatusPlanned, Board: board},
+		{ID: "done", BoardID: board.ID, Name: "Done", Status: models.SprintStatusCompleted, Board: board},
+	} {
+		sprintRepo.sprints[sprint.ID] = sprint
+	}
+
+	return NewSprintService(sprintRepo, boardRepo, newMockTaskRepository(), &mockAnalyticsRepository{}), sprintRepo
+}
+
+func TestSprintService_CompleteRollsOverUnfinishedTasks(t *testing.T) {
+	tests := []struct {
+		name         string
+		nextSprintID string
+		wantSprint   *string
+	}{
+		{name: "to the backlog"},
+		{name: "to the next sprint", nextSprintID: "next", wantSprint: stringPointer("next")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, sprintRepo := setupSprintService()
+			current := "current"
+			sprintRepo.tasks["finished"] = &models.Task{ID: "finished", SprintID: &current, Column: &models.Column{ID: "col-done", BoardID: "board123", Stage: models.ColumnStageDone}}
+			sprintRepo.tasks["open"] = &models.Task{ID: "open", SprintID: &current, Column: &models.Column{ID: "col-doing", BoardID: "board123", Stage: models.ColumnStageInProgress}}
+
+			completion, err := service.Complete(context.Background(), "current", "user123", tt.nextSprintID)
+			if err != nil {
+				t.Fatalf("Complete() unexpected error = %v", err)
+			}
+
+			if completion.RolledOver != 1 {
+				t.Errorf("RolledOver = %d, want 1", completion.RolledOver)
+			}
+			if completion.Sprint.Status != models.SprintStatusCompleted || completion.Sprint.CompletedAt == nil {
+				t.Errorf("expected sprint to be completed, got %+v", completion.Sprint)
+			}
+			if got := sprintRepo.tasks["finished"].SprintID; got == nil || *got != "current" {
+				t.Errorf("finished task sprint = %v, want current", got)
+			}
+			if got := sprintRepo.tasks["open"].SprintID; stringValue(got) != stringValue(tt.wantSprint) {
+				t.Errorf("open task sprint = %v, want %v", stringValue(got), stringValue(tt.wantSprint))
+			}
+		})
+	}
+}
+
+func TestSprintService_StateTransitions(t *testing.T) {
+	service, _ := setupSprintService()
+	ctx := context.Background()
+
+	var validationErr utils.ErrValidation
+	if _, err := service.Start(ctx, "next", "user123"); !errors.As(err, &validationErr) {
+		t.Errorf("Start() with another active sprint error = %v, want validation error", err)
+	}
+	if _, err := service.Complete(ctx, "next", "user123", ""); !errors.As(err, &validationErr) {
+		t.Errorf("Complete() of a planned sprint error = %v, want validation error", err)
+	}
+	if _, err := service.Complete(ctx, "current", "user123", "done"); !errors.As(err, &validationErr) {
+		t.Errorf("Complete() rolling over to a completed sprint error = %v, want validation error", err)
+	}
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.Start(ctx, "next", "someone-else"); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("Start() error = %v, want unauthorized", err)
+	}
+
+	if _, err := service.Complete(ctx, "current", "user123", ""); err != nil {
+		t.Fatalf("Complete() unexpected error = %v", err)
+	}
+	sprint, err := service.Start(ctx, "next", "user123")
+	if err != nil {
+		t.Fatalf("Start() unexpected error = %v", err)
+	}
+	if sprint.Status != models.SprintStatusActive || sprint.StartedAt == nil {
+		t.Errorf("expected sprint to be active, got %+v", sprint)
+	}
+}
+
+// racingSprintRepository misses the active sprint another request is
+// starting at the same time
+type racingSprintRepository struct {
+	*mockSprintRepository
+}
+
+func (m racingSprintRepository) FindActiveByBoardID(ctx context.Context, boardID string) (*models.Sprint, error) {
+	return nil, errors.New("no active sprint")
+}
+
+func TestSprintService_ConcurrentStart(t *testing.T) {
+	_, sprintRepo := setupSprintService()
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards["board123"] = &models.Board{ID: "board123", UserID: "user123"}
+	service := NewSprintService(racingSprintRepository{sprintRepo}, boardRepo, newMockTaskRepository(), &mockAnalyticsRepository{})
+
+	var validationErr utils.ErrValidation
+	if _, err := service.Start(context.Background(), "next", "user123"); !errors.As(err, &validationErr) || err.Error() != "the board already has an active sprint" {
+		t.Errorf("Start() racing another start error = %v, want validation error", err)
+	}
+	if sprintRepo.sprints["next"].Status != models.SprintStatusPlanned {
+		t.Errorf("sprint that failed to start is %s", sprintRepo.sprints["next"].Status)
+	}
+}
+
+func TestSprintService_CreateValidatesDates(t *testing.T) {
+	service, _ := setupSprintService()
+	start := time.Date(2026, 3, 13, 15, 0, 0, 0, time.UTC)
+
+	var validationErr utils.ErrValidation
+	if _, err := service.Create(context.Background(), "board123", "user123", "Backwards", "", start, start.AddDate(0, 0, -1)); !errors.As(err, &validationErr) {
+		t.Errorf("Create() error = %v, want validation error", err)
+	}
+
+	sprint, err := service.Create(context.Background(), "board123", "user123", "Single day", "", start, start)
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if !sprint.StartDate.Equal(time.Date(2026, 3, 13, 0, 0, 0, 0, time.UTC)) || sprint.Status != models.SprintStatusPlanned {
+		t.Errorf("unexpected sprint %+v", sprint)
+	}
+}
+
+func TestComputeBurndown(t *testing.T) {
+	columns, _ := analyticsFixture()
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, 3, day, hour, 0, 0, 0, time.UTC)
+	}
+	change := func(taskID, field, from, to string, when time.Time) *models.TaskActivity {
+		return &models.TaskActivity{TaskID: taskID, Field: field, OldValue: optionalString(from), NewValue: optionalString(to), CreatedAt: when}
+	}
+
+	sprint := &models.Sprint{ID: "s1", StartDate: at(2, 0), EndDate: at(5, 0)}
+	tasks := []*models.Task{
+		{ID: "a", ColumnID: "done", SprintID: stringPointer("s1"), StoryPoints: intPointer(3), CreatedAt: at(1, 9)},
+		{ID: "b", ColumnID: "todo", SprintID: stringPointer("s1"), StoryPoints: intPointer(5), CreatedAt: at(1, 9)},
+		{ID: "c", ColumnID: "doing", SprintID: stringPointer("s1"), CreatedAt: at(2, 12)},
+		{ID: "d", ColumnID: "todo", StoryPoints: intPointer(8), CreatedAt: at(1, 9)},
+	}
+	changes := []*models.TaskActivity{
+		change("a", "sprint_id", "", "s1", at(1, 10)),
+		change("b", "sprint_id", "", "s1", at(1, 10)),
+		change("c", "sprint_id", "", "s1", at(3, 9)),
+		change("b", "story_points", "2", "5", at(3, 10)),
+		change("d", "sprint_id", "s1", "", at(3, 12)),
+		change("a", "column_id", "todo", "done", at(3, 15)),
+	}
+
+	burndown := computeBurndown(sprint, columns, tasks, changes, at(4, 12))
+
+	want := []BurndownDay{
+		{Date: at(2, 0), TotalTasks: 3, TotalPoints: 13, RemainingTasks: 3, RemainingPoints: 13},
+		{Date: at(3, 0), TotalTasks: 3, TotalPoints: 8, RemainingTasks: 2, RemainingPoints: 5},
+		{Date: at(4, 0), TotalTasks: 3, TotalPoints: 8, RemainingTasks: 2, RemainingPoints: 5},
+	}
+	if len(burndown.Days) != len(want) {
+		t.Fatalf("burndown has %d days, want %d: %+v", len(burndown.Days), len(want), burndown.Days)
+	}
+	for i := range want {
+		if burndown.Days[i] != want[i] {
+			t.Errorf("day %d = %+v, want %+v", i, burndown.Days[i], want[i])
+		}
+	}
+}
+
+func stringPointer(value string) *string {
+	return &value
+}
+
+func intPointer(value int) *int {
+	return &value
+}
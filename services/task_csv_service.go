@@ -0,0 +1,453 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+// Task fields that CSV columns can be mapped to
+const (
+	CSVFieldTitle       = "title"
+	CSVFieldDescription = "description"
+	CSVFieldColumn      = "column"
+	CSVFieldLabels      = "labels"
+	CSVFieldAssignees   = "assignees"
+	CSVFieldDeadline    = "deadline"
+	CSVFieldStoryPoints = "story_points"
+)
+
+// maxCSVImportRows caps the size of a single import
+const maxCSVImportRows = 5000
+
+// csvListSeparator separates label names and assignee emails within a cell
+const csvListSeparator = ";"
+
+// csvFormulaPrefixes start cells that spreadsheets evaluate as formulas.
+// Exported cells starting with one, or with the escaping quote itself, get
+// a leading quote, which spreadsheets hide and imports strip again.
+const csvFormulaPrefixes = "=+-@\t\r'"
+
+var csvImportFields = []string{CSVFieldTitle, CSVFieldDescription, CSVFieldColumn, CSVFieldLabels, CSVFieldAssignees, CSVFieldDeadline, CSVFieldStoryPoints}
+
+var csvExportHeader = []string{"id", "key", CSVFieldTitle, CSVFieldDescription, CSVFieldColumn, CSVFieldLabels, CSVFieldAssignees, CSVFieldDeadline, CSVFieldStoryPoints, "created_at"}
+
+// CSVRowError is a validation problem with one row. Row is the line number in
+// the file, so the header is row 1.
+type CSVRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// CSVImportResult describes an import. When Errors is not empty nothing was
+// imported.
+type CSVImportResult struct {
+	DryRun   bool          `json:"dry_run"`
+	Rows     int           `json:"rows"`
+	Imported int           `json:"imported"`
+	Errors   []CSVRowError `json:"errors"`
+}
+
+type TaskCSVService interface {
+	Export(ctx context.Context, boardID, userID string, w io.Writer) error
+	Import(ctx context.Context, boardID, userID string, r io.Reader, mapping map[string]string, dryRun bool) (*CSVImportResult, error)
+}
+
+type taskCSVService struct {
+	csvRepo    repositories.TaskCSVRepository
+	boardRepo  repositories.BoardRepository
+	exportRepo repositories.BoardExportRepository
+}
+
+func NewTaskCSVService(csvRepo repositories.TaskCSVRepository, boardRepo repositories.BoardRepository, exportRepo repositories.BoardExportRepository) TaskCSVService {
+	return &taskCSVService{
+		csvRepo:    csvRepo,
+		boardRepo:  boardRepo,
+		exportRepo: exportRepo,
+	}
+}
+
+// Export writes the board's unarchived tasks as CSV. The header matches the
+// default import mapping, so an export can be imported again as is. Custom
+// fields are left out until tasks store values for them.
+func (s *taskCSVService) Export(ctx context.Context, boardID, userID string, w io.Writer) error {
+	ctx, span := tracing.Start(ctx, "TaskCSVService.Export")
+	defer span.End()
+
+	if _, err := s.findOwnBoard(ctx, boardID, userID); err != nil {
+		return err
+	}
+
+	tasks, err := s.csvRepo.FindBoardTasks(ctx, boardID)
+	if err != nil {
+		return err
+	}
+
+	var userIDs []string
+	for _, task := range tasks {
+		for _, assignee := range task.Assignees {
+			userIDs = append(userIDs, assignee.UserID)
+		}
+	}
+	users, err := s.exportRepo.FindUsersByID(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	emails := make(map[string]string, len(users))
+	for _, user := range users {
+		emails[user.ID] = user.Email
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvExportHeader); err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		var column string
+		if task.Column != nil {
+			column = task.Column.Title
+		}
+
+		labels := make([]string, 0, len(task.Labels))
+		for _, label := range task.Labels {
+			labels = append(labels, label.Name)
+		}
+		sort.Strings(labels)
+
+		assignees := make([]string, 0, len(task.Assignees))
+		for _, assignee := range task.Assignees {
+			if email, ok := emails[assignee.UserID]; ok {
+				assignees = append(assignees, email)
+			}
+		}
+		sort.Strings(assignees)
+
+		var storyPoints string
+		if task.StoryPoints != nil {
+			storyPoints = strconv.Itoa(*task.StoryPoints)
+		}
+
+		record := []string{
+			task.ID,
+			task.Key,
+			task.Title,
+			task.Description,
+			column,
+			strings.Join(labels, csvListSeparator),
+			strings.Join(assignees, csvListSeparator),
+			deadlineValue(task.Deadline, task.DeadlineAllDay),
+			storyPoints,
+			task.CreatedAt.UTC().Format(time.RFC3339),
+		}
+		for i, value := range record {
+			record[i] = escapeCSVFormula(value)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Import validates every row and, unless dryRun is set or a row is invalid,
+// creates all tasks in one transaction. mapping maps task fields to CSV
+// header names; without a mapping, headers named like the fields are used.
+func (s *taskCSVService) Import(ctx context.Context, boardID, userID string, r io.Reader, mapping map[string]string, dryRun bool) (*CSVImportResult, error) {
+	ctx, span := tracing.Start(ctx, "TaskCSVService.Import")
+	defer span.End()
+
+	board, err := s.findOwnBoard(ctx, boardID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(board.Columns) == 0 {
+		return nil, utils.NewValidation("board has no columns to import into")
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, utils.NewValidation("CSV file is empty")
+	}
+	if err != nil {
+		return nil, utils.NewValidation("invalid CSV: " + err.Error())
+	}
+
+	fieldIndex, err := resolveCSVMapping(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var records [][]string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, utils.NewValidation("invalid CSV: " + err.Error())
+		}
+		records = append(records, record)
+		if len(records) > maxCSVImportRows {
+			return nil, utils.NewValidation(fmt.Sprintf("CSV imports are limited to %d rows", maxCSVImportRows))
+		}
+	}
+
+	var emails []string
+	if index, ok := fieldIndex[CSVFieldAssignees]; ok {
+		for _, record := range records {
+			emails = append(emails, splitCSVList(csvCell(record, index))...)
+		}
+	}
+	users, err := s.exportRepo.FindUsersByEmail(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := newCSVTaskParser(board.Columns, users, fieldIndex)
+	result := &CSVImportResult{DryRun: dryRun, Rows: len(records), Errors: []CSVRowError{}}
+	tasks := make([]*models.Task, 0, len(records))
+	for i, record := range records {
+		task, rowErrors := parser.parse(record, i+2)
+		result.Errors = append(result.Errors, rowErrors...)
+		tasks = append(tasks, task)
+	}
+
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	if err := s.csvRepo.CreateTasks(ctx, tasks); err != nil {
+		return nil, err
+	}
+	result.Imported = len(tasks)
+
+	for _, task := range tasks {
+		event := events.ForTask(events.TaskCreated, userID, task)
+		event.BoardID = board.ID
+		events.Publish(ctx, event)
+	}
+
+	return result, nil
+}
+
+func (s *taskCSVService) findOwnBoard(ctx context.Context, boardID, userID string) (*models.Board, error) {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return nil, utils.NewNotFound("board not found")
+	}
+
+	if board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	return board, nil
+}
+
+// resolveCSVMapping returns the header index of each mapped task field
+func resolveCSVMapping(header []string, mapping map[string]string) (map[string]int, error) {
+	headerIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		// Spreadsheet exports often start with a byte order mark
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+		if _, exists := headerIndex[strings.ToLower(name)]; !exists {
+			headerIndex[strings.ToLower(name)] = i
+		}
+	}
+
+	if len(mapping) == 0 {
+		mapping = make(map[string]string, len(csvImportFields))
+		for _, field := range csvImportFields {
+			if _, ok := headerIndex[field]; ok {
+				mapping[field] = field
+			}
+		}
+	}
+
+	fieldIndex := make(map[string]int, len(mapping))
+	for field, column := range mapping {
+		if !isCSVImportField(field) {
+			return nil, utils.NewValidation(fmt.Sprintf("unknown task field %q in mapping", field))
+		}
+		index, ok := headerIndex[strings.ToLower(strings.TrimSpace(column))]
+		if !ok {
+			return nil, utils.NewValidation(fmt.Sprintf("CSV has no column %q mapped to %s", column, field))
+		}
+		fieldIndex[field] = index
+	}
+
+	if _, ok := fieldIndex[CSVFieldTitle]; !ok {
+		return nil, utils.NewValidation("a CSV column must be mapped to title")
+	}
+
+	return fieldIndex, nil
+}
+
+func isCSVImportField(field string) bool {
+	for _, known := range csvImportFields {
+		if field == known {
+			return true
+		}
+	}
+	return false
+}
+
+// csvTaskParser turns CSV records into tasks for one board
+type csvTaskParser struct {
+	columns       map[string]string
+	defaultColumn string
+	users         map[string]string
+	fieldIndex    map[string]int
+}
+
+func newCSVTaskParser(columns []models.Column, users []*models.User, fieldIndex map[string]int) *csvTaskParser {
+	parser := &csvTaskParser{
+		columns:    make(map[string]string, len(columns)),
+		users:      make(map[string]string, len(users)),
+		fieldIndex: fieldIndex,
+	}
+
+	sorted := make([]models.Column, len(columns))
+	copy(sorted, columns)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].OrderNum < sorted[j].OrderNum
+	})
+	parser.defaultColumn = sorted[0].ID
+	for _, column := range sorted {
+		key := strings.ToLower(strings.TrimSpace(column.Title))
+		if _, exists := parser.columns[key]; !exists {
+			parser.columns[key] = column.ID
+		}
+	}
+
+	for _, user := range users {
+		parser.users[strings.ToLower(user.Email)] = user.ID
+	}
+
+	return parser
+}
+
+// parse converts a record into a task, collecting every problem in the row.
+// Tasks without a column go into the board's first column.
+func (p *csvTaskParser) parse(record []string, row int) (*models.Task, []CSVRowError) {
+	var rowErrors []CSVRowError
+	fail := func(field, message string) {
+		rowErrors = append(rowErrors, CSVRowError{Row: row, Field: field, Message: message})
+	}
+	value := func(field string) string {
+		index, ok := p.fieldIndex[field]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(csvCell(record, index))
+	}
+
+	task := &models.Task{
+		Title:       value(CSVFieldTitle),
+		Description: value(CSVFieldDescription),
+		ColumnID:    p.defaultColumn,
+	}
+
+	if task.Title == "" {
+		fail(CSVFieldTitle, "title is required")
+	} else if len(task.Title) > 255 {
+		fail(CSVFieldTitle, "title must be at most 255 characters")
+	}
+
+	if column := value(CSVFieldColumn); column != "" {
+		columnID, ok := p.columns[strings.ToLower(column)]
+		if !ok {
+			fail(CSVFieldColumn, fmt.Sprintf("board has no column %q", column))
+		}
+		task.ColumnID = columnID
+	}
+
+	for _, name := range splitCSVList(value(CSVFieldLabels)) {
+		task.Labels = append(task.Labels, models.Label{Name: name})
+	}
+
+	assigned := make(map[string]bool)
+	for _, email := range splitCSVList(value(CSVFieldAssignees)) {
+		userID, ok := p.users[strings.ToLower(email)]
+		if !ok {
+			fail(CSVFieldAssignees, fmt.Sprintf("no user with email %q", email))
+			continue
+		}
+		if !assigned[userID] {
+			assigned[userID] = true
+			task.Assignees = append(task.Assignees, models.TaskAssignee{UserID: userID})
+		}
+	}
+
+	deadline, allDay, err := utils.ParseDeadline(value(CSVFieldDeadline))
+	if err != nil {
+		fail(CSVFieldDeadline, err.Error())
+	}
+	task.Deadline = normalizeDeadline(deadline, allDay)
+	task.DeadlineAllDay = deadline != nil && allDay
+
+	if points := value(CSVFieldStoryPoints); points != "" {
+		storyPoints, err := strconv.Atoi(points)
+		if err != nil || storyPoints < 0 {
+			fail(CSVFieldStoryPoints, "story points must be a whole number of at least 0")
+		} else {
+			task.StoryPoints = &storyPoints
+		}
+	}
+
+	return task, rowErrors
+}
+
+func csvCell(record []string, index int) string {
+	if index >= len(record) {
+		return ""
+	}
+	return unescapeCSVFormula(record[index])
+}
+
+// escapeCSVFormula keeps spreadsheets from running a cell as a formula
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// unescapeCSVFormula undoes escapeCSVFormula, so exports import unchanged
+func unescapeCSVFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// splitCSVList splits a cell holding several values, dropping empty entries
+func splitCSVList(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, csvListSeparator) {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+type mockTaskCSVRepository struct {
+	tasks   []*models.Task
+	created []*models.Task
+}
+
+func (m *mockTaskCSVRepository) FindBoardTasks(ctx context.Context, boardID string) ([]*models.Task, error) {
+	return m.tasks, nil
+}
+
+func (m *mockTaskCSVRepository) CreateTasks(ctx context.Context, tasks []*models.Task) error {
+	m.created = append(m.created, tasks...)
+	return nil
+}
+
+func setupTaskCSVService() (TaskCSVService, *mockTaskCSVRepository) {
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards["board123"] = &models.Board{
+		ID:     "board123",
+		UserID: "user123",
+		Columns: []models.Column{
+			{ID: "col-doing", Title: "Doing", OrderNum: 2},
+			{ID: "col-todo", Title: "To Do", OrderNum: 1},
+		},
+		CustomFields: []models.CustomField{{ID: "field-1", Name: "Severity", Type: models.CustomFieldText}},
+	}
+	exportRepo := &mockBoardExportRepository{users: []*models.User{{ID: "user456", Email: "dev@example.com"}}}
+	csvRepo := &mockTaskCSVRepository{}
+	return NewTaskCSVService(csvRepo, boardRepo, exportRepo), csvRepo
+}
+
+func TestTaskCSVService_Export(t *testing.T) {
+	service, csvRepo := setupTaskCSVService()
+	deadline := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	points := 3
+	csvRepo.tasks = []*models.Task{{
+		ID:             "task-1",
+		Key:            "SPEC-1",
+		Title:          "Write spec, v2",
+		Column:         &models.Column{Title: "To Do"},
+		Labels:         []models.Label{{Name: "Docs"}, {Name: "Api"}},
+		Assignees:      []models.TaskAssignee{{UserID: "user456"}},
+		Deadline:       &deadline,
+		DeadlineAllDay: true,
+		StoryPoints:    &points,
+	}}
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if err := service.Export(context.Background(), "board123", "someone-else", &bytes.Buffer{}); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("Export() error = %v, want unauthorized", err)
+	}
+
+	var buf bytes.Buffer
+	if err := service.Export(context.Background(), "board123", "user123", &buf); err != nil {
+		t.Fatalf("Export() unexpected error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("export is not valid CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("export has %d records, want header and one task", len(records))
+	}
+	want := []string{"task-1", "SPEC-1", "Write spec, v2", "", "To Do", "Api;Docs", "dev@example.com", "2026-04-01", "3"}
+	for i, value := range want {
+		if records[1][i] != value {
+			t.Errorf("%s = %q, want %q", records[0][i], records[1][i], value)
+		}
+	}
+	if len(records[0]) != len(csvExportHeader) {
+		t.Errorf("header = %v, want no columns for custom fields without values", records[0])
+	}
+}
+
+func TestTaskCSVService_ExportEscapesFormulas(t *testing.T) {
+	service, csvRepo := setupTaskCSVService()
+	titles := []string{`=HYPERLINK("https://evil.example.com","Open")`, "+1", "-1", "@SUM(A1)", "'quoted", "Plain"}
+	for _, title := range titles {
+		csvRepo.tasks = append(csvRepo.tasks, &models.Task{ID: "task-" + title, Title: title, Column: &models.Column{Title: "To Do"}})
+	}
+
+	var buf bytes.Buffer
+	if err := service.Export(context.Background(), "board123", "user123", &buf); err != nil {
+		t.Fatalf("Export() unexpected error = %v", err)
+	}
+	exported := buf.String()
+
+	records, err := csv.NewReader(strings.NewReader(exported)).ReadAll()
+	if err != nil {
+		t.Fatalf("export is not valid CSV: %v", err)
+	}
+	want := []string{`'=HYPERLINK("https://evil.example.com","Open")`, "'+1", "'-1", "'@SUM(A1)", "''quoted", "Plain"}
+	for i, title := range want {
+		if got := records[i+1][2]; got != title {
+			t.Errorf("exported title = %q, want %q", got, title)
+		}
+	}
+
+	if _, err := service.Import(context.Background(), "board123", "user123", strings.NewReader(exported), nil, false); err != nil {
+		t.Fatalf("Import() of the export unexpected error = %v", err)
+	}
+	if len(csvRepo.created) != len(titles) {
+		t.Fatalf("imported %d tasks, want %d", len(csvRepo.created), len(titles))
+	}
+	for i, task := range csvRepo.created {
+		if task.Title != titles[i] {
+			t.Errorf("imported title = %q, want %q", task.Title, titles[i])
+		}
+	}
+}
+
+func TestTaskCSVService_Import(t *testing.T) {
+	const file = "Summary,Status,Tags,Owner,Due,Points\n" +
+		"Fix login,Doing,Bug; Urgent,dev@example.com,2026-04-01,2\n" +
+		"Write docs,,,,2026-04-02T15:00:00Z,\n"
+	mapping := map[string]string{
+		CSVFieldTitle:       "Summary",
+		CSVFieldColumn:      "status",
+		CSVFieldLabels:      "Tags",
+		CSVFieldAssignees:   "Owner",
+		CSVFieldDeadline:    "Due",
+		CSVFieldStoryPoints: "Points",
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		service, csvRepo := setupTaskCSVService()
+
+		result, err := service.Import(context.Background(), "board123", "user123", strings.NewReader(file), mapping, true)
+		if err != nil {
+			t.Fatalf("Import() unexpected error = %v", err)
+		}
+		if result.Rows != 2 || result.Imported != 0 || len(result.Errors) != 0 {
+			t.Errorf("unexpected dry run result %+v", result)
+		}
+		if len(csvRepo.created) != 0 {
+			t.Error("a dry run must not create tasks")
+		}
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		service, csvRepo := setupTaskCSVService()
+
+		result, err := service.Import(context.Background(), "board123", "user123", strings.NewReader(file), mapping, false)
+		if err != nil {
+			t.Fatalf("Import() unexpected error = %v", err)
+		}
+		if result.Imported != 2 || len(csvRepo.created) != 2 {
+			t.Fatalf("expected 2 imported tasks, got %+v", result)
+		}
+
+		first, second := csvRepo.created[0], csvRepo.created[1]
+		if first.ColumnID != "col-doing" || len(first.Labels) != 2 || len(first.Assignees) != 1 || first.StoryPoints == nil || !first.DeadlineAllDay {
+			t.Errorf("unexpected first task %+v", first)
+		}
+		if second.ColumnID != "col-todo" {
+			t.Errorf("task without a column should go to the first column, got %s", second.ColumnID)
+		}
+		if second.Deadline == nil || second.DeadlineAllDay {
+			t.Errorf("expected a timed deadline, got %+v", second.Deadline)
+		}
+	})
+
+	t.Run("row errors", func(t *testing.T) {
+		service, csvRepo := setupTaskCSVService()
+		invalid := "title,column,assignees,deadline,story_points\n" +
+			",Review,nobody@example.com,tomorrow,-1\n" +
+			"Valid task,To Do,,,\n"
+
+		result, err := service.Import(context.Background(), "board123", "user123", strings.NewReader(invalid), nil, false)
+		if err != nil {
+			t.Fatalf("Import() unexpected error = %v", err)
+		}
+		if len(csvRepo.created) != 0 || result.Imported != 0 {
+			t.Error("nothing should be imported when a row is invalid")
+		}
+
+		fields := make(map[string]bool)
+		for _, rowErr := range result.Errors {
+			if rowErr.Row != 2 {
+				t.Errorf("error reported on row %d, want 2", rowErr.Row)
+			}
+			fields[rowErr.Field] = true
+		}
+		for _, field := range []string{CSVFieldTitle, CSVFieldColumn, CSVFieldAssignees, CSVFieldDeadline, CSVFieldStoryPoints} {
+			if !fields[field] {
+				t.Errorf("expected an error for %s, got %+v", field, result.Errors)
+			}
+		}
+	})
+
+	t.Run("invalid mapping", func(t *testing.T) {
+		service, _ := setupTaskCSVService()
+
+		for _, mapping := range []map[string]string{
+			{CSVFieldTitle: "Missing"},
+			{"priority": "Summary"},
+			{CSVFieldColumn: "Status"},
+		} {
+			var validationErr utils.ErrValidation
+			if _, err := service.Import(context.Background(), "board123", "user123", strings.NewReader(file), mapping, true); !errors.As(err, &validationErr) {
+				t.Errorf("Import() with mapping %v error = %v, want validation error", mapping, err)
+			}
+		}
+	})
+}
@@ -3,21 +3,26 @@ package services
 import (
 	"context"
 	"errors"
+	"strconv"
 	"time"
 
+	"kanban-backend/events"
 	"kanban-backend/models"
 	"kanban-backend/repositories"
+	"kanban-backend/tracing"
 	"kanban-backend/utils"
 )
 
 type TaskService interface {
-	Create(ctx context.Context, userID, columnID, title, description string, deadline *time.Time) (*models.Task, error)
+	Create(ctx context.Context, userID, columnID, title, description string, deadline *time.Time, deadlineAllDay bool) (*models.Task, error)
 	FindByID(ctx context.Context, taskID, userID string) (*models.Task, error)
+	FindByKey(ctx context.Context, key, userID string) (*models.Task, error)
 	FindByColumnID(ctx context.Context, columnID, userID string) ([]*models.Task, error)
-	FindByColumnIDWithFilters(ctx context.Context, columnID, userID string, title string, page, limit int) ([]*models.Task, int, error)
+	FindByColumnIDWithFilters(ctx context.Context, columnID, userID string, title string, archived bool, page, limit int) ([]*models.Task, int, error)
 	Search(ctx context.Context, boardID, userID string, keyword string, page, limit int) ([]*models.Task, int, error)
-	Update(ctx context.Context, taskID, userID, title, description string, deadline *time.Time) (*models.Task, error)
+	Update(ctx context.Context, taskID, userID, title, description string, deadline *time.Time, deadlineAllDay bool, storyPoints *int) (*models.Task, error)
 	Delete(ctx context.Context, taskID, userID string) error
+	Archive(ctx context.Context, taskID, userID string, archived bool) (*models.Task, error)
 	Move(ctx context.Context, taskID, columnID, userID string) error
 }
 
@@ -33,7 +38,10 @@ func NewTaskService(taskRepo repositories.TaskRepository, columnRepo repositorie
 	}
 }
 
-func (s *taskService) Create(ctx context.Context, userID, columnID, title, description string, deadline *time.Time) (*models.Task, error) {
+func (s *taskService) Create(ctx context.Context, userID, columnID, title, description string, deadline *time.Time, deadlineAllDay bool) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Create")
+	defer span.End()
+
 	column, err := s.columnRepo.FindByID(ctx, columnID)
 	if err != nil {
 		return nil, utils.NewNotFound("column not found")
@@ -44,10 +52,11 @@ func (s *taskService) Create(ctx context.Context, userID, columnID, title, descr
 	}
 
 	task := &models.Task{
-		ColumnID:    columnID,
-		Title:       title,
-		Description: description,
-		Deadline:    deadline,
+		ColumnID:       columnID,
+		Title:          title,
+		Description:    description,
+		Deadline:       normalizeDeadline(deadline, deadlineAllDay),
+		DeadlineAllDay: deadline != nil && deadlineAllDay,
 	}
 
 	err = s.taskRepo.Create(ctx, task)
@@ -55,10 +64,17 @@ func (s *taskService) Create(ctx context.Context, userID, columnID, title, descr
 		return nil, err
 	}
 
+	event := events.ForTask(events.TaskCreated, userID, task)
+	event.BoardID = column.BoardID
+	events.Publish(ctx, event)
+
 	return task, nil
 }
 
 func (s *taskService) FindByID(ctx context.Context, taskID, userID string) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.FindByID")
+	defer span.End()
+
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
 		return nil, utils.NewNotFound("task not found")
@@ -75,7 +91,32 @@ func (s *taskService) FindByID(ctx context.Context, taskID, userID string) (*mod
 	return task, nil
 }
 
+// FindByKey finds one of the user's tasks by its key, e.g. OPS-123; the key
+// is case-insensitive
+func (s *taskService) FindByKey(ctx context.Context, key, userID string) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.FindByKey")
+	defer span.End()
+
+	key, ok := models.ParseTaskKey(key)
+	if !ok {
+		return nil, utils.NewValidation("task key must look like OPS-123")
+	}
+
+	task, err := s.taskRepo.FindByKey(ctx, userID, key)
+	if errors.Is(err, repositories.ErrAmbiguousTaskKey) {
+		return nil, utils.NewConflict("task key matches more than one task")
+	}
+	if err != nil {
+		return nil, utils.NewNotFound("task not found")
+	}
+
+	return task, nil
+}
+
 func (s *taskService) FindByColumnID(ctx context.Context, columnID, userID string) ([]*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.FindByColumnID")
+	defer span.End()
+
 	column, err := s.columnRepo.FindByID(ctx, columnID)
 	if err != nil {
 		return nil, utils.NewNotFound("column not found")
@@ -93,22 +134,44 @@ func (s *taskService) FindByColumnID(ctx context.Context, columnID, userID strin
 	return tasks, nil
 }
 
-func (s *taskService) Update(ctx context.Context, taskID, userID, title, description string, deadline *time.Time) (*models.Task, error) {
+func (s *taskService) Update(ctx context.Context, taskID, userID, title, description string, deadline *time.Time, deadlineAllDay bool, storyPoints *int) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Update")
+	defer span.End()
+
 	task, err := s.FindByID(ctx, taskID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	if title != "" {
+	var changes []events.Change
+	if title != "" && title != task.Title {
+		changes = append(changes, events.Change{Field: "title", OldValue: task.Title, NewValue: title})
 		task.Title = title
 	}
 
-	if description != "" {
+	if description != "" && description != task.Description {
+		changes = append(changes, events.Change{Field: "description", OldValue: task.Description, NewValue: description})
 		task.Description = description
 	}
 
 	if deadline != nil {
-		task.Deadline = deadline
+		previous := deadlineValue(task.Deadline, task.DeadlineAllDay)
+		task.Deadline = normalizeDeadline(deadline, deadlineAllDay)
+		task.DeadlineAllDay = deadlineAllDay
+		if current := deadlineValue(task.Deadline, task.DeadlineAllDay); current != previous {
+			changes = append(changes, events.Change{Field: "deadline", OldValue: previous, NewValue: current})
+		}
+	}
+
+	if storyPoints != nil {
+		if *storyPoints < 0 {
+			return nil, utils.NewValidation("story points cannot be negative")
+		}
+		previous := storyPointsValue(task.StoryPoints)
+		task.StoryPoints = storyPoints
+		if current := storyPointsValue(task.StoryPoints); current != previous {
+			changes = append(changes, events.Change{Field: "story_points", OldValue: previous, NewValue: current})
+		}
 	}
 
 	err = s.taskRepo.Update(ctx, task)
@@ -116,24 +179,73 @@ func (s *taskService) Update(ctx context.Context, taskID, userID, title, descrip
 		return nil, err
 	}
 
+	if len(changes) > 0 {
+		event := events.ForTask(events.TaskUpdated, userID, task)
+		event.Changes = changes
+		events.Publish(ctx, event)
+	}
+
 	return task, nil
 }
 
+// Delete moves the task to the trash
 func (s *taskService) Delete(ctx context.Context, taskID, userID string) error {
-	_, err := s.FindByID(ctx, taskID, userID)
+	ctx, span := tracing.Start(ctx, "TaskService.Delete")
+	defer span.End()
+
+	task, err := s.FindByID(ctx, taskID, userID)
 	if err != nil {
 		return err
 	}
 
-	err = s.taskRepo.Delete(ctx, taskID)
+	err = s.taskRepo.SoftDelete(ctx, taskID)
 	if err != nil {
 		return err
 	}
 
+	events.Publish(ctx, events.ForTask(events.TaskDeleted, userID, task))
+
 	return nil
 }
 
+// Archive hides the task from column listings and search, or brings it back
+// when archived is false
+func (s *taskService) Archive(ctx context.Context, taskID, userID string, archived bool) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Archive")
+	defer span.End()
+
+	task, err := s.FindByID(ctx, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if archived == (task.ArchivedAt != nil) {
+		return task, nil
+	}
+
+	task.ArchivedAt = nil
+	if archived {
+		now := time.Now().UTC()
+		task.ArchivedAt = &now
+	}
+
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return nil, err
+	}
+
+	eventType := events.TaskUnarchived
+	if archived {
+		eventType = events.TaskArchived
+	}
+	events.Publish(ctx, events.ForTask(eventType, userID, task))
+
+	return task, nil
+}
+
 func (s *taskService) Move(ctx context.Context, taskID, columnID, userID string) error {
+	ctx, span := tracing.Start(ctx, "TaskService.Move")
+	defer span.End()
+
 	task, err := s.taskRepo.FindByID(ctx, taskID)
 	if err != nil {
 		return utils.NewNotFound("task not found")
@@ -160,11 +272,26 @@ func (s *taskService) Move(ctx context.Context, taskID, columnID, userID string)
 		return errors.New("cannot move task to a different board")
 	}
 
+	previousColumn := task.Column
 	task.ColumnID = columnID
-	return s.taskRepo.Update(ctx, task)
+	if err := s.taskRepo.Update(ctx, task); err != nil {
+		return err
+	}
+
+	task.Column = column
+	event := events.ForTask(events.TaskMoved, userID, task)
+	event.Changes = []events.Change{{Field: "column_id", OldValue: previousColumn.ID, NewValue: column.ID}}
+	event.Data["from_column"] = previousColumn.Title
+	event.Data["to_column"] = column.Title
+	events.Publish(ctx, event)
+
+	return nil
 }
 
-func (s *taskService) FindByColumnIDWithFilters(ctx context.Context, columnID, userID string, title string, page, limit int) ([]*models.Task, int, error) {
+func (s *taskService) FindByColumnIDWithFilters(ctx context.Context, columnID, userID string, title string, archived bool, page, limit int) ([]*models.Task, int, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.FindByColumnIDWithFilters")
+	defer span.End()
+
 	column, err := s.columnRepo.FindByID(ctx, columnID)
 	if err != nil {
 		return nil, 0, utils.NewNotFound("column not found")
@@ -174,7 +301,7 @@ func (s *taskService) FindByColumnIDWithFilters(ctx context.Context, columnID, u
 		return nil, 0, utils.NewUnauthorized("you do not have access to this column")
 	}
 
-	tasks, total, err := s.taskRepo.FindByColumnIDWithFilters(ctx, columnID, title, page, limit)
+	tasks, total, err := s.taskRepo.FindByColumnIDWithFilters(ctx, columnID, title, archived, page, limit)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -183,6 +310,9 @@ func (s *taskService) FindByColumnIDWithFilters(ctx context.Context, columnID, u
 }
 
 func (s *taskService) Search(ctx context.Context, boardID, userID string, keyword string, page, limit int) ([]*models.Task, int, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.Search")
+	defer span.End()
+
 	boardRepo := repositories.NewBoardRepository()
 	board, err := boardRepo.FindByID(ctx, boardID)
 	if err != nil {
@@ -200,3 +330,37 @@ func (s *taskService) Search(ctx context.Context, boardID, userID string, keywor
 
 	return tasks, total, nil
 }
+
+// normalizeDeadline stores deadlines in UTC. Date-only deadlines keep just the
+// calendar date, stored as midnight UTC.
+func normalizeDeadline(deadline *time.Time, allDay bool) *time.Time {
+	if deadline == nil {
+		return nil
+	}
+
+	normalized := deadline.UTC()
+	if allDay {
+		year, month, day := deadline.Date()
+		normalized = time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	return &normalized
+}
+
+// deadlineValue renders a deadline for activity history and notifications:
+// a calendar date for date-only deadlines, RFC 3339 otherwise
+func deadlineValue(deadline *time.Time, allDay bool) string {
+	if deadline == nil {
+		return ""
+	}
+	if allDay {
+		return deadline.UTC().Format(utils.DateLayout)
+	}
+	return deadline.UTC().Format(time.RFC3339)
+}
+
+func storyPointsValue(points *int) string {
+	if points == nil {
+		return ""
+	}
+	return strconv.Itoa(*points)
+}
@@ -36,6 +36,15 @@ func (m *mockTaskRepository) Create(ctx context.Context, task *models.Task) erro
 	return nil
 }
 
+func (m *mockTaskRepository) FindByKey(ctx context.Context, userID, key string) (*models.Task, error) {
+	for _, task := range m.tasks {
+		if task.Key == key && task.Column != nil && task.Column.Board != nil && task.Column.Board.UserID == userID {
+			return m.FindByID(ctx, task.ID)
+		}
+	}
+	return nil, errors.New("task not found")
+}
+
 func (m *mockTaskRepository) FindByID(ctx context.Context, id string) (*models.Task, error) {
 	task, exists := m.tasks[id]
 	if !exists {
@@ -83,7 +92,7 @@ func (m *mockTaskRepository) SoftDelete(ctx context.Context, id string) error {
 	return m.Delete(ctx, id)
 }
 
-func (m *mockTaskRepository) FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, page, limit int) ([]*models.Task, int, error) {
+func (m *mockTaskRepository) FindByColumnIDWithFilters(ctx context.Context, columnID string, title string, archived bool, page, limit int) ([]*models.Task, int, error) {
 	var tasks []*models.Task
 	for _, task := range m.tasks {
 		if task.ColumnID == columnID && (title == "" || task.Title == title) {
@@ -213,7 +222,7 @@ func TestTaskService_Create(t *testing.T) {
 				t.Fatalf("Setup failed: %v", err)
 			}
 
-			task, err := service.Create(ctx, tt.userID, tt.columnID, tt.title, tt.description, tt.deadline)
+			task, err := service.Create(ctx, tt.userID, tt.columnID, tt.title, tt.description, tt.deadline, false)
 
 			if tt.expectError {
 				if err == nil {
@@ -356,6 +365,40 @@ func TestTaskService_FindByID(t *testing.T) {
 	}
 }
 
+func TestTaskService_FindByKey(t *testing.T) {
+	mockTaskRepo := newMockTaskRepository()
+	service := NewTaskService(mockTaskRepo, newMockColumnRepository())
+	ctx := context.Background()
+
+	column := setupTestColumn("board123")
+	column.Board.UserID = "user123"
+	task := setupTestTask(column.ID)
+	task.Number = 7
+	task.Key = "OPS-7"
+	task.Column = column
+	mockTaskRepo.tasks[task.ID] = task
+
+	found, err := service.FindByKey(ctx, " ops-7", "user123")
+	if err != nil {
+		t.Fatalf("FindByKey() unexpected error = %v", err)
+	}
+	if found.ID != task.ID {
+		t.Errorf("FindByKey() found %s, want %s", found.ID, task.ID)
+	}
+
+	var notFoundErr utils.ErrNotFound
+	if _, err := service.FindByKey(ctx, "OPS-7", "user456"); !errors.As(err, &notFoundErr) {
+		t.Errorf("FindByKey() for another user error = %v, want not found", err)
+	}
+
+	var validationErr utils.ErrValidation
+	for _, key := range []string{"OPS", "OPS-0", "7-OPS", "O-7"} {
+		if _, err := service.FindByKey(ctx, key, "user123"); !errors.As(err, &validationErr) {
+			t.Errorf("FindByKey(%q) error = %v, want validation error", key, err)
+		}
+	}
+}
+
 func TestTaskService_FindByColumnID(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -468,9 +511,29 @@ func TestTaskService_Update(t *testing.T) {
 		title         string
 		description   string
 		deadline      *time.Time
+		storyPoints   *int
 		expectError   bool
 		errorType     string
 	}{
+		{
+			name:          "Valid update story points",
+			setupTask:     true,
+			taskID:        "task1",
+			taskUserID:    "user123",
+			requestUserID: "user123",
+			storyPoints:   intPointer(5),
+			expectError:   false,
+		},
+		{
+			name:          "Negative story points",
+			setupTask:     true,
+			taskID:        "task1",
+			taskUserID:    "user123",
+			requestUserID: "user123",
+			storyPoints:   intPointer(-1),
+			expectError:   true,
+			errorType:     "validation",
+		},
 		{
 			name:          "Valid update title",
 			setupTask:     true,
@@ -540,7 +603,7 @@ func TestTaskService_Update(t *testing.T) {
 				}
 			}
 
-			task, err := service.Update(ctx, tt.taskID, tt.requestUserID, tt.title, tt.description, tt.deadline)
+			task, err := service.Update(ctx, tt.taskID, tt.requestUserID, tt.title, tt.description, tt.deadline, false, tt.storyPoints)
 
 			if tt.expectError {
 				if err == nil {
@@ -558,6 +621,11 @@ func TestTaskService_Update(t *testing.T) {
 					if !errors.As(err, &notFoundErr) {
 						t.Errorf("Update() should return ErrNotFound, got %v", err)
 					}
+				} else if tt.errorType == "validation" {
+					var validationErr utils.ErrValidation
+					if !errors.As(err, &validationErr) {
+						t.Errorf("Update() should return ErrValidation, got %v", err)
+					}
 				}
 				return
 			}
@@ -572,6 +640,10 @@ func TestTaskService_Update(t *testing.T) {
 				return
 			}
 
+			if tt.storyPoints != nil && (task.StoryPoints == nil || *task.StoryPoints != *tt.storyPoints) {
+				t.Errorf("Update() story points = %v, want %v", task.StoryPoints, *tt.storyPoints)
+			}
+
 			if tt.title != "" && task.Title != tt.title {
 				t.Errorf("Update() title = %v, want %v", task.Title, tt.title)
 			}
@@ -886,7 +958,7 @@ func TestTaskService_Integration(t *testing.T) {
 
 	title := "My Task"
 	description := "Task description"
-	task, err := service.Create(ctx, userID, column1.ID, title, description, nil)
+	task, err := service.Create(ctx, userID, column1.ID, title, description, nil, false)
 	if err != nil {
 		t.Fatalf("Create() failed: %v", err)
 	}
@@ -910,7 +982,7 @@ func TestTaskService_Integration(t *testing.T) {
 	}
 
 	updatedTitle := "Updated Task"
-	updatedTask, err := service.Update(ctx, task.ID, userID, updatedTitle, "", nil)
+	updatedTask, err := service.Update(ctx, task.ID, userID, updatedTitle, "", nil, false, nil)
 	if err != nil {
 		t.Fatalf("Update() failed: %v", err)
 	}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+// Trash item types
+const (
+	TrashItemBoard      = "board"
+	TrashItemTask       = "task"
+	TrashItemComment    = "comment"
+	TrashItemAttachment = "attachment"
+)
+
+// trashTitleLength caps how much of a comment is shown as its trash title
+const trashTitleLength = 80
+
+// TrashItem is a deleted board, task, comment or attachment. PurgeAt is when
+// the retention job removes it for good.
+type TrashItem struct {
+	Type      string    `json:"type"`
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	TaskID    string    `json:"task_id,omitempty"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
+
+type TrashService interface {
+	FindAll(ctx context.Context, userID string) ([]TrashItem, error)
+	Restore(ctx context.Context, itemType, id, userID string) error
+	Purge(ctx context.Context, now time.Time) (int64, error)
+}
+
+// FileRemover deletes stored attachment files; S3Service is the
+// implementation. Files whose URL has no key in the store are left alone.
+type FileRemover interface {
+	KeyForURL(url string) (string, bool)
+	DeleteFile(key string) error
+}
+
+type trashService struct {
+	trashRepo repositories.TrashRepository
+	files     FileRemover
+	retention time.Duration
+}
+
+// NewTrashService returns a service that keeps deleted items for the given
+// retention period before Purge removes them, along with their stored files
+func NewTrashService(trashRepo repositories.TrashRepository, files FileRemover, retention time.Duration) TrashService {
+	return &trashService{
+		trashRepo: trashRepo,
+		files:     files,
+		retention: retention,
+	}
+}
+
+// FindAll lists everything the user has in the trash, most recently deleted first
+func (s *trashService) FindAll(ctx context.Context, userID string) ([]TrashItem, error) {
+	ctx, span := tracing.Start(ctx, "TrashService.FindAll")
+	defer span.End()
+
+	boards, err := s.trashRepo.FindBoards(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.trashRepo.FindTasks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.trashRepo.FindComments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.trashRepo.FindAttachments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]TrashItem, 0, len(boards)+len(tasks)+len(comments)+len(attachments))
+	for _, board := range boards {
+		items = append(items, s.item(TrashItemBoard, board.ID, board.Title, "", board.DeletedAt.Time))
+	}
+	for _, task := range tasks {
+		items = append(items, s.item(TrashItemTask, task.ID, task.Title, "", task.DeletedAt.Time))
+	}
+	for _, comment := range comments {
+		items = append(items, s.item(TrashItemComment, comment.ID, truncate(comment.Content, trashTitleLength), comment.TaskID, comment.DeletedAt.Time))
+	}
+	for _, attachment := range attachments {
+		items = append(items, s.item(TrashItemAttachment, attachment.ID, attachment.FileName, attachment.TaskID, attachment.DeletedAt.Time))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+
+	return items, nil
+}
+
+// Restore takes an item out of the trash. Tasks can only be restored onto a
+// live board, and comments and attachments only onto a live task.
+func (s *trashService) Restore(ctx context.Context, itemType, id, userID string) error {
+	ctx, span := tracing.Start(ctx, "TrashService.Restore")
+	defer span.End()
+
+	switch itemType {
+	case TrashItemBoard:
+		board, err := s.trashRepo.FindBoard(ctx, id)
+		if err != nil || !board.DeletedAt.Valid {
+			return utils.NewNotFound("board not found in trash")
+		}
+		if board.UserID != userID {
+			return utils.NewUnauthorized("you do not have access to this board")
+		}
+		if err := s.trashRepo.Restore(ctx, &models.Board{}, id); err != nil {
+			return err
+		}
+		events.Publish(ctx, events.ForBoard(events.BoardRestored, userID, board))
+		return nil
+
+	case TrashItemTask:
+		task, err := s.findTask(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+		if !task.DeletedAt.Valid {
+			return utils.NewNotFound("task not found in trash")
+		}
+		if task.Column.DeletedAt.Valid || task.Column.Board.DeletedAt.Valid {
+			return utils.NewValidation("restore the task's board first")
+		}
+		if err := s.trashRepo.Restore(ctx, &models.Task{}, id); err != nil {
+			return err
+		}
+		events.Publish(ctx, events.ForTask(events.TaskRestored, userID, task))
+		return nil
+
+	case TrashItemComment:
+		comment, err := s.trashRepo.FindComment(ctx, id)
+		if err != nil || !comment.DeletedAt.Valid {
+			return utils.NewNotFound("comment not found in trash")
+		}
+		if err := s.checkParentTask(ctx, comment.TaskID, userID); err != nil {
+			return err
+		}
+		return s.trashRepo.Restore(ctx, &models.Comment{}, id)
+
+	case TrashItemAttachment:
+		attachment, err := s.trashRepo.FindAttachment(ctx, id)
+		if err != nil || !attachment.DeletedAt.Valid {
+			return utils.NewNotFound("attachment not found in trash")
+		}
+		if err := s.checkParentTask(ctx, attachment.TaskID, userID); err != nil {
+			return err
+		}
+		return s.trashRepo.Restore(ctx, &models.Attachment{}, id)
+	}
+
+	return utils.NewValidation("type must be one of board, task, comment or attachment")
+}
+
+// Purge permanently deletes items that have been in the trash longer than the
+// retention period. Stored files no remaining attachment uses are deleted once
+// the rows are gone; a file that fails to delete is only logged, since nothing
+// refers to it any more.
+func (s *trashService) Purge(ctx context.Context, now time.Time) (int64, error) {
+	ctx, span := tracing.Start(ctx, "TrashService.Purge")
+	defer span.End()
+
+	purged, orphaned, err := s.trashRepo.Purge(ctx, now.Add(-s.retention))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, url := range orphaned {
+		key, ok := s.files.KeyForURL(url)
+		if !ok {
+			continue
+		}
+		if err := s.files.DeleteFile(key); err != nil {
+			slog.WarnContext(ctx, "failed to delete purged attachment file", "file_url", url, "error", err)
+		}
+	}
+
+	return purged, nil
+}
+
+func (s *trashService) item(itemType, id, title, taskID string, deletedAt time.Time) TrashItem {
+	return TrashItem{
+		Type:      itemType,
+		ID:        id,
+		Title:     title,
+		TaskID:    taskID,
+		DeletedAt: deletedAt,
+		PurgeAt:   deletedAt.Add(s.retention),
+	}
+}
+
+// findTask loads a task, deleted or not, and checks that the user owns its board
+func (s *trashService) findTask(ctx context.Context, taskID, userID string) (*models.Task, error) {
+	task, err := s.trashRepo.FindTask(ctx, taskID)
+	if err != nil || task.Column == nil || task.Column.Board == nil {
+		return nil, utils.NewNotFound("task not found")
+	}
+
+	if task.Column.Board.UserID != userID {
+		return nil, utils.NewUnauthorized("you do not have access to this task")
+	}
+
+	return task, nil
+}
+
+// checkParentTask makes sure a comment or attachment is restored onto a task
+// the user owns and that is not itself in the trash
+func (s *trashService) checkParentTask(ctx context.Context, taskID, userID string) error {
+	task, err := s.findTask(ctx, taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if task.DeletedAt.Valid || task.Column.DeletedAt.Valid || task.Column.Board.DeletedAt.Valid {
+		return utils.NewValidation("restore the task first")
+	}
+
+	return nil
+}
+
+// truncate shortens s to at most max runes, marking the cut with an ellipsis
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-1]) + "…"
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+
+	"gorm.io/gorm"
+)
+
+type mockTrashRepository struct {
+	boards      map[string]*models.Board
+	tasks       map[string]*models.Task
+	comments    map[string]*models.Comment
+	attachments map[string]*models.Attachment
+	restored    []string
+	purgeBefore time.Time
+	orphaned    []string
+}
+
+func newMockTrashRepository() *mockTrashRepository {
+	return &mockTrashRepository{
+		boards:      make(map[string]*models.Board),
+		tasks:       make(map[string]*models.Task),
+		comments:    make(map[string]*models.Comment),
+		attachments: make(map[string]*models.Attachment),
+	}
+}
+
+func (m *mockTrashRepository) FindBoards(ctx context.Context, userID string) ([]*models.Board, error) {
+	var boards []*models.Board
+	for _, board := range m.boards {
+		if board.UserID == userID && board.DeletedAt.Valid {
+			boards = append(boards, board)
+		}
+	}
+	return boards, nil
+}
+
+func (m *mockTrashRepository) FindTasks(ctx context.Context, userID string) ([]*models.Task, error) {
+	var tasks []*models.Task
+	for _, task := range m.tasks {
+		if task.DeletedAt.Valid {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+func (m *mockTrashRepository) FindComments(ctx context.Context, userID string) ([]*models.Comment, error) {
+	var comments []*models.Comment
+	for _, comment := range m.comments {
+		if comment.DeletedAt.Valid {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+func (m *mockTrashRepository) FindAttachments(ctx context.Context, userID string) ([]*models.Attachment, error) {
+	return nil, nil
+}
+
+func (m *mockTrashRepository) FindBoard(ctx context.Context, id string) (*models.Board, error) {
+	if board, exists := m.boards[id]; exists {
+		return board, nil
+	}
+	return nil, errors.New("board not found")
+}
+
+func (m *mockTrashRepository) FindTask(ctx context.Context, id string) (*models.Task, error) {
+	if task, exists := m.tasks[id]; exists {
+		return task, nil
+	}
+	return nil, errors.New("task not found")
+}
+
+func (m *mockTrashRepository) FindComment(ctx context.Context, id string) (*models.Comment, error) {
+	if comment, exists := m.comments[id]; exists {
+		return comment, nil
+	}
+	return nil, errors.New("comment not found")
+}
+
+func (m *mockTrashRepository) FindAttachment(ctx context.Context, id string) (*models.Attachment, error) {
+	if attachment, exists := m.attachments[id]; exists {
+		return attachment, nil
+	}
+	return nil, errors.New("attachment not found")
+}
+
+func (m *mockTrashRepository) Restore(ctx context.Context, model interface{}, id string) error {
+	m.restored = append(m.restored, id)
+	return nil
+}
+
+func (m *mockTrashRepository) Purge(ctx context.Context, before time.Time) (int64, []string, error) {
+	m.purgeBefore = before
+	return 0, m.orphaned, nil
+}
+
+type mockFileRemover struct {
+	deleted []string
+}
+
+func (m *mockFileRemover) KeyForURL(url string) (string, bool) {
+	return strings.CutPrefix(url, "https://files.example.com/")
+}
+
+func (m *mockFileRemover) DeleteFile(key string) error {
+	m.deleted = append(m.deleted, key)
+	if key == "broken.txt" {
+		return errors.New("storage unavailable")
+	}
+	return nil
+}
+
+func deletedAt(t time.Time) gorm.DeletedAt {
+	return gorm.DeletedAt{Time: t, Valid: true}
+}
+
+func TestTrashService_Restore(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	repo := newMockTrashRepository()
+	liveBoard := &models.Board{ID: "live", UserID: "user123"}
+	trashedBoard := &models.Board{ID: "trashed", UserID: "user123", DeletedAt: deletedAt(now)}
+	repo.boards[trashedBoard.ID] = trashedBoard
+	repo.boards[liveBoard.ID] = liveBoard
+
+	liveColumn := &models.Column{ID: "col-live", Board: liveBoard}
+	trashedColumn := &models.Column{ID: "col-trashed", Board: trashedBoard}
+	repo.tasks["task-live"] = &models.Task{ID: "task-live", Column: liveColumn}
+	repo.tasks["task-trashed"] = &models.Task{ID: "task-trashed", Column: liveColumn, DeletedAt: deletedAt(now)}
+	repo.tasks["task-on-trashed-board"] = &models.Task{ID: "task-on-trashed-board", Column: trashedColumn, DeletedAt: deletedAt(now)}
+	repo.comments["comment-ok"] = &models.Comment{ID: "comment-ok", TaskID: "task-live", DeletedAt: deletedAt(now)}
+	repo.comments["comment-orphan"] = &models.Comment{ID: "comment-orphan", TaskID: "task-trashed", DeletedAt: deletedAt(now)}
+
+	service := NewTrashService(repo, &mockFileRemover{}, 30*24*time.Hour)
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		itemType  string
+		id        string
+		userID    string
+		errorType string
+	}{
+		{name: "board", itemType: TrashItemBoard, id: "trashed", userID: "user123"},
+		{name: "board that is not deleted", itemType: TrashItemBoard, id: "live", userID: "user123", errorType: "not_found"},
+		{name: "another user's board", itemType: TrashItemBoard, id: "trashed", userID: "user456", errorType: "unauthorized"},
+		{name: "task", itemType: TrashItemTask, id: "task-trashed", userID: "user123"},
+		{name: "task on a deleted board", itemType: TrashItemTask, id: "task-on-trashed-board", userID: "user123", errorType: "validation"},
+		{name: "comment", itemType: TrashItemComment, id: "comment-ok", userID: "user123"},
+		{name: "comment on a deleted task", itemType: TrashItemComment, id: "comment-orphan", userID: "user123", errorType: "validation"},
+		{name: "unknown type", itemType: "label", id: "label-1", userID: "user123", errorType: "validation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.restored = nil
+			err := service.Restore(ctx, tt.itemType, tt.id, tt.userID)
+
+			switch tt.errorType {
+			case "":
+				if err != nil {
+					t.Fatalf("Restore() unexpected error = %v", err)
+				}
+				if len(repo.restored) != 1 || repo.restored[0] != tt.id {
+					t.Errorf("expected %s to be restored, got %v", tt.id, repo.restored)
+				}
+				return
+			case "not_found":
+				var notFoundErr utils.ErrNotFound
+				if !errors.As(err, &notFoundErr) {
+					t.Errorf("Restore() should return ErrNotFound, got %v", err)
+				}
+			case "unauthorized":
+				var unauthorizedErr utils.ErrUnauthorized
+				if !errors.As(err, &unauthorizedErr) {
+					t.Errorf("Restore() should return ErrUnauthorized, got %v", err)
+				}
+			case "validation":
+				var validationErr utils.ErrValidation
+				if !errors.As(err, &validationErr) {
+					t.Errorf("Restore() should return ErrValidation, got %v", err)
+				}
+			}
+			if len(repo.restored) != 0 {
+				t.Errorf("nothing should be restored, got %v", repo.restored)
+			}
+		})
+	}
+}
+
+func TestTrashService_FindAllAndPurge(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	retention := 30 * 24 * time.Hour
+
+	repo := newMockTrashRepository()
+	repo.boards["board-1"] = &models.Board{ID: "board-1", UserID: "user123", Title: "Old board", DeletedAt: deletedAt(now.Add(-48 * time.Hour))}
+	repo.tasks["task-1"] = &models.Task{ID: "task-1", Title: "Recent task", DeletedAt: deletedAt(now.Add(-time.Hour))}
+	repo.comments["comment-1"] = &models.Comment{ID: "comment-1", TaskID: "task-2", Content: string(make([]rune, 200)), DeletedAt: deletedAt(now.Add(-24 * time.Hour))}
+
+	repo.orphaned = []string{"https://files.example.com/broken.txt", "https://trello.com/attachment.png", "https://files.example.com/report.pdf"}
+	files := &mockFileRemover{}
+
+	service := NewTrashService(repo, files, retention)
+
+	items, err := service.FindAll(context.Background(), "user123")
+	if err != nil {
+		t.Fatalf("FindAll() unexpected error = %v", err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("FindAll() returned %d items, want 3", len(items))
+	}
+
+	wantOrder := []string{TrashItemTask, TrashItemComment, TrashItemBoard}
+	for i, itemType := range wantOrder {
+		if items[i].Type != itemType {
+			t.Errorf("item %d type = %s, want %s", i, items[i].Type, itemType)
+		}
+	}
+	if !items[0].PurgeAt.Equal(now.Add(-time.Hour).Add(retention)) {
+		t.Errorf("PurgeAt = %v, want deletion time plus retention", items[0].PurgeAt)
+	}
+	if got := len([]rune(items[1].Title)); got != trashTitleLength {
+		t.Errorf("comment title has %d runes, want %d", got, trashTitleLength)
+	}
+	if items[1].TaskID != "task-2" {
+		t.Errorf("comment TaskID = %q, want task-2", items[1].TaskID)
+	}
+
+	if _, err := service.Purge(context.Background(), now); err != nil {
+		t.Fatalf("Purge() unexpected error = %v", err)
+	}
+	if !repo.purgeBefore.Equal(now.Add(-retention)) {
+		t.Errorf("Purge() cutoff = %v, want %v", repo.purgeBefore, now.Add(-retention))
+	}
+	if want := []string{"broken.txt", "report.pdf"}; !reflect.DeepEqual(files.deleted, want) {
+		t.Errorf("Purge() deleted files %v, want %v", files.deleted, want)
+	}
+}
@@ -0,0 +1,349 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"kanban-backend/models"
+)
+
+// trelloBoard is the subset of Trello's board JSON export that is imported
+type trelloBoard struct {
+	Name  string `json:"name"`
+	Prefs struct {
+		BackgroundColor string `json:"backgroundColor"`
+	} `json:"prefs"`
+	Lists      []trelloList      `json:"lists"`
+	Cards      []trelloCard      `json:"cards"`
+	Labels     []trelloLabel     `json:"labels"`
+	Checklists []trelloChecklist `json:"checklists"`
+	Actions    []trelloAction    `json:"actions"`
+	Members    []trelloMember    `json:"members"`
+}
+
+type trelloList struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Closed bool    `json:"closed"`
+	Pos    float64 `json:"pos"`
+}
+
+type trelloCard struct {
+	ID               string             `json:"id"`
+	Name             string             `json:"name"`
+	Desc             string             `json:"desc"`
+	IDList           string             `json:"idList"`
+	Closed           bool               `json:"closed"`
+	Pos              float64            `json:"pos"`
+	Due              *time.Time         `json:"due"`
+	IDLabels         []string           `json:"idLabels"`
+	IDMembers        []string           `json:"idMembers"`
+	Attachments      []trelloAttachment `json:"attachments"`
+	DateLastActivity *time.Time         `json:"dateLastActivity"`
+}
+
+type trelloLabel struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type trelloChecklist struct {
+	ID         string  `json:"id"`
+	IDCard     string  `json:"idCard"`
+	Name       string  `json:"name"`
+	Pos        float64 `json:"pos"`
+	CheckItems []struct {
+		Name  string  `json:"name"`
+		State string  `json:"state"`
+		Pos   float64 `json:"pos"`
+	} `json:"checkItems"`
+}
+
+type trelloAction struct {
+	Type string    `json:"type"`
+	Date time.Time `json:"date"`
+	Data struct {
+		Text string `json:"text"`
+		Card struct {
+			ID string `json:"id"`
+		} `json:"card"`
+	} `json:"data"`
+	IDMemberCreator string `json:"idMemberCreator"`
+	MemberCreator   struct {
+		FullName string `json:"fullName"`
+	} `json:"memberCreator"`
+}
+
+type trelloAttachment struct {
+	Name  string     `json:"name"`
+	URL   string     `json:"url"`
+	Bytes *int64     `json:"bytes"`
+	Date  *time.Time `json:"date"`
+}
+
+// trelloMember carries an email only when the export was made with member
+// emails visible; otherwise members are matched by username
+type trelloMember struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+	FullName string `json:"fullName"`
+	Email    string `json:"email"`
+}
+
+// trelloColors maps Trello's named label and background colors to hex
+var trelloColors = map[string]string{
+	"green":  "#61BD4F",
+	"yellow": "#F2D600",
+	"orange": "#FF9F1A",
+	"red":    "#EB5A46",
+	"purple": "#C377E0",
+	"blue":   "#0079BF",
+	"sky":    "#00C2E0",
+	"lime":   "#51E898",
+	"pink":   "#FF78CB",
+	"black":  "#344563",
+	"grey":   "#838C91",
+}
+
+// trelloColor resolves a Trello color, including the _light and _dark
+// variants, to hex. Unknown colors map to an empty string.
+func trelloColor(name string) string {
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "_light"), "_dark")
+	return trelloColors[name]
+}
+
+// trelloIDTime returns the creation time encoded in the first four bytes of a
+// Trello object ID
+func trelloIDTime(id string) (time.Time, bool) {
+	if len(id) < 8 {
+		return time.Time{}, false
+	}
+	seconds, err := strconv.ParseInt(id[:8], 16, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(seconds, 0).UTC(), true
+}
+
+// convertTrelloBoard turns a Trello export into a board export document.
+// members maps Trello member IDs to local users. Archived lists, unnamed
+// labels and members without a local user are reported as skipped;
+// checklists, which have no counterpart here, become task lists in the task
+// description.
+func convertTrelloBoard(board *trelloBoard, members map[string]*models.User, now time.Time) (*models.BoardExport, []models.ImportSkippedItem) {
+	var skipped []models.ImportSkippedItem
+	skip := func(itemType, name, reason string) {
+		skipped = append(skipped, models.ImportSkippedItem{Type: itemType, Name: name, Reason: reason})
+	}
+
+	title := strings.TrimSpace(board.Name)
+	if title == "" {
+		title = "Imported from Trello"
+	}
+	export := &models.BoardExport{
+		Version:    models.BoardExportVersion,
+		ExportedAt: now,
+		Board: models.ExportedBoard{
+			Title: title,
+			Color: trelloColor(board.Prefs.BackgroundColor),
+		},
+		Columns: []models.ExportedColumn{},
+	}
+
+	lists := make([]trelloList, len(board.Lists))
+	copy(lists, board.Lists)
+	sort.SliceStable(lists, func(i, j int) bool {
+		return lists[i].Pos < lists[j].Pos
+	})
+
+	listOrder := make(map[string]int, len(lists))
+	for _, list := range lists {
+		if list.Closed {
+			skip("list", list.Name, "list is archived")
+			continue
+		}
+
+		name := strings.TrimSpace(list.Name)
+		if name == "" {
+			name = "Untitled list"
+		}
+		stage := models.ColumnStageInProgress
+		if strings.EqualFold(name, "done") {
+			stage = models.ColumnStageDone
+		} else if len(export.Columns) == 0 {
+			stage = models.ColumnStageTodo
+		}
+
+		listOrder[list.ID] = len(export.Columns)
+		export.Columns = append(export.Columns, models.ExportedColumn{
+			ID:    list.ID,
+			Title: name,
+			Order: len(export.Columns) + 1,
+			Stage: stage,
+		})
+	}
+
+	labelNames := make(map[string]string, len(board.Labels))
+	seenLabels := make(map[string]bool, len(board.Labels))
+	for _, label := range board.Labels {
+		name := strings.TrimSpace(label.Name)
+		if name == "" {
+			skip("label", label.Color, "label has no name")
+			continue
+		}
+		labelNames[label.ID] = name
+		if !seenLabels[name] {
+			seenLabels[name] = true
+			export.Labels = append(export.Labels, models.TemplateLabel{Name: name, Color: trelloColor(label.Color)})
+		}
+	}
+
+	memberNames := make(map[string]string, len(board.Members))
+	for _, member := range board.Members {
+		memberNames[member.ID] = member.FullName
+		if _, ok := members[member.ID]; !ok {
+			skip("member", member.FullName, "no user with a matching email or username")
+		}
+	}
+
+	checklists := make(map[string][]trelloChecklist)
+	for _, checklist := range board.Checklists {
+		checklists[checklist.IDCard] = append(checklists[checklist.IDCard], checklist)
+	}
+
+	// Trello lists actions newest first
+	comments := make(map[string][]trelloAction)
+	for _, action := range board.Actions {
+		if action.Type == "commentCard" {
+			comments[action.Data.Card.ID] = append(comments[action.Data.Card.ID], action)
+		}
+	}
+
+	cards := make([]trelloCard, 0, len(board.Cards))
+	for _, card := range board.Cards {
+		if _, ok := listOrder[card.IDList]; !ok {
+			skip("card", card.Name, "card is in an archived or missing list")
+			continue
+		}
+		cards = append(cards, card)
+	}
+	sort.SliceStable(cards, func(i, j int) bool {
+		if listOrder[cards[i].IDList] != listOrder[cards[j].IDList] {
+			return listOrder[cards[i].IDList] < listOrder[cards[j].IDList]
+		}
+		return cards[i].Pos < cards[j].Pos
+	})
+
+	for _, card := range cards {
+		task := models.ExportedTask{
+			ID:          card.ID,
+			ColumnID:    card.IDList,
+			Title:       strings.TrimSpace(card.Name),
+			Description: strings.TrimSpace(card.Desc + checklistMarkdown(checklists[card.ID])),
+			Deadline:    card.Due,
+			CreatedAt:   now,
+		}
+		if task.Title == "" {
+			task.Title = "Untitled card"
+		}
+		if created, ok := trelloIDTime(card.ID); ok {
+			task.CreatedAt = created
+		}
+		if card.Closed {
+			archivedAt := now
+			if card.DateLastActivity != nil {
+				archivedAt = *card.DateLastActivity
+			}
+			task.ArchivedAt = &archivedAt
+		}
+
+		for _, labelID := range card.IDLabels {
+			if name, ok := labelNames[labelID]; ok {
+				task.Labels = append(task.Labels, name)
+			}
+		}
+
+		for _, memberID := range card.IDMembers {
+			if user, ok := members[memberID]; ok {
+				task.Assignees = append(task.Assignees, user.Email)
+			}
+		}
+
+		cardComments := comments[card.ID]
+		sort.SliceStable(cardComments, func(i, j int) bool {
+			return cardComments[i].Date.Before(cardComments[j].Date)
+		})
+		for _, action := range cardComments {
+			comment := models.ExportedComment{
+				Content:   action.Data.Text,
+				CreatedAt: action.Date,
+			}
+			if user, ok := members[action.IDMemberCreator]; ok {
+				comment.AuthorEmail = user.Email
+			} else {
+				author := action.MemberCreator.FullName
+				if author == "" {
+					author = memberNames[action.IDMemberCreator]
+				}
+				comment.Content = fmt.Sprintf("%s wrote on Trello:\n\n%s", author, action.Data.Text)
+			}
+			task.Comments = append(task.Comments, comment)
+		}
+
+		for _, attachment := range card.Attachments {
+			if attachment.URL == "" {
+				skip("attachment", attachment.Name, "attachment has no URL")
+				continue
+			}
+			exported := models.ExportedAttachment{
+				FileName:  attachment.Name,
+				FileURL:   attachment.URL,
+				CreatedAt: now,
+			}
+			if exported.FileName == "" {
+				exported.FileName = attachment.URL
+			}
+			if attachment.Bytes != nil {
+				exported.FileSize = *attachment.Bytes
+			}
+			if attachment.Date != nil {
+				exported.CreatedAt = *attachment.Date
+			}
+			task.Attachments = append(task.Attachments, exported)
+		}
+
+		export.Tasks = append(export.Tasks, task)
+	}
+
+	return export, skipped
+}
+
+// checklistMarkdown renders checklists as Markdown task lists to append to a
+// task description
+func checklistMarkdown(checklists []trelloChecklist) string {
+	sort.SliceStable(checklists, func(i, j int) bool {
+		return checklists[i].Pos < checklists[j].Pos
+	})
+
+	var b strings.Builder
+	for _, checklist := range checklists {
+		fmt.Fprintf(&b, "\n\n### %s\n", checklist.Name)
+
+		items := checklist.CheckItems
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Pos < items[j].Pos
+		})
+		for _, item := range items {
+			mark := " "
+			if item.State == "complete" {
+				mark = "x"
+			}
+			fmt.Fprintf(&b, "\n- [%s] %s", mark, item.Name)
+		}
+	}
+	return b.String()
+}
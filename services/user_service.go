@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+type UserService interface {
+	GetSettings(ctx context.Context, userID string) (*models.User, error)
+	UpdateSettings(ctx context.Context, userID, reminderLeadTimes, timezone string) (*models.User, error)
+}
+
+type userService struct {
+	userRepo repositories.UserRepository
+}
+
+func NewUserService(userRepo repositories.UserRepository) UserService {
+	return &userService{
+		userRepo: userRepo,
+	}
+}
+
+func (s *userService) GetSettings(ctx context.Context, userID string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetSettings")
+	defer span.End()
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, utils.NewNotFound("user not found")
+	}
+	return user, nil
+}
+
+func (s *userService) UpdateSettings(ctx context.Context, userID, reminderLeadTimes, timezone string) (*models.User, error) {
+	ctx, span := tracing.Start(ctx, "UserService.UpdateSettings")
+	defer span.End()
+
+	user, err := s.GetSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reminderLeadTimes != "" {
+		normalized, err := normalizeReminderLeadTimes(reminderLeadTimes)
+		if err != nil {
+			return nil, err
+		}
+		user.ReminderLeadTimes = normalized
+	}
+
+	if timezone != "" {
+		loc, err := utils.LoadTimezone(timezone)
+		if err != nil {
+			return nil, utils.NewValidation(err.Error())
+		}
+		user.Timezone = loc.String()
+	}
+
+	err = s.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// normalizeReminderLeadTimes validates a comma-separated list of minutes and
+// returns it sorted from largest to smallest without duplicates
+func normalizeReminderLeadTimes(value string) (string, error) {
+	leads, err := models.ParseReminderLeadTimes(value)
+	if err != nil {
+		return "", utils.NewValidation(err.Error())
+	}
+
+	if len(leads) == 0 {
+		return "", utils.NewValidation("at least one reminder lead time is required")
+	}
+
+	parts := make([]string, len(leads))
+	for i, lead := range leads {
+		if lead > MaxReminderLeadTime {
+			return "", utils.NewValidation("reminder lead times cannot exceed 7 days")
+		}
+		parts[i] = strconv.Itoa(int(lead.Minutes()))
+	}
+
+	return strings.Join(parts, ","), nil
+}
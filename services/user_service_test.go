@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+func TestUserService_UpdateSettings(t *testing.T) {
+	tests := []struct {
+		name          string
+		leadTimes     string
+		timezone      string
+		wantLeadTimes string
+		wantTimezone  string
+		wantErr       bool
+	}{
+		{
+			name:          "normalizes lead times",
+			leadTimes:     "30, 1440,30,120",
+			wantLeadTimes: "1440,120,30",
+			wantTimezone:  "UTC",
+		},
+		{
+			name:          "empty value keeps existing settings",
+			leadTimes:     "",
+			wantLeadTimes: models.DefaultReminderLeadTimes,
+			wantTimezone:  "UTC",
+		},
+		{
+			name:          "updates timezone",
+			timezone:      "America/New_York",
+			wantLeadTimes: models.DefaultReminderLeadTimes,
+			wantTimezone:  "America/New_York",
+		},
+		{
+			name:     "rejects unknown timezone",
+			timezone: "Mars/Olympus_Mons",
+			wantErr:  true,
+		},
+		{
+			name:      "rejects invalid values",
+			leadTimes: "abc",
+			wantErr:   true,
+		},
+		{
+			name:      "rejects negative values",
+			leadTimes: "-5",
+			wantErr:   true,
+		},
+		{
+			name:      "rejects lead times longer than a week",
+			leadTimes: "20160",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := newMockUserRepository()
+			userRepo.users["user@example.com"] = &models.User{
+				ID:                "user-1",
+				Email:             "user@example.com",
+				ReminderLeadTimes: models.DefaultReminderLeadTimes,
+				Timezone:          "UTC",
+			}
+			service := NewUserService(userRepo)
+
+			user, err := service.UpdateSettings(context.Background(), "user-1", tt.leadTimes, tt.timezone)
+			if tt.wantErr {
+				var validationErr utils.ErrValidation
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("UpdateSettings() error = %v, want validation error", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("UpdateSettings() unexpected error = %v", err)
+			}
+			if user.ReminderLeadTimes != tt.wantLeadTimes {
+				t.Errorf("ReminderLeadTimes = %s, want %s", user.ReminderLeadTimes, tt.wantLeadTimes)
+			}
+			if user.Timezone != tt.wantTimezone {
+				t.Errorf("Timezone = %s, want %s", user.Timezone, tt.wantTimezone)
+			}
+		})
+	}
+}
+
+func TestUserService_GetSettingsNotFound(t *testing.T) {
+	service := NewUserService(newMockUserRepository())
+
+	_, err := service.GetSettings(context.Background(), "missing")
+
+	var notFoundErr utils.ErrNotFound
+	if !errors.As(err, &notFoundErr) {
+		t.Errorf("GetSettings() error = %v, want not found error", err)
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+)
+
+type WatchService interface {
+	WatchTask(ctx context.Context, taskID, userID string) error
+	UnwatchTask(ctx context.Context, taskID, userID string) error
+	WatchBoard(ctx context.Context, boardID, userID string) error
+	UnwatchBoard(ctx context.Context, boardID, userID string) error
+	FindByUserID(ctx context.Context, userID string) ([]*models.Watch, error)
+	HandleEvent(ctx context.Context, event events.Event)
+}
+
+type watchService struct {
+	watchRepo        repositories.WatchRepository
+	notificationRepo repositories.NotificationRepository
+	taskRepo         repositories.TaskRepository
+	boardRepo        repositories.BoardRepository
+}
+
+func NewWatchService(watchRepo repositories.WatchRepository, notificationRepo repositories.NotificationRepository, taskRepo repositories.TaskRepository, boardRepo repositories.BoardRepository) WatchService {
+	return &watchService{
+		watchRepo:        watchRepo,
+		notificationRepo: notificationRepo,
+		taskRepo:         taskRepo,
+		boardRepo:        boardRepo,
+	}
+}
+
+func (s *watchService) WatchTask(ctx context.Context, taskID, userID string) error {
+	ctx, span := tracing.Start(ctx, "WatchService.WatchTask")
+	defer span.End()
+
+	if err := s.checkTaskAccess(ctx, taskID, userID); err != nil {
+		return err
+	}
+
+	return s.watchRepo.Add(ctx, userID, models.WatchTargetTask, taskID)
+}
+
+func (s *watchService) UnwatchTask(ctx context.Context, taskID, userID string) error {
+	ctx, span := tracing.Start(ctx, "WatchService.UnwatchTask")
+	defer span.End()
+
+	if err := s.watchRepo.Remove(ctx, userID, models.WatchTargetTask, taskID); err != nil {
+		return utils.NewNotFound("you are not watching this task")
+	}
+	return nil
+}
+
+func (s *watchService) WatchBoard(ctx context.Context, boardID, userID string) error {
+	ctx, span := tracing.Start(ctx, "WatchService.WatchBoard")
+	defer span.End()
+
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return utils.NewNotFound("board not found")
+	}
+
+	if !isBoardMember(board, userID) {
+		return utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	return s.watchRepo.Add(ctx, userID, models.WatchTargetBoard, boardID)
+}
+
+func (s *watchService) UnwatchBoard(ctx context.Context, boardID, userID string) error {
+	ctx, span := tracing.Start(ctx, "WatchService.UnwatchBoard")
+	defer span.End()
+
+	if err := s.watchRepo.Remove(ctx, userID, models.WatchTargetBoard, boardID); err != nil {
+		return utils.NewNotFound("you are not watching this board")
+	}
+	return nil
+}
+
+func (s *watchService) FindByUserID(ctx context.Context, userID string) ([]*models.Watch, error) {
+	ctx, span := tracing.Start(ctx, "WatchService.FindByUserID")
+	defer span.End()
+
+	return s.watchRepo.FindByUserID(ctx, userID)
+}
+
+// HandleEvent auto-subscribes creators, commenters and assignees, then notifies
+// everyone watching the task or its board, except the user who made the change
+func (s *watchService) HandleEvent(ctx context.Context, event events.Event) {
+	ctx, span := tracing.Start(ctx, "WatchService.HandleEvent")
+	defer span.End()
+
+	switch event.Type {
+	case events.TaskCreated, events.CommentCreated:
+		s.autoWatch(ctx, event.ActorID, event.TaskID)
+	case events.AssigneeAdded:
+		s.autoWatch(ctx, event.Data["user_id"], event.TaskID)
+	}
+
+	notificationType, message := watchNotification(event)
+	if notificationType == "" {
+		return
+	}
+
+	watcherIDs, err := s.watchRepo.FindWatcherIDs(ctx, event.TaskID, event.BoardID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load watchers", "task_id", event.TaskID, "error", err)
+		return
+	}
+
+	taskID := event.TaskID
+	notifications := make([]*models.Notification, 0, len(watcherIDs))
+	for _, watcherID := range watcherIDs {
+		if watcherID == event.ActorID {
+			continue
+		}
+		notifications = append(notifications, &models.Notification{
+			UserID:  watcherID,
+			Type:    notificationType,
+			TaskID:  &taskID,
+			Message: message,
+		})
+	}
+
+	if err := s.notificationRepo.CreateBatch(ctx, notifications); err != nil {
+		slog.ErrorContext(ctx, "failed to notify watchers", "task_id", event.TaskID, "error", err)
+	}
+}
+
+func (s *watchService) autoWatch(ctx context.Context, userID, taskID string) {
+	if userID == "" || taskID == "" {
+		return
+	}
+	if err := s.watchRepo.Add(ctx, userID, models.WatchTargetTask, taskID); err != nil {
+		slog.ErrorContext(ctx, "failed to subscribe user to task", "watcher_id", userID, "task_id", taskID, "error", err)
+	}
+}
+
+// checkTaskAccess allows the board owner, board members and assignees to watch a task
+func (s *watchService) checkTaskAccess(ctx context.Context, taskID, userID string) error {
+	task, err := s.taskRepo.FindByID(ctx, taskID)
+	if err != nil {
+		return utils.NewNotFound("task not found")
+	}
+
+	if task.Column == nil {
+		return utils.NewNotFound("column not found for task")
+	}
+
+	// The task's preloaded board has no members, so load it with them
+	board, err := s.boardRepo.FindByID(ctx, task.Column.BoardID)
+	if err != nil {
+		return utils.NewNotFound("board not found")
+	}
+
+	if isBoardMember(board, userID) || isTaskAssignee(task, userID) {
+		return nil
+	}
+
+	return utils.NewUnauthorized("you do not have access to this task")
+}
+
+func isBoardMember(board *models.Board, userID string) bool {
+	if board.UserID == userID {
+		return true
+	}
+	for _, member := range board.Members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func isTaskAssignee(task *models.Task, userID string) bool {
+	for _, assignee := range task.Assignees {
+		if assignee.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// watchNotification returns the notification type and message sent to
+// watchers for an event, or an empty type when watchers are not notified
+func watchNotification(event events.Event) (string, string) {
+	title := fmt.Sprintf("%q", event.TaskID)
+	if event.Task != nil {
+		title = fmt.Sprintf("%q", event.Task.Title)
+	}
+
+	switch event.Type {
+	case events.CommentCreated:
+		return models.NotificationTypeTaskComment, fmt.Sprintf("New comment on %s", title)
+	case events.TaskMoved:
+		return models.NotificationTypeTaskMoved, fmt.Sprintf("Task %s moved from %s to %s", title, event.Data["from_column"], event.Data["to_column"])
+	case events.TaskUpdated:
+		change, ok := event.Changed("deadline")
+		if !ok {
+			return "", ""
+		}
+		return models.NotificationTypeDeadlineChanged, fmt.Sprintf("Deadline of %s changed to %s", title, change.NewValue)
+	case events.AttachmentCreated:
+		return models.NotificationTypeTaskAttachment, fmt.Sprintf("%s was attached to %s", event.Data["file_name"], title)
+	default:
+		return "", ""
+	}
+}
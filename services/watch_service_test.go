@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/utils"
+)
+
+type mockWatchRepository struct {
+	watches map[models.Watch]bool
+}
+
+func newMockWatchRepository() *mockWatchRepository {
+	return &mockWatchRepository{
+		watches: make(map[models.Watch]bool),
+	}
+}
+
+func (m *mockWatchRepository) Add(ctx context.Context, userID, targetType, targetID string) error {
+	m.watches[models.Watch{UserID: userID, TargetType: targetType, TargetID: targetID}] = true
+	return nil
+}
+
+func (m *mockWatchRepository) Remove(ctx context.Context, userID, targetType, targetID string) error {
+	key := models.Watch{UserID: userID, TargetType: targetType, TargetID: targetID}
+	if !m.watches[key] {
+		return errors.New("not watching")
+	}
+	delete(m.watches, key)
+	return nil
+}
+
+func (m *mockWatchRepository) FindByUserID(ctx context.Context, userID string) ([]*models.Watch, error) {
+	var watches []*models.Watch
+	for watch := range m.watches {
+		if watch.UserID == userID {
+			w := watch
+			watches = append(watches, &w)
+		}
+	}
+	return watches, nil
+}
+
+func (m *mockWatchRepository) FindWatcherIDs(ctx context.Context, taskID, boardID string) ([]string, error) {
+	seen := make(map[string]bool)
+	var userIDs []string
+	for watch := range m.watches {
+		matches := (watch.TargetType == models.WatchTargetTask && watch.TargetID == taskID) ||
+			(watch.TargetType == models.WatchTargetBoard && watch.TargetID == boardID)
+		if matches && !seen[watch.UserID] {
+			seen[watch.UserID] = true
+			userIDs = append(userIDs, watch.UserID)
+		}
+	}
+	sort.Strings(userIDs)
+	return userIDs, nil
+}
+
+type mockNotificationRepository struct {
+	notifications []*models.Notification
+}
+
+func (m *mockNotificationRepository) CreateBatch(ctx context.Context, notifications []*models.Notification) error {
+	m.notifications = append(m.notifications, notifications...)
+	return nil
+}
+
+func setupWatchService() (WatchService, *mockWatchRepository, *mockNotificationRepository, *models.Task) {
+	taskRepo := newMockTaskRepository()
+	task := setupTestTask("col-1")
+	task.Assignees = []models.TaskAssignee{{TaskID: task.ID, UserID: "bob"}}
+	taskRepo.tasks[task.ID] = task
+
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards["board123"] = &models.Board{
+		ID:      "board123",
+		UserID:  "user123",
+		Members: []models.Member{{BoardID: "board123", UserID: "carol"}},
+	}
+
+	watchRepo := newMockWatchRepository()
+	notificationRepo := &mockNotificationRepository{}
+	return NewWatchService(watchRepo, notificationRepo, taskRepo, boardRepo), watchRepo, notificationRepo, task
+}
+
+func TestWatchService_WatchTask(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		wantErr bool
+	}{
+		{name: "board owner can watch", userID: "user123"},
+		{name: "assignee can watch", userID: "bob"},
+		{name: "board member can watch", userID: "carol"},
+		{name: "outsider cannot watch", userID: "mallory", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, watchRepo, _, task := setupWatchService()
+
+			err := service.WatchTask(context.Background(), task.ID, tt.userID)
+			if tt.wantErr {
+				var unauthorizedErr utils.ErrUnauthorized
+				if !errors.As(err, &unauthorizedErr) {
+					t.Fatalf("WatchTask() error = %v, want unauthorized", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("WatchTask() unexpected error = %v", err)
+			}
+			if !watchRepo.watches[models.Watch{UserID: tt.userID, TargetType: models.WatchTargetTask, TargetID: task.ID}] {
+				t.Error("expected watch to be stored")
+			}
+		})
+	}
+}
+
+func TestWatchService_WatchBoard(t *testing.T) {
+	service, _, _, _ := setupWatchService()
+	ctx := context.Background()
+
+	if err := service.WatchBoard(ctx, "board123", "carol"); err != nil {
+		t.Errorf("WatchBoard() member unexpected error = %v", err)
+	}
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if err := service.WatchBoard(ctx, "board123", "mallory"); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("WatchBoard() outsider error = %v, want unauthorized", err)
+	}
+
+	var notFoundErr utils.ErrNotFound
+	if err := service.WatchBoard(ctx, "missing", "carol"); !errors.As(err, &notFoundErr) {
+		t.Errorf("WatchBoard() missing board error = %v, want not found", err)
+	}
+}
+
+func TestWatchService_UnwatchTaskNotWatching(t *testing.T) {
+	service, _, _, task := setupWatchService()
+
+	err := service.UnwatchTask(context.Background(), task.ID, "user123")
+
+	var notFoundErr utils.ErrNotFound
+	if !errors.As(err, &notFoundErr) {
+		t.Errorf("UnwatchTask() error = %v, want not found", err)
+	}
+}
+
+func TestWatchService_HandleEventAutoSubscribes(t *testing.T) {
+	service, watchRepo, _, task := setupWatchService()
+	ctx := context.Background()
+
+	service.HandleEvent(ctx, events.ForTask(events.TaskCreated, "user123", task))
+
+	assigned := events.ForTask(events.AssigneeAdded, "user123", task)
+	assigned.Data["user_id"] = "bob"
+	service.HandleEvent(ctx, assigned)
+
+	service.HandleEvent(ctx, events.ForTask(events.CommentCreated, "carol", task))
+
+	watchers, _ := watchRepo.FindWatcherIDs(ctx, task.ID, "")
+	want := []string{"bob", "carol", "user123"}
+	if len(watchers) != len(want) {
+		t.Fatalf("watchers = %v, want %v", watchers, want)
+	}
+	for i := range want {
+		if watchers[i] != want[i] {
+			t.Fatalf("watchers = %v, want %v", watchers, want)
+		}
+	}
+}
+
+func TestWatchService_HandleEventNotifiesWatchersExceptActor(t *testing.T) {
+	service, watchRepo, notificationRepo, task := setupWatchService()
+	ctx := context.Background()
+
+	watchRepo.Add(ctx, "user123", models.WatchTargetTask, task.ID)
+	watchRepo.Add(ctx, "bob", models.WatchTargetTask, task.ID)
+	watchRepo.Add(ctx, "carol", models.WatchTargetBoard, "board123")
+
+	moved := events.ForTask(events.TaskMoved, "user123", task)
+	moved.Data["from_column"] = "To Do"
+	moved.Data["to_column"] = "Done"
+	service.HandleEvent(ctx, moved)
+
+	if len(notificationRepo.notifications) != 2 {
+		t.Fatalf("expected 2 notifications, got %d", len(notificationRepo.notifications))
+	}
+	for _, notification := range notificationRepo.notifications {
+		if notification.UserID == "user123" {
+			t.Error("actor should not be notified")
+		}
+		if notification.Type != models.NotificationTypeTaskMoved {
+			t.Errorf("notification type = %s, want %s", notification.Type, models.NotificationTypeTaskMoved)
+		}
+		if notification.Message != `Task "Test Task" moved from To Do to Done` {
+			t.Errorf("unexpected message %q", notification.Message)
+		}
+	}
+}
+
+func TestWatchService_HandleEventIgnoresQuietEvents(t *testing.T) {
+	service, watchRepo, notificationRepo, task := setupWatchService()
+	ctx := context.Background()
+
+	watchRepo.Add(ctx, "bob", models.WatchTargetTask, task.ID)
+	service.HandleEvent(ctx, events.ForTask(events.TaskUpdated, "user123", task))
+
+	if len(notificationRepo.notifications) != 0 {
+		t.Errorf("expected no notifications, got %d", len(notificationRepo.notifications))
+	}
+}
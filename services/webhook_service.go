@@ -0,0 +1,630 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"kanban-backend/events"
+	"kanban-backend/metrics"
+	"kanban-backend/models"
+	"kanban-backend/repositories"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// webhookMaxAttempts is how often a delivery is tried before it fails for good
+	webhookMaxAttempts = 8
+	// webhookRetryBase is the delay before the first retry; it doubles with
+	// every further attempt up to webhookRetryMax
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = 6 * time.Hour
+	// webhookDisableAfter is how many failed attempts in a row disable a webhook
+	webhookDisableAfter = 10
+	// webhookDeliveryLease keeps a claimed delivery from being sent twice. It
+	// covers a single send, so it is stretched if the client's timeout is long.
+	webhookDeliveryLease = 2 * time.Minute
+	webhookDeliveryBatch = 100
+	webhookDeliveryLog   = 50
+	webhookMinSecret     = 16
+	// webhookDeliveryRetrying labels failed attempts that will be retried in
+	// the delivery metrics
+	webhookDeliveryRetrying = "retrying"
+)
+
+// errWebhookTargetBlocked is returned when a webhook would reach a loopback,
+// link-local, private or otherwise internal address
+var errWebhookTargetBlocked = errors.New("webhook target is not a public address")
+
+// WebhookSignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of
+// the request body, keyed with the webhook's secret
+const WebhookSignatureHeader = "X-Signature"
+
+// CreatedWebhook carries the signing secret of a new webhook. The secret
+// cannot be retrieved again later.
+type CreatedWebhook struct {
+	*models.Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhookPayload is the JSON body POSTed to webhooks
+type WebhookPayload struct {
+	Event      string            `json:"event"`
+	OccurredAt time.Time         `json:"occurred_at"`
+	BoardID    string            `json:"board_id"`
+	TaskID     string            `json:"task_id"`
+	ActorID    string            `json:"actor_id"`
+	Task       *WebhookTask      `json:"task,omitempty"`
+	Changes    []WebhookChange   `json:"changes,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+}
+
+// WebhookTask is the task snapshot sent with an event
+type WebhookTask struct {
+	ID             string     `json:"id"`
+	Key            string     `json:"key"`
+	ColumnID       string     `json:"column_id"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Deadline       *time.Time `json:"deadline,omitempty"`
+	DeadlineAllDay bool       `json:"deadline_all_day"`
+	SprintID       *string    `json:"sprint_id,omitempty"`
+	StoryPoints    *int       `json:"story_points,omitempty"`
+	ArchivedAt     *time.Time `json:"archived_at,omitempty"`
+}
+
+type WebhookChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+type WebhookService interface {
+	Create(ctx context.Context, boardID, userID, targetURL, secret string, eventTypes []string) (*CreatedWebhook, error)
+	FindByBoardID(ctx context.Context, boardID, userID string) ([]*models.Webhook, error)
+	Update(ctx context.Context, webhookID, userID, targetURL string, eventTypes []string, active *bool) (*models.Webhook, error)
+	Delete(ctx context.Context, webhookID, userID string) error
+	FindDeliveries(ctx context.Context, webhookID, userID string) ([]*models.WebhookDelivery, error)
+	HandleEvent(ctx context.Context, event events.Event)
+	DeliverDue(ctx context.Context, now time.Time) (int, error)
+}
+
+type webhookService struct {
+	webhookRepo         repositories.WebhookRepository
+	boardRepo           repositories.BoardRepository
+	client              *http.Client
+	allowPrivateTargets bool
+}
+
+// NewWebhookService delivers through client, which should come from
+// NewWebhookClient. Unless allowPrivateTargets is set, which is only meant
+// for local development, webhooks cannot point at internal addresses.
+func NewWebhookService(webhookRepo repositories.WebhookRepository, boardRepo repositories.BoardRepository, client *http.Client, allowPrivateTargets bool) WebhookService {
+	return &webhookService{
+		webhookRepo:         webhookRepo,
+		boardRepo:           boardRepo,
+		client:              client,
+		allowPrivateTargets: allowPrivateTargets,
+	}
+}
+
+// NewWebhookClient returns the HTTP client for webhook deliveries. It does
+// not follow redirects or use a proxy, and unless allowPrivateTargets is set
+// it refuses to connect to internal addresses. The check runs on the address
+// actually dialed, so DNS rebinding after the URL was validated cannot get
+// around it.
+func NewWebhookClient(timeout time.Duration, allowPrivateTargets bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	if !allowPrivateTargets {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !isPublicAddress(addrPort.Addr()) {
+				return errWebhookTargetBlocked
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// A redirect could lead into the internal network, so the 3xx
+		// response itself counts as the receiver's answer
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Create subscribes a URL to the board's events. Without event types the
+// webhook receives every event; without a secret one is generated.
+func (s *webhookService) Create(ctx context.Context, boardID, userID, targetURL, secret string, eventTypes []string) (*CreatedWebhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Create")
+	defer span.End()
+
+	if err := s.checkBoardAccess(ctx, boardID, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateURL(ctx, targetURL); err != nil {
+		return nil, err
+	}
+
+	eventTypes, err := normalizeWebhookEventTypes(eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			return nil, err
+		}
+	} else if len(secret) < webhookMinSecret {
+		return nil, utils.NewValidation(fmt.Sprintf("secret must be at least %d characters", webhookMinSecret))
+	}
+
+	webhook := &models.Webhook{
+		BoardID:    boardID,
+		URL:        targetURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+	}
+	if err := s.webhookRepo.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return &CreatedWebhook{Webhook: webhook, Secret: secret}, nil
+}
+
+func (s *webhookService) FindByBoardID(ctx context.Context, boardID, userID string) ([]*models.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.FindByBoardID")
+	defer span.End()
+
+	if err := s.checkBoardAccess(ctx, boardID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.webhookRepo.FindByBoardID(ctx, boardID)
+}
+
+// Update changes the URL and event types when given. Re-activating a webhook
+// clears its failure count.
+func (s *webhookService) Update(ctx context.Context, webhookID, userID, targetURL string, eventTypes []string, active *bool) (*models.Webhook, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.Update")
+	defer span.End()
+
+	webhook, err := s.findWebhook(ctx, webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if targetURL != "" {
+		if err := s.validateURL(ctx, targetURL); err != nil {
+			return nil, err
+		}
+		webhook.URL = targetURL
+	}
+
+	if eventTypes != nil {
+		if webhook.EventTypes, err = normalizeWebhookEventTypes(eventTypes); err != nil {
+			return nil, err
+		}
+	}
+
+	if active != nil && *active != webhook.Active {
+		webhook.Active = *active
+		if webhook.Active {
+			webhook.ConsecutiveFailures = 0
+			webhook.DisabledAt = nil
+		}
+	}
+
+	if err := s.webhookRepo.Update(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *webhookService) Delete(ctx context.Context, webhookID, userID string) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.Delete")
+	defer span.End()
+
+	if _, err := s.findWebhook(ctx, webhookID, userID); err != nil {
+		return err
+	}
+
+	return s.webhookRepo.Delete(ctx, webhookID)
+}
+
+// FindDeliveries returns the webhook's recent delivery log, newest first
+func (s *webhookService) FindDeliveries(ctx context.Context, webhookID, userID string) ([]*models.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.FindDeliveries")
+	defer span.End()
+
+	if _, err := s.findWebhook(ctx, webhookID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.webhookRepo.FindDeliveries(ctx, webhookID, webhookDeliveryLog)
+}
+
+// HandleEvent queues a delivery for every active webhook on the event's board
+// that subscribes to its type. The deliveries are sent by DeliverDue.
+func (s *webhookService) HandleEvent(ctx context.Context, event events.Event) {
+	ctx, span := tracing.Start(ctx, "WebhookService.HandleEvent")
+	defer span.End()
+
+	if event.BoardID == "" {
+		return
+	}
+
+	webhooks, err := s.webhookRepo.FindActiveByBoardID(ctx, event.BoardID)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to load webhooks", "board_id", event.BoardID, "error", err)
+		return
+	}
+
+	var subscribed []*models.Webhook
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(event.Type) {
+			subscribed = append(subscribed, webhook)
+		}
+	}
+	if len(subscribed) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(webhookPayload(event))
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to encode webhook payload", "event", event.Type, "error", err)
+		return
+	}
+
+	dueAt := event.OccurredAt
+	if dueAt.IsZero() {
+		dueAt = time.Now()
+	}
+
+	deliveries := make([]*models.WebhookDelivery, 0, len(subscribed))
+	for _, webhook := range subscribed {
+		deliveries = append(deliveries, &models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        models.DeliveryStatusPending,
+			NextAttemptAt: &dueAt,
+		})
+	}
+
+	if err := s.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		slog.ErrorContext(ctx, "failed to queue webhook deliveries", "event", event.Type, "error", err)
+	}
+}
+
+// DeliverDue sends every pending delivery whose next attempt is due. Failed
+// attempts are retried with exponential backoff; a webhook is disabled after
+// too many failures in a row. It returns the number of attempts made.
+//
+// Each delivery is claimed right before it is sent, at now plus the time the
+// batch has taken so far, so its lease covers that one send however long the
+// batch runs. Once the batch has run for a whole lease the rest is left to the
+// next tick, which reads it afresh.
+func (s *webhookService) DeliverDue(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.DeliverDue")
+	defer span.End()
+
+	deliveries, err := s.webhookRepo.FindDueDeliveries(ctx, now, webhookDeliveryBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	lease := s.deliveryLease()
+	started := time.Now()
+	attempted := 0
+	for _, delivery := range deliveries {
+		elapsed := time.Since(started)
+		if elapsed >= lease {
+			break
+		}
+
+		at := now.Add(elapsed)
+		claimed, err := s.webhookRepo.ClaimDelivery(ctx, delivery, at.Add(lease))
+		if err != nil {
+			return attempted, err
+		}
+		if !claimed {
+			continue
+		}
+
+		if delivery.Webhook == nil || !delivery.Webhook.Active {
+			delivery.Status = models.DeliveryStatusFailed
+			delivery.NextAttemptAt = nil
+			delivery.Error = "webhook is disabled"
+		} else {
+			attempted++
+			s.attempt(ctx, delivery, at)
+		}
+
+		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return attempted, err
+		}
+	}
+
+	return attempted, nil
+}
+
+// deliveryLease is how long a claim lasts: webhookDeliveryLease, or twice the
+// client's timeout if that is longer
+func (s *webhookService) deliveryLease() time.Duration {
+	return max(webhookDeliveryLease, 2*s.client.Timeout)
+}
+
+// attempt sends the delivery once and records the outcome on it
+func (s *webhookService) attempt(ctx context.Context, delivery *models.WebhookDelivery, now time.Time) {
+	delivery.Attempts++
+
+	start := time.Now()
+	status, sendErr := s.send(ctx, delivery)
+	duration := time.Since(start)
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	if sendErr == nil {
+		delivery.Status = models.DeliveryStatusSucceeded
+		delivery.Error = ""
+		delivery.NextAttemptAt = nil
+		delivery.DeliveredAt = &now
+		if err := s.webhookRepo.RecordSuccess(ctx, delivery.WebhookID); err != nil {
+			slog.ErrorContext(ctx, "failed to reset webhook failures", "webhook_id", delivery.WebhookID, "error", err)
+		}
+		metrics.ObserveWebhookDelivery(models.DeliveryStatusSucceeded, duration)
+		return
+	}
+
+	delivery.Error = sendErr.Error()
+	disabled, err := s.webhookRepo.RecordFailure(ctx, delivery.WebhookID, webhookDisableAfter, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to record webhook failure", "webhook_id", delivery.WebhookID, "error", err)
+	}
+	if disabled {
+		slog.WarnContext(ctx, "disabled webhook after repeated failures", "webhook_id", delivery.WebhookID, "failures", webhookDisableAfter)
+	}
+
+	if disabled || delivery.Attempts >= webhookMaxAttempts {
+		delivery.Status = models.DeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+		metrics.ObserveWebhookDelivery(models.DeliveryStatusFailed, duration)
+		return
+	}
+
+	next := now.Add(webhookRetryDelay(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+	metrics.ObserveWebhookDelivery(webhookDeliveryRetrying, duration)
+}
+
+// send POSTs the signed payload and returns the response status, if any. The
+// request carries a traceparent header so receivers can join the trace.
+func (s *webhookService) send(ctx context.Context, delivery *models.WebhookDelivery) (status int, err error) {
+	ctx, span := tracing.Start(ctx, "POST webhook",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(http.MethodPost),
+			attribute.String("webhook.id", delivery.WebhookID),
+			attribute.String("webhook.event", delivery.EventType),
+		),
+	)
+	defer func() {
+		if status != 0 {
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	span.SetAttributes(semconv.ServerAddress(req.URL.Hostname()))
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "kanban-webhooks")
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Delivery", delivery.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(delivery.Webhook.Secret, delivery.Payload))
+	tracing.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("receiver responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (s *webhookService) findWebhook(ctx context.Context, webhookID, userID string) (*models.Webhook, error) {
+	webhook, err := s.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, utils.NewNotFound("webhook not found")
+	}
+
+	if err := s.checkBoardAccess(ctx, webhook.BoardID, userID); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *webhookService) checkBoardAccess(ctx context.Context, boardID, userID string) error {
+	board, err := s.boardRepo.FindByID(ctx, boardID)
+	if err != nil {
+		return utils.NewNotFound("board not found")
+	}
+
+	if board.UserID != userID {
+		return utils.NewUnauthorized("you do not have access to this board")
+	}
+
+	return nil
+}
+
+// SignWebhookPayload returns the X-Signature header value for a payload
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookRetryDelay is the backoff after the given number of failed attempts
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookRetryMax {
+			return webhookRetryMax
+		}
+	}
+	return delay
+}
+
+func webhookPayload(event events.Event) WebhookPayload {
+	payload := WebhookPayload{
+		Event:      event.Type,
+		OccurredAt: event.OccurredAt,
+		BoardID:    event.BoardID,
+		TaskID:     event.TaskID,
+		ActorID:    event.ActorID,
+		Data:       event.Data,
+	}
+
+	if task := event.Task; task != nil {
+		payload.Task = &WebhookTask{
+			ID:             task.ID,
+			Key:            task.Key,
+			ColumnID:       task.ColumnID,
+			Title:          task.Title,
+			Description:    task.Description,
+			Deadline:       task.Deadline,
+			DeadlineAllDay: task.DeadlineAllDay,
+			SprintID:       task.SprintID,
+			StoryPoints:    task.StoryPoints,
+			ArchivedAt:     task.ArchivedAt,
+		}
+	}
+
+	for _, change := range event.Changes {
+		payload.Changes = append(payload.Changes, WebhookChange{
+			Field:    change.Field,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+		})
+	}
+
+	return payload
+}
+
+// validateURL accepts absolute http and https URLs whose host is, or
+// resolves only to, public addresses
+func (s *webhookService) validateURL(ctx context.Context, targetURL string) error {
+	parsed, err := url.Parse(targetURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return utils.NewValidation("url must be an absolute http or https URL")
+	}
+	if s.allowPrivateTargets {
+		return nil
+	}
+
+	host := parsed.Hostname()
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else if addrs, err = net.DefaultResolver.LookupNetIP(ctx, "ip", host); err != nil {
+		return utils.NewValidation("url host could not be resolved")
+	}
+
+	for _, addr := range addrs {
+		if !isPublicAddress(addr) {
+			return utils.NewValidation("url must not point at a loopback, link-local or private address")
+		}
+	}
+	return nil
+}
+
+// isPublicAddress reports whether addr may be reached by webhooks
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified()
+}
+
+// normalizeWebhookEventTypes validates and de-duplicates event types; an empty
+// list subscribes to every event type
+func normalizeWebhookEventTypes(eventTypes []string) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return append([]string(nil), events.Types...), nil
+	}
+
+	seen := make(map[string]bool, len(eventTypes))
+	normalized := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !events.IsValidType(eventType) {
+			return nil, utils.NewValidation(fmt.Sprintf("unknown event type %q", eventType))
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			normalized = append(normalized, eventType)
+		}
+	}
+	return normalized, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,472 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"kanban-backend/events"
+	"kanban-backend/models"
+	"kanban-backend/tracing"
+	"kanban-backend/utils"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type mockWebhookRepository struct {
+	webhooks   map[string]*models.Webhook
+	deliveries []*models.WebhookDelivery
+	leases     []time.Time
+}
+
+func newMockWebhookRepository() *mockWebhookRepository {
+	return &mockWebhookRepository{webhooks: make(map[string]*models.Webhook)}
+}
+
+func (m *mockWebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	if webhook.ID == "" {
+		webhook.ID = fmt.Sprintf("webhook-%d", len(m.webhooks)+1)
+	}
+	m.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *mockWebhookRepository) FindByID(ctx context.Context, id string) (*models.Webhook, error) {
+	webhook, exists := m.webhooks[id]
+	if !exists {
+		return nil, errors.New("webhook not found")
+	}
+	return webhook, nil
+}
+
+func (m *mockWebhookRepository) FindByBoardID(ctx context.Context, boardID string) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	for _, webhook := range m.webhooks {
+		if webhook.BoardID == boardID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (m *mockWebhookRepository) FindActiveByBoardID(ctx context.Context, boardID string) ([]*models.Webhook, error) {
+	var webhooks []*models.Webhook
+	for _, webhook := range m.webhooks {
+		if webhook.BoardID == boardID && webhook.Active {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (m *mockWebhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	m.webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *mockWebhookRepository) Delete(ctx context.Context, id string) error {
+	delete(m.webhooks, id)
+	return nil
+}
+
+func (m *mockWebhookRepository) RecordSuccess(ctx context.Context, id string) error {
+	m.webhooks[id].ConsecutiveFailures = 0
+	return nil
+}
+
+func (m *mockWebhookRepository) RecordFailure(ctx context.Context, id string, disableAfter int, now time.Time) (bool, error) {
+	webhook := m.webhooks[id]
+	webhook.ConsecutiveFailures++
+	if webhook.Active && webhook.ConsecutiveFailures >= disableAfter {
+		webhook.Active = false
+		webhook.DisabledAt = &now
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m *mockWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	for _, delivery := range deliveries {
+		delivery.ID = fmt.Sprintf("delivery-%d", len(m.deliveries)+1)
+		m.deliveries = append(m.deliveries, delivery)
+	}
+	return nil
+}
+
+func (m *mockWebhookRepository) FindDeliveries(ctx context.Context, webhookID string, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (m *mockWebhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	for _, delivery := range m.deliveries {
+		if delivery.Status == models.DeliveryStatusPending && !delivery.NextAttemptAt.After(now) {
+			delivery.Webhook = m.webhooks[delivery.WebhookID]
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return deliveries, nil
+}
+
+func (m *mockWebhookRepository) ClaimDelivery(ctx context.Context, delivery *models.WebhookDelivery, leaseUntil time.Time) (bool, error) {
+	m.leases = append(m.leases, leaseUntil)
+	delivery.NextAttemptAt = &leaseUntil
+	return true, nil
+}
+
+func (m *mockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return nil
+}
+
+// webhookReceiver is a local endpoint that checks signatures and records the
+// payloads it receives
+type webhookReceiver struct {
+	mu       sync.Mutex
+	secret   string
+	status   int
+	delay    time.Duration
+	payloads []WebhookPayload
+	badSigs  int
+	// traceparents are the trace-context headers of the requests received
+	traceparents []string
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	time.Sleep(r.delay)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if req.Header.Get(WebhookSignatureHeader) != SignWebhookPayload(r.secret, body) {
+		r.badSigs++
+	}
+	r.traceparents = append(r.traceparents, req.Header.Get("traceparent"))
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err == nil {
+		r.payloads = append(r.payloads, payload)
+	}
+	w.WriteHeader(r.status)
+}
+
+func setupWebhookService(t *testing.T, receiverStatus int) (WebhookService, *mockWebhookRepository, *webhookReceiver, string) {
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards["board123"] = &models.Board{ID: "board123", UserID: "user123"}
+
+	receiver := &webhookReceiver{secret: "0123456789abcdef", status: receiverStatus}
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	webhookRepo := newMockWebhookRepository()
+	return NewWebhookService(webhookRepo, boardRepo, server.Client(), true), webhookRepo, receiver, server.URL
+}
+
+// closeTo reports whether got is want, give or take the few milliseconds a
+// delivery batch takes before reaching the attempt
+func closeTo(got, want time.Time) bool {
+	return !got.Before(want) && got.Sub(want) < time.Second
+}
+
+func TestWebhookService_LeasesEachDeliveryWhenSent(t *testing.T) {
+	service, webhookRepo, receiver, receiverURL := setupWebhookService(t, http.StatusNoContent)
+	receiver.delay = 50 * time.Millisecond
+	ctx := context.Background()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	if _, err := service.Create(ctx, "board123", "user123", receiverURL, receiver.secret, nil); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	service.HandleEvent(ctx, webhookTestEvent(events.TaskCreated, now))
+	service.HandleEvent(ctx, webhookTestEvent(events.TaskUpdated, now))
+
+	if _, err := service.DeliverDue(ctx, now); err != nil {
+		t.Fatalf("DeliverDue() unexpected error = %v", err)
+	}
+	if len(webhookRepo.leases) != 2 {
+		t.Fatalf("claimed %d deliveries, want 2", len(webhookRepo.leases))
+	}
+	if gap := webhookRepo.leases[1].Sub(webhookRepo.leases[0]); gap < receiver.delay {
+		t.Errorf("second lease starts %v after the first, want at least the %v the first send took", gap, receiver.delay)
+	}
+	second := webhookRepo.deliveries[1]
+	if second.DeliveredAt == nil || !second.DeliveredAt.After(now) {
+		t.Errorf("second delivery recorded at %v, want its own send time after %v", second.DeliveredAt, now)
+	}
+}
+
+func webhookTestEvent(eventType string, at time.Time) events.Event {
+	task := &models.Task{ID: "task-1", ColumnID: "col-1", Title: "Ship it", Column: &models.Column{ID: "col-1", BoardID: "board123"}}
+	event := events.ForTask(eventType, "user123", task)
+	event.OccurredAt = at
+	return event
+}
+
+func TestWebhookService_DeliversSignedEvents(t *testing.T) {
+	service, webhookRepo, receiver, receiverURL := setupWebhookService(t, http.StatusNoContent)
+	ctx := context.Background()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	created, err := service.Create(ctx, "board123", "user123", receiverURL, receiver.secret, []string{events.TaskCreated})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if _, err := service.Create(ctx, "board123", "user123", receiverURL, receiver.secret, []string{events.CommentCreated}); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	service.HandleEvent(ctx, webhookTestEvent(events.TaskCreated, now))
+	if len(webhookRepo.deliveries) != 1 {
+		t.Fatalf("queued %d deliveries, want 1", len(webhookRepo.deliveries))
+	}
+
+	attempted, err := service.DeliverDue(ctx, now)
+	if err != nil {
+		t.Fatalf("DeliverDue() unexpected error = %v", err)
+	}
+	if attempted != 1 {
+		t.Errorf("attempted = %d, want 1", attempted)
+	}
+
+	delivery := webhookRepo.deliveries[0]
+	if delivery.Status != models.DeliveryStatusSucceeded || delivery.ResponseStatus == nil || *delivery.ResponseStatus != http.StatusNoContent {
+		t.Errorf("unexpected delivery %+v", delivery)
+	}
+	if receiver.badSigs != 0 {
+		t.Errorf("receiver saw %d bad signatures", receiver.badSigs)
+	}
+	if len(receiver.payloads) != 1 {
+		t.Fatalf("receiver got %d payloads, want 1", len(receiver.payloads))
+	}
+	payload := receiver.payloads[0]
+	if payload.Event != events.TaskCreated || payload.BoardID != "board123" || payload.Task == nil || payload.Task.Title != "Ship it" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+
+	if attempted, _ := service.DeliverDue(ctx, now.Add(time.Hour)); attempted != 0 {
+		t.Errorf("succeeded deliveries must not be resent, attempted = %d", attempted)
+	}
+
+	deliveries, err := service.FindDeliveries(ctx, created.ID, "user123")
+	if err != nil || len(deliveries) != 1 {
+		t.Errorf("FindDeliveries() = %d deliveries, %v", len(deliveries), err)
+	}
+}
+
+func TestWebhookService_PropagatesTraceContext(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(previous)
+
+	service, _, receiver, receiverURL := setupWebhookService(t, http.StatusNoContent)
+	now := time.Now()
+	ctx, span := tracing.Start(context.Background(), "job webhook-delivery")
+	defer span.End()
+
+	if _, err := service.Create(ctx, "board123", "user123", receiverURL, receiver.secret, []string{events.TaskCreated}); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	service.HandleEvent(ctx, webhookTestEvent(events.TaskCreated, now))
+	if _, err := service.DeliverDue(ctx, now); err != nil {
+		t.Fatalf("DeliverDue() unexpected error = %v", err)
+	}
+
+	if len(receiver.traceparents) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(receiver.traceparents))
+	}
+	traceID := span.SpanContext().TraceID().String()
+	if !strings.HasPrefix(receiver.traceparents[0], "00-"+traceID+"-") {
+		t.Errorf("traceparent = %q, want trace %s", receiver.traceparents[0], traceID)
+	}
+}
+
+func TestWebhookService_RetriesWithBackoffAndDisables(t *testing.T) {
+	service, webhookRepo, receiver, receiverURL := setupWebhookService(t, http.StatusInternalServerError)
+	ctx := context.Background()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	created, err := service.Create(ctx, "board123", "user123", receiverURL, receiver.secret, nil)
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	service.HandleEvent(ctx, webhookTestEvent(events.TaskMoved, now))
+	delivery := webhookRepo.deliveries[0]
+
+	if _, err := service.DeliverDue(ctx, now); err != nil {
+		t.Fatalf("DeliverDue() unexpected error = %v", err)
+	}
+	if delivery.Status != models.DeliveryStatusPending || delivery.Attempts != 1 || *delivery.ResponseStatus != http.StatusInternalServerError {
+		t.Fatalf("unexpected delivery after first failure %+v", delivery)
+	}
+	if want := now.Add(30 * time.Second); !closeTo(*delivery.NextAttemptAt, want) {
+		t.Errorf("next attempt = %v, want %v", delivery.NextAttemptAt, want)
+	}
+
+	if attempted, _ := service.DeliverDue(ctx, now.Add(10*time.Second)); attempted != 0 {
+		t.Errorf("retried before the backoff passed, attempted = %d", attempted)
+	}
+
+	at := *delivery.NextAttemptAt
+	if _, err := service.DeliverDue(ctx, at); err != nil {
+		t.Fatalf("DeliverDue() unexpected error = %v", err)
+	}
+	if want := at.Add(time.Minute); delivery.Attempts != 2 || !closeTo(*delivery.NextAttemptAt, want) {
+		t.Errorf("after second failure attempts = %d, next = %v, want 2 and %v", delivery.Attempts, delivery.NextAttemptAt, want)
+	}
+
+	for delivery.Status == models.DeliveryStatusPending {
+		if _, err := service.DeliverDue(ctx, *delivery.NextAttemptAt); err != nil {
+			t.Fatalf("DeliverDue() unexpected error = %v", err)
+		}
+	}
+	if delivery.Status != models.DeliveryStatusFailed || delivery.Attempts != webhookMaxAttempts {
+		t.Errorf("delivery should fail after %d attempts, got %+v", webhookMaxAttempts, delivery)
+	}
+
+	webhook := webhookRepo.webhooks[created.ID]
+	if !webhook.Active {
+		t.Fatalf("webhook disabled after only %d failures", webhook.ConsecutiveFailures)
+	}
+
+	later := now.Add(24 * time.Hour)
+	service.HandleEvent(ctx, webhookTestEvent(events.TaskUpdated, later))
+	retry := webhookRepo.deliveries[1]
+	for retry.Status == models.DeliveryStatusPending {
+		if _, err := service.DeliverDue(ctx, *retry.NextAttemptAt); err != nil {
+			t.Fatalf("DeliverDue() unexpected error = %v", err)
+		}
+	}
+	if webhook.Active || webhook.DisabledAt == nil || webhook.ConsecutiveFailures != webhookDisableAfter {
+		t.Errorf("webhook should be disabled after %d failures in a row, got %+v", webhookDisableAfter, webhook)
+	}
+	if retry.Status != models.DeliveryStatusFailed {
+		t.Errorf("delivery of a disabled webhook should fail, got %s", retry.Status)
+	}
+
+	service.HandleEvent(ctx, webhookTestEvent(events.TaskUpdated, later))
+	if len(webhookRepo.deliveries) != 2 {
+		t.Errorf("disabled webhook got a new delivery")
+	}
+
+	active := true
+	reenabled, err := service.Update(ctx, created.ID, "user123", "", nil, &active)
+	if err != nil {
+		t.Fatalf("Update() unexpected error = %v", err)
+	}
+	if !reenabled.Active || reenabled.ConsecutiveFailures != 0 || reenabled.DisabledAt != nil {
+		t.Errorf("re-enabled webhook should start over, got %+v", reenabled)
+	}
+}
+
+func TestWebhookService_CreateValidates(t *testing.T) {
+	service, _, _, receiverURL := setupWebhookService(t, http.StatusOK)
+	ctx := context.Background()
+
+	var validationErr utils.ErrValidation
+	for name, create := range map[string]func() error{
+		"relative url": func() error {
+			_, err := service.Create(ctx, "board123", "user123", "/hook", "", nil)
+			return err
+		},
+		"unknown event type": func() error {
+			_, err := service.Create(ctx, "board123", "user123", receiverURL, "", []string{"board.exploded"})
+			return err
+		},
+		"short secret": func() error {
+			_, err := service.Create(ctx, "board123", "user123", receiverURL, "short", nil)
+			return err
+		},
+	} {
+		if err := create(); !errors.As(err, &validationErr) {
+			t.Errorf("%s: Create() error = %v, want validation error", name, err)
+		}
+	}
+
+	var unauthorizedErr utils.ErrUnauthorized
+	if _, err := service.Create(ctx, "board123", "someone-else", receiverURL, "", nil); !errors.As(err, &unauthorizedErr) {
+		t.Errorf("Create() error = %v, want unauthorized", err)
+	}
+
+	created, err := service.Create(ctx, "board123", "user123", receiverURL, "", nil)
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if len(created.Secret) < webhookMinSecret || len(created.EventTypes) != len(events.Types) {
+		t.Errorf("expected a generated secret and every event type, got %+v", created)
+	}
+}
+
+func TestWebhookService_RejectsInternalTargets(t *testing.T) {
+	boardRepo := newMockBoardRepository()
+	boardRepo.boards["board123"] = &models.Board{ID: "board123", UserID: "user123"}
+	service := NewWebhookService(newMockWebhookRepository(), boardRepo, NewWebhookClient(time.Second, false), false)
+	ctx := context.Background()
+
+	var validationErr utils.ErrValidation
+	for _, target := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://10.0.0.5/hook",
+		"http://172.16.3.4/hook",
+		"http://192.168.1.10/hook",
+		"http://0.0.0.0/hook",
+		"http://[::1]/hook",
+		"http://[fe80::1]/hook",
+		"http://[::ffff:127.0.0.1]/hook",
+	} {
+		if _, err := service.Create(ctx, "board123", "user123", target, "", nil); !errors.As(err, &validationErr) {
+			t.Errorf("Create(%s) error = %v, want validation error", target, err)
+		}
+	}
+
+	created, err := service.Create(ctx, "board123", "user123", "https://93.184.215.14/hook", "", nil)
+	if err != nil {
+		t.Fatalf("Create() with a public address unexpected error = %v", err)
+	}
+	if _, err := service.Update(ctx, created.ID, "user123", "http://169.254.169.254/", nil, nil); !errors.As(err, &validationErr) {
+		t.Errorf("Update() error = %v, want validation error", err)
+	}
+}
+
+func TestNewWebhookClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/internal", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// The dial-time check catches addresses that passed validation, e.g.
+	// after the receiver's DNS record was changed
+	if _, err := NewWebhookClient(time.Second, false).Post(server.URL, "application/json", nil); !errors.Is(err, errWebhookTargetBlocked) {
+		t.Errorf("Post() to a loopback address error = %v, want errWebhookTargetBlocked", err)
+	}
+
+	resp, err := NewWebhookClient(time.Second, true).Post(server.URL+"/redirect", "application/json", nil)
+	if err != nil {
+		t.Fatalf("Post() unexpected error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Errorf("redirect was followed, got status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,134 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// recordSpans installs a tracer provider that keeps finished spans in memory
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestSetup_Exporters(t *testing.T) {
+	ctx := context.Background()
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	if _, err := Setup(ctx, "zipkin", nil); err == nil {
+		t.Error("expected an error for an unknown exporter")
+	}
+
+	shutdown, err := Setup(ctx, "", nil)
+	if err != nil || shutdown(ctx) != nil {
+		t.Fatalf("Setup() without exporter failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	shutdown, err = Setup(ctx, ExporterConsole, &out)
+	if err != nil {
+		t.Fatalf("Setup() unexpected error = %v", err)
+	}
+	_, span := Start(ctx, "BoardService.FindByID")
+	span.End()
+	if err := shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"Name":"BoardService.FindByID"`, `"Value":"kanban-backend"`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("console output misses %s:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestInjectExtract_RoundTrip(t *testing.T) {
+	recordSpans(t)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctx, span := Start(context.Background(), "outgoing")
+	defer span.End()
+	headers := http.Header{}
+	Inject(ctx, propagation.HeaderCarrier(headers))
+	if headers.Get("traceparent") == "" {
+		t.Fatal("traceparent header not set")
+	}
+
+	_, child := Start(Extract(context.Background(), propagation.HeaderCarrier(headers)), "incoming")
+	defer child.End()
+	if child.SpanContext().TraceID() != span.SpanContext().TraceID() {
+		t.Error("extracted span is not part of the same trace")
+	}
+}
+
+type widget struct {
+	ID    int
+	Name  string
+	Parts []part
+}
+
+type part struct {
+	ID       int
+	WidgetID int
+}
+
+func TestInstrumentDB_NestsPreloads(t *testing.T) {
+	recorder := recordSpans(t)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&widget{}, &part{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := InstrumentDB(db); err != nil {
+		t.Fatalf("InstrumentDB() unexpected error = %v", err)
+	}
+	db.Create(&widget{Name: "gear", Parts: []part{{}, {}}})
+
+	ctx, parent := Start(context.Background(), "WidgetService.FindByID")
+	var found widget
+	if err := db.WithContext(ctx).Preload("Parts").First(&found).Error; err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	query, ok := spans["gorm.query widgets"]
+	if !ok {
+		t.Fatalf("no span for the widgets query in %v", spans)
+	}
+	preload, ok := spans["gorm.query parts"]
+	if !ok {
+		t.Fatalf("no span for the preload in %v", spans)
+	}
+
+	if query.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("query span is not a child of the service span")
+	}
+	if preload.Parent().SpanID() != query.SpanContext().SpanID() {
+		t.Error("preload span is not a child of the query span")
+	}
+	for _, attr := range query.Attributes() {
+		if attr.Key == "db.query.text" && !strings.Contains(attr.Value.AsString(), "SELECT * FROM `widgets`") {
+			t.Errorf("unexpected query text %q", attr.Value.AsString())
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"errors"
+	"time"
+)
+
+// ParseDateRange parses optional "from" and "to" query values given as dates
+// (YYYY-MM-DD) or RFC 3339 timestamps. A date-only "to" includes the whole
+// day. Missing values default to now and to defaultSpan before "to".
+func ParseDateRange(fromValue, toValue string, now time.Time, defaultSpan time.Duration) (time.Time, time.Time, error) {
+	to := now.UTC()
+	if toValue != "" {
+		parsed, allDay, err := ParseDeadline(toValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("to must be a date (YYYY-MM-DD) or an RFC 3339 timestamp")
+		}
+		to = *parsed
+		if allDay {
+			to = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+
+	from := to.Add(-defaultSpan)
+	if fromValue != "" {
+		parsed, _, err := ParseDeadline(fromValue)
+		if err != nil {
+			return time.Time{}, time.Time{}, errors.New("from must be a date (YYYY-MM-DD) or an RFC 3339 timestamp")
+		}
+		from = *parsed
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, errors.New("from must be before to")
+	}
+
+	return from, to, nil
+}
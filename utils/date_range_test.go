@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateRange(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "defaults to span ending now",
+			wantFrom: now.Add(-7 * 24 * time.Hour),
+			wantTo:   now,
+		},
+		{
+			name:     "date-only to covers the whole day",
+			from:     "2026-10-01",
+			to:       "2026-10-07",
+			wantFrom: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond),
+		},
+		{
+			name:     "timestamps are used as given",
+			from:     "2026-10-01T08:00:00Z",
+			to:       "2026-10-02T08:00:00+02:00",
+			wantFrom: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC),
+			wantTo:   time.Date(2026, 10, 2, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid from",
+			from:    "last week",
+			wantErr: true,
+		},
+		{
+			name:    "from after to",
+			from:    "2026-10-10",
+			to:      "2026-10-01",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := ParseDateRange(tt.from, tt.to, now, 7*24*time.Hour)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ParseDateRange() expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDateRange() unexpected error = %v", err)
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("ParseDateRange() = %v, %v, want %v, %v", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
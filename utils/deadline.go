@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DateLayout is the format used for date-only deadlines
+const DateLayout = "2006-01-02"
+
+// ParseDeadline parses a deadline that is either a calendar date ("2006-01-02")
+// or an RFC 3339 timestamp. Date-only deadlines are returned as midnight UTC of
+// that date with allDay set; timestamps are normalized to UTC.
+func ParseDeadline(value string) (*time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, false, nil
+	}
+
+	if date, err := time.Parse(DateLayout, value); err == nil {
+		return &date, true, nil
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, false, errors.New("deadline must be a date (YYYY-MM-DD) or an RFC 3339 timestamp")
+	}
+
+	timestamp = timestamp.UTC()
+	return &timestamp, false, nil
+}
+
+// LoadTimezone validates an IANA timezone name such as "Europe/Berlin"
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || strings.EqualFold(name, "local") {
+		return nil, errors.New("timezone must be an IANA name such as Europe/Berlin")
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("unknown timezone: " + name)
+	}
+
+	return loc, nil
+}
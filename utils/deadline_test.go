@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDeadline(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		want       *time.Time
+		wantAllDay bool
+		wantErr    bool
+	}{
+		{
+			name:  "empty value",
+			value: "",
+		},
+		{
+			name:       "date only",
+			value:      "2026-10-20",
+			want:       ptrTime(time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)),
+			wantAllDay: true,
+		},
+		{
+			name:  "timestamp with offset is normalized to UTC",
+			value: "2026-10-20T17:00:00+08:00",
+			want:  ptrTime(time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:    "invalid value",
+			value:   "next tuesday",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, allDay, err := ParseDeadline(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDeadline() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if allDay != tt.wantAllDay {
+				t.Errorf("ParseDeadline() allDay = %v, want %v", allDay, tt.wantAllDay)
+			}
+
+			if (got == nil) != (tt.want == nil) {
+				t.Fatalf("ParseDeadline() = %v, want %v", got, tt.want)
+			}
+
+			if got != nil {
+				if !got.Equal(*tt.want) || got.Location() != time.UTC {
+					t.Errorf("ParseDeadline() = %v, want %v in UTC", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	if _, err := LoadTimezone("Europe/Berlin"); err != nil {
+		t.Errorf("LoadTimezone(Europe/Berlin) unexpected error = %v", err)
+	}
+
+	for _, name := range []string{"", "Local", "Mars/Olympus"} {
+		if _, err := LoadTimezone(name); err == nil {
+			t.Errorf("LoadTimezone(%q) expected error", name)
+		}
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
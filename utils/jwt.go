@@ -3,13 +3,15 @@ package utils
 import (
 	"errors"
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtSecret = []byte(os.Getenv("JWT_SECRET"))
+var jwtSecret []byte
+
+// ErrJWTSecretNotSet is returned when tokens are used before SetJWTSecret
+var ErrJWTSecretNotSet = errors.New("JWT secret is not configured")
 
 // CustomClaims extends jwt.RegisteredClaims to include user_id
 type CustomClaims struct {
@@ -17,12 +19,18 @@ type CustomClaims struct {
 	jwt.RegisteredClaims
 }
 
-// GetJWTSecret returns the JWT signing key
-func GetJWTSecret() []byte {
+// SetJWTSecret sets the key used to sign and validate tokens
+func SetJWTSecret(secret string) {
+	jwtSecret = []byte(secret)
+}
+
+// GetJWTSecret returns the JWT signing key. There is no fallback key, so
+// tokens cannot be issued with a guessable secret.
+func GetJWTSecret() ([]byte, error) {
 	if len(jwtSecret) == 0 {
-		jwtSecret = []byte("default-secret-key-change-in-production")
+		return nil, ErrJWTSecretNotSet
 	}
-	return jwtSecret
+	return jwtSecret, nil
 }
 
 // GenerateToken creates a new JWT access token with specified expiry
@@ -31,7 +39,10 @@ func GenerateToken(userID string, expiry time.Duration) (string, error) {
 		return "", errors.New("user_id cannot be empty")
 	}
 
-	secret := GetJWTSecret()
+	secret, err := GetJWTSecret()
+	if err != nil {
+		return "", err
+	}
 
 	claims := CustomClaims{
 		UserID: userID,
@@ -57,7 +68,10 @@ func ValidateToken(tokenString string) (*CustomClaims, error) {
 		return nil, errors.New("token cannot be empty")
 	}
 
-	secret := GetJWTSecret()
+	secret, err := GetJWTSecret()
+	if err != nil {
+		return nil, err
+	}
 
 	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
 		// Validate signing method
@@ -92,7 +106,10 @@ func GenerateRefreshToken(userID string) (string, error) {
 	// Refresh token expires in 7 days
 	expiry := 7 * 24 * time.Hour
 
-	secret := GetJWTSecret()
+	secret, err := GetJWTSecret()
+	if err != nil {
+		return "", err
+	}
 
 	claims := CustomClaims{
 		UserID: userID,
@@ -118,7 +135,10 @@ func ValidateRefreshToken(tokenString string) (string, error) {
 		return "", errors.New("refresh token cannot be empty")
 	}
 
-	secret := GetJWTSecret()
+	secret, err := GetJWTSecret()
+	if err != nil {
+		return "", err
+	}
 
 	token, err := jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (interface{}, error) {
 		// Validate signing method
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"errors"
 	"os"
 	"testing"
 	"time"
@@ -413,6 +414,29 @@ func TestRefreshTokenExpirySevenDays(t *testing.T) {
 	}
 }
 
+func TestTokensRequireSecret(t *testing.T) {
+	teardownTestJWTSecret()
+
+	if _, err := GenerateToken("user123", time.Hour); !errors.Is(err, ErrJWTSecretNotSet) {
+		t.Errorf("GenerateToken() error = %v, want ErrJWTSecretNotSet", err)
+	}
+	if _, err := GenerateRefreshToken("user123"); !errors.Is(err, ErrJWTSecretNotSet) {
+		t.Errorf("GenerateRefreshToken() error = %v, want ErrJWTSecretNotSet", err)
+	}
+
+	SetJWTSecret("test-secret-key-for-testing")
+	defer teardownTestJWTSecret()
+	token, err := GenerateToken("user123", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateToken() unexpected error = %v", err)
+	}
+
+	SetJWTSecret("")
+	if _, err := ValidateToken(token); !errors.Is(err, ErrJWTSecretNotSet) {
+		t.Errorf("ValidateToken() error = %v, want ErrJWTSecretNotSet", err)
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && (s[:len(substr)] == substr || contains(s[1:], substr)))